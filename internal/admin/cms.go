@@ -0,0 +1,368 @@
+package admin
+
+import (
+	"path"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// CmsType represents a CMS block or element type registered in the administration
+type CmsType struct {
+	// Name is the type name (e.g., "image-text" or "product-box")
+	Name string
+
+	// Category is the block category (e.g., "text-image"), empty for elements
+	Category string
+
+	// Label is the snippet key of the label shown in the CMS sidebar
+	Label string
+
+	// FilePath is the absolute path to the file registering the type
+	FilePath string
+
+	// Line is the line number where the type is registered (1-based)
+	Line int
+}
+
+var (
+	// JSCmsBlockRegistrationPattern matches calls registering a CMS block
+	//
+	// Example: Shopware.Service('cmsService').registerCmsBlock({ name: 'image-text', ... })
+	JSCmsBlockRegistrationPattern = cmsRegistrationPattern("registerCmsBlock")
+
+	// JSCmsElementRegistrationPattern matches calls registering a CMS element
+	//
+	// Example: Shopware.Service('cmsService').registerCmsElement({ name: 'image', ... })
+	JSCmsElementRegistrationPattern = cmsRegistrationPattern("registerCmsElement")
+)
+
+func cmsRegistrationPattern(method string) treesitterhelper.Pattern {
+	return treesitterhelper.FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		if node == nil || node.Kind() != "call_expression" {
+			return false
+		}
+
+		function := node.ChildByFieldName("function")
+		if function == nil || function.Kind() != "member_expression" {
+			return false
+		}
+
+		property := function.ChildByFieldName("property")
+		return property != nil && property.Utf8Text(content) == method
+	})
+}
+
+// JSStringInCmsSlotPattern matches the element type of a slot in a CMS block registration
+// Matches the quote character as well, so completion works in an empty string
+//
+// Example: registerCmsBlock({ slots: { left: '<caret>', right: { type: '<caret>' } } })
+var JSStringInCmsSlotPattern = treesitterhelper.FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+	if node.Kind() != "string" {
+		node = node.Parent()
+	}
+	if node == nil || node.Kind() != "string" {
+		return false
+	}
+
+	slot := node.Parent()
+	if slot == nil || slot.Kind() != "pair" {
+		return false
+	}
+	if value := slot.ChildByFieldName("value"); value == nil || !value.Equals(*node) {
+		return false
+	}
+
+	// { type: '...' } declares the slot with its config
+	if objectKey(slot, content) == "type" {
+		if slot = enclosingPair(slot); slot == nil {
+			return false
+		}
+	}
+
+	slots := enclosingPair(slot)
+	if slots == nil || objectKey(slots, content) != "slots" {
+		return false
+	}
+
+	config := slots.Parent()
+	if config == nil || config.Parent() == nil || config.Parent().Kind() != "arguments" {
+		return false
+	}
+
+	return JSCmsBlockRegistrationPattern.Matches(config.Parent().Parent(), content)
+})
+
+// enclosingPair returns the pair whose value is the object containing the given pair
+func enclosingPair(pair *tree_sitter.Node) *tree_sitter.Node {
+	object := pair.Parent()
+	if object == nil || object.Kind() != "object" {
+		return nil
+	}
+
+	parent := object.Parent()
+	if parent == nil || parent.Kind() != "pair" {
+		return nil
+	}
+
+	return parent
+}
+
+// objectKey returns the key of an object pair without quotes
+func objectKey(pair *tree_sitter.Node, content []byte) string {
+	key := pair.ChildByFieldName("key")
+	if key == nil {
+		return ""
+	}
+	if key.Kind() == "string" {
+		return extractStringContent(key, content)
+	}
+	return key.Utf8Text(content)
+}
+
+// CmsIndexer indexes the CMS block and element types registered in the administration
+type CmsIndexer struct {
+	blockIndex   *indexer.DataIndexer[CmsType]
+	elementIndex *indexer.DataIndexer[CmsType]
+}
+
+func NewCmsIndexer(configDir string) (*CmsIndexer, error) {
+	blockIndex, err := indexer.NewDataIndexer[CmsType](path.Join(configDir, "admin_cms_block.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	elementIndex, err := indexer.NewDataIndexer[CmsType](path.Join(configDir, "admin_cms_element.db"))
+	if err != nil {
+		_ = blockIndex.Close()
+		return nil, err
+	}
+
+	return &CmsIndexer{blockIndex: blockIndex, elementIndex: elementIndex}, nil
+}
+
+func (idx *CmsIndexer) ID() string {
+	return "admin.cms.indexer"
+}
+
+func (idx *CmsIndexer) FileExtensions() []string {
+	return []string{".js", ".ts"}
+}
+
+func (idx *CmsIndexer) Index(filePath string, node *tree_sitter.Node, fileContent []byte) error {
+	fileType := indexer.FileType(filePath)
+	if fileType != ".js" && fileType != ".ts" {
+		return nil
+	}
+
+	// Only index files in Administration directory
+	if !strings.Contains(filePath, "Resources/app/administration") {
+		return nil
+	}
+
+	if blocks := parseCmsRegistrations(node, fileContent, filePath, JSCmsBlockRegistrationPattern); len(blocks) > 0 {
+		if err := idx.blockIndex.BatchSaveItems(cmsBatch(filePath, blocks)); err != nil {
+			return err
+		}
+	}
+
+	if elements := parseCmsRegistrations(node, fileContent, filePath, JSCmsElementRegistrationPattern); len(elements) > 0 {
+		if err := idx.elementIndex.BatchSaveItems(cmsBatch(filePath, elements)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func cmsBatch(filePath string, types []CmsType) map[string]map[string]CmsType {
+	batchSave := map[string]map[string]CmsType{filePath: {}}
+	for _, cmsType := range types {
+		batchSave[filePath][cmsType.Name] = cmsType
+	}
+	return batchSave
+}
+
+// parseCmsRegistrations finds all CMS types registered with the calls matched by the pattern
+func parseCmsRegistrations(root *tree_sitter.Node, content []byte, filePath string, pattern treesitterhelper.Pattern) []CmsType {
+	var types []CmsType
+
+	for _, call := range treesitterhelper.FindAll(root, pattern, content) {
+		argsNode := call.ChildByFieldName("arguments")
+		if argsNode == nil {
+			continue
+		}
+
+		args := getArguments(argsNode)
+		if len(args) == 0 || args[0].Kind() != "object" {
+			continue
+		}
+
+		cmsType := CmsType{
+			FilePath: filePath,
+			Line:     int(call.StartPosition().Row) + 1,
+		}
+
+		for i := uint(0); i < args[0].NamedChildCount(); i++ {
+			pair := args[0].NamedChild(i)
+			if pair.Kind() != "pair" {
+				continue
+			}
+
+			value := pair.ChildByFieldName("value")
+			if value == nil || value.Kind() != "string" {
+				continue
+			}
+
+			switch objectKey(pair, content) {
+			case "name":
+				cmsType.Name = extractStringContent(value, content)
+			case "category":
+				cmsType.Category = extractStringContent(value, content)
+			case "label":
+				cmsType.Label = extractStringContent(value, content)
+			}
+		}
+
+		if cmsType.Name != "" {
+			types = append(types, cmsType)
+		}
+	}
+
+	return types
+}
+
+func (idx *CmsIndexer) RemovedFiles(paths []string) error {
+	if err := idx.blockIndex.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+	return idx.elementIndex.BatchDeleteByFilePaths(paths)
+}
+
+func (idx *CmsIndexer) Close() error {
+	if err := idx.blockIndex.Close(); err != nil {
+		return err
+	}
+	return idx.elementIndex.Close()
+}
+
+func (idx *CmsIndexer) Clear() error {
+	if err := idx.blockIndex.Clear(); err != nil {
+		return err
+	}
+	return idx.elementIndex.Clear()
+}
+
+func (idx *CmsIndexer) BeginBatch() error {
+	return indexer.BeginBatches(idx.blockIndex, idx.elementIndex)
+}
+
+func (idx *CmsIndexer) Commit() error {
+	return indexer.CommitBatches(idx.blockIndex, idx.elementIndex)
+}
+
+// Count returns the number of indexed block and element types
+func (idx *CmsIndexer) Count() (int, error) {
+	blocks, err := idx.blockIndex.CountKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	elements, err := idx.elementIndex.CountKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	return blocks + elements, nil
+}
+
+// GetAllBlocks returns all registered CMS blocks sorted by name
+func (idx *CmsIndexer) GetAllBlocks() ([]CmsType, error) {
+	return idx.blockIndex.GetAllValuesSorted()
+}
+
+// GetAllElements returns all registered CMS elements sorted by name
+func (idx *CmsIndexer) GetAllElements() ([]CmsType, error) {
+	return idx.elementIndex.GetAllValuesSorted()
+}
+
+// TwigCmsTypeComparison reports which CMS types a string compared with a type property refers to:
+// "block" for block.type, "element" for element.type and slot.type, empty otherwise
+//
+// Example: {% if element.type == '<caret>' %}
+func TwigCmsTypeComparison(node *tree_sitter.Node, content []byte) string {
+	if node == nil || node.Kind() != "string" {
+		return ""
+	}
+
+	comparison := node.Parent()
+	if comparison == nil || comparison.Kind() != "binary_expression" {
+		return ""
+	}
+
+	other := comparison.ChildByFieldName("left")
+	if other != nil && other.Equals(*node) {
+		other = comparison.ChildByFieldName("right")
+	}
+	if other == nil || other.Kind() != "member_expression" {
+		return ""
+	}
+
+	property := other.ChildByFieldName("property")
+	object := other.ChildByFieldName("object")
+	if property == nil || object == nil || property.Utf8Text(content) != "type" {
+		return ""
+	}
+
+	// The variable naming is the only hint, as Twig templates are untyped
+	variable := strings.ToLower(object.Utf8Text(content))
+	if i := strings.LastIndex(variable, "."); i != -1 {
+		variable = variable[i+1:]
+	}
+
+	switch {
+	case strings.HasSuffix(variable, "block"):
+		return "block"
+	case strings.HasSuffix(variable, "element"), strings.HasSuffix(variable, "slot"):
+		return "element"
+	}
+
+	return ""
+}
+
+// IsCmsXMLSlotType reports whether the node is the content of the type of a slot in an app cms.xml
+//
+// Example: <slot name="left"><type><caret></type></slot>
+func IsCmsXMLSlotType(node *tree_sitter.Node, content []byte) bool {
+	element := node
+	for element != nil && element.Kind() != "element" {
+		element = element.Parent()
+	}
+	if element == nil || xmlElementName(element, content) != "type" {
+		return false
+	}
+
+	parent := element.Parent()
+	if parent != nil && parent.Kind() == "content" {
+		parent = parent.Parent()
+	}
+
+	return parent != nil && parent.Kind() == "element" && xmlElementName(parent, content) == "slot"
+}
+
+// xmlElementName returns the tag name of an XML element
+func xmlElementName(element *tree_sitter.Node, content []byte) string {
+	tag := treesitterhelper.GetFirstNodeOfKind(element, "STag")
+	if tag == nil {
+		return ""
+	}
+
+	name := treesitterhelper.GetFirstNodeOfKind(tag, "Name")
+	if name == nil {
+		return ""
+	}
+
+	return name.Utf8Text(content)
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
+)
+
+func TestCmsIndexer(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+
+	idx, err := NewCmsIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	blockCode := `
+Shopware.Service('cmsService').registerCmsBlock({
+    name: 'image-text',
+    label: 'sw-cms.blocks.textImage.imageText.label',
+    category: 'text-image',
+    component: 'sw-cms-block-image-text',
+    slots: {
+        left: 'image',
+        right: { type: 'text' },
+    },
+});
+`
+	elementCode := `
+const { Service } = Shopware;
+
+Service('cmsService').registerCmsElement({
+    name: 'image',
+    label: 'sw-cms.elements.image.label',
+    component: 'sw-cms-el-image',
+});
+
+Service('cmsService').registerCmsElement({ component: 'sw-cms-el-nameless' });
+`
+
+	blockPath := "/project/src/Administration/Resources/app/administration/src/module/sw-cms/blocks/text-image/image-text/index.js"
+	elementPath := "/project/src/Administration/Resources/app/administration/src/module/sw-cms/elements/image/index.js"
+
+	for path, code := range map[string]string{blockPath: blockCode, elementPath: elementCode} {
+		tree := parser.Parse([]byte(code), nil)
+		require.NoError(t, idx.Index(path, tree.RootNode(), []byte(code)))
+		tree.Close()
+	}
+
+	blocks, err := idx.GetAllBlocks()
+	require.NoError(t, err)
+	assert.Equal(t, []CmsType{{
+		Name:     "image-text",
+		Category: "text-image",
+		Label:    "sw-cms.blocks.textImage.imageText.label",
+		FilePath: blockPath,
+		Line:     2,
+	}}, blocks)
+
+	elements, err := idx.GetAllElements()
+	require.NoError(t, err)
+	assert.Equal(t, []CmsType{{
+		Name:     "image",
+		Label:    "sw-cms.elements.image.label",
+		FilePath: elementPath,
+		Line:     4,
+	}}, elements)
+
+	count, err := idx.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Dropping the registration from the file removes the type, the file scanner removes the old data before indexing
+	require.NoError(t, idx.RemovedFiles([]string{elementPath}))
+	tree := parser.Parse([]byte("export default {};"), nil)
+	require.NoError(t, idx.Index(elementPath, tree.RootNode(), []byte("export default {};")))
+	tree.Close()
+
+	elements, err = idx.GetAllElements()
+	require.NoError(t, err)
+	assert.Empty(t, elements)
+
+	require.NoError(t, idx.RemovedFiles([]string{blockPath}))
+	blocks, err = idx.GetAllBlocks()
+	require.NoError(t, err)
+	assert.Empty(t, blocks)
+}
@@ -17,12 +17,20 @@ type VueComponent struct {
 	// DefinitionPath is the resolved absolute path to the component definition file
 	DefinitionPath string
 
+	// DynamicImportPath indicates the import path is built at runtime (template literal or concatenation)
+	// ImportPath then only holds its static prefix and DefinitionPath stays empty
+	DynamicImportPath bool
+
 	// Line is the line number where the component is registered
 	Line int
 
 	// Props contains the component's props definitions
 	Props []VueComponentProp
 
+	// UnlistedProps indicates the component accepts props which are not in Props: props of mixins
+	// are not indexed and components setting inheritAttrs handle their attributes themselves
+	UnlistedProps bool
+
 	// Emits contains the component's emitted events
 	Emits []string
 
@@ -41,6 +49,9 @@ type VueComponent struct {
 	// TemplatePath is the path to the Twig template (from the template import)
 	TemplatePath string
 
+	// LocalComponents contains the child components registered locally via `components: { ... }`
+	LocalComponents []LocalComponent
+
 	// InlineDefinition contains the parsed definition for inline component registrations
 	// This is only populated during indexing and not persisted (used to store in definition index)
 	InlineDefinition *ComponentDefinition `msgpack:"-"`
@@ -81,3 +92,15 @@ type TwigBlock struct {
 	// Line is the line number where the block is defined in the template (1-based)
 	Line int
 }
+
+// LocalComponent represents a child component registered locally in a component's `components` option
+type LocalComponent struct {
+	// Name is the kebab-case tag name used in the template (e.g., "mt-card")
+	Name string
+
+	// Identifier is the JavaScript identifier bound to the component (e.g., "MtCard")
+	Identifier string
+
+	// ImportPath is the resolved path of the imported component definition, empty if unknown
+	ImportPath string
+}
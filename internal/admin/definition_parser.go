@@ -43,20 +43,25 @@ func ParseComponentDefinition(root *tree_sitter.Node, content []byte) *Component
 	// Find template import
 	def.TemplatePath = findTemplateImport(root, content)
 
+	// Link locally registered components to their import statements
+	linkLocalComponentImports(def.LocalComponents, root, content)
+
 	return def
 }
 
 // ComponentDefinition holds the parsed component definition details
 type ComponentDefinition struct {
-	FilePath     string
-	Props        []VueComponentProp
-	Emits        []string
-	Methods      []string
-	Computed     []string
-	Slots        []VueComponentSlot
-	Blocks       []TwigBlock
-	TemplatePath string
-	HasTemplate  bool
+	FilePath        string
+	Props           []VueComponentProp
+	UnlistedProps   bool
+	Emits           []string
+	Methods         []string
+	Computed        []string
+	Slots           []VueComponentSlot
+	Blocks          []TwigBlock
+	TemplatePath    string
+	HasTemplate     bool
+	LocalComponents []LocalComponent
 }
 
 // findExportDefault finds the export default statement in the AST
@@ -84,6 +89,12 @@ func parsePair(node *tree_sitter.Node, content []byte, def *ComponentDefinition)
 	}
 	propName := string(propIdent.Utf8Text(content))
 
+	// Props of mixins are unknown and inheritAttrs can have any value
+	if propName == "mixins" || propName == "inheritAttrs" {
+		def.UnlistedProps = true
+		return
+	}
+
 	// Get value node (second child after property_identifier and colon)
 	var valueNode *tree_sitter.Node
 	for i := uint(0); i < node.ChildCount(); i++ {
@@ -108,6 +119,8 @@ func parsePair(node *tree_sitter.Node, content []byte, def *ComponentDefinition)
 		def.Methods = parseMethods(valueNode, content)
 	case "computed":
 		def.Computed = parseMethods(valueNode, content) // Same structure as methods
+	case "components":
+		def.LocalComponents = parseLocalComponents(valueNode, content)
 	case "template":
 		def.HasTemplate = true
 	}
@@ -159,11 +172,8 @@ func parsePropDefinition(node *tree_sitter.Node, content []byte) *VueComponentPr
 	// Get prop definition object
 	propObj := treesitterhelper.GetFirstNodeOfKind(node, "object")
 	if propObj == nil {
-		// Prop might be defined with just a type: `title: String`
-		typeIdent := treesitterhelper.GetFirstNodeOfKind(node, "identifier")
-		if typeIdent != nil {
-			prop.Type = string(typeIdent.Utf8Text(content))
-		}
+		// Prop might be defined with just a type: `title: String` or `title: [String, Number]`
+		prop.Type = parsePropType(node, content)
 		return prop
 	}
 
@@ -179,14 +189,7 @@ func parsePropDefinition(node *tree_sitter.Node, content []byte) *VueComponentPr
 
 			switch optName {
 			case "type":
-				// Get the type identifier
-				for j := uint(0); j < child.ChildCount(); j++ {
-					c := child.Child(j)
-					if c.Kind() == "identifier" {
-						prop.Type = string(c.Utf8Text(content))
-						break
-					}
-				}
+				prop.Type = parsePropType(child, content)
 			case "required":
 				// Check if value is true
 				for j := uint(0); j < child.ChildCount(); j++ {
@@ -213,6 +216,37 @@ func parsePropDefinition(node *tree_sitter.Node, content []byte) *VueComponentPr
 	return prop
 }
 
+// parsePropType extracts the type from a prop pair (`type: String` or `title: String`)
+// Arrays of constructors are recorded as a union, e.g. `[String, Number]` -> "String|Number"
+// A trailing `as PropType<...>` cast is ignored and only the base type is kept
+func parsePropType(pair *tree_sitter.Node, content []byte) string {
+	for i := uint(0); i < pair.ChildCount(); i++ {
+		child := pair.Child(i)
+
+		switch child.Kind() {
+		case "identifier":
+			return string(child.Utf8Text(content))
+		case "array":
+			var types []string
+			for j := uint(0); j < child.ChildCount(); j++ {
+				c := child.Child(j)
+				if c.Kind() == "identifier" {
+					types = append(types, string(c.Utf8Text(content)))
+				}
+			}
+			return strings.Join(types, "|")
+		case "ERROR", "as_expression":
+			// `Array as PropType<Foo[]>` is TypeScript syntax, the JavaScript grammar
+			// puts the base type as the first identifier of an ERROR node
+			if typeIdent := treesitterhelper.GetFirstNodeOfKind(child, "identifier"); typeIdent != nil {
+				return string(typeIdent.Utf8Text(content))
+			}
+		}
+	}
+
+	return ""
+}
+
 // parseEmits parses the emits array
 func parseEmits(node *tree_sitter.Node, content []byte) []string {
 	var emits []string
@@ -263,6 +297,93 @@ func parseMethods(node *tree_sitter.Node, content []byte) []string {
 	return methods
 }
 
+// parseLocalComponents parses the `components` object of a component definition
+// Supports `'mt-card': MtCard`, `MtCard: MtCard`, the shorthand `MtCard` and `'sw-foo': () => import('...')`
+func parseLocalComponents(node *tree_sitter.Node, content []byte) []LocalComponent {
+	var components []LocalComponent
+
+	if node.Kind() != "object" {
+		return components
+	}
+
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+
+		switch child.Kind() {
+		case "shorthand_property_identifier":
+			identifier := string(child.Utf8Text(content))
+			components = append(components, LocalComponent{
+				Name:       CamelToKebab(identifier),
+				Identifier: identifier,
+			})
+		case "pair":
+			keyNode := child.ChildByFieldName("key")
+			valueNode := child.ChildByFieldName("value")
+			if keyNode == nil || valueNode == nil {
+				continue
+			}
+
+			var name string
+			switch keyNode.Kind() {
+			case "string":
+				name = extractStringContent(keyNode, content)
+			case "property_identifier":
+				name = string(keyNode.Utf8Text(content))
+			}
+			if name == "" {
+				continue
+			}
+
+			local := LocalComponent{Name: CamelToKebab(name)}
+			switch valueNode.Kind() {
+			case "identifier":
+				local.Identifier = string(valueNode.Utf8Text(content))
+			case "arrow_function":
+				if importPath, dynamic := extractImportPath(valueNode, content); !dynamic {
+					local.ImportPath = importPath
+				}
+			}
+
+			components = append(components, local)
+		}
+	}
+
+	return components
+}
+
+// linkLocalComponentImports fills the import path of local components bound to an imported identifier
+// e.g. `import MtCard from './mt-card';` -> ImportPath "./mt-card" for identifier MtCard
+func linkLocalComponentImports(components []LocalComponent, root *tree_sitter.Node, content []byte) {
+	if len(components) == 0 || root == nil {
+		return
+	}
+
+	imports := make(map[string]string)
+	for i := uint(0); i < root.ChildCount(); i++ {
+		child := root.Child(i)
+		if child.Kind() != "import_statement" {
+			continue
+		}
+
+		importClause := treesitterhelper.GetFirstNodeOfKind(child, "import_clause")
+		stringNode := treesitterhelper.GetFirstNodeOfKind(child, "string")
+		if importClause == nil || stringNode == nil {
+			continue
+		}
+
+		ident := treesitterhelper.GetFirstNodeOfKind(importClause, "identifier")
+		if ident != nil {
+			imports[string(ident.Utf8Text(content))] = extractStringContent(stringNode, content)
+		}
+	}
+
+	for i := range components {
+		if components[i].ImportPath == "" && components[i].Identifier != "" {
+			components[i].ImportPath = imports[components[i].Identifier]
+		}
+	}
+}
+
 // extractStringContent extracts the content from a string node
 func extractStringContent(node *tree_sitter.Node, content []byte) string {
 	for i := uint(0); i < node.ChildCount(); i++ {
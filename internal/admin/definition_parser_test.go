@@ -70,6 +70,45 @@ export default {
 	assert.Equal(t, "active", def.Props[2].Name)
 }
 
+func TestParseComponentDefinition_PropTypes(t *testing.T) {
+	code := `
+export default {
+    props: {
+        value: {
+            type: [String, Number],
+            required: true,
+        },
+        items: {
+            type: Array as PropType<Foo[]>,
+            required: true,
+            default: () => [],
+        },
+        size: [String, Number],
+        entity: Object as PropType<Entity>,
+    },
+};
+`
+	root := parseJS(t, code)
+	def := ParseComponentDefinition(root, []byte(code))
+
+	require.Len(t, def.Props, 4)
+
+	assert.Equal(t, "value", def.Props[0].Name)
+	assert.Equal(t, "String|Number", def.Props[0].Type)
+	assert.True(t, def.Props[0].Required)
+
+	assert.Equal(t, "items", def.Props[1].Name)
+	assert.Equal(t, "Array", def.Props[1].Type)
+	assert.True(t, def.Props[1].Required)
+	assert.Equal(t, "() => []", def.Props[1].Default)
+
+	assert.Equal(t, "size", def.Props[2].Name)
+	assert.Equal(t, "String|Number", def.Props[2].Type)
+
+	assert.Equal(t, "entity", def.Props[3].Name)
+	assert.Equal(t, "Object", def.Props[3].Type)
+}
+
 func TestParseComponentDefinition_Emits(t *testing.T) {
 	code := `
 export default {
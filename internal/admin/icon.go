@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// meteorIconKitPaths are the locations of the icons of the meteor icon kit, relative to the project root.
+// The kit ships one folder per icon mode, the icon name is the mode and the file name, e.g. regular-products.
+var meteorIconKitPaths = []string{
+	"vendor/shopware/administration/Resources/app/administration/node_modules/@shopware-ag/meteor-icon-kit/icons",
+	"vendor/shopware/platform/src/Administration/Resources/app/administration/node_modules/@shopware-ag/meteor-icon-kit/icons",
+	"src/Administration/Resources/app/administration/node_modules/@shopware-ag/meteor-icon-kit/icons",
+	"node_modules/@shopware-ag/meteor-icon-kit/icons",
+}
+
+// knownIconNames are offered when the meteor icon kit is not installed, e.g. before npm install ran
+var knownIconNames = []string{
+	"regular-bars",
+	"regular-bell",
+	"regular-calendar",
+	"regular-checkmark",
+	"regular-chevron-down-xs",
+	"regular-chevron-left-xs",
+	"regular-chevron-right-xs",
+	"regular-chevron-up-xs",
+	"regular-clock",
+	"regular-cog",
+	"regular-copy",
+	"regular-ellipsis-h-s",
+	"regular-envelope",
+	"regular-exclamation-triangle",
+	"regular-external-link",
+	"regular-eye",
+	"regular-eye-slash",
+	"regular-info-circle",
+	"regular-lock",
+	"regular-pencil-s",
+	"regular-plus-circle",
+	"regular-products",
+	"regular-question-circle",
+	"regular-search",
+	"regular-shopping-bag",
+	"regular-times-s",
+	"regular-trash",
+	"regular-user",
+	"regular-users",
+	"solid-checkmark",
+	"solid-exclamation-circle",
+	"solid-info-circle",
+	"solid-star",
+	"solid-times-s",
+}
+
+// IconProvider lists the icons usable with <sw-icon> and <mt-icon> in admin templates
+type IconProvider struct {
+	projectRoot string
+
+	// The icon names of the kit are read once and kept until the kit or one of its mode folders changes
+	mu       sync.Mutex
+	iconPath string
+	modTime  time.Time
+	names    []string
+}
+
+func NewIconProvider(projectRoot string) *IconProvider {
+	return &IconProvider{
+		projectRoot: projectRoot,
+	}
+}
+
+// GetIconNames returns the names of the icons of the meteor icon kit sorted alphabetically,
+// or a list of well known icons when the kit can't be found
+func (p *IconProvider) GetIconNames() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, iconPath := range meteorIconKitPaths {
+		iconPath = filepath.Join(p.projectRoot, iconPath)
+		modTime, ok := iconKitModTime(iconPath)
+		if !ok {
+			continue
+		}
+
+		if iconPath == p.iconPath && modTime.Equal(p.modTime) {
+			return p.names
+		}
+
+		if names := readIconNames(iconPath); len(names) > 0 {
+			p.iconPath, p.modTime, p.names = iconPath, modTime, names
+			return names
+		}
+	}
+
+	return knownIconNames
+}
+
+// iconKitModTime returns the latest modification time of the kit directory and its mode folders.
+// Adding an icon to a mode only changes the mode folder, not the kit directory.
+func iconKitModTime(iconPath string) (time.Time, bool) {
+	info, err := os.Stat(iconPath)
+	if err != nil || !info.IsDir() {
+		return time.Time{}, false
+	}
+
+	modTime := info.ModTime()
+
+	modes, err := os.ReadDir(iconPath)
+	if err != nil {
+		return modTime, true
+	}
+
+	for _, mode := range modes {
+		if !mode.IsDir() {
+			continue
+		}
+
+		if modeInfo, err := mode.Info(); err == nil && modeInfo.ModTime().After(modTime) {
+			modTime = modeInfo.ModTime()
+		}
+	}
+
+	return modTime, true
+}
+
+// readIconNames returns the icons of the kit directory, which has one folder per icon mode
+func readIconNames(iconPath string) []string {
+	modes, err := os.ReadDir(iconPath)
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, mode := range modes {
+		if !mode.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(iconPath, mode.Name()))
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".svg") {
+				names = append(names, mode.Name()+"-"+strings.TrimSuffix(entry.Name(), ".svg"))
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// IsIconTag reports whether the tag renders an icon by its name attribute
+func IsIconTag(tagName string) bool {
+	return tagName == "sw-icon" || tagName == "mt-icon"
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIconProvider_GetIconNames(t *testing.T) {
+	projectRoot := t.TempDir()
+	iconPath := filepath.Join(projectRoot, meteorIconKitPaths[len(meteorIconKitPaths)-1])
+
+	addIcon := func(mode, name string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(iconPath, mode), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(iconPath, mode, name+".svg"), []byte("<svg/>"), 0644))
+	}
+
+	provider := NewIconProvider(projectRoot)
+	assert.Equal(t, knownIconNames, provider.GetIconNames())
+
+	addIcon("regular", "products")
+	addIcon("solid", "cog")
+	assert.Equal(t, []string{"regular-products", "solid-cog"}, provider.GetIconNames())
+
+	// The names are kept while the icon kit is unchanged
+	names := provider.GetIconNames()
+	assert.Same(t, &names[0], &provider.GetIconNames()[0])
+
+	// Adding an icon only changes the folder of its mode. The timestamp is moved explicitly,
+	// as the file system may not tell two changes within a short time apart.
+	addIcon("regular", "bell")
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(iconPath, "regular"), later, later))
+	assert.Equal(t, []string{"regular-bell", "regular-products", "solid-cog"}, provider.GetIconNames())
+}
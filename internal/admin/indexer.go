@@ -66,6 +66,7 @@ var (
 type AdminComponentIndexer struct {
 	componentIndex  *indexer.DataIndexer[VueComponent]
 	definitionIndex *indexer.DataIndexer[ComponentDefinition]
+	templateCache   *templateParseCache
 }
 
 func NewAdminComponentIndexer(configDir string) (*AdminComponentIndexer, error) {
@@ -82,6 +83,7 @@ func NewAdminComponentIndexer(configDir string) (*AdminComponentIndexer, error)
 	return &AdminComponentIndexer{
 		componentIndex:  componentIndex,
 		definitionIndex: definitionIndex,
+		templateCache:   newTemplateParseCache(),
 	}, nil
 }
 
@@ -89,6 +91,10 @@ func (idx *AdminComponentIndexer) ID() string {
 	return "admin.component.indexer"
 }
 
+func (idx *AdminComponentIndexer) FileExtensions() []string {
+	return []string{".js", ".ts"}
+}
+
 func (idx *AdminComponentIndexer) Index(filePath string, node *tree_sitter.Node, fileContent []byte) error {
 	ext := filepath.Ext(filePath)
 	if ext != ".js" && ext != ".ts" {
@@ -214,7 +220,7 @@ func (idx *AdminComponentIndexer) indexWrappedComponents(filePath string, node *
 		if templatePath != "" {
 			templateAbsPath := ResolveTemplatePath(filePath, templatePath)
 			def.TemplatePath = templateAbsPath // Store absolute path
-			if result, err := ParseTemplateFromFile(templateAbsPath); err == nil {
+			if result, err := idx.templateCache.parse(templateAbsPath); err == nil {
 				def.Slots = result.Slots
 				def.Blocks = result.Blocks
 			}
@@ -233,11 +239,13 @@ func (idx *AdminComponentIndexer) indexWrappedComponents(filePath string, node *
 	// Copy props/emits/methods/computed/slots/blocks from definition to component
 	if def != nil {
 		comp.Props = def.Props
+		comp.UnlistedProps = def.UnlistedProps
 		comp.Emits = def.Emits
 		comp.Methods = def.Methods
 		comp.Computed = def.Computed
 		comp.Slots = def.Slots
 		comp.Blocks = def.Blocks
+		comp.LocalComponents = def.LocalComponents
 	}
 
 	// Save the component
@@ -281,6 +289,43 @@ func deriveComponentNameFromPath(filePath string) string {
 	return strings.TrimSuffix(base, ext)
 }
 
+// ConventionalComponentName returns the component name expected by the folder naming
+// convention for a Component.register/extend call, or "" if no convention applies.
+// Lazily imported components are named after the imported folder, inline definitions
+// after the folder of the registering file.
+//
+// e.g., Component.register('...', () => import('./component/sw-foo-bar')) -> "sw-foo-bar"
+func ConventionalComponentName(callNode *tree_sitter.Node, content []byte, filePath string) string {
+	memberExpr := treesitterhelper.GetFirstNodeOfKind(callNode, "member_expression")
+	argsNode := callNode.ChildByFieldName("arguments")
+	if memberExpr == nil || argsNode == nil {
+		return ""
+	}
+
+	// The definition follows the name for register and the parent name for extend
+	definitionIndex := 1
+	if strings.HasSuffix(string(memberExpr.Utf8Text(content)), ".extend") {
+		definitionIndex = 2
+	}
+
+	definitionPath := filePath
+	if args := getArguments(argsNode); len(args) > definitionIndex && args[definitionIndex].Kind() == "arrow_function" {
+		importPath, dynamic := extractImportPath(args[definitionIndex], content)
+		if importPath == "" || dynamic {
+			return ""
+		}
+		definitionPath = resolveImportPath(filePath, importPath)
+	}
+
+	// Folders like "src" or "component" don't follow the convention, component names always contain a dash
+	name := deriveComponentNameFromPath(definitionPath)
+	if !strings.Contains(name, "-") {
+		return ""
+	}
+
+	return name
+}
+
 // indexDefinition indexes component definition files (export default { ... })
 func (idx *AdminComponentIndexer) indexDefinition(filePath string, node *tree_sitter.Node, fileContent []byte) error {
 	// Check if this file has an export default with an object
@@ -297,6 +342,7 @@ func (idx *AdminComponentIndexer) indexDefinition(filePath string, node *tree_si
 
 	// Set the file path
 	def.FilePath = filePath
+	resolveLocalComponentPaths(def.LocalComponents, filePath)
 
 	// Parse slots and blocks from the template if available
 	if def.TemplatePath != "" {
@@ -306,7 +352,7 @@ func (idx *AdminComponentIndexer) indexDefinition(filePath string, node *tree_si
 			templateAbsPath = ResolveTemplatePath(filePath, def.TemplatePath)
 			def.TemplatePath = templateAbsPath // Store absolute path
 		}
-		if result, err := ParseTemplateFromFile(templateAbsPath); err == nil {
+		if result, err := idx.templateCache.parse(templateAbsPath); err == nil {
 			def.Slots = result.Slots
 			def.Blocks = result.Blocks
 		}
@@ -338,6 +384,7 @@ func normalizeDefinitionPath(filePath string) string {
 }
 
 func (idx *AdminComponentIndexer) RemovedFiles(paths []string) error {
+	idx.templateCache.invalidate(paths)
 	if err := idx.componentIndex.BatchDeleteByFilePaths(paths); err != nil {
 		return err
 	}
@@ -352,15 +399,29 @@ func (idx *AdminComponentIndexer) Close() error {
 }
 
 func (idx *AdminComponentIndexer) Clear() error {
+	idx.templateCache.reset()
 	if err := idx.componentIndex.Clear(); err != nil {
 		return err
 	}
 	return idx.definitionIndex.Clear()
 }
 
-// GetAllComponents returns all registered Vue components
+func (idx *AdminComponentIndexer) BeginBatch() error {
+	return indexer.BeginBatches(idx.componentIndex, idx.definitionIndex)
+}
+
+func (idx *AdminComponentIndexer) Commit() error {
+	return indexer.CommitBatches(idx.componentIndex, idx.definitionIndex)
+}
+
+// Count returns the number of registered component names
+func (idx *AdminComponentIndexer) Count() (int, error) {
+	return idx.componentIndex.CountKeys()
+}
+
+// GetAllComponents returns all registered Vue components ordered by name
 func (idx *AdminComponentIndexer) GetAllComponents() ([]VueComponent, error) {
-	return idx.componentIndex.GetAllValues()
+	return idx.componentIndex.GetAllValuesSorted()
 }
 
 // GetComponentByTemplatePath returns the component that uses the given template path
@@ -402,9 +463,54 @@ func (idx *AdminComponentIndexer) GetComponentByTemplatePath(templatePath string
 	return nil, nil
 }
 
-// GetAllComponentNames returns all registered component names
+// GetLocalComponents returns the components registered locally by the component owning the given template
+func (idx *AdminComponentIndexer) GetLocalComponents(templatePath string) ([]LocalComponent, error) {
+	comp, err := idx.GetComponentByTemplatePath(templatePath)
+	if err != nil || comp == nil {
+		return nil, err
+	}
+	return comp.LocalComponents, nil
+}
+
+// GetLocalComponentDefinition returns the definition of a locally registered component
+// It prefers a globally registered component with the same name and falls back to the imported definition file
+func (idx *AdminComponentIndexer) GetLocalComponentDefinition(local LocalComponent) (*VueComponent, error) {
+	components, err := idx.GetComponentWithDefinition(local.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > 0 {
+		return &components[0], nil
+	}
+
+	if local.ImportPath == "" {
+		return nil, nil
+	}
+
+	def, err := idx.GetComponentDefinition(local.ImportPath)
+	if err != nil || def == nil {
+		return nil, err
+	}
+
+	return &VueComponent{
+		Name:            local.Name,
+		FilePath:        def.FilePath,
+		DefinitionPath:  def.FilePath,
+		Props:           def.Props,
+		UnlistedProps:   def.UnlistedProps,
+		Emits:           def.Emits,
+		Methods:         def.Methods,
+		Computed:        def.Computed,
+		Slots:           def.Slots,
+		Blocks:          def.Blocks,
+		TemplatePath:    def.TemplatePath,
+		LocalComponents: def.LocalComponents,
+	}, nil
+}
+
+// GetAllComponentNames returns all registered component names in alphabetical order
 func (idx *AdminComponentIndexer) GetAllComponentNames() ([]string, error) {
-	return idx.componentIndex.GetAllKeys()
+	return idx.componentIndex.GetAllKeysSorted()
 }
 
 // GetComponent returns components by name (may have multiple if extended)
@@ -457,12 +563,14 @@ func (idx *AdminComponentIndexer) GetComponentWithDefinition(name string) ([]Vue
 			def, err := idx.GetComponentDefinition(components[i].DefinitionPath)
 			if err == nil && def != nil {
 				components[i].Props = def.Props
+				components[i].UnlistedProps = def.UnlistedProps
 				components[i].Emits = def.Emits
 				components[i].Methods = def.Methods
 				components[i].Computed = def.Computed
 				components[i].Slots = def.Slots
 				components[i].Blocks = def.Blocks
 				components[i].TemplatePath = def.TemplatePath
+				components[i].LocalComponents = def.LocalComponents
 				continue
 			}
 		}
@@ -471,12 +579,14 @@ func (idx *AdminComponentIndexer) GetComponentWithDefinition(name string) ([]Vue
 		def, err := idx.GetComponentDefinitionByName(components[i].Name)
 		if err == nil && def != nil {
 			components[i].Props = def.Props
+			components[i].UnlistedProps = def.UnlistedProps
 			components[i].Emits = def.Emits
 			components[i].Methods = def.Methods
 			components[i].Computed = def.Computed
 			components[i].Slots = def.Slots
 			components[i].Blocks = def.Blocks
 			components[i].TemplatePath = def.TemplatePath
+			components[i].LocalComponents = def.LocalComponents
 		}
 	}
 
@@ -485,6 +595,78 @@ func (idx *AdminComponentIndexer) GetComponentWithDefinition(name string) ([]Vue
 	return deduplicateComponents(components), nil
 }
 
+// GetComponentWithInheritance returns a component with its definition populated and the props, emits
+// and slots of the components it extends merged in (see ResolveInheritance)
+func (idx *AdminComponentIndexer) GetComponentWithInheritance(name string) ([]VueComponent, error) {
+	components, err := idx.GetComponentWithDefinition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range components {
+		components[i] = idx.ResolveInheritance(components[i])
+	}
+
+	return components, nil
+}
+
+// ResolveInheritance follows the ExtendsComponent chain of a component and adds the props, emits and
+// slots of its parents. Definitions of the component itself (or a closer parent) take precedence.
+func (idx *AdminComponentIndexer) ResolveInheritance(comp VueComponent) VueComponent {
+	result := comp
+	result.Props = append([]VueComponentProp(nil), comp.Props...)
+	result.Emits = append([]string(nil), comp.Emits...)
+	result.Slots = append([]VueComponentSlot(nil), comp.Slots...)
+
+	seenProps := make(map[string]bool)
+	for _, prop := range result.Props {
+		seenProps[prop.Name] = true
+	}
+	seenEmits := make(map[string]bool)
+	for _, emit := range result.Emits {
+		seenEmits[emit] = true
+	}
+	seenSlots := make(map[string]bool)
+	for _, slot := range result.Slots {
+		seenSlots[slot.Name] = true
+	}
+
+	// Guard against circular extends
+	visited := map[string]bool{comp.Name: true}
+	current := comp
+	for current.ExtendsComponent != "" && !visited[current.ExtendsComponent] {
+		visited[current.ExtendsComponent] = true
+
+		parents, err := idx.GetComponentWithDefinition(current.ExtendsComponent)
+		if err != nil || len(parents) == 0 {
+			break
+		}
+		current = parents[0]
+		result.UnlistedProps = result.UnlistedProps || current.UnlistedProps
+
+		for _, prop := range current.Props {
+			if !seenProps[prop.Name] {
+				seenProps[prop.Name] = true
+				result.Props = append(result.Props, prop)
+			}
+		}
+		for _, emit := range current.Emits {
+			if !seenEmits[emit] {
+				seenEmits[emit] = true
+				result.Emits = append(result.Emits, emit)
+			}
+		}
+		for _, slot := range current.Slots {
+			if !seenSlots[slot.Name] {
+				seenSlots[slot.Name] = true
+				result.Slots = append(result.Slots, slot)
+			}
+		}
+	}
+
+	return result
+}
+
 // deduplicateComponents merges multiple component entries with the same name
 // into a single entry, preferring entries with more complete data
 func deduplicateComponents(components []VueComponent) []VueComponent {
@@ -543,6 +725,7 @@ func mergeComponents(fallback, preferred VueComponent) VueComponent {
 	}
 	if result.ImportPath == "" && fallback.ImportPath != "" {
 		result.ImportPath = fallback.ImportPath
+		result.DynamicImportPath = fallback.DynamicImportPath
 	}
 	if result.DefinitionPath == "" && fallback.DefinitionPath != "" {
 		result.DefinitionPath = fallback.DefinitionPath
@@ -550,6 +733,7 @@ func mergeComponents(fallback, preferred VueComponent) VueComponent {
 	if len(result.Props) == 0 && len(fallback.Props) > 0 {
 		result.Props = fallback.Props
 	}
+	result.UnlistedProps = result.UnlistedProps || fallback.UnlistedProps
 	if len(result.Emits) == 0 && len(fallback.Emits) > 0 {
 		result.Emits = fallback.Emits
 	}
@@ -568,6 +752,9 @@ func mergeComponents(fallback, preferred VueComponent) VueComponent {
 	if result.TemplatePath == "" && fallback.TemplatePath != "" {
 		result.TemplatePath = fallback.TemplatePath
 	}
+	if len(result.LocalComponents) == 0 && len(fallback.LocalComponents) > 0 {
+		result.LocalComponents = fallback.LocalComponents
+	}
 
 	return result
 }
@@ -660,9 +847,10 @@ func parseRegisterArgs(argsNode *tree_sitter.Node, content []byte, filePath stri
 
 	case "arrow_function":
 		// Dynamic import: Component.register('name', () => import('path'))
-		importPath := extractImportPath(secondArg, content)
-		if importPath != "" {
-			comp.ImportPath = importPath
+		importPath, dynamic := extractImportPath(secondArg, content)
+		comp.ImportPath = importPath
+		comp.DynamicImportPath = dynamic
+		if importPath != "" && !dynamic {
 			comp.DefinitionPath = resolveImportPath(filePath, importPath)
 		}
 	}
@@ -702,9 +890,10 @@ func parseExtendArgs(argsNode *tree_sitter.Node, content []byte, filePath string
 
 	case "arrow_function":
 		// Dynamic import: Component.extend('name', 'parent', () => import('path'))
-		importPath := extractImportPath(thirdArg, content)
-		if importPath != "" {
-			comp.ImportPath = importPath
+		importPath, dynamic := extractImportPath(thirdArg, content)
+		comp.ImportPath = importPath
+		comp.DynamicImportPath = dynamic
+		if importPath != "" && !dynamic {
 			comp.DefinitionPath = resolveImportPath(filePath, importPath)
 		}
 	}
@@ -717,8 +906,8 @@ func getArguments(argsNode *tree_sitter.Node) []*tree_sitter.Node {
 	for i := uint(0); i < argsNode.ChildCount(); i++ {
 		child := argsNode.Child(i)
 		kind := child.Kind()
-		// Skip punctuation
-		if kind == "(" || kind == ")" || kind == "," {
+		// Skip punctuation and comments like webpackChunkName hints
+		if kind == "(" || kind == ")" || kind == "," || kind == "comment" {
 			continue
 		}
 		args = append(args, child)
@@ -729,7 +918,10 @@ func getArguments(argsNode *tree_sitter.Node) []*tree_sitter.Node {
 
 // extractImportPath extracts the import path from an arrow function with dynamic import
 // e.g., () => import('path') -> 'path'
-func extractImportPath(arrowFunc *tree_sitter.Node, content []byte) string {
+// Paths built with template literals or concatenation can't be resolved statically,
+// for them only the literal prefix is returned and dynamic is true
+// e.g., () => import(`src/${name}/index`) -> 'src/'
+func extractImportPath(arrowFunc *tree_sitter.Node, content []byte) (path string, dynamic bool) {
 	// Find the call_expression with import
 	importCallPattern := treesitterhelper.And(
 		treesitterhelper.NodeKind("call_expression"),
@@ -738,17 +930,48 @@ func extractImportPath(arrowFunc *tree_sitter.Node, content []byte) string {
 
 	importCall := treesitterhelper.FindFirst(arrowFunc, importCallPattern, content)
 	if importCall == nil {
-		return ""
+		return "", false
 	}
 
-	// Find the string argument
-	stringFragmentPattern := treesitterhelper.NodeKind("string_fragment")
-	fragment := treesitterhelper.FindFirst(importCall, stringFragmentPattern, content)
-	if fragment == nil {
-		return ""
+	argsNode := importCall.ChildByFieldName("arguments")
+	if argsNode == nil {
+		return "", false
+	}
+
+	args := getArguments(argsNode)
+	if len(args) == 0 {
+		return "", false
+	}
+
+	return importPathPrefix(args[0], content)
+}
+
+// importPathPrefix returns the statically known part of an import path expression
+func importPathPrefix(node *tree_sitter.Node, content []byte) (string, bool) {
+	switch node.Kind() {
+	case "string":
+		return extractStringContent(node, content), false
+	case "template_string":
+		var prefix strings.Builder
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			child := node.NamedChild(i)
+			if child.Kind() != "string_fragment" {
+				return prefix.String(), true
+			}
+			prefix.WriteString(string(child.Utf8Text(content)))
+		}
+		return prefix.String(), false
+	case "binary_expression":
+		// 'src/' + name + '/index': only the left-most operand is known
+		prefix, _ := importPathPrefix(node.ChildByFieldName("left"), content)
+		return prefix, true
+	case "parenthesized_expression":
+		if node.NamedChildCount() > 0 {
+			return importPathPrefix(node.NamedChild(0), content)
+		}
 	}
 
-	return string(fragment.Utf8Text(content))
+	return "", true
 }
 
 // parseInlineDefinition parses an inline component definition object
@@ -788,9 +1011,27 @@ func parseInlineDefinition(objNode *tree_sitter.Node, content []byte, filePath s
 		}
 	}
 
+	// Link locally registered components to the import statements of the file
+	root := objNode
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	linkLocalComponentImports(def.LocalComponents, root, content)
+	resolveLocalComponentPaths(def.LocalComponents, filePath)
+
 	return def
 }
 
+// resolveLocalComponentPaths resolves the import paths of local components relative to the definition file
+// Package imports (e.g. @shopware-ag/meteor-component-library) are kept as-is
+func resolveLocalComponentPaths(components []LocalComponent, filePath string) {
+	for i := range components {
+		if components[i].ImportPath != "" {
+			components[i].ImportPath = resolveImportPath(filePath, components[i].ImportPath)
+		}
+	}
+}
+
 // parseDefinitionPair parses a key-value pair in an inline component definition
 func parseDefinitionPair(node *tree_sitter.Node, content []byte, def *ComponentDefinition) {
 	// Get property name
@@ -800,6 +1041,12 @@ func parseDefinitionPair(node *tree_sitter.Node, content []byte, def *ComponentD
 	}
 	propName := string(propIdent.Utf8Text(content))
 
+	// Props of mixins are unknown and inheritAttrs can have any value
+	if propName == "mixins" || propName == "inheritAttrs" {
+		def.UnlistedProps = true
+		return
+	}
+
 	// Get value node
 	var valueNode *tree_sitter.Node
 	for i := uint(0); i < node.ChildCount(); i++ {
@@ -824,11 +1071,19 @@ func parseDefinitionPair(node *tree_sitter.Node, content []byte, def *ComponentD
 		def.Methods = parseMethods(valueNode, content)
 	case "computed":
 		def.Computed = parseMethods(valueNode, content)
+	case "components":
+		def.LocalComponents = parseLocalComponents(valueNode, content)
 	case "template":
 		def.HasTemplate = true
 	}
 }
 
+// ResolveImportPath resolves a component import path (e.g. from `() => import('src/app/...')`)
+// relative to the registration file to an absolute JS/TS file path
+func ResolveImportPath(registrationFile, importPath string) string {
+	return resolveImportPath(registrationFile, importPath)
+}
+
 // resolveImportPath resolves an import path relative to the registration file
 func resolveImportPath(registrationFile, importPath string) string {
 	if importPath == "" {
@@ -613,3 +613,162 @@ func TestMergeComponents(t *testing.T) {
 	assert.Len(t, result.Emits, 1)
 	assert.Equal(t, "fallbackEmit", result.Emits[0])
 }
+
+func TestGetComponentWithInheritance(t *testing.T) {
+	indexer, err := NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = indexer.Close() }()
+
+	components := []VueComponent{
+		{
+			Name:  "sw-base-field",
+			Props: []VueComponentProp{{Name: "label"}, {Name: "disabled", Type: "Boolean"}},
+			Emits: []string{"focus"},
+			Slots: []VueComponentSlot{{Name: "label"}},
+		},
+		{
+			Name:             "sw-text-field",
+			ExtendsComponent: "sw-base-field",
+			Props:            []VueComponentProp{{Name: "value"}, {Name: "disabled", Type: "String"}},
+			Emits:            []string{"update:value"},
+		},
+		{
+			Name:             "sw-custom-field",
+			ExtendsComponent: "sw-text-field",
+		},
+		// Circular extends must not loop forever
+		{Name: "sw-loop-a", ExtendsComponent: "sw-loop-b", Props: []VueComponentProp{{Name: "a"}}},
+		{Name: "sw-loop-b", ExtendsComponent: "sw-loop-a", Props: []VueComponentProp{{Name: "b"}}},
+	}
+	for _, comp := range components {
+		comp.FilePath = "/admin/" + comp.Name + "/index.js"
+		require.NoError(t, indexer.SaveComponent(comp))
+	}
+
+	resolved, err := indexer.GetComponentWithInheritance("sw-custom-field")
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+
+	var props []string
+	for _, prop := range resolved[0].Props {
+		props = append(props, prop.Name+":"+prop.Type)
+	}
+	assert.Equal(t, []string{"value:", "disabled:String", "label:"}, props)
+	assert.Equal(t, []string{"update:value", "focus"}, resolved[0].Emits)
+	assert.Equal(t, []VueComponentSlot{{Name: "label"}}, resolved[0].Slots)
+
+	// The indexed component itself is left untouched
+	plain, err := indexer.GetComponentWithDefinition("sw-custom-field")
+	require.NoError(t, err)
+	require.Len(t, plain, 1)
+	assert.Empty(t, plain[0].Props)
+
+	loop, err := indexer.GetComponentWithInheritance("sw-loop-a")
+	require.NoError(t, err)
+	require.Len(t, loop, 1)
+	assert.Len(t, loop[0].Props, 2)
+}
+
+func TestParseLocalComponents(t *testing.T) {
+	code := `
+import MtCard from './mt-card';
+import { MtButton } from '@shopware-ag/meteor-component-library';
+
+Shopware.Component.register('sw-local-parent', {
+    template,
+
+    components: {
+        'mt-card': MtCard,
+        MtButton,
+        SwLazyChild: () => import('./sw-lazy-child'),
+    },
+});
+`
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	filePath := "/project/src/Resources/app/administration/src/component/sw-local-parent/index.js"
+	components := parseComponentRegistrations(tree.RootNode(), []byte(code), filePath)
+
+	require.Len(t, components, 1)
+	def := components[0].InlineDefinition
+	require.NotNil(t, def)
+
+	require.Len(t, def.LocalComponents, 3)
+
+	assert.Equal(t, "mt-card", def.LocalComponents[0].Name)
+	assert.Equal(t, "MtCard", def.LocalComponents[0].Identifier)
+	assert.Equal(t, "/project/src/Resources/app/administration/src/component/sw-local-parent/mt-card/index.js", def.LocalComponents[0].ImportPath)
+
+	assert.Equal(t, "mt-button", def.LocalComponents[1].Name)
+	assert.Equal(t, "MtButton", def.LocalComponents[1].Identifier)
+	assert.Empty(t, def.LocalComponents[1].ImportPath)
+
+	assert.Equal(t, "sw-lazy-child", def.LocalComponents[2].Name)
+	assert.Equal(t, "/project/src/Resources/app/administration/src/component/sw-local-parent/sw-lazy-child/index.js", def.LocalComponents[2].ImportPath)
+}
+
+func TestParseComponentDynamicImportPath(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+
+	filePath := "/project/src/Administration/Resources/app/administration/src/app/component/index.ts"
+
+	tests := []struct {
+		name           string
+		code           string
+		importPath     string
+		dynamic        bool
+		definitionPath string
+	}{
+		{
+			name:           "template literal without substitution",
+			code:           "Component.register('sw-foo', () => import(`src/app/component/sw-foo/index.js`));",
+			importPath:     "src/app/component/sw-foo/index.js",
+			definitionPath: "/project/src/Administration/Resources/app/administration/src/app/component/sw-foo/index.js",
+		},
+		{
+			name:           "webpack magic comment",
+			code:           "Component.register('sw-foo', () => import(/* webpackChunkName: \"sw-foo\" */ './sw-foo'));",
+			importPath:     "./sw-foo",
+			definitionPath: "/project/src/Administration/Resources/app/administration/src/app/component/sw-foo/index.js",
+		},
+		{
+			name:       "template literal with substitution",
+			code:       "Component.register('sw-foo', () => import(`src/app/component/${name}/index`));",
+			importPath: "src/app/component/",
+			dynamic:    true,
+		},
+		{
+			name:       "concatenation",
+			code:       "Component.extend('sw-foo', 'sw-bar', () => import('src/app/component/' + name + '/index'));",
+			importPath: "src/app/component/",
+			dynamic:    true,
+		},
+		{
+			name:    "variable",
+			code:    "Component.register('sw-foo', () => import(path));",
+			dynamic: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := parser.Parse([]byte(tt.code), nil)
+			defer tree.Close()
+
+			components := parseComponentRegistrations(tree.RootNode(), []byte(tt.code), filePath)
+
+			require.Len(t, components, 1)
+			assert.Equal(t, tt.importPath, components[0].ImportPath)
+			assert.Equal(t, tt.dynamic, components[0].DynamicImportPath)
+			assert.Equal(t, tt.definitionPath, components[0].DefinitionPath)
+		})
+	}
+}
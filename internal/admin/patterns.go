@@ -269,3 +269,30 @@ func CamelToKebab(s string) string {
 	}
 	return string(result)
 }
+
+// GetAttributeOfValue returns the tag and attribute name when the node is the value of an attribute
+// or one of its quotes
+//
+// Example: <sw-icon name="regular-products">
+//
+//	^^^^^^^^^^^^^^^^^^ given this node, returns "sw-icon" and "name"
+func GetAttributeOfValue(node *tree_sitter.Node, content []byte) (string, string) {
+	if node != nil && node.Kind() != "html_attribute_value" {
+		node = node.Parent()
+	}
+	if node == nil || node.Kind() != "html_attribute_value" {
+		return "", ""
+	}
+
+	attribute := node.Parent()
+	if attribute == nil || attribute.Kind() != "html_attribute" {
+		return "", ""
+	}
+
+	name := attribute.ChildByFieldName("name")
+	if name == nil {
+		return "", ""
+	}
+
+	return GetTagNameFromStartTag(attribute.Parent(), content), name.Utf8Text(content)
+}
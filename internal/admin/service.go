@@ -0,0 +1,233 @@
+package admin
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// AdminService represents a service registered in the administration service container
+type AdminService struct {
+	// Name is the service name (e.g., "repositoryFactory")
+	Name string
+
+	// Decorator indicates the registration decorates an existing service instead of providing it
+	Decorator bool
+
+	// FilePath is the absolute path to the file registering the service
+	FilePath string
+
+	// Line is the line number where the service is registered (1-based)
+	Line int
+}
+
+var (
+	// JSServiceRegistrationPattern matches calls registering a service provider
+	//
+	// Example: Shopware.Application.addServiceProvider('foo', () => ...)
+	//          Shopware.Service().register('foo', () => ...)
+	JSServiceRegistrationPattern = treesitterhelper.And(
+		treesitterhelper.NodeKind("call_expression"),
+		treesitterhelper.HasChild(
+			treesitterhelper.And(
+				treesitterhelper.NodeKind("member_expression"),
+				treesitterhelper.Or(
+					treesitterhelper.NodeText("Shopware.Application.addServiceProvider"),
+					treesitterhelper.NodeText("Application.addServiceProvider"),
+					treesitterhelper.NodeText("Shopware.Service().register"),
+					treesitterhelper.NodeText("Service().register"),
+				),
+			),
+		),
+	)
+
+	// JSServiceDecorationPattern matches calls decorating an existing service
+	//
+	// Example: Shopware.Application.addServiceProviderDecorator('foo', (service) => ...)
+	//          Shopware.Service().registerDecorator('foo', (service) => ...)
+	JSServiceDecorationPattern = treesitterhelper.And(
+		treesitterhelper.NodeKind("call_expression"),
+		treesitterhelper.HasChild(
+			treesitterhelper.And(
+				treesitterhelper.NodeKind("member_expression"),
+				treesitterhelper.Or(
+					treesitterhelper.NodeText("Shopware.Application.addServiceProviderDecorator"),
+					treesitterhelper.NodeText("Application.addServiceProviderDecorator"),
+					treesitterhelper.NodeText("Shopware.Service().registerDecorator"),
+					treesitterhelper.NodeText("Service().registerDecorator"),
+				),
+			),
+		),
+	)
+)
+
+// JSServiceCallPattern matches the service lookup call
+//
+// Example: Shopware.Service('repositoryFactory')
+var JSServiceCallPattern = treesitterhelper.And(
+	treesitterhelper.NodeKind("call_expression"),
+	treesitterhelper.HasChild(
+		treesitterhelper.Or(
+			treesitterhelper.And(
+				treesitterhelper.NodeKind("member_expression"),
+				treesitterhelper.NodeText("Shopware.Service"),
+			),
+			treesitterhelper.And(
+				treesitterhelper.NodeKind("identifier"),
+				treesitterhelper.NodeText("Service"),
+			),
+		),
+	),
+)
+
+// JSStringInServiceCallPattern matches the service name string passed to Shopware.Service()
+// Matches the quote character as well, so completion works in an empty string
+//
+// Example: Shopware.Service('<caret>')
+var JSStringInServiceCallPattern = treesitterhelper.FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+	if node.Kind() != "string" {
+		node = node.Parent()
+	}
+	if node == nil || node.Kind() != "string" {
+		return false
+	}
+
+	argsNode := node.Parent()
+	if argsNode == nil || argsNode.Kind() != "arguments" {
+		return false
+	}
+
+	args := getArguments(argsNode)
+	if len(args) == 0 || !args[0].Equals(*node) {
+		return false
+	}
+
+	return JSServiceCallPattern.Matches(argsNode.Parent(), content)
+})
+
+// AdminServiceIndexer indexes the services registered in the administration service container
+type AdminServiceIndexer struct {
+	serviceIndex *indexer.DataIndexer[AdminService]
+}
+
+func NewAdminServiceIndexer(configDir string) (*AdminServiceIndexer, error) {
+	serviceIndex, err := indexer.NewDataIndexer[AdminService](path.Join(configDir, "admin_service.db"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminServiceIndexer{serviceIndex: serviceIndex}, nil
+}
+
+func (idx *AdminServiceIndexer) ID() string {
+	return "admin.service.indexer"
+}
+
+func (idx *AdminServiceIndexer) FileExtensions() []string {
+	return []string{".js", ".ts"}
+}
+
+func (idx *AdminServiceIndexer) Index(filePath string, node *tree_sitter.Node, fileContent []byte) error {
+	ext := filepath.Ext(filePath)
+	if ext != ".js" && ext != ".ts" {
+		return nil
+	}
+
+	// Only index files in Administration directory
+	if !strings.Contains(filePath, "Resources/app/administration") {
+		return nil
+	}
+
+	services := parseServiceRegistrations(node, fileContent, filePath)
+	if len(services) == 0 {
+		return nil
+	}
+
+	batchSave := map[string]map[string]AdminService{filePath: {}}
+	for _, service := range services {
+		// Keep the provider when a file registers and decorates the same service
+		if existing, ok := batchSave[filePath][service.Name]; ok && !existing.Decorator {
+			continue
+		}
+		batchSave[filePath][service.Name] = service
+	}
+
+	return idx.serviceIndex.BatchSaveItems(batchSave)
+}
+
+// parseServiceRegistrations finds all service registrations and decorations in a file
+func parseServiceRegistrations(root *tree_sitter.Node, content []byte, filePath string) []AdminService {
+	var services []AdminService
+
+	for _, decorator := range []bool{false, true} {
+		pattern := JSServiceRegistrationPattern
+		if decorator {
+			pattern = JSServiceDecorationPattern
+		}
+
+		for _, call := range treesitterhelper.FindAll(root, pattern, content) {
+			argsNode := call.ChildByFieldName("arguments")
+			if argsNode == nil {
+				continue
+			}
+
+			args := getArguments(argsNode)
+			if len(args) == 0 || args[0].Kind() != "string" {
+				continue
+			}
+
+			name := extractStringContent(args[0], content)
+			if name == "" {
+				continue
+			}
+
+			services = append(services, AdminService{
+				Name:      name,
+				Decorator: decorator,
+				FilePath:  filePath,
+				Line:      int(call.StartPosition().Row) + 1,
+			})
+		}
+	}
+
+	return services
+}
+
+func (idx *AdminServiceIndexer) RemovedFiles(paths []string) error {
+	return idx.serviceIndex.BatchDeleteByFilePaths(paths)
+}
+
+func (idx *AdminServiceIndexer) Close() error {
+	return idx.serviceIndex.Close()
+}
+
+func (idx *AdminServiceIndexer) Clear() error {
+	return idx.serviceIndex.Clear()
+}
+
+func (idx *AdminServiceIndexer) BeginBatch() error {
+	return idx.serviceIndex.BeginBatch()
+}
+
+func (idx *AdminServiceIndexer) Commit() error {
+	return idx.serviceIndex.Commit()
+}
+
+// Count returns the number of indexed service names
+func (idx *AdminServiceIndexer) Count() (int, error) {
+	return idx.serviceIndex.CountKeys()
+}
+
+// GetAllServiceNames returns all registered service names sorted alphabetically
+func (idx *AdminServiceIndexer) GetAllServiceNames() ([]string, error) {
+	return idx.serviceIndex.GetAllKeysSorted()
+}
+
+// GetService returns all registrations and decorations of the given service
+func (idx *AdminServiceIndexer) GetService(name string) ([]AdminService, error) {
+	return idx.serviceIndex.GetValues(name)
+}
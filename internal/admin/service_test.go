@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
+)
+
+func TestAdminServiceIndexer(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+
+	idx, err := NewAdminServiceIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	coreCode := `
+const { Application } = Shopware;
+
+Application.addServiceProvider('repositoryFactory', () => {
+    return new RepositoryFactory();
+});
+
+Shopware.Service().register('acl', () => new AclService());
+Shopware.Service('acl').can('product.viewer');
+`
+	pluginCode := `
+Shopware.Application.addServiceProviderDecorator('acl', (aclService) => aclService);
+`
+
+	corePath := "/project/src/Administration/Resources/app/administration/src/app/init/services.js"
+	pluginPath := "/project/custom/plugins/MyPlugin/src/Resources/app/administration/src/main.js"
+
+	for path, code := range map[string]string{corePath: coreCode, pluginPath: pluginCode} {
+		tree := parser.Parse([]byte(code), nil)
+		require.NoError(t, idx.Index(path, tree.RootNode(), []byte(code)))
+		tree.Close()
+	}
+
+	names, err := idx.GetAllServiceNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acl", "repositoryFactory"}, names)
+
+	count, err := idx.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	services, err := idx.GetService("acl")
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+
+	byPath := make(map[string]AdminService)
+	for _, service := range services {
+		byPath[service.FilePath] = service
+	}
+	assert.Equal(t, AdminService{Name: "acl", FilePath: corePath, Line: 8}, byPath[corePath])
+	assert.Equal(t, AdminService{Name: "acl", Decorator: true, FilePath: pluginPath, Line: 2}, byPath[pluginPath])
+
+	require.NoError(t, idx.RemovedFiles([]string{pluginPath}))
+	services, err = idx.GetService("acl")
+	require.NoError(t, err)
+	assert.Len(t, services, 1)
+}
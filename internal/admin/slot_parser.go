@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 )
 
 // slotTagPattern matches <slot> and <slot name="..."> tags
@@ -44,6 +46,76 @@ func ParseTemplateFromFile(templatePath string) (*TemplateParseResult, error) {
 	return &result, nil
 }
 
+// templateParseCache caches template parse results keyed by path, so that
+// re-indexing components whose template did not change skips the re-parse.
+// Entries are invalidated when the file's modification time or size changes.
+type templateParseCache struct {
+	mu      sync.Mutex
+	entries map[string]templateCacheEntry
+}
+
+type templateCacheEntry struct {
+	modTime time.Time
+	size    int64
+	result  TemplateParseResult
+}
+
+func newTemplateParseCache() *templateParseCache {
+	return &templateParseCache{entries: make(map[string]templateCacheEntry)}
+}
+
+// parse returns the parse result for the template, reading the file only
+// when it is not cached or has changed since it was cached
+func (c *templateParseCache) parse(templatePath string) (*TemplateParseResult, error) {
+	info, err := os.Stat(templatePath)
+	if err != nil {
+		c.invalidate([]string{templatePath})
+		return nil, err
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[templatePath]
+	c.mu.Unlock()
+
+	if ok && entry.modTime.Equal(info.ModTime()) && entry.size == info.Size() {
+		result := entry.result
+		return &result, nil
+	}
+
+	result, err := ParseTemplateFromFile(templatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[templatePath] = templateCacheEntry{
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		result:  *result,
+	}
+	c.mu.Unlock()
+
+	return result, nil
+}
+
+// invalidate drops the cached results for the given paths
+func (c *templateParseCache) invalidate(paths []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range paths {
+		delete(c.entries, p)
+	}
+}
+
+// reset drops all cached results
+func (c *templateParseCache) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]templateCacheEntry)
+}
+
 // parseTemplateContent extracts slots and blocks from template content
 func parseTemplateContent(content string) TemplateParseResult {
 	var result TemplateParseResult
@@ -1,9 +1,13 @@
 package admin
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseSlotsFromContent(t *testing.T) {
@@ -232,3 +236,48 @@ func TestResolveTemplatePath(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateParseCache(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "sw-card.html.twig")
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	writeTemplate := func(content string, mtime time.Time) {
+		require.NoError(t, os.WriteFile(templatePath, []byte(content), 0o644))
+		require.NoError(t, os.Chtimes(templatePath, mtime, mtime))
+	}
+
+	cache := newTemplateParseCache()
+
+	writeTemplate(`<slot name="aaa"></slot>`, modTime)
+	result, err := cache.parse(templatePath)
+	require.NoError(t, err)
+	require.Len(t, result.Slots, 1)
+	assert.Equal(t, "aaa", result.Slots[0].Name)
+
+	// Same size and modification time: the cached result is returned without re-parsing
+	writeTemplate(`<slot name="bbb"></slot>`, modTime)
+	result, err = cache.parse(templatePath)
+	require.NoError(t, err)
+	require.Len(t, result.Slots, 1)
+	assert.Equal(t, "aaa", result.Slots[0].Name)
+
+	// Changed modification time invalidates the entry
+	writeTemplate(`<slot name="bbb"></slot>`, modTime.Add(time.Second))
+	result, err = cache.parse(templatePath)
+	require.NoError(t, err)
+	require.Len(t, result.Slots, 1)
+	assert.Equal(t, "bbb", result.Slots[0].Name)
+
+	// Explicit invalidation forces a re-parse
+	writeTemplate(`<slot name="ccc"></slot>`, modTime.Add(time.Second))
+	cache.invalidate([]string{templatePath})
+	result, err = cache.parse(templatePath)
+	require.NoError(t, err)
+	require.Len(t, result.Slots, 1)
+	assert.Equal(t, "ccc", result.Slots[0].Name)
+
+	// Missing files return an error and drop the entry
+	require.NoError(t, os.Remove(templatePath))
+	_, err = cache.parse(templatePath)
+	assert.Error(t, err)
+}
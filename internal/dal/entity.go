@@ -0,0 +1,295 @@
+package dal
+
+import (
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/php"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// EntityDefinition represents a DAL entity definition class
+type EntityDefinition struct {
+	Class      string        // FQCN of the definition class
+	EntityName string        // Entity name returned by getEntityName(), e.g. product
+	Fields     []EntityField // Fields declared in defineFields()
+	Path       string        // Source file path
+	Line       int           // Line number of the class declaration
+}
+
+// EntityField represents a field declared in defineFields()
+type EntityField struct {
+	Name      string // Property name used in criteria, e.g. productNumber
+	Type      string // Field class, e.g. StringField
+	Reference string // FQCN of the definition an association field refers to
+	Line      int    // Line number of the field declaration
+}
+
+// IsAssociation reports whether the field is an association to another entity
+func (f EntityField) IsAssociation() bool {
+	return strings.HasSuffix(f.Type, "AssociationField")
+}
+
+// ParseEntityDefinitions extracts the entity definitions declared in a PHP file.
+// Classes are considered entity definitions when they declare a defineFields() method.
+func ParseEntityDefinitions(path string, rootNode *tree_sitter.Node, content []byte) []EntityDefinition {
+	var definitions []EntityDefinition
+
+	namespace := ""
+	namespaceNode := treesitterhelper.FindFirst(rootNode, treesitterhelper.NodeKind("namespace_name"), content)
+	if namespaceNode != nil {
+		namespace = namespaceNode.Utf8Text(content)
+	}
+
+	classNodes := treesitterhelper.FindAll(rootNode, treesitterhelper.NodeKind("class_declaration"), content)
+	for _, classNode := range classNodes {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			continue
+		}
+
+		defineFields := findMethod(classNode, content, "defineFields")
+		if defineFields == nil {
+			continue
+		}
+
+		className := nameNode.Utf8Text(content)
+		if namespace != "" {
+			className = namespace + "\\" + className
+		}
+
+		definition := EntityDefinition{
+			Class:  className,
+			Fields: parseFields(rootNode, defineFields, content),
+			Path:   path,
+			Line:   int(classNode.StartPosition().Row) + 1,
+		}
+
+		if extendsEntityDefinition(classNode, content) {
+			definition.EntityName = parseEntityName(classNode, content)
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions
+}
+
+// extendsEntityDefinition checks if the class extends EntityDefinition or one of its
+// abstract subclasses like EntityTranslationDefinition or MappingEntityDefinition
+func extendsEntityDefinition(classNode *tree_sitter.Node, content []byte) bool {
+	baseClause := treesitterhelper.GetFirstNodeOfKind(classNode, "base_clause")
+	if baseClause == nil {
+		return false
+	}
+
+	for i := uint(0); i < baseClause.NamedChildCount(); i++ {
+		child := baseClause.NamedChild(i)
+		if child.Kind() != "name" && child.Kind() != "qualified_name" {
+			continue
+		}
+
+		parent := child.Utf8Text(content)
+		return strings.HasSuffix(parent[strings.LastIndex(parent, "\\")+1:], "EntityDefinition")
+	}
+
+	return false
+}
+
+// parseEntityName resolves the value returned by getEntityName(), either a string
+// literal or a constant of the class itself like self::ENTITY_NAME
+func parseEntityName(classNode *tree_sitter.Node, content []byte) string {
+	method := findMethod(classNode, content, "getEntityName")
+	if method == nil {
+		return ""
+	}
+
+	returnStatement := treesitterhelper.FindFirst(method, treesitterhelper.NodeKind("return_statement"), content)
+	if returnStatement == nil || returnStatement.NamedChildCount() == 0 {
+		return ""
+	}
+
+	value := returnStatement.NamedChild(0)
+	switch value.Kind() {
+	case "string", "encapsed_string":
+		return treesitterhelper.GetNodeText(value, content)
+	case "class_constant_access_expression":
+		if value.NamedChildCount() != 2 {
+			return ""
+		}
+
+		scope := value.NamedChild(0).Utf8Text(content)
+		if scope != "self" && scope != "static" {
+			return ""
+		}
+
+		return classConstantValue(classNode, content, value.NamedChild(1).Utf8Text(content))
+	}
+
+	return ""
+}
+
+// classConstantValue returns the string value of a constant declared in the class
+func classConstantValue(classNode *tree_sitter.Node, content []byte, constName string) string {
+	elements := treesitterhelper.FindAll(classNode, treesitterhelper.NodeKind("const_element"), content)
+	for _, element := range elements {
+		nameNode := treesitterhelper.GetFirstNodeOfKind(element, "name")
+		if nameNode == nil || nameNode.Utf8Text(content) != constName {
+			continue
+		}
+
+		value := element.NamedChild(element.NamedChildCount() - 1)
+		if value.Kind() == "string" || value.Kind() == "encapsed_string" {
+			return treesitterhelper.GetNodeText(value, content)
+		}
+	}
+
+	return ""
+}
+
+// findMethod returns the declaration of the given method in the class
+func findMethod(classNode *tree_sitter.Node, content []byte, methodName string) *tree_sitter.Node {
+	body := classNode.ChildByFieldName("body")
+	if body == nil {
+		return nil
+	}
+
+	for i := uint(0); i < body.NamedChildCount(); i++ {
+		child := body.NamedChild(i)
+		if child.Kind() != "method_declaration" {
+			continue
+		}
+
+		nameNode := child.ChildByFieldName("name")
+		if nameNode != nil && nameNode.Utf8Text(content) == methodName {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// parseFields collects all `new XxxField(...)` expressions of a method body
+func parseFields(rootNode *tree_sitter.Node, methodNode *tree_sitter.Node, content []byte) []EntityField {
+	var fields []EntityField
+	seen := make(map[string]bool)
+	imports := php.ParseFileImports(rootNode, content)
+
+	creations := treesitterhelper.FindAll(methodNode, treesitterhelper.NodeKind("object_creation_expression"), content)
+	for _, creation := range creations {
+		fieldType := objectCreationClassName(creation, content)
+		if !strings.HasSuffix(fieldType, "Field") {
+			continue
+		}
+
+		args := stringArguments(creation, content)
+		if len(args) == 0 {
+			continue
+		}
+
+		// Storage based fields take the storage name first and the property name second,
+		// associations and translated fields only take the property name
+		name := args[0]
+		if len(args) > 1 && !strings.HasSuffix(fieldType, "AssociationField") {
+			name = args[1]
+		}
+
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		field := EntityField{
+			Name: name,
+			Type: fieldType,
+			Line: int(creation.StartPosition().Row) + 1,
+		}
+
+		// The first class argument is the referenced definition, many to many associations
+		// pass the mapping definition second
+		if field.IsAssociation() {
+			if reference := classArgument(creation, content); reference != "" {
+				field.Reference = imports.Resolve(reference)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// objectCreationClassName returns the unqualified class name of a `new` expression
+func objectCreationClassName(node *tree_sitter.Node, content []byte) string {
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		child := node.NamedChild(i)
+		switch child.Kind() {
+		case "name":
+			return child.Utf8Text(content)
+		case "qualified_name":
+			name := child.Utf8Text(content)
+			return name[strings.LastIndex(name, "\\")+1:]
+		}
+	}
+
+	return ""
+}
+
+// stringArguments returns the leading string literal arguments of a call
+func stringArguments(node *tree_sitter.Node, content []byte) []string {
+	arguments := treesitterhelper.GetFirstNodeOfKind(node, "arguments")
+	if arguments == nil {
+		return nil
+	}
+
+	var values []string
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		argument := arguments.NamedChild(i)
+		if argument.Kind() != "argument" || argument.NamedChildCount() == 0 {
+			break
+		}
+
+		value := argument.NamedChild(argument.NamedChildCount() - 1)
+		if value.Kind() != "string" && value.Kind() != "encapsed_string" {
+			break
+		}
+
+		values = append(values, treesitterhelper.GetNodeText(value, content))
+	}
+
+	return values
+}
+
+// classArgument returns the class name of the first Foo::class argument of a call
+func classArgument(node *tree_sitter.Node, content []byte) string {
+	arguments := treesitterhelper.GetFirstNodeOfKind(node, "arguments")
+	if arguments == nil {
+		return ""
+	}
+
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		argument := arguments.NamedChild(i)
+		if argument.Kind() != "argument" || argument.NamedChildCount() == 0 {
+			continue
+		}
+
+		value := argument.NamedChild(argument.NamedChildCount() - 1)
+		if value.Kind() != "class_constant_access_expression" || value.NamedChildCount() != 2 {
+			continue
+		}
+
+		if value.NamedChild(1).Utf8Text(content) == "class" {
+			return value.NamedChild(0).Utf8Text(content)
+		}
+	}
+
+	return ""
+}
+
+// repositorySuffix is appended to the entity name for the id of the entity repository service
+const repositorySuffix = ".repository"
+
+// RepositoryServiceID returns the id of the repository service of an entity, e.g. product.repository
+func RepositoryServiceID(entityName string) string {
+	return entityName + repositorySuffix
+}
@@ -0,0 +1,202 @@
+package dal
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// EntityIndexer indexes DAL entity definitions and their fields
+type EntityIndexer struct {
+	entityIndex     *indexer.DataIndexer[EntityDefinition]
+	entityNameIndex *indexer.DataIndexer[EntityDefinition]
+}
+
+func NewEntityIndexer(configDir string) (*EntityIndexer, error) {
+	entityIndex, err := indexer.NewDataIndexer[EntityDefinition](filepath.Join(configDir, "dal.entity"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entity index: %w", err)
+	}
+
+	entityNameIndex, err := indexer.NewDataIndexer[EntityDefinition](filepath.Join(configDir, "dal.entity_name"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create entity name index: %w", err)
+	}
+
+	return &EntityIndexer{
+		entityIndex:     entityIndex,
+		entityNameIndex: entityNameIndex,
+	}, nil
+}
+
+func (i *EntityIndexer) ID() string {
+	return "dal.entity"
+}
+
+func (i *EntityIndexer) FileExtensions() []string {
+	return []string{".php"}
+}
+
+func (i *EntityIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
+	if !strings.HasSuffix(path, ".php") || !bytes.Contains(fileContent, []byte("defineFields")) {
+		return nil
+	}
+
+	definitions := ParseEntityDefinitions(path, node, fileContent)
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	batchSave := map[string]map[string]EntityDefinition{path: {}}
+	nameBatchSave := map[string]map[string]EntityDefinition{path: {}}
+	for _, definition := range definitions {
+		batchSave[path][definition.Class] = definition
+
+		if definition.EntityName != "" {
+			nameBatchSave[path][definition.EntityName] = definition
+		}
+	}
+
+	if err := i.entityIndex.BatchSaveItems(batchSave); err != nil {
+		return fmt.Errorf("saving entity definitions: %w", err)
+	}
+
+	if err := i.entityNameIndex.BatchSaveItems(nameBatchSave); err != nil {
+		return fmt.Errorf("saving entity names: %w", err)
+	}
+
+	return nil
+}
+
+func (i *EntityIndexer) RemovedFiles(paths []string) error {
+	if err := i.entityIndex.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+
+	return i.entityNameIndex.BatchDeleteByFilePaths(paths)
+}
+
+func (i *EntityIndexer) Close() error {
+	if err := i.entityIndex.Close(); err != nil {
+		return err
+	}
+
+	return i.entityNameIndex.Close()
+}
+
+func (i *EntityIndexer) Clear() error {
+	if err := i.entityIndex.Clear(); err != nil {
+		return err
+	}
+
+	return i.entityNameIndex.Clear()
+}
+
+func (i *EntityIndexer) BeginBatch() error {
+	return indexer.BeginBatches(i.entityIndex, i.entityNameIndex)
+}
+
+func (i *EntityIndexer) Commit() error {
+	return indexer.CommitBatches(i.entityIndex, i.entityNameIndex)
+}
+
+// Count returns the number of indexed entity definitions
+func (i *EntityIndexer) Count() (int, error) {
+	return i.entityIndex.CountKeys()
+}
+
+// GetAllDefinitions returns all indexed entity definitions sorted by class
+func (i *EntityIndexer) GetAllDefinitions() ([]EntityDefinition, error) {
+	return i.entityIndex.GetAllValuesSorted()
+}
+
+// GetFieldNames returns all field names mapped to the definition classes declaring them, in class order
+func (i *EntityIndexer) GetFieldNames() (map[string][]string, error) {
+	definitions, err := i.GetAllDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string][]string)
+	for _, definition := range definitions {
+		for _, field := range definition.Fields {
+			fields[field.Name] = append(fields[field.Name], definition.Class)
+		}
+	}
+
+	return fields, nil
+}
+
+// GetEntityByName returns the definition of the entity with the given name
+func (i *EntityIndexer) GetEntityByName(name string) (EntityDefinition, bool) {
+	definitions, err := i.entityNameIndex.GetValues(name)
+	if err != nil || len(definitions) == 0 {
+		return EntityDefinition{}, false
+	}
+
+	return definitions[0], true
+}
+
+// GetAllEntityNames returns the names of all indexed entities sorted alphabetically
+func (i *EntityIndexer) GetAllEntityNames() ([]string, error) {
+	return i.entityNameIndex.GetAllKeysSorted()
+}
+
+// GetDefinition returns the definition with the given class
+func (i *EntityIndexer) GetDefinition(class string) (EntityDefinition, bool) {
+	definitions, err := i.entityIndex.GetValues(class)
+	if err != nil || len(definitions) == 0 {
+		return EntityDefinition{}, false
+	}
+
+	return definitions[0], true
+}
+
+// ResolveAssociationPath returns the definitions a dot separated path like product.categories leads to.
+// The first segment is an entity name, or an association name of any entity when no entity has that name.
+func (i *EntityIndexer) ResolveAssociationPath(path string) ([]EntityDefinition, error) {
+	segments := strings.Split(path, ".")
+
+	var current []EntityDefinition
+	if definition, found := i.GetEntityByName(segments[0]); found {
+		current = append(current, definition)
+	} else {
+		definitions, err := i.GetAllDefinitions()
+		if err != nil {
+			return nil, err
+		}
+
+		current = i.followAssociation(definitions, segments[0])
+	}
+
+	for _, segment := range segments[1:] {
+		current = i.followAssociation(current, segment)
+	}
+
+	return current, nil
+}
+
+// followAssociation returns the definitions referenced by the association with the given name
+func (i *EntityIndexer) followAssociation(definitions []EntityDefinition, name string) []EntityDefinition {
+	var referenced []EntityDefinition
+	seen := make(map[string]bool)
+
+	for _, definition := range definitions {
+		for _, field := range definition.Fields {
+			if field.Name != name || field.Reference == "" || seen[field.Reference] {
+				continue
+			}
+			seen[field.Reference] = true
+
+			if reference, found := i.GetDefinition(field.Reference); found {
+				referenced = append(referenced, reference)
+			}
+		}
+	}
+
+	return referenced
+}
@@ -0,0 +1,123 @@
+package dal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func indexTestFile(t *testing.T, idx *EntityIndexer, filePath string) {
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	require.NoError(t, idx.Index(filePath, tree.RootNode(), content))
+}
+
+func TestEntityIndexer_Index(t *testing.T) {
+	idx, err := NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	filePath := filepath.Join("testdata", "ProductDefinition.php")
+	indexTestFile(t, idx, filePath)
+
+	definitions, err := idx.GetAllDefinitions()
+	require.NoError(t, err)
+	require.Len(t, definitions, 1)
+
+	definition := definitions[0]
+	assert.Equal(t, `Shopware\Core\Content\Product\ProductDefinition`, definition.Class)
+	assert.Equal(t, "product", definition.EntityName)
+	assert.Equal(t, filePath, definition.Path)
+	assert.Equal(t, 15, definition.Line)
+
+	names := make([]string, 0, len(definition.Fields))
+	for _, field := range definition.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"id", "manufacturerId", "productNumber", "name", "manufacturer"}, names)
+
+	assert.Equal(t, "StringField", definition.Fields[2].Type)
+	assert.Equal(t, 29, definition.Fields[2].Line)
+	assert.Empty(t, definition.Fields[1].Reference)
+	assert.Equal(t, `Shopware\Core\Content\Product\ProductManufacturerDefinition`, definition.Fields[4].Reference)
+
+	fields, err := idx.GetFieldNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{`Shopware\Core\Content\Product\ProductDefinition`}, fields["productNumber"])
+
+	count, err := idx.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, idx.RemovedFiles([]string{filePath}))
+	definitions, err = idx.GetAllDefinitions()
+	require.NoError(t, err)
+	assert.Empty(t, definitions)
+}
+
+func TestEntityIndexer_EntityNames(t *testing.T) {
+	idx, err := NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	indexTestFile(t, idx, filepath.Join("testdata", "ProductDefinition.php"))
+	indexTestFile(t, idx, filepath.Join("testdata", "ExampleDefinition.php"))
+
+	names, err := idx.GetAllEntityNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"my_plugin_example", "product"}, names)
+
+	example, found := idx.GetEntityByName("my_plugin_example")
+	require.True(t, found)
+	assert.Equal(t, `MyPlugin\Core\Content\Example\ExampleDefinition`, example.Class)
+	require.Len(t, example.Fields, 2)
+	assert.Equal(t, "technicalName", example.Fields[1].Name)
+
+	_, found = idx.GetEntityByName("unknown")
+	assert.False(t, found)
+
+	require.NoError(t, idx.RemovedFiles([]string{filepath.Join("testdata", "ExampleDefinition.php")}))
+	names, err = idx.GetAllEntityNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"product"}, names)
+}
+
+func TestEntityIndexer_ResolveAssociationPath(t *testing.T) {
+	idx, err := NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	indexTestFile(t, idx, filepath.Join("testdata", "ProductDefinition.php"))
+	indexTestFile(t, idx, filepath.Join("testdata", "ProductManufacturerDefinition.php"))
+
+	classes := func(path string) []string {
+		definitions, err := idx.ResolveAssociationPath(path)
+		require.NoError(t, err)
+
+		var result []string
+		for _, definition := range definitions {
+			result = append(result, definition.Class)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{`Shopware\Core\Content\Product\ProductDefinition`}, classes("product"))
+	assert.Equal(t, []string{`Shopware\Core\Content\Product\ProductManufacturerDefinition`}, classes("product.manufacturer"))
+	assert.Equal(t, []string{`Shopware\Core\Content\Product\ProductDefinition`}, classes("product.manufacturer.products"))
+	assert.Equal(t, []string{`Shopware\Core\Content\Product\ProductManufacturerDefinition`}, classes("manufacturer"))
+	assert.Empty(t, classes("product.productNumber"))
+	assert.Empty(t, classes("unknown"))
+}
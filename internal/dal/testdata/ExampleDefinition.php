@@ -0,0 +1,24 @@
+<?php declare(strict_types=1);
+
+namespace MyPlugin\Core\Content\Example;
+
+use Shopware\Core\Framework\DataAbstractionLayer\EntityDefinition;
+use Shopware\Core\Framework\DataAbstractionLayer\Field\IdField;
+use Shopware\Core\Framework\DataAbstractionLayer\Field\StringField;
+use Shopware\Core\Framework\DataAbstractionLayer\FieldCollection;
+
+class ExampleDefinition extends EntityDefinition
+{
+    public function getEntityName(): string
+    {
+        return 'my_plugin_example';
+    }
+
+    protected function defineFields(): FieldCollection
+    {
+        return new FieldCollection([
+            new IdField('id', 'id'),
+            new StringField('technical_name', 'technicalName'),
+        ]);
+    }
+}
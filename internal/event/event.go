@@ -0,0 +1,139 @@
+package event
+
+import (
+	"strings"
+
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Event represents an event name which can be listened to
+type Event struct {
+	Name     string // Event name, e.g. checkout.order.placed or the FQCN of an event class
+	Class    string // FQCN of the class declaring the event
+	Constant string // Constant holding the event name, empty for event classes
+	Path     string // Source file path
+	Line     int    // Line number of the declaration
+}
+
+// ParseEvents extracts the event names declared in a PHP file.
+// Three kinds of declarations are considered events:
+//   - string constants of classes named *Events, e.g. CheckoutEvents::CHECKOUT_ORDER_PLACED
+//   - EVENT_NAME string constants, e.g. CheckoutOrderPlacedEvent::EVENT_NAME
+//   - non-abstract classes named *Event which extend or implement another type, as Symfony
+//     dispatches events under their class name by default
+func ParseEvents(path string, rootNode *tree_sitter.Node, content []byte) []Event {
+	var events []Event
+
+	namespace := ""
+	namespaceNode := treesitterhelper.FindFirst(rootNode, treesitterhelper.NodeKind("namespace_name"), content)
+	if namespaceNode != nil {
+		namespace = namespaceNode.Utf8Text(content)
+	}
+
+	classNodes := treesitterhelper.FindAll(rootNode, treesitterhelper.NodeKind("class_declaration"), content)
+	for _, classNode := range classNodes {
+		nameNode := classNode.ChildByFieldName("name")
+		if nameNode == nil {
+			continue
+		}
+
+		shortName := nameNode.Utf8Text(content)
+		className := shortName
+		if namespace != "" {
+			className = namespace + "\\" + className
+		}
+
+		isEventsClass := strings.HasSuffix(shortName, "Events")
+
+		for _, constant := range stringConstants(classNode, content) {
+			if !isEventsClass && constant.name != "EVENT_NAME" {
+				continue
+			}
+
+			events = append(events, Event{
+				Name:     constant.value,
+				Class:    className,
+				Constant: constant.name,
+				Path:     path,
+				Line:     constant.line,
+			})
+		}
+
+		if strings.HasSuffix(shortName, "Event") && isConcreteSubtype(classNode) {
+			events = append(events, Event{
+				Name:  className,
+				Class: className,
+				Path:  path,
+				Line:  int(nameNode.StartPosition().Row) + 1,
+			})
+		}
+	}
+
+	return events
+}
+
+type stringConstant struct {
+	name  string
+	value string
+	line  int
+}
+
+// stringConstants returns the constants of a class which are assigned a string literal
+func stringConstants(classNode *tree_sitter.Node, content []byte) []stringConstant {
+	body := classNode.ChildByFieldName("body")
+	if body == nil {
+		return nil
+	}
+
+	var constants []stringConstant
+	for i := uint(0); i < body.NamedChildCount(); i++ {
+		declaration := body.NamedChild(i)
+		if declaration.Kind() != "const_declaration" {
+			continue
+		}
+
+		for j := uint(0); j < declaration.NamedChildCount(); j++ {
+			element := declaration.NamedChild(j)
+			if element.Kind() != "const_element" {
+				continue
+			}
+
+			nameNode := treesitterhelper.GetFirstNodeOfKind(element, "name")
+			valueNode := element.NamedChild(element.NamedChildCount() - 1)
+			if nameNode == nil || valueNode == nil {
+				continue
+			}
+
+			if valueNode.Kind() != "string" && valueNode.Kind() != "encapsed_string" {
+				continue
+			}
+
+			value := ""
+			if contentNode := treesitterhelper.GetFirstNodeOfKind(valueNode, "string_content"); contentNode != nil {
+				value = contentNode.Utf8Text(content)
+			}
+			if value == "" {
+				continue
+			}
+
+			constants = append(constants, stringConstant{
+				name:  nameNode.Utf8Text(content),
+				value: value,
+				line:  int(element.StartPosition().Row) + 1,
+			})
+		}
+	}
+
+	return constants
+}
+
+// isConcreteSubtype checks if a class is instantiable and extends or implements another type
+func isConcreteSubtype(classNode *tree_sitter.Node) bool {
+	if treesitterhelper.GetFirstNodeOfKind(classNode, "abstract_modifier") != nil {
+		return false
+	}
+
+	return treesitterhelper.GetFirstNodeOfKind(classNode, "base_clause") != nil ||
+		treesitterhelper.GetFirstNodeOfKind(classNode, "class_interface_clause") != nil
+}
@@ -0,0 +1,90 @@
+package event
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// EventIndexer indexes event names declared in PHP classes
+type EventIndexer struct {
+	eventIndex *indexer.DataIndexer[Event]
+}
+
+func NewEventIndexer(configDir string) (*EventIndexer, error) {
+	eventIndex, err := indexer.NewDataIndexer[Event](filepath.Join(configDir, "event.events"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event index: %w", err)
+	}
+
+	return &EventIndexer{eventIndex: eventIndex}, nil
+}
+
+func (i *EventIndexer) ID() string {
+	return "event.indexer"
+}
+
+func (i *EventIndexer) FileExtensions() []string {
+	return []string{".php"}
+}
+
+func (i *EventIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
+	if !strings.HasSuffix(path, ".php") || !bytes.Contains(fileContent, []byte("Event")) {
+		return nil
+	}
+
+	events := ParseEvents(path, node, fileContent)
+	if len(events) == 0 {
+		return nil
+	}
+
+	batchSave := map[string]map[string][]Event{path: {}}
+	for _, event := range events {
+		batchSave[path][event.Name] = append(batchSave[path][event.Name], event)
+	}
+
+	if err := i.eventIndex.BatchSaveItemLists(batchSave); err != nil {
+		return fmt.Errorf("saving events: %w", err)
+	}
+
+	return nil
+}
+
+func (i *EventIndexer) RemovedFiles(paths []string) error {
+	return i.eventIndex.BatchDeleteByFilePaths(paths)
+}
+
+func (i *EventIndexer) Close() error {
+	return i.eventIndex.Close()
+}
+
+func (i *EventIndexer) Clear() error {
+	return i.eventIndex.Clear()
+}
+
+func (i *EventIndexer) BeginBatch() error {
+	return i.eventIndex.BeginBatch()
+}
+
+func (i *EventIndexer) Commit() error {
+	return i.eventIndex.Commit()
+}
+
+// Count returns the number of indexed event names
+func (i *EventIndexer) Count() (int, error) {
+	return i.eventIndex.CountKeys()
+}
+
+// GetAllEvents returns all indexed events sorted by name
+func (i *EventIndexer) GetAllEvents() ([]Event, error) {
+	return i.eventIndex.GetAllValuesSorted()
+}
+
+// GetEvent returns all declarations of the given event name
+func (i *EventIndexer) GetEvent(name string) ([]Event, error) {
+	return i.eventIndex.GetValues(name)
+}
@@ -0,0 +1,76 @@
+package event
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func indexTestFile(t *testing.T, idx *EventIndexer, filePath string) {
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	require.NoError(t, idx.Index(filePath, tree.RootNode(), content))
+}
+
+func TestEventIndexer_Index(t *testing.T) {
+	idx, err := NewEventIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	eventsFile := filepath.Join("testdata", "CheckoutEvents.php")
+	eventClassFile := filepath.Join("testdata", "CheckoutOrderPlacedEvent.php")
+	indexTestFile(t, idx, eventsFile)
+	indexTestFile(t, idx, eventClassFile)
+
+	events, err := idx.GetAllEvents()
+	require.NoError(t, err)
+
+	var names []string
+	for _, ev := range events {
+		names = append(names, ev.Name)
+	}
+	assert.Equal(t, []string{
+		`Shopware\Core\Checkout\Cart\Event\CheckoutOrderPlacedEvent`,
+		"checkout.order.placed",
+		"checkout.order.placed",
+		"checkout.order.placed.criteria",
+	}, names)
+
+	placed, err := idx.GetEvent("checkout.order.placed")
+	require.NoError(t, err)
+	require.Len(t, placed, 2)
+	assert.Equal(t, `Shopware\Core\Checkout\CheckoutEvents`, placed[0].Class)
+	assert.Equal(t, "CHECKOUT_ORDER_PLACED", placed[0].Constant)
+	assert.Equal(t, eventsFile, placed[0].Path)
+	assert.Equal(t, 8, placed[0].Line)
+	assert.Equal(t, `Shopware\Core\Checkout\Cart\Event\CheckoutOrderPlacedEvent`, placed[1].Class)
+	assert.Equal(t, "EVENT_NAME", placed[1].Constant)
+
+	eventClass, err := idx.GetEvent(`Shopware\Core\Checkout\Cart\Event\CheckoutOrderPlacedEvent`)
+	require.NoError(t, err)
+	require.Len(t, eventClass, 1)
+	assert.Empty(t, eventClass[0].Constant)
+	assert.Equal(t, 9, eventClass[0].Line)
+
+	count, err := idx.Count()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, idx.RemovedFiles([]string{eventsFile}))
+	placed, err = idx.GetEvent("checkout.order.placed")
+	require.NoError(t, err)
+	assert.Len(t, placed, 1)
+}
@@ -0,0 +1,13 @@
+<?php declare(strict_types=1);
+
+namespace Shopware\Core\Checkout;
+
+#[Package('checkout')]
+class CheckoutEvents
+{
+    final public const CHECKOUT_ORDER_PLACED = 'checkout.order.placed';
+
+    final public const CHECKOUT_ORDER_PLACED_CRITERIA = 'checkout.order.placed.criteria';
+
+    final public const ORDER_CLASS = OrderEntity::class;
+}
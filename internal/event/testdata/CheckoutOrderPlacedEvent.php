@@ -0,0 +1,26 @@
+<?php declare(strict_types=1);
+
+namespace Shopware\Core\Checkout\Cart\Event;
+
+use Shopware\Core\Framework\Event\FlowEventAware;
+use Symfony\Contracts\EventDispatcher\Event;
+
+#[Package('checkout')]
+class CheckoutOrderPlacedEvent extends Event implements FlowEventAware
+{
+    public const EVENT_NAME = 'checkout.order.placed';
+
+    public function getName(): string
+    {
+        return self::EVENT_NAME;
+    }
+}
+
+abstract class AbstractCheckoutEvent extends Event
+{
+}
+
+class OrderStateEvent
+{
+    public const STATE = 'open';
+}
@@ -0,0 +1,29 @@
+package extension
+
+import (
+	"encoding/json"
+	"path/filepath"
+)
+
+// composerPackageName returns the package name of a composer.json, empty for invalid files
+func composerPackageName(fileContent []byte) string {
+	var composer struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(fileContent, &composer); err != nil {
+		return ""
+	}
+
+	return composer.Name
+}
+
+// composerCandidates returns the possible composer.json files of a bundle, which is next to the bundle class
+// or one directory above for plugins with a src directory
+func composerCandidates(bundlePath string) []string {
+	dir := filepath.Dir(bundlePath)
+
+	return []string{
+		filepath.Join(dir, "composer.json"),
+		filepath.Join(filepath.Dir(dir), "composer.json"),
+	}
+}
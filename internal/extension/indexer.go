@@ -11,16 +11,25 @@ import (
 
 type ExtensionIndexer struct {
 	indexer *indexer.DataIndexer[ShopwareExtension]
+	// composerIndex stores the package names of the composer.json files by their path
+	composerIndex *indexer.DataIndexer[string]
 }
 
 func NewExtensionIndexer(configDir string) (*ExtensionIndexer, error) {
-	indexer, err := indexer.NewDataIndexer[ShopwareExtension](filepath.Join(configDir, "extension.db"))
+	extensionIndex, err := indexer.NewDataIndexer[ShopwareExtension](filepath.Join(configDir, "extension.db"))
 	if err != nil {
 		return nil, err
 	}
 
+	composerIndex, err := indexer.NewDataIndexer[string](filepath.Join(configDir, "extension_composer.db"))
+	if err != nil {
+		_ = extensionIndex.Close()
+		return nil, err
+	}
+
 	return &ExtensionIndexer{
-		indexer: indexer,
+		indexer:       extensionIndex,
+		composerIndex: composerIndex,
 	}, nil
 }
 
@@ -28,6 +37,10 @@ func (idx *ExtensionIndexer) ID() string {
 	return "extension.indexer"
 }
 
+func (idx *ExtensionIndexer) FileExtensions() []string {
+	return []string{".php", ".xml", ".json"}
+}
+
 func (idx *ExtensionIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	if !isValidForIndex(path) {
 		return nil
@@ -38,6 +51,8 @@ func (idx *ExtensionIndexer) Index(path string, node *tree_sitter.Node, fileCont
 		return idx.indexBundle(path, node, fileContent)
 	case ".xml":
 		return idx.indexApp(path, node, fileContent)
+	case ".json":
+		return idx.indexComposer(path, fileContent)
 	default:
 		return nil
 	}
@@ -90,6 +105,34 @@ func (idx *ExtensionIndexer) indexApp(path string, node *tree_sitter.Node, fileC
 	return idx.indexer.BatchSaveItems(batchSave)
 }
 
+// indexComposer stores the package name of a composer.json, bundles look it up when their package is needed
+func (idx *ExtensionIndexer) indexComposer(path string, fileContent []byte) error {
+	if filepath.Base(path) != "composer.json" {
+		return nil
+	}
+
+	return idx.composerIndex.BatchSaveItems(map[string]map[string]string{
+		path: {path: composerPackageName(fileContent)},
+	})
+}
+
+// GetComposerPackage returns the indexed composer.json of a bundle and its package name,
+// empty strings for apps and bundles without one
+func (idx *ExtensionIndexer) GetComposerPackage(extension ShopwareExtension) (string, string) {
+	if extension.Type != ShopwareExtensionTypeBundle {
+		return "", ""
+	}
+
+	for _, composerPath := range composerCandidates(extension.Path) {
+		names, err := idx.composerIndex.GetValues(composerPath)
+		if err == nil && len(names) > 0 {
+			return composerPath, names[0]
+		}
+	}
+
+	return "", ""
+}
+
 func (idx *ExtensionIndexer) GetExtensionByName(name string) *ShopwareExtension {
 	extension, err := idx.indexer.GetValues(name)
 	if err != nil {
@@ -103,18 +146,51 @@ func (idx *ExtensionIndexer) GetExtensionByName(name string) *ShopwareExtension
 	return &extension[0]
 }
 
+// GetBundleDir returns the directory of the bundle class with the given name,
+// empty for unknown bundles and apps
+func (idx *ExtensionIndexer) GetBundleDir(name string) string {
+	extension := idx.GetExtensionByName(name)
+	if extension == nil || extension.Type != ShopwareExtensionTypeBundle {
+		return ""
+	}
+
+	return filepath.Dir(extension.Path)
+}
+
 func (idx *ExtensionIndexer) RemovedFiles(paths []string) error {
-	return idx.indexer.BatchDeleteByFilePaths(paths)
+	if err := idx.indexer.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+	return idx.composerIndex.BatchDeleteByFilePaths(paths)
 }
 
 func (idx *ExtensionIndexer) Close() error {
-	return idx.indexer.Close()
+	if err := idx.indexer.Close(); err != nil {
+		return err
+	}
+	return idx.composerIndex.Close()
 }
 
 func (idx *ExtensionIndexer) Clear() error {
-	return idx.indexer.Clear()
+	if err := idx.indexer.Clear(); err != nil {
+		return err
+	}
+	return idx.composerIndex.Clear()
+}
+
+func (idx *ExtensionIndexer) BeginBatch() error {
+	return indexer.BeginBatches(idx.indexer, idx.composerIndex)
+}
+
+func (idx *ExtensionIndexer) Commit() error {
+	return indexer.CommitBatches(idx.indexer, idx.composerIndex)
 }
 
 func (idx *ExtensionIndexer) GetAll() ([]ShopwareExtension, error) {
 	return idx.indexer.GetAllValues()
 }
+
+// GetAllExtensions returns all indexed bundles and apps sorted by their technical name
+func (idx *ExtensionIndexer) GetAllExtensions() ([]ShopwareExtension, error) {
+	return idx.indexer.GetAllValuesSorted()
+}
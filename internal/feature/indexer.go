@@ -28,6 +28,10 @@ func (i *FeatureIndexer) ID() string {
 	return "feature.indexer"
 }
 
+func (i *FeatureIndexer) FileExtensions() []string {
+	return []string{".yaml", ".yml"}
+}
+
 func (i *FeatureIndexer) Index(path string, node *sitter.Node, fileContent []byte) error {
 	// Only index .yaml files that might contain feature flags
 	if !strings.HasSuffix(path, ".yaml") && !strings.HasSuffix(path, ".yml") {
@@ -86,6 +90,14 @@ func (i *FeatureIndexer) Clear() error {
 	return i.featureIndex.Clear()
 }
 
+func (i *FeatureIndexer) BeginBatch() error {
+	return i.featureIndex.BeginBatch()
+}
+
+func (i *FeatureIndexer) Commit() error {
+	return i.featureIndex.Commit()
+}
+
 func (i *FeatureIndexer) GetFeatureByName(name string) ([]Feature, error) {
 	return i.featureIndex.GetValues(name)
 }
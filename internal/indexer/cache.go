@@ -0,0 +1,155 @@
+package indexer
+
+import (
+	"container/list"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultCacheSize is the number of keys each DataIndexer keeps in memory
+const defaultCacheSize = 1000
+
+// cacheSizeEnv configures the cache size when it is not set by the client
+const cacheSizeEnv = "SHOPWARE_LSP_CACHE_SIZE"
+
+var (
+	// cacheSize is the configured number of cached keys per DataIndexer, -1 when not configured
+	cacheSize        atomic.Int64
+	envCacheSize     int
+	envCacheSizeOnce sync.Once
+
+	// totalCacheHits and totalCacheMisses count the lookups of all DataIndexers
+	totalCacheHits   atomic.Uint64
+	totalCacheMisses atomic.Uint64
+)
+
+func init() {
+	cacheSize.Store(-1)
+}
+
+// SetCacheSize sets the number of keys each DataIndexer keeps in memory, 0 disables the cache and
+// negative values restore the default. Caches which are larger shrink with the next lookups.
+func SetCacheSize(size int) {
+	cacheSize.Store(int64(max(size, -1)))
+}
+
+// cacheCapacity returns the configured cache size. Without configuration the SHOPWARE_LSP_CACHE_SIZE
+// environment variable is used and finally defaultCacheSize.
+func cacheCapacity() int {
+	if size := cacheSize.Load(); size >= 0 {
+		return int(size)
+	}
+
+	envCacheSizeOnce.Do(func() {
+		envCacheSize = defaultCacheSize
+
+		value := os.Getenv(cacheSizeEnv)
+		if value == "" {
+			return
+		}
+
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 0 {
+			log.Printf("Ignoring invalid %s value: %q", cacheSizeEnv, value)
+			return
+		}
+		envCacheSize = size
+	})
+
+	return envCacheSize
+}
+
+// CacheStats are the counters of the in-memory caches of DataIndexers
+type CacheStats struct {
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Entries int    `json:"entries,omitempty"`
+}
+
+// TotalCacheStats returns the hits and misses of all DataIndexers together
+func TotalCacheStats() CacheStats {
+	return CacheStats{
+		Hits:   totalCacheHits.Load(),
+		Misses: totalCacheMisses.Load(),
+	}
+}
+
+// lruCache is a least recently used cache, values are cloned so callers can't modify cached slices
+type lruCache[V any] struct {
+	mu     sync.Mutex
+	items  map[string]*list.Element
+	order  *list.List
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value []V
+}
+
+func newLRUCache[V any]() *lruCache[V] {
+	return &lruCache[V]{
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// get returns the cached value of the key and counts the lookup
+func (c *lruCache[V]) get(key string) ([]V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		totalCacheMisses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	totalCacheHits.Add(1)
+	c.order.MoveToFront(element)
+	return slices.Clone(element.Value.(*lruEntry[V]).value), true
+}
+
+// add stores the value and evicts the least recently used keys above the configured size
+func (c *lruCache[V]) add(key string, value []V) {
+	capacity := cacheCapacity()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		element.Value.(*lruEntry[V]).value = slices.Clone(value)
+		c.order.MoveToFront(element)
+	} else if capacity > 0 {
+		c.items[key] = c.order.PushFront(&lruEntry[V]{key: key, value: slices.Clone(value)})
+	}
+
+	for c.order.Len() > capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[V]).key)
+	}
+}
+
+// clear removes all cached values
+func (c *lruCache[V]) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.order.Init()
+}
+
+func (c *lruCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -5,17 +5,158 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/vmihailenco/msgpack/v5"
 	_ "modernc.org/sqlite"
 )
 
+// maxBatchWrites is the number of writes after which a batch is committed and a new one is started,
+// so the WAL of a full index doesn't grow unbounded
+const maxBatchWrites = 5000
+
 // DataIndexer is a generic indexer that can store any type of data in a SQLite database
 type DataIndexer[T any] struct {
 	db     *sql.DB
 	mu     sync.RWMutex
 	dbPath string
+
+	// batchTx is the transaction all reads and writes use between BeginBatch and Commit
+	batchTx     *sql.Tx
+	batchDepth  int
+	batchWrites int
+	// batchErr is the failed intermediate commit of a batch, Commit reports it
+	batchErr error
+
+	// valueCache and keyCache hold the results of GetValues and the key listings,
+	// they are cleared by every write of this instance
+	valueCache *lruCache[T]
+	keyCache   *lruCache[string]
+}
+
+// dbConn is implemented by *sql.DB and *sql.Tx
+type dbConn interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// conn returns the connection reads have to use, with a single connection per database
+// they have to go through a running batch transaction to see its writes and to not block
+func (idx *DataIndexer[T]) conn() dbConn {
+	if idx.batchTx != nil {
+		return idx.batchTx
+	}
+	return idx.db
+}
+
+// BeginBatch groups all following writes into a single transaction until Commit is called, which saves the
+// per-write commits while indexing many files. Calls can be nested, only the outermost Commit commits.
+// A failing write only rolls back its own changes.
+func (idx *DataIndexer[T]) BeginBatch() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.batchDepth > 0 {
+		idx.batchDepth++
+		return nil
+	}
+
+	tx, err := idx.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin batch: %w", err)
+	}
+
+	idx.batchTx = tx
+	idx.batchDepth = 1
+	idx.batchWrites = 0
+	return nil
+}
+
+// Commit commits the writes since BeginBatch
+func (idx *DataIndexer[T]) Commit() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if idx.batchDepth == 0 {
+		return nil
+	}
+
+	idx.batchDepth--
+	if idx.batchDepth > 0 {
+		return nil
+	}
+
+	tx := idx.batchTx
+	idx.batchTx = nil
+	batchErr := idx.batchErr
+	idx.batchErr = nil
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			// The cache may hold reads of the rolled back writes
+			idx.invalidateCache()
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+	}
+	return batchErr
+}
+
+// write runs fn in a transaction, inside of a batch a savepoint of the batch transaction is used instead.
+// The caller must hold the write lock.
+func (idx *DataIndexer[T]) write(fn func(tx *sql.Tx) error) error {
+	idx.invalidateCache()
+
+	if idx.batchTx == nil {
+		tx, err := idx.db.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer func() { _ = tx.Rollback() }()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	}
+
+	if _, err := idx.batchTx.Exec("SAVEPOINT batch_write"); err != nil {
+		return fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	if err := fn(idx.batchTx); err != nil {
+		_, _ = idx.batchTx.Exec("ROLLBACK TO batch_write")
+		_, _ = idx.batchTx.Exec("RELEASE batch_write")
+		return err
+	}
+
+	if _, err := idx.batchTx.Exec("RELEASE batch_write"); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	idx.batchWrites++
+	if idx.batchWrites < maxBatchWrites {
+		return nil
+	}
+
+	// Commit the batch so far and continue with a new transaction. The batch stays open when this fails,
+	// the following writes use their own transactions and Commit reports the lost writes.
+	if err := idx.batchTx.Commit(); err != nil {
+		idx.invalidateCache()
+		idx.batchTx = nil
+		idx.batchErr = fmt.Errorf("failed to commit batch: %w", err)
+		return idx.batchErr
+	}
+
+	tx, err := idx.db.Begin()
+	if err != nil {
+		idx.batchTx = nil
+		return fmt.Errorf("failed to begin batch: %w", err)
+	}
+	idx.batchTx = tx
+	idx.batchWrites = 0
+	return nil
 }
 
 // NewDataIndexer creates a new generic data indexer
@@ -77,11 +218,28 @@ func NewDataIndexer[T any](dbPath string) (*DataIndexer[T], error) {
 	}
 
 	return &DataIndexer[T]{
-		db:     db,
-		dbPath: dbPath,
+		db:         db,
+		dbPath:     dbPath,
+		valueCache: newLRUCache[T](),
+		keyCache:   newLRUCache[string](),
 	}, nil
 }
 
+// invalidateCache drops all cached reads, the caller must hold the write lock
+func (idx *DataIndexer[T]) invalidateCache() {
+	idx.valueCache.clear()
+	idx.keyCache.clear()
+}
+
+// CacheStats returns the hits and misses of the in-memory cache of this indexer
+func (idx *DataIndexer[T]) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:    idx.valueCache.hits.Load() + idx.keyCache.hits.Load(),
+		Misses:  idx.valueCache.misses.Load() + idx.keyCache.misses.Load(),
+		Entries: idx.valueCache.len() + idx.keyCache.len(),
+	}
+}
+
 // SaveItem saves an item to the database with the given key and associates it with a file path
 func (idx *DataIndexer[T]) SaveItem(filePath, key string, item T) error {
 	idx.mu.Lock()
@@ -93,44 +251,55 @@ func (idx *DataIndexer[T]) SaveItem(filePath, key string, item T) error {
 		return fmt.Errorf("failed to marshal item: %w", err)
 	}
 
-	tx, err := idx.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
-
-	// Insert the data
-	result, err := tx.Exec("INSERT INTO data (key, value) VALUES (?, ?)", key, data)
-	if err != nil {
-		return fmt.Errorf("failed to save item: %w", err)
-	}
+	return idx.write(func(tx *sql.Tx) error {
+		// Insert the data
+		result, err := tx.Exec("INSERT INTO data (key, value) VALUES (?, ?)", key, data)
+		if err != nil {
+			return fmt.Errorf("failed to save item: %w", err)
+		}
 
-	dataID, err := result.LastInsertId()
-	if err != nil {
-		return fmt.Errorf("failed to get last insert id: %w", err)
-	}
+		dataID, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get last insert id: %w", err)
+		}
 
-	// Associate with file path
-	_, err = tx.Exec("INSERT INTO files (file_path, data_id) VALUES (?, ?)", filePath, dataID)
-	if err != nil {
-		return fmt.Errorf("failed to save file association: %w", err)
-	}
+		// Associate with file path
+		_, err = tx.Exec("INSERT INTO files (file_path, data_id) VALUES (?, ?)", filePath, dataID)
+		if err != nil {
+			return fmt.Errorf("failed to save file association: %w", err)
+		}
 
-	return tx.Commit()
+		return nil
+	})
 }
 
 // BatchSaveItems saves multiple items in a single transaction
 // It first deletes any existing entries for the given file paths to avoid duplicates
 func (idx *DataIndexer[T]) BatchSaveItems(items map[string]map[string]T) error {
+	itemLists := make(map[string]map[string][]T, len(items))
+	for filePath, keyItems := range items {
+		itemLists[filePath] = make(map[string][]T, len(keyItems))
+		for key, item := range keyItems {
+			itemLists[filePath][key] = []T{item}
+		}
+	}
+
+	return idx.BatchSaveItemLists(itemLists)
+}
+
+// BatchSaveItemLists works like BatchSaveItems, but allows a file to store several items for the same key
+// The items of a key are returned by GetValues in the given order
+func (idx *DataIndexer[T]) BatchSaveItemLists(items map[string]map[string][]T) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
-	tx, err := idx.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
+	return idx.write(func(tx *sql.Tx) error {
+		return saveItemLists(tx, items)
+	})
+}
 
+// saveItemLists replaces the items of the file paths
+func saveItemLists[T any](tx *sql.Tx, items map[string]map[string][]T) error {
 	// First, delete existing entries for these file paths to avoid duplicates
 	deleteDataStmt, err := tx.Prepare(`
 		DELETE FROM data WHERE id IN (
@@ -171,41 +340,59 @@ func (idx *DataIndexer[T]) BatchSaveItems(items map[string]map[string]T) error {
 	defer func() { _ = fileStmt.Close() }()
 
 	for filePath, keyItems := range items {
-		for key, item := range keyItems {
-			// Marshal item
-			data, err := msgpack.Marshal(item)
-			if err != nil {
-				return fmt.Errorf("failed to marshal item: %w", err)
-			}
-
-			// Insert data
-			result, err := dataStmt.Exec(key, data)
-			if err != nil {
-				return fmt.Errorf("failed to save item: %w", err)
-			}
-
-			dataID, err := result.LastInsertId()
-			if err != nil {
-				return fmt.Errorf("failed to get last insert id: %w", err)
-			}
-
-			// Associate with file path
-			_, err = fileStmt.Exec(filePath, dataID)
-			if err != nil {
-				return fmt.Errorf("failed to save file association: %w", err)
+		for key, keyItemList := range keyItems {
+			for _, item := range keyItemList {
+				// Marshal item
+				data, err := msgpack.Marshal(item)
+				if err != nil {
+					return fmt.Errorf("failed to marshal item: %w", err)
+				}
+
+				// Insert data
+				result, err := dataStmt.Exec(key, data)
+				if err != nil {
+					return fmt.Errorf("failed to save item: %w", err)
+				}
+
+				dataID, err := result.LastInsertId()
+				if err != nil {
+					return fmt.Errorf("failed to get last insert id: %w", err)
+				}
+
+				// Associate with file path
+				_, err = fileStmt.Exec(filePath, dataID)
+				if err != nil {
+					return fmt.Errorf("failed to save file association: %w", err)
+				}
 			}
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
-// GetValues returns all items with the given key
+// GetValues returns all items with the given key in the order they were saved.
+// Results are cached until the next write.
 func (idx *DataIndexer[T]) GetValues(key string) ([]T, error) {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	rows, err := idx.db.Query("SELECT value FROM data WHERE key = ?", key)
+	if items, ok := idx.valueCache.get(key); ok {
+		return items, nil
+	}
+
+	items, err := idx.queryValues(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Writes wait for the read lock, so the cached result can't be outdated
+	idx.valueCache.add(key, items)
+	return items, nil
+}
+
+func (idx *DataIndexer[T]) queryValues(key string) ([]T, error) {
+	rows, err := idx.conn().Query("SELECT value FROM data WHERE key = ? ORDER BY id", key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query data: %w", err)
 	}
@@ -230,10 +417,20 @@ func (idx *DataIndexer[T]) GetValues(key string) ([]T, error) {
 
 // GetAllValues returns all items stored in the data table
 func (idx *DataIndexer[T]) GetAllValues() ([]T, error) {
+	return idx.queryAllValues("SELECT value FROM data")
+}
+
+// GetAllValuesSorted returns all items stored in the data table ordered by key,
+// items sharing a key keep their insertion order
+func (idx *DataIndexer[T]) GetAllValuesSorted() ([]T, error) {
+	return idx.queryAllValues("SELECT value FROM data ORDER BY key, id")
+}
+
+func (idx *DataIndexer[T]) queryAllValues(query string) ([]T, error) {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	rows, err := idx.db.Query("SELECT value FROM data")
+	rows, err := idx.conn().Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query data: %w", err)
 	}
@@ -259,12 +456,25 @@ func (idx *DataIndexer[T]) GetAllValues() ([]T, error) {
 	return items, rows.Err()
 }
 
-// GetAllKeys returns all unique keys in the database
+// GetAllKeys returns all unique keys in the database, the result is cached until the next write
 func (idx *DataIndexer[T]) GetAllKeys() ([]string, error) {
+	return idx.queryAllKeys("SELECT DISTINCT key FROM data")
+}
+
+// GetAllKeysSorted returns all unique keys in the database in alphabetical order
+func (idx *DataIndexer[T]) GetAllKeysSorted() ([]string, error) {
+	return idx.queryAllKeys("SELECT DISTINCT key FROM data ORDER BY key")
+}
+
+func (idx *DataIndexer[T]) queryAllKeys(query string) ([]string, error) {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	rows, err := idx.db.Query("SELECT DISTINCT key FROM data")
+	if keys, ok := idx.keyCache.get(query); ok {
+		return keys, nil
+	}
+
+	rows, err := idx.conn().Query(query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query keys: %w", err)
 	}
@@ -278,38 +488,70 @@ func (idx *DataIndexer[T]) GetAllKeys() ([]string, error) {
 		}
 		keys = append(keys, key)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	return keys, rows.Err()
+	idx.keyCache.add(query, keys)
+	return keys, nil
 }
 
-// DeleteByFilePath deletes all items associated with the given file path
-func (idx *DataIndexer[T]) DeleteByFilePath(filePath string) error {
-	idx.mu.Lock()
-	defer idx.mu.Unlock()
+// SearchKeysBySegmentPrefix returns the unique keys in alphabetical order whose last segment, the part after
+// the last separator, starts with the prefix ignoring ASCII case. At most limit keys are returned.
+func (idx *DataIndexer[T]) SearchKeysBySegmentPrefix(separator, prefix string, limit int) ([]string, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
 
-	tx, err := idx.db.Begin()
+	// rtrim removes all characters but the separator from the end, which leaves the key up to its last separator
+	rows, err := idx.conn().Query(`
+		SELECT DISTINCT key FROM data
+		WHERE replace(key, rtrim(key, replace(key, ?1, '')), '') LIKE ?2 ESCAPE '!'
+		ORDER BY key
+		LIMIT ?3
+	`, separator, escapeLike(prefix)+"%", limit)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to search keys: %w", err)
 	}
-	defer func() { _ = tx.Rollback() }()
+	defer func() { _ = rows.Close() }()
 
-	// Delete data entries associated with this file
-	_, err = tx.Exec(`
-		DELETE FROM data WHERE id IN (
-			SELECT data_id FROM files WHERE file_path = ?
-		)
-	`, filePath)
-	if err != nil {
-		return fmt.Errorf("failed to delete data: %w", err)
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan key: %w", err)
+		}
+		keys = append(keys, key)
 	}
 
-	// Delete file associations
-	_, err = tx.Exec("DELETE FROM files WHERE file_path = ?", filePath)
-	if err != nil {
-		return fmt.Errorf("failed to delete file associations: %w", err)
+	return keys, rows.Err()
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern with the escape character !
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
+
+// CountKeys returns the number of unique keys in the database
+func (idx *DataIndexer[T]) CountKeys() (int, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	var count int
+	if err := idx.conn().QueryRow("SELECT COUNT(DISTINCT key) FROM data").Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count keys: %w", err)
 	}
 
-	return tx.Commit()
+	return count, nil
+}
+
+// DeleteByFilePath deletes all items associated with the given file path
+func (idx *DataIndexer[T]) DeleteByFilePath(filePath string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	return idx.write(func(tx *sql.Tx) error {
+		return deleteFilePaths(tx, []string{filePath})
+	})
 }
 
 // GetAllKeysByPath returns all unique keys associated with a specific file path
@@ -317,7 +559,7 @@ func (idx *DataIndexer[T]) GetAllKeysByPath(filePath string) ([]string, error) {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	rows, err := idx.db.Query(`
+	rows, err := idx.conn().Query(`
 		SELECT DISTINCT d.key FROM data d
 		INNER JOIN files f ON d.id = f.data_id
 		WHERE f.file_path = ?
@@ -348,15 +590,16 @@ func (idx *DataIndexer[T]) BatchDeleteByFilePaths(filePaths []string) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
-	tx, err := idx.db.Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() { _ = tx.Rollback() }()
+	return idx.write(func(tx *sql.Tx) error {
+		return deleteFilePaths(tx, filePaths)
+	})
+}
 
+// deleteFilePaths deletes the items and file associations of the file paths
+func deleteFilePaths(tx *sql.Tx, filePaths []string) error {
 	for _, filePath := range filePaths {
 		// Delete data entries associated with this file
-		_, err = tx.Exec(`
+		_, err := tx.Exec(`
 			DELETE FROM data WHERE id IN (
 				SELECT data_id FROM files WHERE file_path = ?
 			)
@@ -372,18 +615,25 @@ func (idx *DataIndexer[T]) BatchDeleteByFilePaths(filePaths []string) error {
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 func (idx *DataIndexer[T]) Clear() error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
-	_, err := idx.db.Exec("DELETE FROM files; DELETE FROM data;")
+	idx.invalidateCache()
+
+	_, err := idx.conn().Exec("DELETE FROM files; DELETE FROM data;")
 	if err != nil {
 		return err
 	}
 
+	// VACUUM can't run inside of a transaction, the space is reclaimed after the batch
+	if idx.batchTx != nil {
+		return nil
+	}
+
 	// Reclaim space after clearing all data
 	_, err = idx.db.Exec("PRAGMA incremental_vacuum")
 	return err
@@ -394,6 +644,14 @@ func (idx *DataIndexer[T]) Close() error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
 
+	// Keep the writes of a batch which was not committed
+	if idx.batchTx != nil {
+		_ = idx.batchTx.Commit()
+		idx.batchTx = nil
+	}
+	idx.batchDepth = 0
+	idx.batchErr = nil
+
 	// Optimize query planner statistics before closing
 	_, _ = idx.db.Exec("PRAGMA optimize")
 
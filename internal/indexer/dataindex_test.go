@@ -1,6 +1,8 @@
 package indexer
 
 import (
+	"errors"
+	"fmt"
 	"path/filepath"
 	"sync"
 	"testing"
@@ -217,6 +219,44 @@ func TestDataIndexer_BatchSaveItems_DeletesOldEntries(t *testing.T) {
 	assert.Equal(t, "ItemC", valuesB[0].Name)
 }
 
+func TestDataIndexer_BatchSaveItemLists_KeepsAllItems(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	err := indexer.BatchSaveItemLists(map[string]map[string][]testStruct{
+		"file1.txt": {
+			"keyA": {{Name: "First", Value: 1}, {Name: "Second", Value: 2}},
+		},
+	})
+	require.NoError(t, err)
+
+	err = indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file2.txt": {
+			"keyA": {Name: "Third", Value: 3},
+		},
+	})
+	require.NoError(t, err)
+
+	values, err := indexer.GetValues("keyA")
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	assert.Equal(t, []string{"First", "Second", "Third"}, []string{values[0].Name, values[1].Name, values[2].Name})
+
+	// Re-saving a file replaces all of its items
+	err = indexer.BatchSaveItemLists(map[string]map[string][]testStruct{
+		"file1.txt": {
+			"keyA": {{Name: "Fourth", Value: 4}},
+		},
+	})
+	require.NoError(t, err)
+
+	values, err = indexer.GetValues("keyA")
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.Equal(t, "Third", values[0].Name)
+	assert.Equal(t, "Fourth", values[1].Name)
+}
+
 func TestDataIndexer_ConcurrentAccess(t *testing.T) {
 	// This test verifies that multiple connections to the same SQLite database
 	// can work concurrently without blocking/hanging (the original BBolt issue)
@@ -302,3 +342,323 @@ func TestDataIndexer_ConcurrentAccess(t *testing.T) {
 	require.NoError(t, err)
 	assert.GreaterOrEqual(t, len(values), 10, "Expected at least 10 values from concurrent writes")
 }
+
+func TestDataIndexer_SortedQueries(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	require.NoError(t, indexer.SaveItem("file1.txt", "charlie", testStruct{Name: "C", Value: 3}))
+	require.NoError(t, indexer.SaveItem("file2.txt", "alpha", testStruct{Name: "A1", Value: 1}))
+	require.NoError(t, indexer.SaveItem("file3.txt", "bravo", testStruct{Name: "B", Value: 2}))
+	require.NoError(t, indexer.SaveItem("file4.txt", "alpha", testStruct{Name: "A2", Value: 4}))
+
+	keys, err := indexer.GetAllKeysSorted()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpha", "bravo", "charlie"}, keys)
+
+	values, err := indexer.GetAllValuesSorted()
+	require.NoError(t, err)
+	names := make([]string, 0, len(values))
+	for _, v := range values {
+		names = append(names, v.Name)
+	}
+	assert.Equal(t, []string{"A1", "A2", "B", "C"}, names)
+}
+
+func TestDataIndexer_SearchKeysBySegmentPrefix(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	require.NoError(t, indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file1.txt": {
+			"App\\Product\\ProductEntity":       {Name: "A"},
+			"App\\Product\\Category":            {Name: "B"},
+			"Shopware\\Core\\ProductDefinition": {Name: "C"},
+			"ProductInterface":                  {Name: "D"},
+			"App\\Product_Legacy":               {Name: "E"},
+			"App\\ProductXLegacy":               {Name: "F"},
+		},
+	}))
+
+	keys, err := indexer.SearchKeysBySegmentPrefix("\\", "product", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"App\\ProductXLegacy",
+		"App\\Product\\ProductEntity",
+		"App\\Product_Legacy",
+		"ProductInterface",
+		"Shopware\\Core\\ProductDefinition",
+	}, keys)
+
+	keys, err = indexer.SearchKeysBySegmentPrefix("\\", "Product_", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"App\\Product_Legacy"}, keys)
+
+	keys, err = indexer.SearchKeysBySegmentPrefix("\\", "Product", 2)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestDataIndexer_CountKeys(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	count, err := indexer.CountKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	require.NoError(t, indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file1.txt": {"keyA": {Name: "A"}, "keyB": {Name: "B"}},
+		"file2.txt": {"keyA": {Name: "A2"}},
+	}))
+
+	count, err = indexer.CountKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestDataIndexer_Batch(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	indexer, err := NewDataIndexer[testStruct](dbPath)
+	require.NoError(t, err)
+	defer func() { _ = indexer.Close() }()
+
+	reader, err := NewDataIndexer[testStruct](dbPath)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	require.NoError(t, indexer.BeginBatch())
+	require.NoError(t, indexer.BeginBatch())
+
+	require.NoError(t, indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file1.txt": {"keyA": {Name: "A"}},
+	}))
+	require.NoError(t, indexer.SaveItem("file2.txt", "keyB", testStruct{Name: "B"}))
+	require.NoError(t, indexer.DeleteByFilePath("file2.txt"))
+
+	// Reads of the batching indexer see the pending writes
+	values, err := indexer.GetValues("keyA")
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	keys, err := indexer.GetAllKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keyA"}, keys)
+
+	// Only the outermost commit makes them visible to other connections,
+	// GetAllKeysByPath is used as the cache of the reader doesn't know about writes of other instances
+	require.NoError(t, indexer.Commit())
+
+	keys, err = reader.GetAllKeysByPath("file1.txt")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	require.NoError(t, indexer.Commit())
+
+	keys, err = reader.GetAllKeysByPath("file1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keyA"}, keys)
+
+	// Commit without a batch is a no-op
+	require.NoError(t, indexer.Commit())
+}
+
+func TestDataIndexer_Batch_AutoCommit(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	indexer, err := NewDataIndexer[testStruct](dbPath)
+	require.NoError(t, err)
+	defer func() { _ = indexer.Close() }()
+
+	reader, err := NewDataIndexer[testStruct](dbPath)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	require.NoError(t, indexer.BeginBatch())
+	for i := 0; i < maxBatchWrites; i++ {
+		require.NoError(t, indexer.SaveItem(fmt.Sprintf("file%d.txt", i), "key", testStruct{Value: i}))
+	}
+
+	// The batch is committed once it reaches the limit and continues in a new transaction
+	count, err := reader.CountKeys()
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, indexer.SaveItem("last.txt", "last", testStruct{}))
+	keys, err := reader.GetAllKeysByPath("last.txt")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	require.NoError(t, indexer.Commit())
+	keys, err = reader.GetAllKeysByPath("last.txt")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"last"}, keys)
+}
+
+func TestDataIndexer_Batch_FailedAutoCommit(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	require.NoError(t, indexer.BeginBatch())
+	require.NoError(t, indexer.BeginBatch())
+
+	// An intermediate commit failed, the writes after it use their own transactions
+	indexer.mu.Lock()
+	_ = indexer.batchTx.Rollback()
+	indexer.batchTx = nil
+	indexer.batchErr = errors.New("failed to commit batch: disk full")
+	indexer.mu.Unlock()
+
+	require.NoError(t, indexer.SaveItem("file.txt", "key", testStruct{}))
+
+	// Only the outermost Commit reports the lost writes
+	require.NoError(t, indexer.Commit())
+	assert.EqualError(t, indexer.Commit(), "failed to commit batch: disk full")
+	assert.NoError(t, indexer.Commit())
+}
+
+func TestDataIndexer_Batch_FailedCommitClearsCache(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	require.NoError(t, indexer.BeginBatch())
+	require.NoError(t, indexer.SaveItem("file.txt", "key", testStruct{Name: "uncommitted"}))
+
+	values, err := indexer.GetValues("key")
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+
+	// The batch transaction is gone, so the commit fails and the write is lost
+	indexer.mu.Lock()
+	_ = indexer.batchTx.Rollback()
+	indexer.mu.Unlock()
+
+	require.Error(t, indexer.Commit())
+
+	values, err = indexer.GetValues("key")
+	require.NoError(t, err)
+	assert.Empty(t, values, "Cache returned a rolled back write")
+}
+
+func benchmarkDataIndexerSave(b *testing.B, batch bool) {
+	indexer, err := NewDataIndexer[testStruct](filepath.Join(b.TempDir(), "bench.db"))
+	require.NoError(b, err)
+	defer func() { _ = indexer.Close() }()
+
+	// Simulates a full index, every file stores its items with one write
+	const files = 1000
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if batch {
+			require.NoError(b, indexer.BeginBatch())
+		}
+
+		for i := 0; i < files; i++ {
+			filePath := fmt.Sprintf("src/file%d.php", i)
+			require.NoError(b, indexer.BatchSaveItems(map[string]map[string]testStruct{
+				filePath: {
+					fmt.Sprintf("Class%d", i):         {Name: filePath, Value: i},
+					fmt.Sprintf("Class%d::method", i): {Name: filePath, Value: i},
+				},
+			}))
+		}
+
+		if batch {
+			require.NoError(b, indexer.Commit())
+		}
+	}
+}
+
+func BenchmarkDataIndexer_Save(b *testing.B) {
+	benchmarkDataIndexerSave(b, false)
+}
+
+func BenchmarkDataIndexer_SaveBatch(b *testing.B) {
+	benchmarkDataIndexerSave(b, true)
+}
+
+func TestDataIndexer_Cache(t *testing.T) {
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	require.NoError(t, indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file1.txt": {"keyA": {Name: "A"}},
+	}))
+
+	values, err := indexer.GetValues("keyA")
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+
+	// Modifying a result doesn't change the cached value
+	values[0].Name = "changed"
+
+	values, err = indexer.GetValues("keyA")
+	require.NoError(t, err)
+	assert.Equal(t, "A", values[0].Name)
+
+	keys, err := indexer.GetAllKeys()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keyA"}, keys)
+
+	stats := indexer.CacheStats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, 2, stats.Entries)
+
+	// Writes invalidate the cache
+	require.NoError(t, indexer.BatchSaveItems(map[string]map[string]testStruct{
+		"file2.txt": {"keyA": {Name: "A2"}, "keyB": {Name: "B"}},
+	}))
+
+	values, err = indexer.GetValues("keyA")
+	require.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	keys, err = indexer.GetAllKeysSorted()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"keyA", "keyB"}, keys)
+
+	require.NoError(t, indexer.BatchDeleteByFilePaths([]string{"file2.txt"}))
+
+	values, err = indexer.GetValues("keyA")
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+
+	require.NoError(t, indexer.Clear())
+
+	values, err = indexer.GetValues("keyA")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
+
+func TestDataIndexer_CacheSize(t *testing.T) {
+	SetCacheSize(2)
+	defer SetCacheSize(-1)
+
+	indexer, cleanup := setupTestDB[testStruct](t)
+	defer cleanup()
+
+	for _, key := range []string{"a", "b", "a", "c"} {
+		_, err := indexer.GetValues(key)
+		require.NoError(t, err)
+	}
+
+	// b was the least recently used key and got evicted
+	assert.Equal(t, 2, indexer.CacheStats().Entries)
+
+	_, err := indexer.GetValues("a")
+	require.NoError(t, err)
+	_, err = indexer.GetValues("b")
+	require.NoError(t, err)
+
+	stats := indexer.CacheStats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(4), stats.Misses)
+
+	SetCacheSize(0)
+	_, err = indexer.GetValues("d")
+	require.NoError(t, err)
+	assert.Equal(t, 0, indexer.CacheStats().Entries)
+}
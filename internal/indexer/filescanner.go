@@ -4,16 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"hash/fnv"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
-	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	_ "modernc.org/sqlite"
 )
 
@@ -33,6 +37,17 @@ var defaultSkipDirs = map[string]bool{
 	"public":       true,
 }
 
+// indexWorkersEnv overrides the number of indexing workers
+const indexWorkersEnv = "SHOPWARE_LSP_INDEX_WORKERS"
+
+// maxDefaultIndexWorkers caps the default number of indexing workers
+const maxDefaultIndexWorkers = 16
+
+// workerBatchSize is the number of files a worker collects before removing their old index data and indexing them.
+// It is fixed instead of derived from the number of workers: every worker keeps its batch with the file contents in
+// memory, so the memory already grows with the workers, and all writes share one transaction per index anyway.
+const workerBatchSize = 50
+
 // FileScanner scans the project for files and tracks changes
 type FileScanner struct {
 	projectRoot string
@@ -42,7 +57,16 @@ type FileScanner struct {
 	watcherCtx  context.Context
 	cancel      context.CancelFunc
 	watcherWg   sync.WaitGroup
+	watching    atomic.Bool
 	onUpdate    func()
+	skipDirs    map[string]bool
+	skipDirsMu  sync.RWMutex
+	workerCount int
+	// indexMu serializes the indexing runs, they share the batch transaction of every index and
+	// a run may only save its file states after its own writes were committed
+	indexMu sync.Mutex
+	// contentHashing stores a content hash to skip files which were only touched
+	contentHashing bool
 }
 
 // NewFileScanner creates a new file scanner
@@ -92,6 +116,11 @@ func NewFileScanner(projectRoot string, dbPath string) (*FileScanner, error) {
 		return nil, fmt.Errorf("failed to initialize tables: %w", err)
 	}
 
+	if err := migrateFileHashesTable(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate tables: %w", err)
+	}
+
 	// Create a new context for the watcher
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -101,13 +130,121 @@ func NewFileScanner(projectRoot string, dbPath string) (*FileScanner, error) {
 		indexer:     []Indexer{},
 		watcherCtx:  ctx,
 		cancel:      cancel,
+		skipDirs:    maps.Clone(defaultSkipDirs),
 	}, nil
 }
 
+// migrateFileHashesTable adds the content hash column to databases created before it existed.
+// Rows without a hash fall back to the size and modification time comparison.
+func migrateFileHashesTable(db *sql.DB) error {
+	rows, err := db.Query("SELECT name FROM pragma_table_info('file_hashes')")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		if name == "hash" {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	_ = rows.Close()
+
+	_, err = db.Exec("ALTER TABLE file_hashes ADD COLUMN hash INTEGER")
+	return err
+}
+
+// contentHash returns the hash of the file content stored to detect real content changes
+func contentHash(content []byte) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write(content)
+	return int64(h.Sum64())
+}
+
+// SetContentHashing enables storing a content hash for indexed files. Files whose
+// modification time changed but whose content is the same are then not indexed again.
+func (fs *FileScanner) SetContentHashing(enabled bool) {
+	fs.contentHashing = enabled
+}
+
+// ProjectRoot returns the root directory of the scanned project
+func (fs *FileScanner) ProjectRoot() string {
+	return fs.projectRoot
+}
+
 func (fs *FileScanner) SetOnUpdate(onUpdate func()) {
 	fs.onUpdate = onUpdate
 }
 
+// SetSkipDirs merges additional directory names to skip with the default skip list.
+// Directories listed in include are indexed even when they are skipped by default.
+func (fs *FileScanner) SetSkipDirs(skip []string, include []string) {
+	skipDirs := maps.Clone(defaultSkipDirs)
+	for _, dir := range skip {
+		skipDirs[dir] = true
+	}
+	for _, dir := range include {
+		delete(skipDirs, dir)
+	}
+
+	fs.skipDirsMu.Lock()
+	fs.skipDirs = skipDirs
+	fs.skipDirsMu.Unlock()
+}
+
+// isSkippedDir reports whether a directory with the given name is excluded from scanning
+func (fs *FileScanner) isSkippedDir(name string) bool {
+	fs.skipDirsMu.RLock()
+	defer fs.skipDirsMu.RUnlock()
+	return fs.skipDirs[name]
+}
+
+// isInSkippedDir reports whether the path is located in an excluded directory of the project
+func (fs *FileScanner) isInSkippedDir(path string) bool {
+	relPath, err := filepath.Rel(fs.projectRoot, path)
+	if err != nil {
+		return false
+	}
+
+	for _, part := range strings.Split(relPath, string(os.PathSeparator)) {
+		if fs.isSkippedDir(part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetWorkerCount sets the number of parallel indexing workers, values below 1 restore the default
+func (fs *FileScanner) SetWorkerCount(count int) {
+	fs.workerCount = count
+}
+
+// indexWorkerCount returns the configured number of indexing workers. Without configuration
+// the SHOPWARE_LSP_INDEX_WORKERS environment variable is used and finally GOMAXPROCS + 2, capped at 16.
+func (fs *FileScanner) indexWorkerCount() int {
+	if fs.workerCount > 0 {
+		return fs.workerCount
+	}
+
+	if value := os.Getenv(indexWorkersEnv); value != "" {
+		count, err := strconv.Atoi(value)
+		if err == nil && count > 0 {
+			return count
+		}
+		log.Printf("Ignoring invalid %s value: %q", indexWorkersEnv, value)
+	}
+
+	return min(runtime.GOMAXPROCS(0)+2, maxDefaultIndexWorkers)
+}
+
 func (fs *FileScanner) AddIndexer(indexer Indexer) {
 	fs.indexer = append(fs.indexer, indexer)
 }
@@ -121,6 +258,7 @@ func (fs *FileScanner) StartWatcher() error {
 	}
 
 	fs.watcher = watcher
+	fs.watching.Store(true)
 	fs.watcherWg.Add(1)
 
 	// Start the watcher goroutine
@@ -181,19 +319,8 @@ func (fs *FileScanner) StartWatcher() error {
 				}
 
 				// Skip directories that should be ignored
-				relPath, err := filepath.Rel(fs.projectRoot, event.Name)
-				if err == nil {
-					pathParts := strings.Split(relPath, string(os.PathSeparator))
-					skip := false
-					for _, part := range pathParts {
-						if defaultSkipDirs[part] {
-							skip = true
-							break
-						}
-					}
-					if skip {
-						continue
-					}
+				if fs.isInSkippedDir(event.Name) {
+					continue
 				}
 
 				// Get file info
@@ -202,8 +329,7 @@ func (fs *FileScanner) StartWatcher() error {
 					// File might have been deleted
 					if event.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
 						// Check if it's a file type we care about
-						ext := strings.ToLower(filepath.Ext(event.Name))
-						if slices.Contains(scannedFileTypes, ext) {
+						if IsScannedFile(event.Name) {
 							pendingRemoves[event.Name] = true
 							// Reset the debounce timer
 							if !debounceTimer.Stop() {
@@ -230,8 +356,7 @@ func (fs *FileScanner) StartWatcher() error {
 				}
 
 				// Handle file events
-				ext := strings.ToLower(filepath.Ext(event.Name))
-				if slices.Contains(scannedFileTypes, ext) {
+				if IsScannedFile(event.Name) {
 					if event.Op&(fsnotify.Create|fsnotify.Write) != 0 {
 						// File was created or modified
 						if event.Op&fsnotify.Create != 0 {
@@ -281,6 +406,11 @@ func (fs *FileScanner) StartWatcher() error {
 	return fs.addDirectoryToWatcher(fs.projectRoot)
 }
 
+// IsWatching reports whether the file watcher is running
+func (fs *FileScanner) IsWatching() bool {
+	return fs.watching.Load()
+}
+
 // StopWatcher stops the file watcher
 func (fs *FileScanner) StopWatcher() {
 	if fs.watcher != nil {
@@ -292,6 +422,7 @@ func (fs *FileScanner) StopWatcher() {
 
 		// Reset the watcher
 		fs.watcher = nil
+		fs.watching.Store(false)
 	}
 }
 
@@ -308,14 +439,8 @@ func (fs *FileScanner) addDirectoryToWatcher(dir string) error {
 		}
 
 		// Skip directories in the skipDirs list
-		relPath, err := filepath.Rel(fs.projectRoot, path)
-		if err == nil {
-			pathParts := strings.Split(relPath, string(os.PathSeparator))
-			for _, part := range pathParts {
-				if defaultSkipDirs[part] {
-					return filepath.SkipDir
-				}
-			}
+		if fs.isInSkippedDir(path) {
+			return filepath.SkipDir
 		}
 
 		// Add the directory to the watcher
@@ -358,10 +483,145 @@ func (fs *FileScanner) Close() error {
 	return nil
 }
 
-func (fs *FileScanner) IndexAll(ctx context.Context) error {
+// ProgressFunc receives the number of processed files and the total number of files of an indexing run.
+// It is called concurrently from the indexing workers.
+type ProgressFunc func(processed, total int)
+
+// IndexAll indexes all files of the project, progress may be nil
+func (fs *FileScanner) IndexAll(ctx context.Context, progress ProgressFunc) error {
+	files, err := fs.collectFiles(fs.projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to walk project directory: %w", err)
+	}
+
+	log.Printf("Found %d files to index", len(files))
+
+	startTime := time.Now()
+
+	if err := fs.IndexFilesWithProgress(ctx, files, progress); err != nil {
+		return fmt.Errorf("failed to index files: %w", err)
+	}
+
+	log.Printf("Indexing took %s", time.Since(startTime))
+
+	return nil
+}
+
+// ReindexDirectory forces reindexing of all files below the given directory.
+// Files which were indexed before but no longer exist are removed from the index.
+func (fs *FileScanner) ReindexDirectory(ctx context.Context, dir string) (int, error) {
+	files, err := fs.collectFiles(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to walk directory: %w", err)
+	}
+
+	indexedFiles, err := fs.indexedFilesBelow(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	existingFiles := make(map[string]bool, len(files))
+	for _, path := range files {
+		existingFiles[path] = true
+	}
+
+	var removedFiles []string
+	for _, path := range indexedFiles {
+		if !existingFiles[path] {
+			removedFiles = append(removedFiles, path)
+		}
+	}
+
+	if len(removedFiles) > 0 {
+		if err := fs.RemoveFiles(ctx, removedFiles); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := fs.deleteFileStates(files); err != nil {
+		return 0, err
+	}
+
+	if err := fs.IndexFiles(ctx, files); err != nil {
+		return 0, err
+	}
+
+	return len(files), nil
+}
+
+// ReindexIndexer clears a single indexer and passes all files of the project to it again.
+// The other indexers and the tracked file states are left untouched.
+func (fs *FileScanner) ReindexIndexer(ctx context.Context, indexer Indexer) (int, error) {
+	if err := indexer.Clear(); err != nil {
+		return 0, fmt.Errorf("failed to clear indexer %s: %w", indexer.ID(), err)
+	}
+
+	files, err := fs.collectFiles(fs.projectRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to walk project directory: %w", err)
+	}
+
+	workerCount := max(1, min(fs.indexWorkerCount(), len(files)))
+	fileChan := make(chan string, workerCount*4)
+	errChan := make(chan error, len(files))
+	var wg sync.WaitGroup
+
+	fs.indexMu.Lock()
+	batchers := beginBatches([]Indexer{indexer})
+
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			parsers := CreateTreesitterParsers()
+			defer CloseTreesitterParsers(parsers)
+
+			for path := range fileChan {
+				parser := parsers[FileType(path)]
+				if parser == nil {
+					continue
+				}
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					continue
+				}
+
+				fs.indexFile(parser, fileWork{path: path, content: content}, []Indexer{indexer}, errChan)
+			}
+		}()
+	}
+
+	for _, path := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		fileChan <- path
+	}
+	close(fileChan)
+
+	wg.Wait()
+	commitBatches(batchers)
+	fs.indexMu.Unlock()
+	close(errChan)
+
+	for err := range errChan {
+		log.Printf("Error processing file: %v", err)
+	}
+
+	if fs.onUpdate != nil {
+		fs.onUpdate()
+	}
+
+	return len(files), ctx.Err()
+}
+
+// collectFiles walks the directory and returns all files which should be indexed
+func (fs *FileScanner) collectFiles(dir string) ([]string, error) {
 	var files []string
 
-	err := filepath.Walk(fs.projectRoot, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return nil // Skip files we can't access
 		}
@@ -369,12 +629,8 @@ func (fs *FileScanner) IndexAll(ctx context.Context) error {
 		// Skip directories
 		if info.IsDir() {
 			// Skip directories in the skipDirs list
-			relPath, err := filepath.Rel(fs.projectRoot, path)
-			if err == nil {
-				pathParts := strings.Split(relPath, string(os.PathSeparator))
-				if len(pathParts) == 1 && defaultSkipDirs[pathParts[0]] {
-					return filepath.SkipDir
-				}
+			if fs.isInSkippedDir(path) {
+				return filepath.SkipDir
 			}
 			return nil
 		}
@@ -384,29 +640,36 @@ func (fs *FileScanner) IndexAll(ctx context.Context) error {
 			return nil
 		}
 
-		ext := strings.ToLower(filepath.Ext(path))
-		if slices.Contains(scannedFileTypes, ext) {
+		if IsScannedFile(path) {
 			files = append(files, path)
 		}
 
 		return nil
 	})
 
-	if err != nil {
-		return fmt.Errorf("failed to walk project directory: %w", err)
-	}
-
-	log.Printf("Found %d files to index", len(files))
+	return files, err
+}
 
-	startTime := time.Now()
+// indexedFilesBelow returns the paths of all indexed files below the directory
+func (fs *FileScanner) indexedFilesBelow(dir string) ([]string, error) {
+	prefix := strings.TrimSuffix(dir, string(os.PathSeparator)) + string(os.PathSeparator)
 
-	if err := fs.IndexFiles(ctx, files); err != nil {
-		return fmt.Errorf("failed to index files: %w", err)
+	rows, err := fs.db.Query("SELECT path FROM file_hashes WHERE substr(path, 1, ?) = ?", len(prefix), prefix)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = rows.Close() }()
 
-	log.Printf("Indexing took %s", time.Since(startTime))
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
 
-	return nil
+	return paths, rows.Err()
 }
 
 // fileNeedsIndexing checks if a file needs to be indexed
@@ -417,7 +680,8 @@ func (fs *FileScanner) fileNeedsIndexing(path string) (bool, []byte, os.FileInfo
 	}
 
 	var storedSize, storedMtime int64
-	err = fs.db.QueryRow("SELECT size, mtime FROM file_hashes WHERE path = ?", path).Scan(&storedSize, &storedMtime)
+	var storedHash sql.NullInt64
+	err = fs.db.QueryRow("SELECT size, mtime, hash FROM file_hashes WHERE path = ?", path).Scan(&storedSize, &storedMtime, &storedHash)
 
 	fileChanged := false
 	if err == sql.ErrNoRows {
@@ -437,6 +701,14 @@ func (fs *FileScanner) fileNeedsIndexing(path string) (bool, []byte, os.FileInfo
 		return false, nil, info, err
 	}
 
+	// Only the modification time changed (e.g. touch or git checkout), the content is the same
+	if fs.contentHashing && storedHash.Valid && storedSize == info.Size() && storedHash.Int64 == contentHash(content) {
+		if _, err := fs.db.Exec("UPDATE file_hashes SET mtime = ? WHERE path = ?", info.ModTime().UnixNano(), path); err != nil {
+			return false, nil, info, err
+		}
+		return false, nil, info, nil
+	}
+
 	return true, content, info, nil
 }
 
@@ -448,6 +720,28 @@ func (fs *FileScanner) RemoveFiles(ctx context.Context, paths []string) error {
 		}
 	}
 
+	if err := fs.deleteFileStates(paths); err != nil {
+		return err
+	}
+
+	if fs.onUpdate != nil {
+		fs.onUpdate()
+	}
+
+	return nil
+}
+
+func (fs *FileScanner) removeFilesFromIndexers(paths []string) error {
+	for _, indexer := range fs.indexer {
+		if err := indexer.RemovedFiles(paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFileStates removes the stored file states, so the files are indexed again on the next run
+func (fs *FileScanner) deleteFileStates(paths []string) error {
 	tx, err := fs.db.Begin()
 	if err != nil {
 		return err
@@ -466,24 +760,7 @@ func (fs *FileScanner) RemoveFiles(ctx context.Context, paths []string) error {
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	if fs.onUpdate != nil {
-		fs.onUpdate()
-	}
-
-	return nil
-}
-
-func (fs *FileScanner) removeFilesFromIndexers(paths []string) error {
-	for _, indexer := range fs.indexer {
-		if err := indexer.RemovedFiles(paths); err != nil {
-			return err
-		}
-	}
-	return nil
+	return tx.Commit()
 }
 
 func (fs *FileScanner) updateFileStates(files []fileState) error {
@@ -493,14 +770,14 @@ func (fs *FileScanner) updateFileStates(files []fileState) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmt, err := tx.Prepare("INSERT OR REPLACE INTO file_hashes (path, size, mtime) VALUES (?, ?, ?)")
+	stmt, err := tx.Prepare("INSERT OR REPLACE INTO file_hashes (path, size, mtime, hash) VALUES (?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = stmt.Close() }()
 
 	for _, file := range files {
-		if _, err := stmt.Exec(file.path, file.info.Size(), file.info.ModTime().UnixNano()); err != nil {
+		if _, err := stmt.Exec(file.path, file.info.Size(), file.info.ModTime().UnixNano(), file.hash); err != nil {
 			return err
 		}
 	}
@@ -508,9 +785,62 @@ func (fs *FileScanner) updateFileStates(files []fileState) error {
 	return tx.Commit()
 }
 
+// indexFile parses a file and passes it to the indexers handling its file type. A panic while parsing or
+// in an indexer is recovered and logged, so one malformed file doesn't abort the batch.
+func (fs *FileScanner) indexFile(parser *tree_sitter.Parser, item fileWork, indexers []Indexer, errChan chan<- error) {
+	relevant := make([]Indexer, 0, len(indexers))
+	for _, indexer := range indexers {
+		if HandlesFile(indexer, item.path) {
+			relevant = append(relevant, indexer)
+		}
+	}
+
+	// Don't parse files no indexer is interested in
+	if len(relevant) == 0 {
+		return
+	}
+
+	var tree *tree_sitter.Tree
+	defer func() {
+		if tree != nil {
+			tree.Close()
+		}
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic while indexing %s: %v", item.path, r)
+		}
+	}()
+
+	tree = parser.Parse(item.content, nil)
+	if tree == nil {
+		log.Printf("Skipping %s: failed to parse file", item.path)
+		return
+	}
+
+	for _, indexer := range relevant {
+		fs.runIndexer(indexer, item, tree.RootNode(), errChan)
+	}
+}
+
+// runIndexer runs a single indexer on a file, recovering from panics so the remaining indexers still run
+func (fs *FileScanner) runIndexer(indexer Indexer, item fileWork, node *tree_sitter.Node, errChan chan<- error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Recovered from panic in indexer %s while indexing %s: %v", indexer.ID(), item.path, r)
+		}
+	}()
+
+	if err := indexer.Index(item.path, node, item.content); err != nil {
+		errChan <- err
+	}
+}
+
 type fileState struct {
 	path string
 	info os.FileInfo
+	hash sql.NullInt64
 }
 
 type fileWork struct {
@@ -521,6 +851,11 @@ type fileWork struct {
 
 // IndexFiles processes multiple files in parallel
 func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
+	return fs.IndexFilesWithProgress(ctx, files, nil)
+}
+
+// IndexFilesWithProgress processes multiple files in parallel and reports the progress to the callback
+func (fs *FileScanner) IndexFilesWithProgress(ctx context.Context, files []string, progress ProgressFunc) error {
 	if len(files) == 0 {
 		return nil
 	}
@@ -528,25 +863,8 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 	// Filter out files in directories that should be skipped
 	filteredFiles := make([]string, 0, len(files))
 	for _, path := range files {
-		// Get the relative path from project root
-		relPath, err := filepath.Rel(fs.projectRoot, path)
-		if err != nil {
-			// If we can't get the relative path, keep the file to be safe
-			filteredFiles = append(filteredFiles, path)
-			continue
-		}
-
-		// Check if the file is in a directory that should be skipped
-		skip := false
-		pathParts := strings.Split(relPath, string(os.PathSeparator))
-		for _, part := range pathParts {
-			if defaultSkipDirs[part] {
-				skip = true
-				break
-			}
-		}
-
-		if !skip {
+		// Files outside the project root are kept to be safe
+		if !fs.isInSkippedDir(path) {
 			filteredFiles = append(filteredFiles, path)
 		}
 	}
@@ -554,14 +872,11 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 	// Update files to only include filtered files
 	files = filteredFiles
 
-	// Determine the number of worker goroutines to use
-	workerCount := runtime.NumCPU() + 2
-	if workerCount > 16 {
-		workerCount = 16
-	}
+	// Determine the number of worker goroutines to use, more workers than files are never busy
+	workerCount := max(1, min(fs.indexWorkerCount(), len(files)))
 
-	// Create a channel to distribute work
-	fileChan := make(chan string, 100)
+	// Create a channel to distribute work, buffered enough to keep every worker busy
+	fileChan := make(chan string, workerCount*4)
 
 	// Create a channel for errors
 	errChan := make(chan error, len(files))
@@ -569,6 +884,16 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 	// Create a wait group to wait for all workers to finish
 	var wg sync.WaitGroup
 
+	var processed atomic.Int64
+	total := len(files)
+
+	// The writes of all files share one transaction per index. The file states are only saved after
+	// the commit, so files whose index data got lost are indexed again on the next start.
+	fs.indexMu.Lock()
+	batchers := beginBatches(fs.indexer)
+	var pendingStates []fileState
+	var pendingMu sync.Mutex
+
 	// Start workers
 	for i := 0; i < workerCount; i++ {
 		wg.Add(1)
@@ -576,8 +901,7 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 			defer wg.Done()
 
 			parsers := CreateTreesitterParsers()
-			const batchSize = 50
-			batch := make([]fileWork, 0, batchSize)
+			batch := make([]fileWork, 0, workerBatchSize)
 
 			processBatch := func(items []fileWork) {
 				if len(items) == 0 {
@@ -595,37 +919,38 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 				}
 
 				for _, item := range items {
-					ext := strings.ToLower(filepath.Ext(item.path))
-					parser := parsers[ext]
+					fileType := FileType(item.path)
+					parser := parsers[fileType]
 					if parser == nil {
-						panic(fmt.Sprintf("no parser found for file type: %s", ext))
-					}
-
-					tree := parser.Parse(item.content, nil)
-
-					for _, indexer := range fs.indexer {
-						if err := indexer.Index(item.path, tree.RootNode(), item.content); err != nil {
-							errChan <- err
-						}
+						log.Printf("Skipping %s: no parser found for file type %s", item.path, fileType)
+						continue
 					}
 
-					tree.Close()
+					fs.indexFile(parser, item, fs.indexer, errChan)
 				}
 
 				fileStates := make([]fileState, 0, len(items))
 				for _, item := range items {
-					fileStates = append(fileStates, fileState{
+					state := fileState{
 						path: item.path,
 						info: item.info,
-					})
+					}
+					if fs.contentHashing {
+						state.hash = sql.NullInt64{Int64: contentHash(item.content), Valid: true}
+					}
+					fileStates = append(fileStates, state)
 				}
 
-				if err := fs.updateFileStates(fileStates); err != nil {
-					errChan <- err
-				}
+				pendingMu.Lock()
+				pendingStates = append(pendingStates, fileStates...)
+				pendingMu.Unlock()
 			}
 
 			for path := range fileChan {
+				if progress != nil {
+					progress(int(processed.Add(1)), total)
+				}
+
 				// Check if file needs indexing
 				needsIndexing, content, info, err := fs.fileNeedsIndexing(path)
 				if err != nil {
@@ -643,7 +968,7 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 					content: content,
 					info:    info,
 				})
-				if len(batch) >= batchSize {
+				if len(batch) >= workerBatchSize {
 					processBatch(batch)
 					batch = batch[:0]
 				}
@@ -663,6 +988,13 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 
 	// Wait for all workers to finish
 	wg.Wait()
+	// Files whose index data was rolled back keep their old state and are indexed again
+	if commitBatches(batchers) {
+		if err := fs.updateFileStates(pendingStates); err != nil {
+			errChan <- err
+		}
+	}
+	fs.indexMu.Unlock()
 	close(errChan)
 
 	// Check if there were any errors
@@ -677,6 +1009,34 @@ func (fs *FileScanner) IndexFiles(ctx context.Context, files []string) error {
 	return nil
 }
 
+// beginBatches starts a batch on all indexers implementing Batcher and returns them
+func beginBatches(indexers []Indexer) []Batcher {
+	var batchers []Batcher
+	for _, indexer := range indexers {
+		batcher, ok := indexer.(Batcher)
+		if !ok {
+			continue
+		}
+
+		if err := batcher.BeginBatch(); err != nil {
+			log.Printf("Failed to begin batch for indexer %s: %v", indexer.ID(), err)
+			continue
+		}
+		batchers = append(batchers, batcher)
+	}
+
+	return batchers
+}
+
+// commitBatches commits the batches started by beginBatches and reports whether all writes were committed
+func commitBatches(batchers []Batcher) bool {
+	if err := CommitBatches(batchers...); err != nil {
+		log.Printf("Failed to commit index batch: %v", err)
+		return false
+	}
+	return true
+}
+
 // ClearHashes clears all file hashes, forcing reindexing
 func (fs *FileScanner) ClearHashes() error {
 	for _, indexer := range fs.indexer {
@@ -2,10 +2,16 @@ package indexer
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -80,6 +86,44 @@ func TestFileScanner_IndexFiles_SkipDirs(t *testing.T) {
 	}
 }
 
+func TestFileScanner_IndexFiles_NestedSkipDirs(t *testing.T) {
+	tempDir := t.TempDir()
+
+	indexedFiles := []string{
+		filepath.Join(tempDir, "src", "MyBundle", "Service", "file.php"),
+	}
+	skippedFiles := []string{
+		filepath.Join(tempDir, "public", "file.php"),
+		filepath.Join(tempDir, "src", "Resources", "public", "file.php"),
+		filepath.Join(tempDir, "src", "MyBundle", "tests", "file.php"),
+		filepath.Join(tempDir, "src", "Resources", "node_modules", "file.php"),
+	}
+
+	for _, path := range append(indexedFiles, skippedFiles...) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	mockIndexer := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(mockIndexer)
+
+	require.NoError(t, fs.IndexAll(context.Background(), nil))
+
+	// Skipped directories are skipped at any depth
+	for _, path := range indexedFiles {
+		assert.True(t, mockIndexer.indexedFiles[path], "File was not indexed: %s", path)
+	}
+	for _, path := range skippedFiles {
+		assert.False(t, mockIndexer.indexedFiles[path], "Skipped file was indexed: %s", path)
+	}
+}
+
 // Helper function to create test files
 func createTestFiles(t *testing.T, baseDir string) {
 	// Create directories and files for testing
@@ -105,14 +149,19 @@ func createTestFiles(t *testing.T, baseDir string) {
 // Mock indexer for testing
 type mockIndexer struct {
 	indexedFiles map[string]bool
+	mu           sync.Mutex
 }
 
 func (m *mockIndexer) Index(path string, node *tree_sitter.Node, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.indexedFiles[path] = true
 	return nil
 }
 
 func (m *mockIndexer) RemovedFiles(paths []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	for _, path := range paths {
 		delete(m.indexedFiles, path)
 	}
@@ -132,5 +181,490 @@ func (m *mockIndexer) Close() error {
 }
 
 func (m *mockIndexer) Clear() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.indexedFiles = make(map[string]bool)
 	return nil
 }
+
+func TestFileScanner_SetSkipDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	createTestFiles(t, tempDir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "storage"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "storage", "file.php"), []byte("<?php\n"), 0644))
+
+	mockIndexer := &mockIndexer{
+		indexedFiles: make(map[string]bool),
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(tempDir, "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	fs.AddIndexer(mockIndexer)
+	fs.SetSkipDirs([]string{"storage"}, []string{"tests"})
+
+	require.NoError(t, fs.IndexAll(context.Background(), nil))
+
+	assert.True(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "regular", "file.php")])
+	assert.True(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "tests", "file.php")], "Included default skip dir was not indexed")
+	assert.False(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "storage", "file.php")], "Additional skip dir was indexed")
+	assert.False(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "node_modules", "file.php")], "Default skip dir was indexed")
+
+	// The defaults themselves are not modified
+	assert.True(t, defaultSkipDirs["tests"])
+	assert.False(t, defaultSkipDirs["storage"])
+}
+
+func TestFileScanner_IndexFilesWithProgress(t *testing.T) {
+	tempDir := t.TempDir()
+
+	var files []string
+	for _, name := range []string{"a.php", "b.php", "c.php"} {
+		path := filepath.Join(tempDir, name)
+		require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+		files = append(files, path)
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(tempDir, "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	fs.AddIndexer(&mockIndexer{indexedFiles: make(map[string]bool)})
+
+	var mu sync.Mutex
+	var reported []int
+	err = fs.IndexFilesWithProgress(context.Background(), files, func(processed, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, len(files), total)
+		reported = append(reported, processed)
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []int{1, 2, 3}, reported)
+}
+
+func TestFileScanner_ReindexDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+	pluginDir := filepath.Join(tempDir, "custom", "plugins", "MyPlugin")
+	otherDir := filepath.Join(tempDir, "src")
+	require.NoError(t, os.MkdirAll(pluginDir, 0755))
+	require.NoError(t, os.MkdirAll(otherDir, 0755))
+
+	pluginFile := filepath.Join(pluginDir, "Plugin.php")
+	staleFile := filepath.Join(pluginDir, "Stale.php")
+	otherFile := filepath.Join(otherDir, "Other.php")
+	for _, path := range []string{pluginFile, staleFile, otherFile} {
+		require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(tempDir, "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	mockIndexer := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(mockIndexer)
+
+	require.NoError(t, fs.IndexAll(context.Background(), nil))
+	require.Len(t, mockIndexer.indexedFiles, 3)
+
+	// Only track the files indexed by the directory reindex
+	mockIndexer.indexedFiles = make(map[string]bool)
+	require.NoError(t, os.Remove(staleFile))
+
+	count, err := fs.ReindexDirectory(context.Background(), pluginDir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.True(t, mockIndexer.indexedFiles[pluginFile], "File in directory was not reindexed")
+	assert.False(t, mockIndexer.indexedFiles[otherFile], "File outside of directory was reindexed")
+
+	indexed, err := fs.indexedFilesBelow(pluginDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{pluginFile}, indexed, "Deleted file is still tracked")
+}
+
+func TestFileScanner_ReindexIndexer(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"First.php", "Second.php"} {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte("<?php\n"), 0644))
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	target := &mockIndexer{indexedFiles: make(map[string]bool)}
+	other := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(target)
+	fs.AddIndexer(other)
+
+	require.NoError(t, fs.IndexAll(context.Background(), nil))
+	require.Len(t, target.indexedFiles, 2)
+
+	// Unchanged files are passed to the reindexed indexer again, the others are not involved
+	target.indexedFiles["/stale/File.php"] = true
+	other.indexedFiles = make(map[string]bool)
+
+	count, err := fs.ReindexIndexer(context.Background(), target)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	assert.Equal(t, map[string]bool{
+		filepath.Join(tempDir, "First.php"):  true,
+		filepath.Join(tempDir, "Second.php"): true,
+	}, target.indexedFiles)
+	assert.Empty(t, other.indexedFiles)
+}
+
+func TestFileScanner_WatcherLifecycle(t *testing.T) {
+	tempDir := t.TempDir()
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	assert.False(t, fs.IsWatching())
+
+	require.NoError(t, fs.StartWatcher())
+	assert.True(t, fs.IsWatching())
+
+	fs.StopWatcher()
+	assert.False(t, fs.IsWatching())
+
+	// Stopping twice is a no-op
+	fs.StopWatcher()
+	assert.False(t, fs.IsWatching())
+}
+
+func TestFileScanner_IndexWorkerCount(t *testing.T) {
+	fs, err := NewFileScanner(t.TempDir(), filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	t.Setenv(indexWorkersEnv, "")
+	assert.Equal(t, min(runtime.GOMAXPROCS(0)+2, maxDefaultIndexWorkers), fs.indexWorkerCount())
+
+	t.Setenv(indexWorkersEnv, "3")
+	assert.Equal(t, 3, fs.indexWorkerCount())
+
+	t.Setenv(indexWorkersEnv, "invalid")
+	assert.Equal(t, min(runtime.GOMAXPROCS(0)+2, maxDefaultIndexWorkers), fs.indexWorkerCount())
+
+	// The explicit option wins over the environment
+	t.Setenv(indexWorkersEnv, "3")
+	fs.SetWorkerCount(1)
+	assert.Equal(t, 1, fs.indexWorkerCount())
+
+	fs.SetWorkerCount(0)
+	assert.Equal(t, 3, fs.indexWorkerCount())
+}
+
+type panickingIndexer struct {
+	mockIndexer
+}
+
+func (p *panickingIndexer) Index(path string, node *tree_sitter.Node, content []byte) error {
+	if strings.HasSuffix(path, "broken.php") {
+		panic("malformed file")
+	}
+	return p.mockIndexer.Index(path, node, content)
+}
+
+type phpOnlyIndexer struct {
+	mockIndexer
+}
+
+func (p *phpOnlyIndexer) FileExtensions() []string {
+	return []string{".php"}
+}
+
+func TestFileScanner_IndexFiles_FileExtensions(t *testing.T) {
+	tempDir := t.TempDir()
+
+	phpFile := filepath.Join(tempDir, "Service.php")
+	xmlFile := filepath.Join(tempDir, "services.xml")
+	require.NoError(t, os.WriteFile(phpFile, []byte("<?php\n"), 0644))
+	require.NoError(t, os.WriteFile(xmlFile, []byte("<container/>\n"), 0644))
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	phpOnly := &phpOnlyIndexer{mockIndexer{indexedFiles: make(map[string]bool)}}
+	all := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(phpOnly)
+	fs.AddIndexer(all)
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{phpFile, xmlFile}))
+
+	assert.Equal(t, map[string]bool{phpFile: true}, phpOnly.indexedFiles)
+	assert.Equal(t, map[string]bool{phpFile: true, xmlFile: true}, all.indexedFiles)
+
+	assert.True(t, HandlesFile(phpOnly, "/project/src/Foo.PHP"))
+	assert.False(t, HandlesFile(phpOnly, "/project/src/foo.html.twig"))
+	assert.True(t, HandlesFile(all, "/project/src/foo.html.twig"))
+}
+
+func TestFileScanner_IndexFiles_RecoversPanics(t *testing.T) {
+	tempDir := t.TempDir()
+
+	brokenFile := filepath.Join(tempDir, "broken.php")
+	regularFile := filepath.Join(tempDir, "regular.php")
+	unknownFile := filepath.Join(tempDir, "notes.txt")
+	for _, path := range []string{brokenFile, regularFile, unknownFile} {
+		require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	// Process all files in the same batch
+	fs.SetWorkerCount(1)
+
+	panicking := &panickingIndexer{mockIndexer{indexedFiles: make(map[string]bool)}}
+	regular := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(panicking)
+	fs.AddIndexer(regular)
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{brokenFile, regularFile, unknownFile}))
+
+	assert.True(t, panicking.indexedFiles[regularFile], "File after the panicking one was not indexed")
+	assert.True(t, regular.indexedFiles[brokenFile], "Other indexers did not run after the panic")
+	assert.True(t, regular.indexedFiles[regularFile])
+	assert.False(t, regular.indexedFiles[unknownFile], "File without parser was indexed")
+}
+
+func TestFileScanner_ContentHashing(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "file.php")
+	require.NoError(t, os.WriteFile(path, []byte("<?php\n// v1\n"), 0644))
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	mockIndexer := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(mockIndexer)
+	fs.SetContentHashing(true)
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	require.True(t, mockIndexer.indexedFiles[path])
+
+	// Touching the file keeps the content, it is not indexed again
+	mockIndexer.indexedFiles = make(map[string]bool)
+	touched := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, touched, touched))
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	assert.False(t, mockIndexer.indexedFiles[path], "Touched file was indexed again")
+
+	// A content change of the same size is detected
+	require.NoError(t, os.WriteFile(path, []byte("<?php\n// v2\n"), 0644))
+	require.NoError(t, os.Chtimes(path, touched.Add(time.Hour), touched.Add(time.Hour)))
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	assert.True(t, mockIndexer.indexedFiles[path], "Changed file was not indexed")
+
+	// Without content hashing, touching triggers indexing
+	fs.SetContentHashing(false)
+	mockIndexer.indexedFiles = make(map[string]bool)
+	require.NoError(t, os.Chtimes(path, touched.Add(2*time.Hour), touched.Add(2*time.Hour)))
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	assert.True(t, mockIndexer.indexedFiles[path])
+}
+
+// failingCommitIndexer is a mockIndexer whose batches fail to commit
+type failingCommitIndexer struct {
+	mockIndexer
+}
+
+func (m *failingCommitIndexer) BeginBatch() error {
+	return nil
+}
+
+func (m *failingCommitIndexer) Commit() error {
+	return errors.New("disk full")
+}
+
+func TestFileScanner_FailedCommitKeepsFileState(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "file.php")
+	require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	failing := &failingCommitIndexer{mockIndexer{indexedFiles: make(map[string]bool)}}
+	fs.AddIndexer(failing)
+
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	require.True(t, failing.indexedFiles[path])
+
+	// The rolled back file is not marked as indexed and is indexed again
+	failing.indexedFiles = make(map[string]bool)
+	require.NoError(t, fs.IndexFiles(context.Background(), []string{path}))
+	assert.True(t, failing.indexedFiles[path], "File of the failed commit was not indexed again")
+}
+
+// overlappingBatchIndexer is a mockIndexer recording whether batches of several runs overlapped
+type overlappingBatchIndexer struct {
+	mockIndexer
+	open       int
+	overlapped bool
+}
+
+func (m *overlappingBatchIndexer) BeginBatch() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.open++
+	if m.open > 1 {
+		m.overlapped = true
+	}
+	return nil
+}
+
+func (m *overlappingBatchIndexer) Commit() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.open--
+	return nil
+}
+
+func TestFileScanner_ConcurrentRunsDontShareBatches(t *testing.T) {
+	tempDir := t.TempDir()
+	var files []string
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(tempDir, fmt.Sprintf("file%d.php", i))
+		require.NoError(t, os.WriteFile(path, []byte("<?php\n"), 0644))
+		files = append(files, path)
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	indexer := &overlappingBatchIndexer{mockIndexer: mockIndexer{indexedFiles: make(map[string]bool)}}
+	fs.AddIndexer(indexer)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, fs.IndexFiles(context.Background(), files))
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, indexer.overlapped, "Indexing runs shared a batch")
+	assert.Len(t, indexer.indexedFiles, len(files))
+}
+
+func TestFileScanner_MigratesFileHashesWithoutHashColumn(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec(`
+		CREATE TABLE file_hashes (
+			path TEXT PRIMARY KEY,
+			size INTEGER NOT NULL,
+			mtime INTEGER NOT NULL
+		);
+		INSERT INTO file_hashes (path, size, mtime) VALUES ('/old.php', 1, 1);
+	`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	fs, err := NewFileScanner(t.TempDir(), dbPath)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	var hash sql.NullInt64
+	require.NoError(t, fs.db.QueryRow("SELECT hash FROM file_hashes WHERE path = '/old.php'").Scan(&hash))
+	assert.False(t, hash.Valid)
+}
+
+func TestFileType(t *testing.T) {
+	t.Cleanup(func() { _ = SetCustomFileTypes(nil) })
+
+	assert.Equal(t, ".twig", FileType("/views/page.html.twig"))
+	assert.Equal(t, ".php", FileType("/src/Controller.PHP"))
+	assert.Equal(t, ".html", FileType("/views/page.twig.html"))
+
+	err := SetCustomFileTypes(map[string]string{
+		".twig.html": "twig",
+		"tpl":        ".TWIG",
+		".html":      ".xml",
+		".md":        "markdown",
+	})
+	assert.ErrorContains(t, err, "unknown file types .markdown")
+
+	assert.Equal(t, ".twig", FileType("/views/page.twig.html"))
+	assert.Equal(t, ".twig", FileType("file:///views/Page.TWIG.HTML"))
+	assert.Equal(t, ".twig", FileType("/views/page.tpl"))
+	assert.Equal(t, ".xml", FileType("/views/page.html"))
+	assert.Equal(t, ".md", FileType("/README.md"))
+
+	assert.True(t, IsScannedFile("/views/page.twig.html"))
+	assert.False(t, IsScannedFile("/README.md"))
+}
+
+func TestFileScanner_CustomFileTypes(t *testing.T) {
+	tempDir := t.TempDir()
+	require.NoError(t, SetCustomFileTypes(map[string]string{".twig.html": "twig"}))
+	t.Cleanup(func() { _ = SetCustomFileTypes(nil) })
+
+	for name, content := range map[string]string{
+		"page.twig.html": "{% block content %}{% endblock %}",
+		"page.html":      "<html></html>",
+		"page.html.twig": "{% block content %}{% endblock %}",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644))
+	}
+
+	fs, err := NewFileScanner(tempDir, filepath.Join(tempDir, "test.db"))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, fs.Close())
+	}()
+
+	mockIndexer := &mockIndexer{indexedFiles: make(map[string]bool)}
+	fs.AddIndexer(mockIndexer)
+
+	require.NoError(t, fs.IndexAll(context.Background(), nil))
+
+	assert.True(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "page.twig.html")], "Mapped file was not indexed")
+	assert.True(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "page.html.twig")], "Twig file was not indexed")
+	assert.False(t, mockIndexer.indexedFiles[filepath.Join(tempDir, "page.html")], "Unmapped file was indexed")
+}
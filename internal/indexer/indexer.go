@@ -1,6 +1,10 @@
 package indexer
 
-import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+import (
+	"slices"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
 
 type Indexer interface {
 	ID() string
@@ -9,3 +13,68 @@ type Indexer interface {
 	Close() error
 	Clear() error
 }
+
+// Counter is optionally implemented by indexers which can report the number of indexed entries
+type Counter interface {
+	Count() (int, error)
+}
+
+// Count returns the number of entries of an indexer. The second return value is false
+// when the indexer does not implement Counter.
+func Count(idx Indexer) (int, bool, error) {
+	counter, ok := idx.(Counter)
+	if !ok {
+		return 0, false, nil
+	}
+
+	count, err := counter.Count()
+	return count, true, err
+}
+
+// FileExtensionFilter is optionally implemented by indexers which only handle some file types.
+// Indexers without it receive every file.
+type FileExtensionFilter interface {
+	// FileExtensions returns the file types (see FileType) the indexer handles, e.g. ".php"
+	FileExtensions() []string
+}
+
+// HandlesFile reports whether the file is passed to the indexer
+func HandlesFile(idx Indexer, path string) bool {
+	filter, ok := idx.(FileExtensionFilter)
+	if !ok {
+		return true
+	}
+
+	return slices.Contains(filter.FileExtensions(), FileType(path))
+}
+
+// Batcher is optionally implemented by indexers which can group the writes of many files into one
+// transaction. The file scanner starts a batch before indexing files and commits it afterwards.
+type Batcher interface {
+	BeginBatch() error
+	Commit() error
+}
+
+// BeginBatches starts a batch on all batchers, indexers storing into several DataIndexers use it to implement Batcher
+func BeginBatches(batchers ...Batcher) error {
+	for i, batcher := range batchers {
+		if err := batcher.BeginBatch(); err != nil {
+			_ = CommitBatches(batchers[:i]...)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CommitBatches commits all batchers and returns the first error
+func CommitBatches(batchers ...Batcher) error {
+	var firstErr error
+	for _, batcher := range batchers {
+		if err := batcher.Commit(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -1,6 +1,12 @@
 package indexer
 
 import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
 	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
 	tree_sitter_scss "github.com/tree-sitter-grammars/tree-sitter-scss/bindings/go"
 	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
@@ -23,6 +29,76 @@ var scannedFileTypes = []string{
 	".ts",
 }
 
+var (
+	// customFileTypes maps additional file name suffixes like ".twig.html" to one of the scanned file types
+	customFileTypes   = map[string]string{}
+	customFileTypesMu sync.RWMutex
+)
+
+// SetCustomFileTypes configures additional file name suffixes to be handled like a scanned file type,
+// e.g. {".twig.html": "twig", ".tpl": ".twig"}. Mappings to unknown file types are reported and skipped.
+func SetCustomFileTypes(mapping map[string]string) error {
+	fileTypes := make(map[string]string, len(mapping))
+	var unknown []string
+
+	for suffix, fileType := range mapping {
+		suffix = strings.ToLower(suffix)
+		if !strings.HasPrefix(suffix, ".") {
+			suffix = "." + suffix
+		}
+
+		fileType = strings.ToLower(fileType)
+		if !strings.HasPrefix(fileType, ".") {
+			fileType = "." + fileType
+		}
+
+		if !slices.Contains(scannedFileTypes, fileType) {
+			unknown = append(unknown, fileType)
+			continue
+		}
+
+		fileTypes[suffix] = fileType
+	}
+
+	customFileTypesMu.Lock()
+	customFileTypes = fileTypes
+	customFileTypesMu.Unlock()
+
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return fmt.Errorf("unknown file types %s, supported are %s", strings.Join(unknown, ", "), strings.Join(scannedFileTypes, ", "))
+	}
+
+	return nil
+}
+
+// FileType returns the file type deciding the grammar and the features of a file. This is the lowercase
+// extension unless a custom file type matches the end of the file name, the longest match wins.
+func FileType(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+
+	customFileTypesMu.RLock()
+	defer customFileTypesMu.RUnlock()
+
+	fileType, matched := "", ""
+	for suffix, mapped := range customFileTypes {
+		if strings.HasSuffix(name, suffix) && len(suffix) > len(matched) {
+			fileType, matched = mapped, suffix
+		}
+	}
+
+	if fileType != "" {
+		return fileType
+	}
+
+	return strings.ToLower(filepath.Ext(name))
+}
+
+// IsScannedFile reports whether the file is indexed by its file type
+func IsScannedFile(path string) bool {
+	return slices.Contains(scannedFileTypes, FileType(path))
+}
+
 func CreateTreesitterParsers() map[string]*tree_sitter.Parser {
 	parsers := make(map[string]*tree_sitter.Parser)
 
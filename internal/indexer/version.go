@@ -11,7 +11,7 @@ import (
 // IndexVersion is the current version of the index schema.
 // Bump this number whenever you make breaking changes to any indexer's schema.
 // This will cause all existing caches to be invalidated and rebuilt.
-const IndexVersion = 1
+const IndexVersion = 7
 
 const versionFileName = "index_version"
 
@@ -3,6 +3,7 @@ package indexer
 import (
 	"os"
 	"path/filepath"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,7 +29,7 @@ func TestCheckAndMigrateCache_MatchingVersion(t *testing.T) {
 
 	// Write current version
 	versionFile := filepath.Join(cacheDir, versionFileName)
-	err := os.WriteFile(versionFile, []byte("1"), 0644)
+	err := os.WriteFile(versionFile, []byte(strconv.Itoa(IndexVersion)), 0644)
 	require.NoError(t, err)
 
 	// Create a dummy file to verify it's not deleted
@@ -71,7 +72,7 @@ func TestCheckAndMigrateCache_OldVersion(t *testing.T) {
 	// Version file should be updated
 	data, err := os.ReadFile(versionFile)
 	require.NoError(t, err)
-	assert.Equal(t, "1", string(data), "Version file should be updated to current version")
+	assert.Equal(t, strconv.Itoa(IndexVersion), string(data), "Version file should be updated to current version")
 }
 
 func TestCheckAndMigrateCache_CorruptedVersion(t *testing.T) {
@@ -90,7 +91,7 @@ func TestCheckAndMigrateCache_CorruptedVersion(t *testing.T) {
 	// Version file should be fixed
 	data, err := os.ReadFile(versionFile)
 	require.NoError(t, err)
-	assert.Equal(t, "1", string(data), "Version file should be fixed")
+	assert.Equal(t, strconv.Itoa(IndexVersion), string(data), "Version file should be fixed")
 }
 
 func TestCheckAndMigrateCache_ClearsSubdirectories(t *testing.T) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -41,11 +42,12 @@ func (p *AdminCodeActionProvider) GetCodeActions(ctx context.Context, params *pr
 	}
 
 	// Only handle .twig files
-	if !strings.HasSuffix(params.TextDocument.URI, ".twig") {
+	if indexer.FileType(params.TextDocument.URI) != ".twig" {
 		return nil
 	}
 
 	var codeActions []protocol.CodeAction
+	var missingPropDiagnostics []protocol.Diagnostic
 
 	// Check diagnostics for missing required props
 	for _, diag := range params.Context.Diagnostics {
@@ -55,13 +57,86 @@ func (p *AdminCodeActionProvider) GetCodeActions(ctx context.Context, params *pr
 			action := p.createAddPropAction(params, &diag)
 			if action != nil {
 				codeActions = append(codeActions, *action)
+				missingPropDiagnostics = append(missingPropDiagnostics, diag)
 			}
 		}
 	}
 
+	// Offer to add all of them at once when a tag misses more than one required prop
+	if len(missingPropDiagnostics) > 1 {
+		if action := p.createAddAllPropsAction(params, missingPropDiagnostics); action != nil {
+			codeActions = append(codeActions, *action)
+		}
+	}
+
 	return codeActions
 }
 
+// createAddAllPropsAction creates a code action to add all missing props of a component tag
+// Every prop gets its own tab stop, so the cursor starts in the value of the first one
+func (p *AdminCodeActionProvider) createAddAllPropsAction(params *protocol.CodeActionParams, diags []protocol.Diagnostic) *protocol.CodeAction {
+	insertPos := p.findInsertPosition(params, &diags[0])
+	if insertPos == nil {
+		return nil
+	}
+
+	var snippet strings.Builder
+	var propNames []string
+	var tagDiags []protocol.Diagnostic
+	seen := make(map[string]bool)
+
+	for _, diag := range diags {
+		// Diagnostics of the same tag share the range of its tag name
+		if diag.Range != diags[0].Range {
+			continue
+		}
+		tagDiags = append(tagDiags, diag)
+
+		data, _ := diag.Data.(map[string]any)
+		componentName, _ := data["componentName"].(string)
+		propName, _ := data["propName"].(string)
+		if seen[propName] {
+			continue
+		}
+		seen[propName] = true
+
+		propAttr, defaultValue := p.getPropAttributeFormat(componentName, propName)
+		propNames = append(propNames, propName)
+
+		tabStop := len(propNames)
+		if defaultValue == "" {
+			snippet.WriteString(fmt.Sprintf(" %s=\"$%d\"", propAttr, tabStop))
+		} else {
+			snippet.WriteString(fmt.Sprintf(" %s=\"${%d:%s}\"", propAttr, tabStop, escapeSnippetPlaceholder(defaultValue)))
+		}
+	}
+
+	if len(propNames) < 2 {
+		return nil
+	}
+
+	return &protocol.CodeAction{
+		Title:       fmt.Sprintf("Add all missing required props (%s)", strings.Join(propNames, ", ")),
+		Kind:        protocol.CodeActionQuickFix,
+		Diagnostics: tagDiags,
+		Command: &protocol.CommandAction{
+			Title:   "Add all missing required props",
+			Command: "shopware.editor.insertSnippetAtPosition",
+			Arguments: []interface{}{
+				params.TextDocument.URI,
+				insertPos.Line,
+				insertPos.Character,
+				snippet.String() + "$0",
+			},
+		},
+	}
+}
+
+// escapeSnippetPlaceholder escapes the characters with a special meaning inside a snippet placeholder
+func escapeSnippetPlaceholder(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`).Replace(text)
+}
+
 // createAddPropAction creates a code action to add a missing prop
 func (p *AdminCodeActionProvider) createAddPropAction(params *protocol.CodeActionParams, diag *protocol.Diagnostic) *protocol.CodeAction {
 	data, ok := diag.Data.(map[string]any)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
 	"github.com/stretchr/testify/assert"
@@ -455,3 +456,123 @@ Component.register('sw-icon', {
 	line := action.Command.Arguments[1].(int)
 	assert.Equal(t, 0, line)
 }
+
+func TestAdminCodeActionProvider_AddAllMissingProps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-select",
+		FilePath: "/project/admin/sw-select/index.js",
+		Props: []admin.VueComponentProp{
+			{Name: "label", Type: "String", Required: true},
+			{Name: "options", Type: "Array", Required: true},
+			{Name: "valueKey", Type: "String", Required: true},
+		},
+	}))
+
+	provider := &AdminCodeActionProvider{
+		adminIndexer: adminIndexer,
+	}
+
+	twigCode := `<sw-select :disabled="false"></sw-select>`
+	tree, parser := parseTwig(t, twigCode)
+	defer tree.Close()
+	defer parser.Close()
+
+	tagRange := protocol.Range{
+		Start: protocol.Position{Line: 0, Character: 1},
+		End:   protocol.Position{Line: 0, Character: 10},
+	}
+
+	var diagnostics []protocol.Diagnostic
+	for _, propName := range []string{"label", "options", "valueKey"} {
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: tagRange,
+			Code:  "admin.component.missing-required-prop",
+			Data: map[string]any{
+				"componentName": "sw-select",
+				"propName":      propName,
+			},
+		})
+	}
+
+	uri := "file:///project/src/Resources/app/administration/src/views/test.html.twig"
+	params := &protocol.CodeActionParams{
+		Range:           tagRange,
+		Context:         protocol.CodeActionContext{Diagnostics: diagnostics},
+		Node:            findNodeAtPosition(tree.RootNode(), 0, 1),
+		DocumentContent: []byte(twigCode),
+	}
+	params.TextDocument.URI = uri
+
+	actions := provider.GetCodeActions(context.Background(), params)
+	require.Len(t, actions, 4, "one action per prop and one for all props")
+
+	action := actions[3]
+	assert.Equal(t, "Add all missing required props (label, options, valueKey)", action.Title)
+	assert.Len(t, action.Diagnostics, 3)
+	require.NotNil(t, action.Command)
+	assert.Equal(t, "shopware.editor.insertSnippetAtPosition", action.Command.Command)
+	assert.Equal(t, []interface{}{
+		uri,
+		0,
+		28,
+		` label="$1" :options="${2:[]}" value-key="$3"$0`,
+	}, action.Command.Arguments)
+}
+
+func TestAdminCodeActionProvider_SingleMissingPropHasNoAddAllAction(t *testing.T) {
+	provider := &AdminCodeActionProvider{}
+
+	twigCode := `<sw-icon></sw-icon>`
+	tree, parser := parseTwig(t, twigCode)
+	defer tree.Close()
+	defer parser.Close()
+
+	params := &protocol.CodeActionParams{
+		Context: protocol.CodeActionContext{Diagnostics: []protocol.Diagnostic{{
+			Code: "admin.component.missing-required-prop",
+			Data: map[string]any{"componentName": "sw-icon", "propName": "name"},
+		}}},
+		Node:            findNodeAtPosition(tree.RootNode(), 0, 1),
+		DocumentContent: []byte(twigCode),
+	}
+	params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/views/test.html.twig"
+
+	actions := provider.GetCodeActions(context.Background(), params)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Add missing prop 'name'", actions[0].Title)
+}
+
+func TestAdminCodeActionProvider_CustomFileType(t *testing.T) {
+	require.NoError(t, indexer.SetCustomFileTypes(map[string]string{".twig.html": "twig"}))
+	t.Cleanup(func() { _ = indexer.SetCustomFileTypes(nil) })
+
+	provider := &AdminCodeActionProvider{}
+
+	twigCode := `<sw-icon></sw-icon>`
+	tree, parser := parseTwig(t, twigCode)
+	defer tree.Close()
+	defer parser.Close()
+
+	params := &protocol.CodeActionParams{
+		Context: protocol.CodeActionContext{Diagnostics: []protocol.Diagnostic{{
+			Code: "admin.component.missing-required-prop",
+			Data: map[string]any{"componentName": "sw-icon", "propName": "name"},
+		}}},
+		Node:            findNodeAtPosition(tree.RootNode(), 0, 1),
+		DocumentContent: []byte(twigCode),
+	}
+	params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/views/test.twig.html"
+
+	assert.Len(t, provider.GetCodeActions(context.Background(), params), 1)
+}
+
+func TestEscapeSnippetPlaceholder(t *testing.T) {
+	assert.Equal(t, `() => {\}`, escapeSnippetPlaceholder("() => {}"))
+	assert.Equal(t, `\$t('label')`, escapeSnippetPlaceholder("$t('label')"))
+}
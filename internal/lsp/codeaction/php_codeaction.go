@@ -0,0 +1,363 @@
+package codeaction
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPCodeActionProvider provides code actions for the use statements of PHP files
+type PHPCodeActionProvider struct {
+	lspServer *lsp.Server
+}
+
+// NewPHPCodeActionProvider creates a new PHPCodeActionProvider
+func NewPHPCodeActionProvider(lspServer *lsp.Server) *PHPCodeActionProvider {
+	return &PHPCodeActionProvider{
+		lspServer: lspServer,
+	}
+}
+
+// GetCodeActionKinds returns the kinds of code actions this provider can provide
+func (p *PHPCodeActionProvider) GetCodeActionKinds() []protocol.CodeActionKind {
+	return []protocol.CodeActionKind{
+		protocol.CodeActionQuickFix,
+		protocol.CodeActionSourceRemoveUnusedImports,
+		protocol.CodeActionSourceOrganizeImports,
+	}
+}
+
+// GetCodeActions returns quick fixes for unused import diagnostics and source actions removing unused imports
+// and organizing the imports
+func (p *PHPCodeActionProvider) GetCodeActions(ctx context.Context, params *protocol.CodeActionParams) []protocol.CodeAction {
+	if indexer.FileType(params.TextDocument.URI) != ".php" || params.Node == nil {
+		return nil
+	}
+
+	rootNode := params.Node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	var codeActions []protocol.CodeAction
+
+	if isCodeActionKindRequested(params, protocol.CodeActionSourceOrganizeImports) {
+		if p.lspServer != nil && p.lspServer.SupportsCodeActionResolve() {
+			// Sorting all imports is deferred to codeAction/resolve, as the action is requested on every cursor move
+			codeActions = append(codeActions, protocol.CodeAction{
+				Title: "Organize imports",
+				Kind:  protocol.CodeActionSourceOrganizeImports,
+				Data: map[string]any{
+					"uri": params.TextDocument.URI,
+				},
+			})
+		} else if edit := organizeImportsEdit(rootNode, params.DocumentContent, p.groupImports()); edit != nil {
+			codeActions = append(codeActions, protocol.CodeAction{
+				Title: "Organize imports",
+				Kind:  protocol.CodeActionSourceOrganizeImports,
+				Edit: &protocol.WorkspaceEdit{
+					Changes: map[string][]protocol.TextEdit{
+						params.TextDocument.URI: {*edit},
+					},
+				},
+			})
+		}
+	}
+
+	unused := php.UnusedUseClauses(rootNode, params.DocumentContent)
+	if len(unused) == 0 {
+		return codeActions
+	}
+
+	if isCodeActionKindRequested(params, protocol.CodeActionQuickFix) {
+		for _, diagnostic := range params.Context.Diagnostics {
+			if code, _ := diagnostic.Code.(string); code != "php.unused-use" {
+				continue
+			}
+
+			data, _ := diagnostic.Data.(map[string]any)
+			name, _ := data["name"].(string)
+
+			for _, clause := range unused {
+				if clause.Name != name {
+					continue
+				}
+
+				codeActions = append(codeActions, protocol.CodeAction{
+					Title:       fmt.Sprintf("Remove unused import '%s'", clause.FQCN),
+					Kind:        protocol.CodeActionQuickFix,
+					Diagnostics: []protocol.Diagnostic{diagnostic},
+					Edit: &protocol.WorkspaceEdit{
+						Changes: map[string][]protocol.TextEdit{
+							params.TextDocument.URI: removeUseClausesEdits([]php.UseClause{clause}, params.DocumentContent),
+						},
+					},
+				})
+				break
+			}
+		}
+	}
+
+	if isCodeActionKindRequested(params, protocol.CodeActionSourceRemoveUnusedImports) {
+		codeActions = append(codeActions, protocol.CodeAction{
+			Title: "Remove all unused imports",
+			Kind:  protocol.CodeActionSourceRemoveUnusedImports,
+			Edit: &protocol.WorkspaceEdit{
+				Changes: map[string][]protocol.TextEdit{
+					params.TextDocument.URI: removeUseClausesEdits(unused, params.DocumentContent),
+				},
+			},
+		})
+	}
+
+	return codeActions
+}
+
+// ResolveCodeAction computes the edit of the organize imports action on the current document content
+func (p *PHPCodeActionProvider) ResolveCodeAction(ctx context.Context, codeAction *protocol.CodeAction) (*protocol.CodeAction, error) {
+	if codeAction.Kind != protocol.CodeActionSourceOrganizeImports || codeAction.Edit != nil || p.lspServer == nil {
+		return nil, nil
+	}
+
+	data, _ := codeAction.Data.(map[string]any)
+	uri, _ := data["uri"].(string)
+	if indexer.FileType(uri) != ".php" {
+		return nil, nil
+	}
+
+	document, ok := p.lspServer.DocumentManager().GetDocument(uri)
+	if !ok {
+		return nil, fmt.Errorf("document %s is not open", uri)
+	}
+	defer document.Release()
+
+	if document.Tree == nil {
+		return nil, fmt.Errorf("document %s is not open", uri)
+	}
+
+	resolved := *codeAction
+	resolved.Edit = &protocol.WorkspaceEdit{
+		Changes: map[string][]protocol.TextEdit{},
+	}
+
+	if edit := organizeImportsEdit(document.Tree.RootNode(), document.Text, p.groupImports()); edit != nil {
+		resolved.Edit.Changes[uri] = []protocol.TextEdit{*edit}
+	}
+
+	return &resolved, nil
+}
+
+// groupImports reads whether organizing imports separates the kinds of imports by a blank line
+func (p *PHPCodeActionProvider) groupImports() bool {
+	return p.lspServer != nil && p.lspServer.GetSettings().PHP.GroupImports
+}
+
+// isCodeActionKindRequested checks the kinds the client asked for, an "only" entry covers all of its sub kinds
+func isCodeActionKindRequested(params *protocol.CodeActionParams, kind protocol.CodeActionKind) bool {
+	if len(params.Context.Only) == 0 {
+		return true
+	}
+
+	for _, only := range params.Context.Only {
+		if string(kind) == only || strings.HasPrefix(string(kind), only+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeUseClausesEdits deletes the given imports. Use statements losing all their imports are removed
+// including their line, otherwise only the clauses are removed together with their separating comma.
+func removeUseClausesEdits(clauses []php.UseClause, content []byte) []protocol.TextEdit {
+	removedByDeclaration := make(map[uintptr]map[uintptr]bool)
+	var declarations []*tree_sitter.Node
+
+	for _, clause := range clauses {
+		if _, ok := removedByDeclaration[clause.Declaration.Id()]; !ok {
+			removedByDeclaration[clause.Declaration.Id()] = make(map[uintptr]bool)
+			declarations = append(declarations, clause.Declaration)
+		}
+		removedByDeclaration[clause.Declaration.Id()][clause.Clause.Id()] = true
+	}
+
+	var edits []protocol.TextEdit
+	for _, declaration := range declarations {
+		removed := removedByDeclaration[declaration.Id()]
+
+		container := declaration
+		if group := declaration.ChildByFieldName("body"); group != nil {
+			container = group
+		}
+
+		var useClauses []*tree_sitter.Node
+		for i := uint(0); i < container.NamedChildCount(); i++ {
+			child := container.NamedChild(i)
+			if child == nil || child.Kind() != "namespace_use_clause" {
+				continue
+			}
+
+			// Skip the empty clause tree-sitter creates for a trailing comma, so the comma is kept
+			if name := child.NamedChild(0); name != nil && name.IsMissing() {
+				continue
+			}
+
+			useClauses = append(useClauses, child)
+		}
+
+		if len(removed) == len(useClauses) {
+			edits = append(edits, protocol.TextEdit{Range: lineRange(declaration, content)})
+			continue
+		}
+
+		// Consecutive removed clauses are deleted up to the next kept clause,
+		// a run at the end of the list is deleted starting after the last kept clause
+		for i := 0; i < len(useClauses); i++ {
+			if !removed[useClauses[i].Id()] {
+				continue
+			}
+
+			end := i
+			for end+1 < len(useClauses) && removed[useClauses[end+1].Id()] {
+				end++
+			}
+
+			if end+1 < len(useClauses) {
+				edits = append(edits, protocol.TextEdit{Range: pointRange(useClauses[i].StartPosition(), useClauses[end+1].StartPosition())})
+			} else {
+				edits = append(edits, protocol.TextEdit{Range: pointRange(useClauses[i-1].EndPosition(), useClauses[end].EndPosition())})
+			}
+
+			i = end
+		}
+	}
+
+	return edits
+}
+
+// organizeImportsEdit rewrites the first block of use statements with one import per statement,
+// ordered by classes, functions and constants and sorted alphabetically. Duplicates are dropped,
+// everything outside the block is kept as is. Nil is returned when the imports are organized already.
+func organizeImportsEdit(rootNode *tree_sitter.Node, content []byte, groupByKind bool) *protocol.TextEdit {
+	var block []*tree_sitter.Node
+	for i := uint(0); i < rootNode.NamedChildCount(); i++ {
+		child := rootNode.NamedChild(i)
+		if child != nil && child.Kind() == "namespace_use_declaration" {
+			block = append(block, child)
+			continue
+		}
+
+		if len(block) > 0 {
+			break
+		}
+	}
+
+	if len(block) == 0 {
+		return nil
+	}
+
+	inBlock := make(map[uintptr]bool, len(block))
+	for _, declaration := range block {
+		inBlock[declaration.Id()] = true
+	}
+
+	kindOrder := map[php.UseKind]int{php.UseKindClass: 0, php.UseKindFunction: 1, php.UseKindConst: 2}
+
+	var clauses []php.UseClause
+	for _, clause := range php.ParseAllUseClauses(rootNode, content) {
+		if inBlock[clause.Declaration.Id()] {
+			clauses = append(clauses, clause)
+		}
+	}
+
+	sort.SliceStable(clauses, func(i, j int) bool {
+		if clauses[i].Kind != clauses[j].Kind {
+			return kindOrder[clauses[i].Kind] < kindOrder[clauses[j].Kind]
+		}
+
+		left, right := strings.ToLower(clauses[i].FQCN), strings.ToLower(clauses[j].FQCN)
+		if left != right {
+			return left < right
+		}
+
+		return clauses[i].Alias < clauses[j].Alias
+	})
+
+	newline := "\n"
+	if strings.Contains(string(content), "\r\n") {
+		newline = "\r\n"
+	}
+
+	var lines []string
+	seen := make(map[string]bool)
+	for i, clause := range clauses {
+		line := "use "
+		if clause.Kind != php.UseKindClass {
+			line += string(clause.Kind) + " "
+		}
+		line += clause.FQCN
+		if clause.Alias != "" {
+			line += " as " + clause.Alias
+		}
+		line += ";"
+
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+
+		if groupByKind && len(lines) > 0 && clause.Kind != clauses[i-1].Kind {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, line)
+	}
+
+	last := block[len(block)-1]
+	organized := strings.Join(lines, newline)
+	if organized == string(content[block[0].StartByte():last.EndByte()]) {
+		return nil
+	}
+
+	return &protocol.TextEdit{
+		Range:   pointRange(block[0].StartPosition(), last.EndPosition()),
+		NewText: organized,
+	}
+}
+
+// lineRange returns the range of a node including its whole line, when nothing else is written on it
+func lineRange(node *tree_sitter.Node, content []byte) protocol.Range {
+	start := node.StartPosition()
+	end := node.EndPosition()
+
+	lineStart := node.StartByte()
+	for lineStart > 0 && (content[lineStart-1] == ' ' || content[lineStart-1] == '\t') {
+		lineStart--
+	}
+
+	lineEnd := node.EndByte()
+	for lineEnd < uint(len(content)) && (content[lineEnd] == ' ' || content[lineEnd] == '\t' || content[lineEnd] == '\r') {
+		lineEnd++
+	}
+
+	if (lineStart == 0 || content[lineStart-1] == '\n') && lineEnd < uint(len(content)) && content[lineEnd] == '\n' {
+		start.Column = 0
+		end = tree_sitter.Point{Row: end.Row + 1, Column: 0}
+	}
+
+	return pointRange(start, end)
+}
+
+func pointRange(start, end tree_sitter.Point) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: int(start.Row), Character: int(start.Column)},
+		End:   protocol.Position{Line: int(end.Row), Character: int(end.Column)},
+	}
+}
@@ -0,0 +1,247 @@
+package codeaction
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const unusedImportsCode = `<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Checkout\Cart\Cart;
+use Shopware\Core\Framework\DataAbstractionLayer\{EntityRepository, Search\Criteria, Search\Filter\EqualsFilter as Equals};
+use Shopware\Core\Framework\Uuid\{
+    Uuid,
+    Exception\InvalidUuidException,
+};
+use Psr\Log\LoggerInterface, Psr\Log\NullLogger;
+
+class ProductSubscriber
+{
+    public function onLoad(Context $context, EntityRepository $repository, NullLogger $logger): void
+    {
+        $criteria = new Criteria([Uuid::randomHex()]);
+    }
+}
+`
+
+func parsePHP(t *testing.T, code string) *tree_sitter.Tree {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(code), nil)
+	t.Cleanup(tree.Close)
+
+	return tree
+}
+
+func phpCodeActions(t *testing.T, code string, diagnostics []protocol.Diagnostic, only ...string) []protocol.CodeAction {
+	tree := parsePHP(t, code)
+
+	params := &protocol.CodeActionParams{
+		Node:            findNodeAtPosition(tree.RootNode(), 4, 5),
+		DocumentContent: []byte(code),
+	}
+	params.TextDocument.URI = "file:///project/src/Subscriber/ProductSubscriber.php"
+	params.Context.Diagnostics = diagnostics
+	params.Context.Only = only
+
+	return NewPHPCodeActionProvider(nil).GetCodeActions(context.Background(), params)
+}
+
+// applyTextEdits applies non-overlapping edits the way an editor does
+func applyTextEdits(t *testing.T, code string, edits []protocol.TextEdit) string {
+	lines := strings.SplitAfter(code, "\n")
+	offset := func(position protocol.Position) int {
+		result := 0
+		for i := 0; i < position.Line; i++ {
+			result += len(lines[i])
+		}
+		return result + position.Character
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return offset(edits[i].Range.Start) > offset(edits[j].Range.Start)
+	})
+
+	for _, edit := range edits {
+		start, end := offset(edit.Range.Start), offset(edit.Range.End)
+		require.LessOrEqual(t, start, end)
+		code = code[:start] + edit.NewText + code[end:]
+	}
+
+	return code
+}
+
+func TestPHPCodeActionProvider_RemoveUnusedImport(t *testing.T) {
+	diagnostic := protocol.Diagnostic{
+		Code: "php.unused-use",
+		Data: map[string]any{"fqcn": "Shopware\\Core\\Checkout\\Cart\\Cart", "name": "Cart"},
+	}
+
+	actions := phpCodeActions(t, unusedImportsCode, []protocol.Diagnostic{diagnostic}, string(protocol.CodeActionQuickFix))
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Remove unused import 'Shopware\\Core\\Checkout\\Cart\\Cart'", actions[0].Title)
+	assert.Equal(t, protocol.CodeActionQuickFix, actions[0].Kind)
+	assert.Equal(t, []protocol.Diagnostic{diagnostic}, actions[0].Diagnostics)
+
+	result := applyTextEdits(t, unusedImportsCode, actions[0].Edit.Changes["file:///project/src/Subscriber/ProductSubscriber.php"])
+	assert.Equal(t, strings.Replace(unusedImportsCode, "use Shopware\\Core\\Checkout\\Cart\\Cart;\n", "", 1), result)
+
+	diagnostic.Data = map[string]any{"name": "Equals"}
+	actions = phpCodeActions(t, unusedImportsCode, []protocol.Diagnostic{diagnostic}, string(protocol.CodeActionQuickFix))
+	require.Len(t, actions, 1)
+
+	result = applyTextEdits(t, unusedImportsCode, actions[0].Edit.Changes["file:///project/src/Subscriber/ProductSubscriber.php"])
+	assert.Contains(t, result, "use Shopware\\Core\\Framework\\DataAbstractionLayer\\{EntityRepository, Search\\Criteria};\n")
+}
+
+func TestPHPCodeActionProvider_RemoveAllUnusedImports(t *testing.T) {
+	actions := phpCodeActions(t, unusedImportsCode, nil, string(protocol.CodeActionSourceRemoveUnusedImports))
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Remove all unused imports", actions[0].Title)
+	assert.Equal(t, protocol.CodeActionSourceRemoveUnusedImports, actions[0].Kind)
+
+	result := applyTextEdits(t, unusedImportsCode, actions[0].Edit.Changes["file:///project/src/Subscriber/ProductSubscriber.php"])
+	assert.Equal(t, `<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\DataAbstractionLayer\{EntityRepository, Search\Criteria};
+use Shopware\Core\Framework\Uuid\{
+    Uuid,
+};
+use Psr\Log\NullLogger;
+
+class ProductSubscriber
+{
+    public function onLoad(Context $context, EntityRepository $repository, NullLogger $logger): void
+    {
+        $criteria = new Criteria([Uuid::randomHex()]);
+    }
+}
+`, result)
+
+	assert.Len(t, phpCodeActions(t, unusedImportsCode, nil, string(protocol.CodeActionSource)), 2)
+	assert.Empty(t, phpCodeActions(t, unusedImportsCode, nil, string(protocol.CodeActionRefactor)))
+	assert.Empty(t, phpCodeActions(t, strings.Replace(unusedImportsCode, "(Context $context,", "(Context $context, Cart $cart, Equals $equals, InvalidUuidException $e, LoggerInterface $l,", 1), nil, string(protocol.CodeActionSourceRemoveUnusedImports)))
+}
+
+func TestPHPCodeActionProvider_OrganizeImports(t *testing.T) {
+	code := `<?php declare(strict_types=1);
+
+namespace App\Subscriber;
+
+use Symfony\Component\EventDispatcher\EventSubscriberInterface;
+use const Shopware\Core\Defaults\LIVE_VERSION;
+use Shopware\Core\Framework\Uuid\{Uuid, Exception\InvalidUuidException as UuidException};
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape;
+use \Psr\Log\LoggerInterface, Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\context as LowerContext;
+use Symfony\Component\EventDispatcher\EventSubscriberInterface;
+
+// Comments after the use statements end the block
+use Zeta\Last;
+
+class ProductSubscriber implements EventSubscriberInterface
+{
+}
+`
+
+	actions := phpCodeActions(t, code, nil, string(protocol.CodeActionSourceOrganizeImports))
+	require.Len(t, actions, 1)
+	assert.Equal(t, "Organize imports", actions[0].Title)
+	assert.Equal(t, protocol.CodeActionSourceOrganizeImports, actions[0].Kind)
+
+	edits := actions[0].Edit.Changes["file:///project/src/Subscriber/ProductSubscriber.php"]
+	organized := applyTextEdits(t, code, edits)
+	assert.Equal(t, `<?php declare(strict_types=1);
+
+namespace App\Subscriber;
+
+use Psr\Log\LoggerInterface;
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\context as LowerContext;
+use Shopware\Core\Framework\Uuid\Exception\InvalidUuidException as UuidException;
+use Shopware\Core\Framework\Uuid\Uuid;
+use Symfony\Component\EventDispatcher\EventSubscriberInterface;
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape;
+use const Shopware\Core\Defaults\LIVE_VERSION;
+
+// Comments after the use statements end the block
+use Zeta\Last;
+
+class ProductSubscriber implements EventSubscriberInterface
+{
+}
+`, organized)
+
+	// Organized imports need no action
+	assert.Empty(t, phpCodeActions(t, organized, nil, string(protocol.CodeActionSourceOrganizeImports)))
+
+	grouped := organizeImportsEdit(parsePHP(t, organized).RootNode(), []byte(organized), true)
+	require.NotNil(t, grouped)
+	assert.Equal(t, `use Psr\Log\LoggerInterface;
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\context as LowerContext;
+use Shopware\Core\Framework\Uuid\Exception\InvalidUuidException as UuidException;
+use Shopware\Core\Framework\Uuid\Uuid;
+use Symfony\Component\EventDispatcher\EventSubscriberInterface;
+
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape;
+
+use const Shopware\Core\Defaults\LIVE_VERSION;`, grouped.NewText)
+}
+
+func TestPHPCodeActionProvider_ResolveOrganizeImports(t *testing.T) {
+	scanner, err := indexer.NewFileScanner(t.TempDir(), filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	server := lsp.NewServer(scanner, t.TempDir(), "test")
+	provider := NewPHPCodeActionProvider(server)
+
+	uri := "file:///project/src/Subscriber/ProductSubscriber.php"
+	server.DocumentManager().OpenDocument(uri, `<?php
+
+use Zeta\Last;
+use Alpha\First;
+`, 1)
+
+	resolved, err := provider.ResolveCodeAction(context.Background(), &protocol.CodeAction{
+		Title: "Organize imports",
+		Kind:  protocol.CodeActionSourceOrganizeImports,
+		Data:  map[string]any{"uri": uri},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, resolved)
+	require.NotNil(t, resolved.Edit)
+	require.Len(t, resolved.Edit.Changes[uri], 1)
+	assert.Equal(t, "use Alpha\\First;\nuse Zeta\\Last;", resolved.Edit.Changes[uri][0].NewText)
+
+	// Actions of other providers are left to them
+	resolved, err = provider.ResolveCodeAction(context.Background(), &protocol.CodeAction{Title: "Create snippet", Kind: protocol.CodeActionQuickFix})
+	require.NoError(t, err)
+	assert.Nil(t, resolved)
+
+	_, err = provider.ResolveCodeAction(context.Background(), &protocol.CodeAction{
+		Kind: protocol.CodeActionSourceOrganizeImports,
+		Data: map[string]any{"uri": "file:///project/src/Closed.php"},
+	})
+	assert.Error(t, err)
+}
@@ -3,9 +3,9 @@ package codeaction
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/snippet"
@@ -37,7 +37,7 @@ func (s *SnippetCodeActionProvider) GetCodeActionKinds() []protocol.CodeActionKi
 
 // GetCodeActions returns code actions for snippet diagnostics
 func (s *SnippetCodeActionProvider) GetCodeActions(ctx context.Context, params *protocol.CodeActionParams) []protocol.CodeAction {
-	if !strings.HasSuffix(strings.ToLower(filepath.Ext(params.TextDocument.URI)), ".twig") {
+	if indexer.FileType(params.TextDocument.URI) != ".twig" {
 		return []protocol.CodeAction{}
 	}
 
@@ -52,8 +52,9 @@ func (s *SnippetCodeActionProvider) GetCodeActions(ctx context.Context, params *
 			Title: "Insert Snippet",
 			Kind:  protocol.CodeActionQuickFix,
 			Command: &protocol.CommandAction{
-				Title:   "Insert Snippet",
-				Command: "shopware.insertSnippet",
+				Title:     "Insert Snippet",
+				Command:   "shopware.insertSnippet",
+				Arguments: []any{params.TextDocument.URI},
 			},
 		})
 	}
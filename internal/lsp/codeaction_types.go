@@ -13,3 +13,10 @@ type CodeActionProvider interface {
 	// GetCodeActionKinds returns the kinds of code actions this provider can provide
 	GetCodeActionKinds() []protocol.CodeActionKind
 }
+
+// CodeActionResolver is implemented by code action providers returning actions without an edit,
+// which is computed when the client executes the action
+type CodeActionResolver interface {
+	// ResolveCodeAction fills the edit of a code action, nil is returned for actions of other providers
+	ResolveCodeAction(ctx context.Context, codeAction *protocol.CodeAction) (*protocol.CodeAction, error)
+}
@@ -9,10 +9,11 @@ import (
 // codeLens handles textDocument/codeLens requests
 func (s *Server) codeLens(ctx context.Context, params *protocol.CodeLensParams) []protocol.CodeLens {
 	// Check if document exists
-	_, ok := s.documentManager.GetDocument(params.TextDocument.URI)
+	document, ok := s.documentManager.GetDocument(params.TextDocument.URI)
 	if !ok {
 		return nil
 	}
+	document.Release()
 
 	// Collect code lenses from all providers
 	var lenses []protocol.CodeLens
@@ -3,8 +3,10 @@ package codelens
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
@@ -12,22 +14,28 @@ import (
 )
 
 type PHPServiceCodelensProvider struct {
-	phpIndex     *php.PHPIndex
-	serviceIndex *symfony.ServiceIndex
+	phpIndex        *php.PHPIndex
+	serviceIndex    *symfony.ServiceIndex
+	routeIndex      *symfony.RouteIndexer
+	routeUsageIndex *symfony.RouteUsageIndexer
 }
 
 func NewPHPCodeLensProvider(lsp *lsp.Server) *PHPServiceCodelensProvider {
 	phpIndex, _ := lsp.GetIndexer("php.index")
 	serviceIndex, _ := lsp.GetIndexer("symfony.service")
+	routeIndex, _ := lsp.GetIndexer("symfony.route")
+	routeUsageIndex, _ := lsp.GetIndexer("symfony.route_usage")
 
 	return &PHPServiceCodelensProvider{
-		phpIndex:     phpIndex.(*php.PHPIndex),
-		serviceIndex: serviceIndex.(*symfony.ServiceIndex),
+		phpIndex:        phpIndex.(*php.PHPIndex),
+		serviceIndex:    serviceIndex.(*symfony.ServiceIndex),
+		routeIndex:      routeIndex.(*symfony.RouteIndexer),
+		routeUsageIndex: routeUsageIndex.(*symfony.RouteUsageIndexer),
 	}
 }
 
 func (p *PHPServiceCodelensProvider) GetCodeLenses(ctx context.Context, params *protocol.CodeLensParams) []protocol.CodeLens {
-	if !strings.HasSuffix(params.TextDocument.URI, ".php") {
+	if indexer.FileType(params.TextDocument.URI) != ".php" {
 		return []protocol.CodeLens{}
 	}
 
@@ -40,6 +48,8 @@ func (p *PHPServiceCodelensProvider) GetCodeLenses(ctx context.Context, params *
 	var lenses []protocol.CodeLens
 
 	for _, phpClass := range phpClasses {
+		lenses = append(lenses, p.routeLenses(phpClass)...)
+
 		locations := p.serviceIndex.GetServicesUsageByClassName(phpClass.Name)
 
 		if len(locations) == 0 {
@@ -75,6 +85,62 @@ func (p *PHPServiceCodelensProvider) GetCodeLenses(ctx context.Context, params *
 	return lenses
 }
 
+// routeLenses returns a lens above every public action method that a route maps to
+func (p *PHPServiceCodelensProvider) routeLenses(phpClass php.PHPClass) []protocol.CodeLens {
+	var lenses []protocol.CodeLens
+
+	for _, method := range phpClass.Methods {
+		if method.Visibility != php.Public {
+			continue
+		}
+
+		routes, err := p.routeIndex.GetRoutesByController(phpClass.Name + "::" + method.Name)
+		if err != nil {
+			continue
+		}
+
+		for _, route := range routes {
+			usages, _ := p.routeUsageIndex.GetRoute(route.Name)
+
+			fileLocations := []string{}
+			for _, usage := range usages {
+				fileLocations = append(fileLocations, fmt.Sprintf("file://%s#%d", usage.File, usage.Line))
+			}
+
+			path := route.Path
+			if len(route.Methods) > 0 {
+				path = strings.Join(route.Methods, "|") + " " + path
+			}
+
+			lenses = append(lenses, protocol.CodeLens{
+				Command: &protocol.Command{
+					Title:   fmt.Sprintf("Route %s: %s", route.Name, path),
+					Command: "shopware.openReferences",
+					Arguments: []any{
+						fileLocations,
+					},
+				},
+				Range: protocol.Range{
+					Start: protocol.Position{
+						Line:      method.Line - 1,
+						Character: 0,
+					},
+					End: protocol.Position{
+						Line:      method.Line - 1,
+						Character: 0,
+					},
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(lenses, func(i, j int) bool {
+		return lenses[i].Range.Start.Line < lenses[j].Range.Start.Line
+	})
+
+	return lenses
+}
+
 func (p *PHPServiceCodelensProvider) ResolveCodeLens(ctx context.Context, params *protocol.CodeLens) (*protocol.CodeLens, error) {
 	return params, nil
 }
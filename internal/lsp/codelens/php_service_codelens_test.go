@@ -0,0 +1,78 @@
+package codelens
+
+import (
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const testController = `<?php
+
+namespace App\Controller;
+
+use Symfony\Component\Routing\Attribute\Route;
+
+class AccountController
+{
+    #[Route(path: '/account', name: 'frontend.account.home', methods: ['GET'])]
+    public function home(): Response
+    {
+        return $this->redirectToRoute('frontend.account.home');
+    }
+
+    public function helper(): void
+    {
+    }
+}
+`
+
+func TestPHPCodeLensProvider_RouteLenses(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	phpIndex, err := php.NewPHPIndex(cacheDir)
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	routeIndex, err := symfony.NewRouteIndexer(cacheDir)
+	require.NoError(t, err)
+	defer func() { _ = routeIndex.Close() }()
+
+	routeUsageIndex, err := symfony.NewRouteUsageIndexer(cacheDir)
+	require.NoError(t, err)
+	defer func() { _ = routeUsageIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	path := "/project/src/Controller/AccountController.php"
+	content := []byte(testController)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	require.NoError(t, phpIndex.Index(path, tree.RootNode(), content))
+	require.NoError(t, routeIndex.Index(path, tree.RootNode(), content))
+	require.NoError(t, routeUsageIndex.Index(path, tree.RootNode(), content))
+
+	provider := &PHPServiceCodelensProvider{
+		phpIndex:        phpIndex,
+		routeIndex:      routeIndex,
+		routeUsageIndex: routeUsageIndex,
+	}
+
+	class := phpIndex.GetClass(`App\Controller\AccountController`)
+	require.NotNil(t, class)
+
+	lenses := provider.routeLenses(*class)
+	require.Len(t, lenses, 1)
+
+	assert.Equal(t, 9, lenses[0].Range.Start.Line)
+	assert.Equal(t, "Route frontend.account.home: GET /account", lenses[0].Command.Title)
+	assert.Equal(t, "shopware.openReferences", lenses[0].Command.Command)
+	assert.Equal(t, []any{[]string{"file://" + path + "#12"}}, lenses[0].Command.Arguments)
+}
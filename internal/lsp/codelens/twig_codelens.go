@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
 	"github.com/shopware/shopware-lsp/internal/twig"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
 type TwigCodeLensProvider struct {
@@ -24,11 +27,16 @@ func NewTwigCodeLensProvider(lspServer *lsp.Server) *TwigCodeLensProvider {
 }
 
 func (p *TwigCodeLensProvider) GetCodeLenses(ctx context.Context, params *protocol.CodeLensParams) []protocol.CodeLens {
-	if !strings.HasSuffix(strings.ToLower(params.TextDocument.URI), ".twig") {
+	if indexer.FileType(params.TextDocument.URI) == ".php" {
+		return p.phpCodeLenses(params)
+	}
+
+	if indexer.FileType(params.TextDocument.URI) != ".twig" {
 		return []protocol.CodeLens{}
 	}
 
 	document, _ := p.lspServer.DocumentManager().GetDocument(params.TextDocument.URI)
+	defer document.Release()
 
 	if document == nil || document.Tree == nil {
 		return []protocol.CodeLens{}
@@ -131,6 +139,75 @@ func (p *TwigCodeLensProvider) GetCodeLenses(ctx context.Context, params *protoc
 	return lenses
 }
 
+var renderTemplateStringPattern = treesitterhelper.And(
+	treesitterhelper.NodeKind("string"),
+	treesitterhelper.IsPHPTemplateRenderCall(),
+)
+
+// phpCodeLenses adds a lens above render calls to open the rendered template
+// $this->renderStorefront('@Storefront/storefront/page/index.html.twig')
+func (p *TwigCodeLensProvider) phpCodeLenses(params *protocol.CodeLensParams) []protocol.CodeLens {
+	document, _ := p.lspServer.DocumentManager().GetDocument(params.TextDocument.URI)
+	defer document.Release()
+
+	if document == nil || document.Tree == nil {
+		return []protocol.CodeLens{}
+	}
+
+	return p.renderCallLenses(document.Tree.RootNode(), document.Text)
+}
+
+func (p *TwigCodeLensProvider) renderCallLenses(root *tree_sitter.Node, content []byte) []protocol.CodeLens {
+	var lenses []protocol.CodeLens
+
+	for _, node := range treesitterhelper.FindAll(root, renderTemplateStringPattern, content) {
+		files, _ := p.twigIndexer.GetTwigFilesByTemplateName(treesitterhelper.GetNodeText(node, content))
+		if len(files) == 0 {
+			continue
+		}
+
+		lensRange := protocol.Range{
+			Start: protocol.Position{
+				Line:      int(node.StartPosition().Row),
+				Character: 0,
+			},
+			End: protocol.Position{
+				Line:      int(node.StartPosition().Row),
+				Character: 0,
+			},
+		}
+
+		if len(files) == 1 {
+			lenses = append(lenses, protocol.CodeLens{
+				Range: lensRange,
+				Command: &protocol.Command{
+					Title:     "Go to template",
+					Command:   "vscode.open",
+					Arguments: []any{fmt.Sprintf("file://%s", files[0].Path)},
+				},
+			})
+
+			continue
+		}
+
+		fileLocations := make([]string, 0, len(files))
+		for _, file := range files {
+			fileLocations = append(fileLocations, fmt.Sprintf("file://%s#1", file.Path))
+		}
+
+		lenses = append(lenses, protocol.CodeLens{
+			Range: lensRange,
+			Command: &protocol.Command{
+				Title:     fmt.Sprintf("Go to template (%d files)", len(files)),
+				Command:   "shopware.openReferences",
+				Arguments: []any{fileLocations},
+			},
+		})
+	}
+
+	return lenses
+}
+
 func (p *TwigCodeLensProvider) ResolveCodeLens(ctx context.Context, codeLens *protocol.CodeLens) (*protocol.CodeLens, error) {
 	return codeLens, nil
 }
@@ -0,0 +1,77 @@
+package codelens
+
+import (
+	"testing"
+
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const testStorefrontController = `<?php
+
+namespace App\Storefront\Controller;
+
+class ExampleController extends StorefrontController
+{
+    public function page(): Response
+    {
+        return $this->renderStorefront('@Storefront/storefront/page/example.html.twig', ['page' => 'x']);
+    }
+
+    public function plugin(): Response
+    {
+        return $this->renderStorefront('@MyPlugin/storefront/page/example.html.twig');
+    }
+
+    public function missing(): Response
+    {
+        return $this->render('@Storefront/storefront/page/missing.html.twig');
+    }
+}
+`
+
+func TestTwigCodeLensProvider_RenderCallLenses(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	templates := []string{
+		"/project/vendor/shopware/storefront/Resources/views/storefront/page/example.html.twig",
+		"/project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/example.html.twig",
+	}
+	for _, path := range templates {
+		content := []byte(`{% block page %}{% endblock %}`)
+		tree := twigParser.Parse(content, nil)
+		require.NoError(t, twigIndexer.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	content := []byte(testStorefrontController)
+	tree := phpParser.Parse(content, nil)
+	defer tree.Close()
+
+	provider := &TwigCodeLensProvider{twigIndexer: twigIndexer}
+	lenses := provider.renderCallLenses(tree.RootNode(), content)
+	require.Len(t, lenses, 2)
+
+	assert.Equal(t, 8, lenses[0].Range.Start.Line)
+	assert.Equal(t, "Go to template (2 files)", lenses[0].Command.Title)
+	assert.Equal(t, "shopware.openReferences", lenses[0].Command.Command)
+
+	assert.Equal(t, 13, lenses[1].Range.Start.Line)
+	assert.Equal(t, "Go to template", lenses[1].Command.Title)
+	assert.Equal(t, "vscode.open", lenses[1].Command.Command)
+	assert.Equal(t, []any{"file://" + templates[1]}, lenses[1].Command.Arguments)
+}
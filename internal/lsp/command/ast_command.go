@@ -0,0 +1,162 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxAstDumpSize caps the returned S-expression, large files easily produce several megabytes
+const maxAstDumpSize = 512 * 1024
+
+// AstCommandProvider provides commands to inspect the tree-sitter trees the server works with.
+// Maintainers can ask users to run them when filing bug reports.
+type AstCommandProvider struct {
+	documentManager *lsp.DocumentManager
+}
+
+// AstDump is the result of the shopware/dumpAst command
+type AstDump struct {
+	URI       string           `json:"uri"`
+	Language  string           `json:"language"`
+	Range     protocol.Range   `json:"range"`
+	Ast       string           `json:"ast"`
+	Truncated bool             `json:"truncated"`
+	Errors    []AstErrorNode   `json:"errors"`
+	Missing   []AstMissingNode `json:"missing"`
+}
+
+// AstErrorNode is an ERROR node produced while parsing
+type AstErrorNode struct {
+	Text  string         `json:"text"`
+	Range protocol.Range `json:"range"`
+}
+
+// AstMissingNode is a node inserted by the parser to recover from a syntax error
+type AstMissingNode struct {
+	Kind  string         `json:"kind"`
+	Range protocol.Range `json:"range"`
+}
+
+func NewAstCommandProvider(server *lsp.Server) *AstCommandProvider {
+	return &AstCommandProvider{
+		documentManager: server.DocumentManager(),
+	}
+}
+
+func (p *AstCommandProvider) GetCommands(ctx context.Context) map[string]lsp.CommandFunc {
+	return map[string]lsp.CommandFunc{
+		"shopware/dumpAst": p.dumpAst,
+	}
+}
+
+func (p *AstCommandProvider) dumpAst(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	var params struct {
+		TextUri string `json:"textUri"`
+	}
+
+	if args == nil {
+		return protocol.NewLspError("Missing parameter: textUri", "ast.missing_uri"), nil
+	}
+
+	if err := json.Unmarshal(*args, &params); err != nil {
+		return nil, err
+	}
+
+	if params.TextUri == "" {
+		return protocol.NewLspError("Missing parameter: textUri", "ast.missing_uri"), nil
+	}
+
+	// Prefer the editor buffer, so unsaved changes are reflected in the dump
+	content, ok := p.documentManager.GetDocumentText(params.TextUri)
+	if !ok {
+		var err error
+		content, err = os.ReadFile(strings.TrimPrefix(params.TextUri, "file://"))
+		if err != nil {
+			return protocol.NewLspError("File not found", "ast.file_not_found"), nil
+		}
+	}
+
+	language := indexer.FileType(params.TextUri)
+
+	parsers := indexer.CreateTreesitterParsers()
+	defer indexer.CloseTreesitterParsers(parsers)
+
+	parser, ok := parsers[language]
+	if !ok {
+		return protocol.NewLspError("No grammar available for "+language+" files", "ast.unsupported_file"), nil
+	}
+
+	tree := parser.Parse(content, nil)
+	if tree == nil {
+		return protocol.NewLspError("Failed to parse file", "ast.parse_failed"), nil
+	}
+	defer tree.Close()
+
+	return newAstDump(params.TextUri, language, tree.RootNode(), content), nil
+}
+
+// newAstDump builds the dump for a parsed tree, including the nodes tree-sitter could not parse
+func newAstDump(uri, language string, root *tree_sitter.Node, content []byte) AstDump {
+	dump := AstDump{
+		URI:      uri,
+		Language: language,
+		Range:    nodeRange(root),
+		Ast:      root.ToSexp(),
+		Errors:   []AstErrorNode{},
+		Missing:  []AstMissingNode{},
+	}
+
+	if len(dump.Ast) > maxAstDumpSize {
+		dump.Ast = dump.Ast[:maxAstDumpSize]
+		dump.Truncated = true
+	}
+
+	if root.HasError() {
+		collectErrorNodes(root, content, &dump)
+	}
+
+	return dump
+}
+
+func collectErrorNodes(node *tree_sitter.Node, content []byte, dump *AstDump) {
+	switch {
+	case node.IsError():
+		dump.Errors = append(dump.Errors, AstErrorNode{
+			Text:  string(node.Utf8Text(content)),
+			Range: nodeRange(node),
+		})
+	case node.IsMissing():
+		dump.Missing = append(dump.Missing, AstMissingNode{
+			Kind:  node.Kind(),
+			Range: nodeRange(node),
+		})
+	}
+
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		// Subtrees without errors can be skipped
+		if child.HasError() || child.IsMissing() {
+			collectErrorNodes(child, content, dump)
+		}
+	}
+}
+
+func nodeRange(node *tree_sitter.Node) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      int(node.StartPosition().Row),
+			Character: int(node.StartPosition().Column),
+		},
+		End: protocol.Position{
+			Line:      int(node.EndPosition().Row),
+			Character: int(node.EndPosition().Column),
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runDumpAst(t *testing.T, provider *AstCommandProvider, uri string) interface{} {
+	args := json.RawMessage(`{"textUri": "` + uri + `"}`)
+	result, err := provider.dumpAst(context.Background(), &args)
+	require.NoError(t, err)
+	return result
+}
+
+func TestDumpAst_OpenDocument(t *testing.T) {
+	documentManager := lsp.NewDocumentManager()
+	defer documentManager.Close()
+
+	uri := "file:///project/src/Service/Foo.php"
+	documentManager.OpenDocument(uri, "<?php\nclass Foo {}\n", 1)
+
+	provider := &AstCommandProvider{documentManager: documentManager}
+	result := runDumpAst(t, provider, uri)
+
+	dump, ok := result.(AstDump)
+	require.True(t, ok, "unexpected result %#v", result)
+	assert.Equal(t, ".php", dump.Language)
+	assert.True(t, strings.HasPrefix(dump.Ast, "(program"))
+	assert.Contains(t, dump.Ast, "(class_declaration")
+	assert.False(t, dump.Truncated)
+	assert.Empty(t, dump.Errors)
+	assert.Empty(t, dump.Missing)
+}
+
+func TestDumpAst_FileWithParseErrors(t *testing.T) {
+	documentManager := lsp.NewDocumentManager()
+	defer documentManager.Close()
+
+	file := filepath.Join(t.TempDir(), "broken.js")
+	require.NoError(t, os.WriteFile(file, []byte("const a = ;\nfoo(\n"), 0o644))
+
+	provider := &AstCommandProvider{documentManager: documentManager}
+	dump, ok := runDumpAst(t, provider, "file://"+file).(AstDump)
+	require.True(t, ok)
+
+	assert.Equal(t, ".js", dump.Language)
+	assert.NotZero(t, len(dump.Errors)+len(dump.Missing))
+	assert.True(t, strings.Contains(dump.Ast, "ERROR") || strings.Contains(dump.Ast, "MISSING"))
+}
+
+func TestDumpAst_TruncatesLargeTrees(t *testing.T) {
+	documentManager := lsp.NewDocumentManager()
+	defer documentManager.Close()
+
+	uri := "file:///project/config/services.yaml"
+	documentManager.OpenDocument(uri, strings.Repeat("key: value\n", 20000), 1)
+
+	provider := &AstCommandProvider{documentManager: documentManager}
+	dump, ok := runDumpAst(t, provider, uri).(AstDump)
+	require.True(t, ok)
+
+	assert.True(t, dump.Truncated)
+	assert.Len(t, dump.Ast, maxAstDumpSize)
+}
+
+func TestDumpAst_Errors(t *testing.T) {
+	documentManager := lsp.NewDocumentManager()
+	defer documentManager.Close()
+
+	provider := &AstCommandProvider{documentManager: documentManager}
+
+	textFile := filepath.Join(t.TempDir(), "notes.txt")
+	require.NoError(t, os.WriteFile(textFile, []byte("notes"), 0o644))
+
+	tests := []struct {
+		name string
+		uri  string
+		code string
+	}{
+		{name: "missing file", uri: "file:///does/not/exist.php", code: "ast.file_not_found"},
+		{name: "unsupported extension", uri: "file://" + textFile, code: "ast.unsupported_file"},
+		{name: "empty uri", uri: "", code: "ast.missing_uri"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lspErr, ok := runDumpAst(t, provider, tt.uri).(*protocol.ShopwareLspError)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, lspErr.Code)
+		})
+	}
+}
@@ -2,8 +2,8 @@ package lsp
 
 import (
 	"context"
-	"path/filepath"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
 )
@@ -11,11 +11,12 @@ import (
 // completion handles textDocument/completion requests
 func (s *Server) completion(ctx context.Context, params *protocol.CompletionParams) *protocol.CompletionList {
 	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
 	if ok {
 		params.Node = node
 		params.DocumentContent = docText.Text
 
-		if filepath.Ext(params.TextDocument.URI) == ".php" {
+		if indexer.FileType(params.TextDocument.URI) == ".php" {
 			phpIndex, _ := s.GetIndexer("php.index")
 			ctx = phpIndex.(*php.PHPIndex).AddContext(ctx, node, docText.Text)
 		}
@@ -23,14 +24,27 @@ func (s *Server) completion(ctx context.Context, params *protocol.CompletionPara
 
 	// Collect completion items from all providers
 	var items []protocol.CompletionItem
+	isIncomplete := false
 	for _, provider := range s.completionProviders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !s.isProviderEnabled(provider) {
+			continue
+		}
+
 		providerItems := provider.GetCompletions(ctx, params)
 		items = append(items, providerItems...)
+
+		if incompleteProvider, ok := provider.(IncompleteCompletionProvider); ok && incompleteProvider.IsIncomplete(params) {
+			isIncomplete = true
+		}
 	}
 
 	// Return the completion list
 	return &protocol.CompletionList{
-		IsIncomplete: false,
+		IsIncomplete: isIncomplete,
 		Items:        items,
 	}
 }
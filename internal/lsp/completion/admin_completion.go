@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -15,24 +16,30 @@ import (
 // AdminCompletionProvider provides completions for Shopware Admin Vue components
 type AdminCompletionProvider struct {
 	adminIndexer *admin.AdminComponentIndexer
+	iconProvider *admin.IconProvider
 }
 
 // NewAdminCompletionProvider creates a new admin completion provider
-func NewAdminCompletionProvider(server *lsp.Server) *AdminCompletionProvider {
+func NewAdminCompletionProvider(projectRoot string, server *lsp.Server) *AdminCompletionProvider {
 	adminIndexer, _ := server.GetIndexer("admin.component.indexer")
 
 	return &AdminCompletionProvider{
 		adminIndexer: adminIndexer.(*admin.AdminComponentIndexer),
+		iconProvider: admin.NewIconProvider(projectRoot),
 	}
 }
 
+func (p *AdminCompletionProvider) ID() string {
+	return "completion.admin"
+}
+
 // GetCompletions returns completion items for admin components
 func (p *AdminCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	ext := strings.ToLower(filepath.Ext(params.TextDocument.URI))
+	ext := indexer.FileType(params.TextDocument.URI)
 
 	// Handle JS/TS files
 	if ext == ".js" || ext == ".ts" {
@@ -59,9 +66,54 @@ func (p *AdminCompletionProvider) jsCompletions(_ context.Context, params *proto
 		items = append(items, p.getComponentCompletions()...)
 	}
 
+	// Suggest the conventional name in the first argument of Component.register/extend
+	if callNode := p.getComponentCallForNameArgument(params.Node, params.DocumentContent); callNode != nil {
+		filePath := strings.TrimPrefix(params.TextDocument.URI, "file://")
+		if name := admin.ConventionalComponentName(callNode, params.DocumentContent, filePath); name != "" {
+			items = append(items, protocol.CompletionItem{
+				Label:  name,
+				Kind:   int(protocol.ClassCompletion),
+				Detail: "Component name derived from folder",
+			})
+		}
+	}
+
 	return items
 }
 
+// getComponentCallForNameArgument returns the Component.register/extend call if the cursor
+// is in its first argument (the component name)
+// Pattern: Component.register('<caret>', ...)
+func (p *AdminCompletionProvider) getComponentCallForNameArgument(node *tree_sitter.Node, content []byte) *tree_sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	// Quote characters and string fragments belong to the string node
+	if node.Kind() != "string" {
+		node = node.Parent()
+	}
+	if node == nil || node.Kind() != "string" {
+		return nil
+	}
+
+	argsNode := node.Parent()
+	if argsNode == nil || argsNode.Kind() != "arguments" || argsNode.NamedChildCount() == 0 {
+		return nil
+	}
+
+	if !argsNode.NamedChild(0).Equals(*node) {
+		return nil
+	}
+
+	callNode := argsNode.Parent()
+	if !admin.JSComponentCallPattern.Matches(callNode, content) {
+		return nil
+	}
+
+	return callNode
+}
+
 // twigCompletions handles completions in Twig admin templates
 func (p *AdminCompletionProvider) twigCompletions(_ context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	node := params.Node
@@ -69,7 +121,9 @@ func (p *AdminCompletionProvider) twigCompletions(_ context.Context, params *pro
 
 	// Check if we're in an HTML tag name position
 	if p.isInHTMLTagName(node, content) {
-		return p.getComponentTagCompletions()
+		prefix := p.getTagNamePrefix(node, content, params.Position)
+		items := p.getComponentTagCompletions(prefix)
+		return append(items, p.getLocalComponentTagCompletions(params.TextDocument.URI, prefix, items)...)
 	}
 
 	// Check if we're in a slot name position (# or v-slot:)
@@ -77,6 +131,11 @@ func (p *AdminCompletionProvider) twigCompletions(_ context.Context, params *pro
 		return p.getSlotCompletions(componentName, node, content)
 	}
 
+	// <sw-icon name="<caret>">
+	if tagName, attributeName := admin.GetAttributeOfValue(node, content); admin.IsIconTag(tagName) && attributeName == "name" {
+		return p.getIconCompletions()
+	}
+
 	// Check if we're in an HTML attribute position
 	if componentName := p.getComponentNameForAttributeCompletion(node, content); componentName != "" {
 		return p.getComponentPropCompletions(componentName)
@@ -85,6 +144,37 @@ func (p *AdminCompletionProvider) twigCompletions(_ context.Context, params *pro
 	return []protocol.CompletionItem{}
 }
 
+// IsIncomplete marks component tag completions as incomplete, as they are filtered by the
+// typed tag name and have to be requested again while typing
+func (p *AdminCompletionProvider) IsIncomplete(params *protocol.CompletionParams) bool {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".twig" {
+		return false
+	}
+
+	return strings.Contains(params.TextDocument.URI, "Resources/app/administration") &&
+		p.isInHTMLTagName(params.Node, params.DocumentContent)
+}
+
+// getTagNamePrefix returns the part of the tag name typed before the cursor, e.g. "sw-but" for "<sw-but"
+func (p *AdminCompletionProvider) getTagNamePrefix(node *tree_sitter.Node, content []byte, position protocol.Position) string {
+	switch node.Kind() {
+	case "html_tag_name":
+		name := string(node.Utf8Text(content))
+		start := node.StartPosition()
+		if int(start.Row) == position.Line && position.Character >= int(start.Column) && position.Character-int(start.Column) < len(name) {
+			return name[:position.Character-int(start.Column)]
+		}
+		return name
+	case "content":
+		trimmed := strings.TrimRight(string(node.Utf8Text(content)), " \t\n\r")
+		if lastLT := strings.LastIndex(trimmed, "<"); lastLT != -1 {
+			return trimmed[lastLT+1:]
+		}
+	}
+
+	return ""
+}
+
 // isInHTMLTagName checks if the cursor is in an HTML tag name position
 func (p *AdminCompletionProvider) isInHTMLTagName(node *tree_sitter.Node, content []byte) bool {
 	if node == nil {
@@ -134,7 +224,8 @@ func (p *AdminCompletionProvider) isInHTMLTagName(node *tree_sitter.Node, conten
 }
 
 // getComponentTagCompletions returns completion items for component tags in Twig
-func (p *AdminCompletionProvider) getComponentTagCompletions() []protocol.CompletionItem {
+// whose name contains the already typed prefix
+func (p *AdminCompletionProvider) getComponentTagCompletions(prefix string) []protocol.CompletionItem {
 	componentNames, err := p.adminIndexer.GetAllComponentNames()
 	if err != nil {
 		return []protocol.CompletionItem{}
@@ -142,6 +233,10 @@ func (p *AdminCompletionProvider) getComponentTagCompletions() []protocol.Comple
 
 	items := make([]protocol.CompletionItem, 0, len(componentNames))
 	for _, name := range componentNames {
+		if !strings.Contains(name, prefix) {
+			continue
+		}
+
 		// Create snippet: <component-name>$0</component-name>
 		// $0 is the cursor position after insertion
 		snippet := name + ">$0</" + name + ">"
@@ -179,6 +274,10 @@ func (p *AdminCompletionProvider) getComponentTagCompletions() []protocol.Comple
 		items = append(items, item)
 	}
 
+	if !strings.Contains("template", prefix) {
+		return items
+	}
+
 	// Add template tag with slot shorthand
 	// Don't close the template yet - the slot completion will close it
 	templateItem := protocol.CompletionItem{
@@ -195,6 +294,50 @@ func (p *AdminCompletionProvider) getComponentTagCompletions() []protocol.Comple
 	return items
 }
 
+// getLocalComponentTagCompletions returns completion items for components registered locally
+// (`components: { ... }`) by the component owning the template, skipping already offered names
+func (p *AdminCompletionProvider) getLocalComponentTagCompletions(uri string, prefix string, existing []protocol.CompletionItem) []protocol.CompletionItem {
+	localComponents, err := p.adminIndexer.GetLocalComponents(strings.TrimPrefix(uri, "file://"))
+	if err != nil || len(localComponents) == 0 {
+		return []protocol.CompletionItem{}
+	}
+
+	seen := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		seen[item.Label] = true
+	}
+
+	items := make([]protocol.CompletionItem, 0, len(localComponents))
+	for _, local := range localComponents {
+		if seen[local.Name] || !strings.Contains(local.Name, prefix) {
+			continue
+		}
+		seen[local.Name] = true
+
+		item := protocol.CompletionItem{
+			Label:            local.Name,
+			Kind:             int(protocol.ClassCompletion),
+			Detail:           "local component",
+			InsertText:       local.Name + ">$0</" + local.Name + ">",
+			InsertTextFormat: int(protocol.SnippetTextFormat),
+		}
+
+		doc := "**Locally registered component**\n\n"
+		if local.Identifier != "" {
+			doc += "**Identifier:** `" + local.Identifier + "`\n\n"
+		}
+		if local.ImportPath != "" {
+			doc += "**Import:** `" + local.ImportPath + "`\n"
+		}
+		item.Documentation.Kind = "markdown"
+		item.Documentation.Value = doc
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
 // isInExtendParentArgument checks if cursor is in the parent component argument of Component.extend
 // Pattern: Component.extend('name', '<caret>', ...)
 func (p *AdminCompletionProvider) isInExtendParentArgument(node *tree_sitter.Node, content []byte) bool {
@@ -403,6 +546,21 @@ func (p *AdminCompletionProvider) getTagNameFromStartTag(startTag *tree_sitter.N
 	return ""
 }
 
+// getIconCompletions returns completion items for the icon names of sw-icon and mt-icon
+func (p *AdminCompletionProvider) getIconCompletions() []protocol.CompletionItem {
+	names := p.iconProvider.GetIconNames()
+
+	items := make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, protocol.CompletionItem{
+			Label: name,
+			Kind:  int(protocol.ValueCompletion),
+		})
+	}
+
+	return items
+}
+
 // getComponentPropCompletions returns completion items for component props
 func (p *AdminCompletionProvider) getComponentPropCompletions(componentName string) []protocol.CompletionItem {
 	components, err := p.adminIndexer.GetComponentWithDefinition(componentName)
@@ -413,8 +571,8 @@ func (p *AdminCompletionProvider) getComponentPropCompletions(componentName stri
 	var items []protocol.CompletionItem
 
 	for _, comp := range components {
-		// Add props
-		for _, prop := range comp.Props {
+		// Add props, including the ones inherited from parent components
+		for _, prop := range p.adminIndexer.ResolveInheritance(comp).Props {
 			// Regular prop
 			item := protocol.CompletionItem{
 				Label:  prop.Name,
@@ -456,17 +614,57 @@ func (p *AdminCompletionProvider) getComponentPropCompletions(componentName stri
 			items = append(items, bindingItem)
 		}
 
-		// Add events (emits)
-		for _, emit := range comp.Emits {
-			item := protocol.CompletionItem{
+		// Add events (emits), including the ones inherited from parent components
+		items = append(items, p.getComponentEventCompletions(comp)...)
+	}
+
+	return items
+}
+
+// getComponentEventCompletions returns completion items for the events of a component
+// It walks the ExtendsComponent chain so events emitted by parent components are offered too
+func (p *AdminCompletionProvider) getComponentEventCompletions(comp admin.VueComponent) []protocol.CompletionItem {
+	var items []protocol.CompletionItem
+	seenEmits := make(map[string]bool)
+	visited := map[string]bool{comp.Name: true}
+
+	current := comp
+	inheritedFrom := ""
+	for {
+		for _, emit := range current.Emits {
+			if seenEmits[emit] {
+				continue
+			}
+			seenEmits[emit] = true
+
+			detail := "event"
+			if inheritedFrom != "" {
+				detail = "inherited from " + inheritedFrom
+			}
+
+			items = append(items, protocol.CompletionItem{
 				Label:            "@" + emit,
 				Kind:             int(protocol.EventCompletion),
-				Detail:           "event",
+				Detail:           detail,
 				InsertText:       "@" + emit + "=\"$0\"",
 				InsertTextFormat: int(protocol.SnippetTextFormat),
-			}
-			items = append(items, item)
+			})
 		}
+
+		// Stop at the root of the chain and guard against circular extends
+		parentName := current.ExtendsComponent
+		if parentName == "" || visited[parentName] || p.adminIndexer == nil {
+			break
+		}
+		visited[parentName] = true
+
+		parents, err := p.adminIndexer.GetComponentWithDefinition(parentName)
+		if err != nil || len(parents) == 0 {
+			break
+		}
+
+		current = parents[0]
+		inheritedFrom = parentName
 	}
 
 	return items
@@ -474,7 +672,7 @@ func (p *AdminCompletionProvider) getComponentPropCompletions(componentName stri
 
 // GetTriggerCharacters returns the characters that trigger this completion provider
 func (p *AdminCompletionProvider) GetTriggerCharacters() []string {
-	return []string{"'", "\"", "<", " ", "#"}
+	return []string{"'", "\"", "<", " ", "#", ":"}
 }
 
 // getComponentNameForSlotCompletion checks if we're in a position to complete slot names
@@ -494,19 +692,16 @@ func (p *AdminCompletionProvider) getComponentNameForSlotCompletion(node *tree_s
 	if nodeKind == "inline_comment" && strings.HasPrefix(nodeText, "#") {
 		parent := node.Parent()
 		if parent != nil && parent.Kind() == "html_start_tag" {
-			tagName := p.getTagNameFromStartTag(parent, content)
-			if tagName == "template" {
-				return p.findParentComponentForSlot(node, content)
-			}
-			// If we're directly in a component tag (not template), still try to find slots
-			// This handles cases like <sw-card #default> directly
-			if tagName != "" {
-				if p.adminIndexer != nil {
-					components, err := p.adminIndexer.GetComponent(tagName)
-					if err == nil && len(components) > 0 {
-						return tagName
-					}
-				}
+			return p.findSlotOwner(parent, node, content)
+		}
+	}
+
+	// Handle case: inside html_attribute_name that is a slot directive, e.g. <template v-slot:>
+	// or <sw-card v-slot:> directly on the component
+	if nodeKind == "html_attribute_name" {
+		if strings.HasPrefix(nodeText, "#") || strings.HasPrefix(nodeText, "v-slot") {
+			if startTag := p.findAncestorOfKind(node, "html_start_tag"); startTag != nil {
+				return p.findSlotOwner(startTag, node, content)
 			}
 		}
 	}
@@ -521,14 +716,27 @@ func (p *AdminCompletionProvider) getComponentNameForSlotCompletion(node *tree_s
 		return p.findParentComponentForSlot(node, content)
 	}
 
-	// Handle case: inside html_attribute_name that is a slot directive
-	if nodeKind == "html_attribute_name" {
-		if strings.HasPrefix(nodeText, "#") || strings.HasPrefix(nodeText, "v-slot") {
-			return p.findParentComponentForSlot(node, content)
-		}
+	return ""
+}
+
+// findSlotOwner returns the component whose slot is referenced in the given start tag
+// For <template #slot> it is the parent component, for <sw-card #slot> the tag itself
+func (p *AdminCompletionProvider) findSlotOwner(startTag *tree_sitter.Node, node *tree_sitter.Node, content []byte) string {
+	tagName := p.getTagNameFromStartTag(startTag, content)
+	if tagName == "template" {
+		return p.findParentComponentForSlot(node, content)
 	}
 
-	return ""
+	if tagName == "" || p.adminIndexer == nil {
+		return ""
+	}
+
+	components, err := p.adminIndexer.GetComponent(tagName)
+	if err != nil || len(components) == 0 {
+		return ""
+	}
+
+	return tagName
 }
 
 // findParentComponentForSlot finds the parent component tag that contains this slot template
@@ -658,19 +866,29 @@ func (p *AdminCompletionProvider) getSlotCompletions(componentName string, node
 		return []protocol.CompletionItem{}
 	}
 
-	components, err := p.adminIndexer.GetComponentWithDefinition(componentName)
+	components, err := p.adminIndexer.GetComponentWithInheritance(componentName)
 	if err != nil || len(components) == 0 {
 		return []protocol.CompletionItem{}
 	}
 
-	// Check if the node already contains a closing >
+	// Check if the tag is already closed with >
 	// If so, we just insert the slot name (without > or </template>)
 	var hasClosingBracket bool
+	inTemplate := true
 	if node != nil {
 		nodeText := string(node.Utf8Text(content))
 		if strings.HasPrefix(nodeText, "#") && strings.Contains(nodeText, ">") {
 			hasClosingBracket = true
 		}
+
+		if startTag := p.findAncestorOfKind(node, "html_start_tag"); startTag != nil {
+			inTemplate = p.getTagNameFromStartTag(startTag, content) == "template"
+
+			// The start tag ends with a real > (tree-sitter inserts a zero width one for unclosed tags)
+			if last := startTag.Child(startTag.ChildCount() - 1); last != nil && !last.IsMissing() && (last.Kind() == ">" || last.Kind() == "/>") {
+				hasClosingBracket = true
+			}
+		}
 	}
 
 	var items []protocol.CompletionItem
@@ -691,12 +909,15 @@ func (p *AdminCompletionProvider) getSlotCompletions(componentName string, node
 			}
 
 			// If there's already a closing >, just insert the slot name
-			// Otherwise insert the full snippet with > and </template>
-			if hasClosingBracket {
+			// Otherwise close the tag, and the <template> as well, as the component tag has its own end tag
+			switch {
+			case hasClosingBracket:
 				item.InsertText = slot.Name
 				item.InsertTextFormat = int(protocol.PlainTextFormat)
-			} else {
+			case inTemplate:
 				item.InsertText = slot.Name + ">$0</template>"
+			default:
+				item.InsertText = slot.Name + ">$0"
 			}
 
 			// Add documentation
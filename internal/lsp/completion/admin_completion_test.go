@@ -1,9 +1,18 @@
 package completion
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
 )
@@ -161,3 +170,336 @@ func TestGetFirstChildOfKind(t *testing.T) {
 	nonExistent := provider.getFirstChildOfKind(root, "class_declaration")
 	assert.Nil(t, nonExistent)
 }
+
+func TestGetComponentEventCompletions_InheritedEmits(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-base-field",
+		FilePath: "/admin/sw-base-field/index.js",
+		Emits:    []string{"update:value", "focus"},
+	}))
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-text-field",
+		FilePath:         "/admin/sw-text-field/index.js",
+		ExtendsComponent: "sw-base-field",
+		Emits:            []string{"update:value", "inheritance-restore"},
+	}))
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-my-field",
+		FilePath:         "/admin/sw-my-field/index.js",
+		ExtendsComponent: "sw-text-field",
+		Emits:            []string{"custom"},
+	}))
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer}
+
+	items := provider.getComponentPropCompletions("sw-my-field")
+
+	details := make(map[string]string)
+	for _, item := range items {
+		details[item.Label] = item.Detail
+	}
+
+	require.Len(t, details, 4)
+	assert.Equal(t, "event", details["@custom"])
+	assert.Equal(t, "inherited from sw-text-field", details["@update:value"])
+	assert.Equal(t, "inherited from sw-text-field", details["@inheritance-restore"])
+	assert.Equal(t, "inherited from sw-base-field", details["@focus"])
+}
+
+func TestGetComponentEventCompletions_CircularExtends(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-a",
+		FilePath:         "/admin/sw-a/index.js",
+		ExtendsComponent: "sw-b",
+		Emits:            []string{"a"},
+	}))
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-b",
+		FilePath:         "/admin/sw-b/index.js",
+		ExtendsComponent: "sw-a",
+		Emits:            []string{"b"},
+	}))
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer}
+
+	items := provider.getComponentPropCompletions("sw-a")
+	assert.Len(t, items, 2)
+}
+
+func TestComponentTagCompletions_FilterByTypedPrefix(t *testing.T) {
+	adminIndexer, err := admin.NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	for _, name := range []string{"sw-button", "sw-button-group", "sw-card", "mt-button"} {
+		require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+			Name:     name,
+			FilePath: "/admin/" + name + "/index.js",
+		}))
+	}
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer}
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "partial tag name",
+			code:     "<div>\n    <sw-but",
+			expected: []string{"sw-button", "sw-button-group"},
+		},
+		{
+			name:     "substring match",
+			code:     "<div>\n    <button",
+			expected: []string{"mt-button", "sw-button", "sw-button-group"},
+		},
+		{
+			name:     "template",
+			code:     "<div>\n    <temp",
+			expected: []string{"template"},
+		},
+		{
+			name:     "only opening bracket",
+			code:     "<div>\n    <",
+			expected: []string{"mt-button", "sw-button", "sw-button-group", "sw-card", "template"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.code)
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			position := protocol.Position{Line: 1, Character: len(tt.code) - len("<div>\n")}
+			params := &protocol.CompletionParams{
+				Node:            findFirstNodeAtOffset(tree.RootNode(), uint(len(content))),
+				DocumentContent: content,
+			}
+			params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/module/sw-foo/sw-foo.html.twig"
+			params.Position = position
+
+			var labels []string
+			for _, item := range provider.GetCompletions(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+			sort.Strings(labels)
+
+			assert.Equal(t, tt.expected, labels)
+			assert.True(t, provider.IsIncomplete(params))
+		})
+	}
+}
+
+// findFirstNodeAtOffset descends into the first child containing the offset, like the document manager does
+func findFirstNodeAtOffset(node *tree_sitter.Node, offset uint) *tree_sitter.Node {
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		if child.StartByte() <= offset && offset <= child.EndByte() {
+			return findFirstNodeAtOffset(child, offset)
+		}
+	}
+
+	return node
+}
+
+func TestSlotCompletions_SlotDirectiveForms(t *testing.T) {
+	adminIndexer, err := admin.NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-card",
+		FilePath: "/admin/sw-card/index.js",
+		Slots:    []admin.VueComponentSlot{{Name: "actions"}},
+	}))
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer}
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{
+			name:     "template v-slot without closing bracket",
+			code:     "<sw-card>\n    <template v-slot:|\n</sw-card>",
+			expected: "actions>$0</template>",
+		},
+		{
+			name:     "template v-slot with closing bracket",
+			code:     "<sw-card>\n    <template v-slot:|>\n    </template>\n</sw-card>",
+			expected: "actions",
+		},
+		{
+			name:     "template v-slot with partial slot name",
+			code:     "<sw-card>\n    <template v-slot:act|>\n    </template>\n</sw-card>",
+			expected: "actions",
+		},
+		{
+			name:     "template shorthand without closing bracket",
+			code:     "<sw-card>\n    <template #|\n</sw-card>",
+			expected: "actions>$0</template>",
+		},
+		{
+			name:     "v-slot on the component tag",
+			code:     "<sw-card v-slot:|>\n</sw-card>",
+			expected: "actions",
+		},
+		{
+			name:     "v-slot on the unclosed component tag",
+			code:     "<sw-card v-slot:|\n</sw-card>",
+			expected: "actions>$0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := strings.Index(tt.code, "|")
+			content := []byte(tt.code[:offset] + tt.code[offset+1:])
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			params := &protocol.CompletionParams{
+				Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+				DocumentContent: content,
+			}
+			params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/module/sw-foo/sw-foo.html.twig"
+
+			items := provider.GetCompletions(context.Background(), params)
+			require.Len(t, items, 1)
+			assert.Equal(t, "actions", items[0].Label)
+			assert.Equal(t, tt.expected, items[0].InsertText)
+		})
+	}
+}
+
+func TestComponentNameCompletion_FolderConvention(t *testing.T) {
+	adminIndexer, err := admin.NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer}
+
+	tests := []struct {
+		name     string
+		code     string
+		uri      string
+		col      uint
+		expected []string
+	}{
+		{
+			name:     "empty name in register with lazy import",
+			code:     `Component.register('', () => import('./component/sw-foo-bar'));`,
+			uri:      "file:///project/Resources/app/administration/src/module/sw-foo/index.js",
+			col:      20,
+			expected: []string{"sw-foo-bar"},
+		},
+		{
+			name:     "inline definition uses the file folder",
+			code:     `Shopware.Component.register('sw-', {});`,
+			uri:      "file:///project/Resources/app/administration/src/component/sw-baz/index.js",
+			col:      31,
+			expected: []string{"sw-baz"},
+		},
+		{
+			name: "parent argument of extend is not a name",
+			code: `Component.extend('sw-baz', '', {});`,
+			uri:  "file:///project/Resources/app/administration/src/component/sw-baz/index.js",
+			col:  28,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseJS(t, tt.code)
+			defer tree.Close()
+			defer parser.Close()
+
+			params := &protocol.CompletionParams{
+				Node:            findFirstNodeAtOffset(tree.RootNode(), tt.col),
+				DocumentContent: []byte(tt.code),
+			}
+			params.TextDocument.URI = tt.uri
+
+			var labels []string
+			for _, item := range provider.jsCompletions(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
+
+func TestAdminCompletionProvider_IconNames(t *testing.T) {
+	projectRoot := t.TempDir()
+	iconsPath := filepath.Join(projectRoot, "vendor", "shopware", "administration", "Resources", "app", "administration", "node_modules", "@shopware-ag", "meteor-icon-kit", "icons")
+	for _, icon := range []string{"regular/products.svg", "regular/cog.svg", "solid/products.svg"} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(iconsPath, icon)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(iconsPath, icon), []byte("<svg/>"), 0o644))
+	}
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	complete := func(provider *AdminCompletionProvider, code string) []string {
+		offset := strings.Index(code, "<caret>")
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := parser.Parse(content, nil)
+		defer tree.Close()
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/module/sw-foo/sw-foo.html.twig"
+		params.Position = protocol.Position{Character: offset}
+
+		var labels []string
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			labels = append(labels, item.Label)
+		}
+		return labels
+	}
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	provider := &AdminCompletionProvider{adminIndexer: adminIndexer, iconProvider: admin.NewIconProvider(projectRoot)}
+	icons := []string{"regular-cog", "regular-products", "solid-products"}
+
+	assert.Equal(t, icons, complete(provider, `<sw-icon name="<caret>"></sw-icon>`))
+	assert.Equal(t, icons, complete(provider, `<sw-icon small name="regular-<caret>"></sw-icon>`))
+	assert.Equal(t, icons, complete(provider, `<mt-icon name="<caret>" />`))
+	assert.Empty(t, complete(provider, `<sw-icon color="<caret>"></sw-icon>`))
+	assert.Empty(t, complete(provider, `<img name="<caret>">`))
+
+	// Without the icon kit the well known icons are offered
+	fallback := complete(&AdminCompletionProvider{adminIndexer: adminIndexer, iconProvider: admin.NewIconProvider(t.TempDir())}, `<sw-icon name="<caret>"></sw-icon>`)
+	assert.Contains(t, fallback, "regular-products")
+}
@@ -0,0 +1,77 @@
+package completion
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// AdminServiceCompletionProvider completes the names of administration services in Shopware.Service() calls
+type AdminServiceCompletionProvider struct {
+	serviceIndexer *admin.AdminServiceIndexer
+}
+
+func NewAdminServiceCompletionProvider(lspServer *lsp.Server) *AdminServiceCompletionProvider {
+	serviceIndexer, _ := lspServer.GetIndexer("admin.service.indexer")
+	return &AdminServiceCompletionProvider{
+		serviceIndexer: serviceIndexer.(*admin.AdminServiceIndexer),
+	}
+}
+
+func (p *AdminServiceCompletionProvider) ID() string {
+	return "completion.admin-service"
+}
+
+func (p *AdminServiceCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil {
+		return nil
+	}
+
+	ext := indexer.FileType(params.TextDocument.URI)
+	if ext != ".js" && ext != ".ts" {
+		return nil
+	}
+
+	// Shopware.Service('<caret>')
+	if !admin.JSStringInServiceCallPattern.Matches(params.Node, params.DocumentContent) {
+		return nil
+	}
+
+	names, err := p.serviceIndexer.GetAllServiceNames()
+	if err != nil {
+		return nil
+	}
+
+	completionItems := make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		item := protocol.CompletionItem{
+			Label: name,
+			Kind:  int(protocol.ModuleCompletion),
+		}
+
+		// Document where the service is registered and decorated
+		if services, err := p.serviceIndexer.GetService(name); err == nil && len(services) > 0 {
+			doc := "**Admin Service**\n\n"
+			for _, service := range services {
+				if service.Decorator {
+					doc += "- Decorated in `" + service.FilePath + "`\n"
+				} else {
+					doc += "- Registered in `" + service.FilePath + "`\n"
+				}
+			}
+			item.Documentation.Kind = "markdown"
+			item.Documentation.Value = doc
+		}
+
+		completionItems = append(completionItems, item)
+	}
+
+	return completionItems
+}
+
+func (p *AdminServiceCompletionProvider) GetTriggerCharacters() []string {
+	return []string{"'", "\""}
+}
@@ -0,0 +1,69 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
+)
+
+func TestAdminServiceCompletionProvider(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+
+	serviceIndexer, err := admin.NewAdminServiceIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndexer.Close() }()
+
+	registration := `Shopware.Application.addServiceProvider('repositoryFactory', () => {});`
+	registrationTree := parser.Parse([]byte(registration), nil)
+	defer registrationTree.Close()
+	require.NoError(t, serviceIndexer.Index("/project/src/Resources/app/administration/src/app/init/services.js", registrationTree.RootNode(), []byte(registration)))
+
+	provider := &AdminServiceCompletionProvider{serviceIndexer: serviceIndexer}
+
+	tests := []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{name: "empty string", code: `Shopware.Service('|');`, expected: true},
+		{name: "partial name", code: `Shopware.Service('repo|');`, expected: true},
+		{name: "destructured", code: `const { Service } = Shopware; Service("|");`, expected: true},
+		{name: "second argument", code: `Shopware.Service('foo', '|');`, expected: false},
+		{name: "other call", code: `Shopware.Snippet('|');`, expected: false},
+		{name: "service registration", code: `Shopware.Service().register('|', () => {});`, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := strings.Index(tt.code, "|")
+			content := []byte(tt.code[:offset] + tt.code[offset+1:])
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			params := &protocol.CompletionParams{
+				Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+				DocumentContent: content,
+			}
+			params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/module/sw-foo/index.js"
+
+			items := provider.GetCompletions(context.Background(), params)
+			if !tt.expected {
+				assert.Empty(t, items)
+				return
+			}
+
+			require.Len(t, items, 1)
+			assert.Equal(t, "repositoryFactory", items[0].Label)
+			assert.Contains(t, items[0].Documentation.Value, "Registered in `/project/src/Resources/app/administration/src/app/init/services.js`")
+		})
+	}
+}
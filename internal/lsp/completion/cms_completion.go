@@ -0,0 +1,109 @@
+package completion
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// CmsCompletionProvider completes the names of CMS block and element types
+type CmsCompletionProvider struct {
+	cmsIndexer *admin.CmsIndexer
+}
+
+func NewCmsCompletionProvider(lspServer *lsp.Server) *CmsCompletionProvider {
+	cmsIndexer, _ := lspServer.GetIndexer("admin.cms.indexer")
+	return &CmsCompletionProvider{
+		cmsIndexer: cmsIndexer.(*admin.CmsIndexer),
+	}
+}
+
+func (p *CmsCompletionProvider) ID() string {
+	return "completion.cms"
+}
+
+func (p *CmsCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil {
+		return nil
+	}
+
+	switch indexer.FileType(params.TextDocument.URI) {
+	case ".js", ".ts":
+		// registerCmsBlock({ slots: { left: '<caret>' } })
+		if admin.JSStringInCmsSlotPattern.Matches(params.Node, params.DocumentContent) {
+			return p.elementCompletions()
+		}
+	case ".twig":
+		// {% if block.type == '<caret>' %}
+		switch admin.TwigCmsTypeComparison(params.Node, params.DocumentContent) {
+		case "block":
+			return p.blockCompletions()
+		case "element":
+			return p.elementCompletions()
+		}
+	case ".xml":
+		// <slot name="left"><type><caret></type></slot>
+		if filepath.Base(params.TextDocument.URI) == "cms.xml" && admin.IsCmsXMLSlotType(params.Node, params.DocumentContent) {
+			return p.elementCompletions()
+		}
+	}
+
+	return nil
+}
+
+func (p *CmsCompletionProvider) blockCompletions() []protocol.CompletionItem {
+	blocks, err := p.cmsIndexer.GetAllBlocks()
+	if err != nil {
+		return nil
+	}
+
+	return cmsTypeCompletionItems(blocks, "CMS Block")
+}
+
+func (p *CmsCompletionProvider) elementCompletions() []protocol.CompletionItem {
+	elements, err := p.cmsIndexer.GetAllElements()
+	if err != nil {
+		return nil
+	}
+
+	return cmsTypeCompletionItems(elements, "CMS Element")
+}
+
+func cmsTypeCompletionItems(types []admin.CmsType, title string) []protocol.CompletionItem {
+	items := make([]protocol.CompletionItem, 0, len(types))
+	seen := make(map[string]bool)
+
+	for _, cmsType := range types {
+		// Plugins may register a type again to override it
+		if seen[cmsType.Name] {
+			continue
+		}
+		seen[cmsType.Name] = true
+
+		item := protocol.CompletionItem{
+			Label:  cmsType.Name,
+			Kind:   int(protocol.EnumMemberCompletion),
+			Detail: cmsType.Category,
+		}
+
+		doc := "**" + title + "**\n\n"
+		if cmsType.Label != "" {
+			doc += "**Label:** `" + cmsType.Label + "`\n\n"
+		}
+		doc += "Registered in `" + cmsType.FilePath + "`"
+		item.Documentation.Kind = "markdown"
+		item.Documentation.Value = doc
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (p *CmsCompletionProvider) GetTriggerCharacters() []string {
+	return []string{"'", "\"", ">"}
+}
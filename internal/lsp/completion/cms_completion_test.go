@@ -0,0 +1,84 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
+)
+
+func TestCmsCompletionProvider(t *testing.T) {
+	jsLanguage := tree_sitter.NewLanguage(tree_sitter_javascript.Language())
+	twigLanguage := tree_sitter.NewLanguage(tree_sitter_twig.Language())
+	xmlLanguage := tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(jsLanguage))
+
+	cmsIndexer, err := admin.NewCmsIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = cmsIndexer.Close() }()
+
+	registration := `
+Shopware.Service('cmsService').registerCmsBlock({ name: 'image-text', category: 'text-image' });
+Shopware.Service('cmsService').registerCmsElement({ name: 'image' });
+Shopware.Service('cmsService').registerCmsElement({ name: 'text' });
+`
+	registrationTree := parser.Parse([]byte(registration), nil)
+	defer registrationTree.Close()
+	require.NoError(t, cmsIndexer.Index("/project/src/Resources/app/administration/src/module/sw-cms/index.js", registrationTree.RootNode(), []byte(registration)))
+
+	provider := &CmsCompletionProvider{cmsIndexer: cmsIndexer}
+
+	tests := []struct {
+		name     string
+		language *tree_sitter.Language
+		uri      string
+		code     string
+		expected []string
+	}{
+		{name: "block slot", language: jsLanguage, uri: "index.js", code: `cmsService.registerCmsBlock({ name: 'foo', slots: { left: '|' } });`, expected: []string{"image", "text"}},
+		{name: "block slot with config", language: jsLanguage, uri: "index.js", code: `cmsService.registerCmsBlock({ slots: { left: { type: "|" } } });`, expected: []string{"image", "text"}},
+		{name: "block name", language: jsLanguage, uri: "index.js", code: `cmsService.registerCmsBlock({ name: '|' });`},
+		{name: "other call", language: jsLanguage, uri: "index.js", code: `foo({ slots: { left: '|' } });`},
+		{name: "twig block type", language: twigLanguage, uri: "cms-section.html.twig", code: `{% if block.type == '|' %}{% endif %}`, expected: []string{"image-text"}},
+		{name: "twig element type", language: twigLanguage, uri: "cms-block.html.twig", code: `{% if element.type != '|' %}{% endif %}`, expected: []string{"image", "text"}},
+		{name: "twig other property", language: twigLanguage, uri: "cms-block.html.twig", code: `{% if element.name == '|' %}{% endif %}`},
+		{name: "cms.xml slot type", language: xmlLanguage, uri: "Resources/cms.xml", code: `<cms><blocks><block><slots><slot name="left"><type>|</type></slot></slots></block></blocks></cms>`, expected: []string{"image", "text"}},
+		{name: "cms.xml block name", language: xmlLanguage, uri: "Resources/cms.xml", code: `<cms><blocks><block><name>|</name></block></blocks></cms>`},
+		{name: "other xml", language: xmlLanguage, uri: "Resources/config/services.xml", code: `<slot name="left"><type>|</type></slot>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, parser.SetLanguage(tt.language))
+
+			offset := strings.Index(tt.code, "|")
+			content := []byte(tt.code[:offset] + tt.code[offset+1:])
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			params := &protocol.CompletionParams{
+				Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+				DocumentContent: content,
+			}
+			params.TextDocument.URI = "file:///project/custom/plugins/MyPlugin/src/" + tt.uri
+
+			var labels []string
+			for _, item := range provider.GetCompletions(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/dal"
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+)
+
+// criteriaFieldFilters are the filters taking a field path as first argument
+var criteriaFieldFilters = []string{"EqualsFilter", "ContainsFilter"}
+
+// DALCompletionProvider provides completions for DAL entity field names and repository service ids
+type DALCompletionProvider struct {
+	entityIndex *dal.EntityIndexer
+}
+
+// NewDALCompletionProvider creates a new DAL completion provider
+func NewDALCompletionProvider(server *lsp.Server) *DALCompletionProvider {
+	entityIndexer, _ := server.GetIndexer("dal.entity")
+
+	return &DALCompletionProvider{
+		entityIndex: entityIndexer.(*dal.EntityIndexer),
+	}
+}
+
+func (p *DALCompletionProvider) ID() string {
+	return "completion.dal"
+}
+
+func (p *DALCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil {
+		return []protocol.CompletionItem{}
+	}
+
+	switch indexer.FileType(params.TextDocument.URI) {
+	case ".php":
+		return p.phpCompletions(ctx, params)
+	case ".xml":
+		return p.xmlCompletions(ctx, params)
+	default:
+		return []protocol.CompletionItem{}
+	}
+}
+
+func (p *DALCompletionProvider) phpCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	// new EqualsFilter('<caret>', $value) or new EqualsFilter('product.manufacturer.<caret>', $value)
+	if treesitterhelper.IsPHPNewExpressionArgument(0, criteriaFieldFilters...).Matches(params.Node, params.DocumentContent) {
+		path := strings.Trim(typedPrefix(params), "'\"")
+		if dot := strings.LastIndex(path, "."); dot >= 0 {
+			return p.associationFieldCompletions(path[:dot])
+		}
+
+		return p.fieldCompletions()
+	}
+
+	// $container->get('<caret>')
+	if treesitterhelper.IsPHPContainerGetArgument().Matches(params.Node, params.DocumentContent) {
+		return p.repositoryCompletions()
+	}
+
+	return []protocol.CompletionItem{}
+}
+
+func (p *DALCompletionProvider) xmlCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	// <argument type="service" id="<caret>"/>
+	if treesitterhelper.SymfonyServiceIsServiceTag(params.Node, params.DocumentContent) {
+		return p.repositoryCompletions()
+	}
+
+	return []protocol.CompletionItem{}
+}
+
+func (p *DALCompletionProvider) fieldCompletions() []protocol.CompletionItem {
+	fields, err := p.entityIndex.GetFieldNames()
+	if err != nil {
+		return []protocol.CompletionItem{}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, protocol.CompletionItem{
+			Label:  name,
+			Kind:   int(protocol.FieldCompletion),
+			Detail: strings.Join(fields[name], ", "),
+		})
+	}
+
+	return items
+}
+
+// associationFieldCompletions offers the fields of the entities an association path like
+// product.manufacturer leads to
+func (p *DALCompletionProvider) associationFieldCompletions(path string) []protocol.CompletionItem {
+	definitions, err := p.entityIndex.ResolveAssociationPath(path)
+	if err != nil {
+		return []protocol.CompletionItem{}
+	}
+
+	items := make([]protocol.CompletionItem, 0)
+	for _, definition := range definitions {
+		for _, field := range definition.Fields {
+			item := protocol.CompletionItem{
+				Label:  field.Name,
+				Kind:   int(protocol.FieldCompletion),
+				Detail: definition.Class,
+			}
+
+			if field.IsAssociation() {
+				item.Kind = int(protocol.ReferenceCompletion)
+				item.Documentation.Kind = "markdown"
+				item.Documentation.Value = field.Type + " to " + field.Reference
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// repositoryCompletions offers the <entity>.repository service registered for every entity
+func (p *DALCompletionProvider) repositoryCompletions() []protocol.CompletionItem {
+	entityNames, err := p.entityIndex.GetAllEntityNames()
+	if err != nil {
+		return []protocol.CompletionItem{}
+	}
+
+	items := make([]protocol.CompletionItem, 0, len(entityNames))
+	for _, entityName := range entityNames {
+		item := protocol.CompletionItem{
+			Label: dal.RepositoryServiceID(entityName),
+			Kind:  int(protocol.ClassCompletion),
+		}
+
+		if definition, found := p.entityIndex.GetEntityByName(entityName); found {
+			item.Detail = definition.Class
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+func (p *DALCompletionProvider) GetTriggerCharacters() []string {
+	return []string{"."}
+}
@@ -0,0 +1,264 @@
+package completion
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/dal"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestDALCompletionProvider_FilterFields(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	entityIndex, err := dal.NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = entityIndex.Close() }()
+
+	definitionPath := filepath.Join("..", "..", "dal", "testdata", "ProductDefinition.php")
+	definitionContent, err := os.ReadFile(definitionPath)
+	require.NoError(t, err)
+	definitionTree := parser.Parse(definitionContent, nil)
+	defer definitionTree.Close()
+	require.NoError(t, entityIndex.Index(definitionPath, definitionTree.RootNode(), definitionContent))
+
+	provider := &DALCompletionProvider{entityIndex: entityIndex}
+
+	tests := []struct {
+		name     string
+		code     string
+		needle   string
+		expected bool
+	}{
+		{
+			name:     "equals filter field",
+			code:     `<?php $criteria->addFilter(new EqualsFilter('productNumber', 'SW1'));`,
+			needle:   "productNumber",
+			expected: true,
+		},
+		{
+			name:     "qualified contains filter field",
+			code:     `<?php $criteria->addFilter(new \Shopware\Core\Framework\DataAbstractionLayer\Search\Filter\ContainsFilter('name', 'foo'));`,
+			needle:   "name'",
+			expected: true,
+		},
+		{
+			name:     "equals filter value",
+			code:     `<?php $criteria->addFilter(new EqualsFilter('productNumber', 'SW1'));`,
+			needle:   "SW1",
+			expected: false,
+		},
+		{
+			name:     "other class",
+			code:     `<?php $criteria->addFilter(new RangeFilter('productNumber', []));`,
+			needle:   "productNumber",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.code)
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			offset := uint(strings.Index(tt.code, tt.needle))
+			node := tree.RootNode().DescendantForByteRange(offset, offset)
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/src/Service/Foo.php"
+
+			items := provider.GetCompletions(context.Background(), params)
+			if !tt.expected {
+				assert.Empty(t, items)
+				return
+			}
+
+			labels := make(map[string]string)
+			for _, item := range items {
+				labels[item.Label] = item.Detail
+			}
+			assert.Equal(t, `Shopware\Core\Content\Product\ProductDefinition`, labels["productNumber"])
+			assert.Contains(t, labels, "manufacturer")
+		})
+	}
+}
+
+func TestDALCompletionProvider_RepositoryIds(t *testing.T) {
+	entityIndex, err := dal.NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = entityIndex.Close() }()
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	definitionPath := filepath.Join("..", "..", "dal", "testdata", "ProductDefinition.php")
+	definitionContent, err := os.ReadFile(definitionPath)
+	require.NoError(t, err)
+	definitionTree := phpParser.Parse(definitionContent, nil)
+	defer definitionTree.Close()
+	require.NoError(t, entityIndex.Index(definitionPath, definitionTree.RootNode(), definitionContent))
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	provider := &DALCompletionProvider{entityIndex: entityIndex}
+
+	tests := []struct {
+		name     string
+		uri      string
+		code     string
+		needle   string
+		expected bool
+	}{
+		{
+			name:     "this container get",
+			uri:      "file:///project/src/Foo.php",
+			code:     `<?php $this->container->get('product.repository');`,
+			needle:   "product.repository",
+			expected: true,
+		},
+		{
+			name:     "container variable get",
+			uri:      "file:///project/src/Foo.php",
+			code:     `<?php $container->get('');`,
+			needle:   "''",
+			expected: true,
+		},
+		{
+			name:     "get on other object",
+			uri:      "file:///project/src/Foo.php",
+			code:     `<?php $request->get('product.repository');`,
+			needle:   "product.repository",
+			expected: false,
+		},
+		{
+			name:     "xml service argument",
+			uri:      "file:///project/src/Resources/config/services.xml",
+			code:     `<container><services><service id="foo"><argument type="service" id="product.repository"/></service></services></container>`,
+			needle:   "product.repository",
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := phpParser
+			if strings.HasSuffix(tt.uri, ".xml") {
+				parser = xmlParser
+			}
+
+			content := []byte(tt.code)
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			offset := uint(strings.Index(tt.code, tt.needle))
+			node := tree.RootNode().DescendantForByteRange(offset, offset)
+			if parser == xmlParser {
+				for node != nil && node.Kind() != "AttValue" {
+					node = node.Parent()
+				}
+			}
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = tt.uri
+
+			items := provider.GetCompletions(context.Background(), params)
+			if !tt.expected {
+				assert.Empty(t, items)
+				return
+			}
+
+			require.Len(t, items, 1)
+			assert.Equal(t, "product.repository", items[0].Label)
+			assert.Equal(t, `Shopware\Core\Content\Product\ProductDefinition`, items[0].Detail)
+		})
+	}
+}
+
+func TestDALCompletionProvider_AssociationPaths(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	entityIndex, err := dal.NewEntityIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = entityIndex.Close() }()
+
+	for _, name := range []string{"ProductDefinition.php", "ProductManufacturerDefinition.php"} {
+		definitionPath := filepath.Join("..", "..", "dal", "testdata", name)
+		definitionContent, err := os.ReadFile(definitionPath)
+		require.NoError(t, err)
+		definitionTree := parser.Parse(definitionContent, nil)
+		require.NoError(t, entityIndex.Index(definitionPath, definitionTree.RootNode(), definitionContent))
+		definitionTree.Close()
+	}
+
+	provider := &DALCompletionProvider{entityIndex: entityIndex}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected []string
+	}{
+		{
+			name:     "entity name",
+			path:     "product.",
+			expected: []string{"id", "manufacturerId", "productNumber", "name", "manufacturer"},
+		},
+		{
+			name:     "association of entity",
+			path:     "product.manufacturer.",
+			expected: []string{"id", "link", "name", "products"},
+		},
+		{
+			name:     "partially typed field of association",
+			path:     "product.manufacturer.li",
+			expected: []string{"id", "link", "name", "products"},
+		},
+		{
+			name:     "association without entity name",
+			path:     "manufacturer.",
+			expected: []string{"id", "link", "name", "products"},
+		},
+		{
+			name: "scalar field",
+			path: "product.productNumber.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := `<?php $criteria->addFilter(new EqualsFilter('` + tt.path + `', 'SW1'));`
+			content := []byte(code)
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			offset := uint(strings.Index(code, tt.path))
+			node := tree.RootNode().DescendantForByteRange(offset, offset)
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/src/Service/Foo.php"
+			params.Position = protocol.Position{Character: int(offset) + len(tt.path)}
+
+			var labels []string
+			for _, item := range provider.GetCompletions(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package completion
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/event"
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+)
+
+// EventCompletionProvider completes event names for event listeners and subscribers
+type EventCompletionProvider struct {
+	eventIndexer *event.EventIndexer
+}
+
+func NewEventCompletionProvider(lspServer *lsp.Server) *EventCompletionProvider {
+	eventIndexer, _ := lspServer.GetIndexer("event.indexer")
+	return &EventCompletionProvider{
+		eventIndexer: eventIndexer.(*event.EventIndexer),
+	}
+}
+
+func (p *EventCompletionProvider) ID() string {
+	return "completion.event"
+}
+
+func (p *EventCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil {
+		return nil
+	}
+
+	switch indexer.FileType(params.TextDocument.URI) {
+	case ".xml":
+		// <tag name="kernel.event_listener" event="<caret>"/>
+		if treesitterhelper.SymfonyServiceIsEventAttribute(params.Node, params.DocumentContent) {
+			return p.eventCompletions()
+		}
+	case ".php":
+		// public static function getSubscribedEvents(): array { return ['<caret>' => 'onEvent']; }
+		if treesitterhelper.IsPHPSubscribedEventKey().Matches(params.Node, params.DocumentContent) {
+			return p.eventCompletions()
+		}
+	}
+
+	return nil
+}
+
+func (p *EventCompletionProvider) eventCompletions() []protocol.CompletionItem {
+	events, err := p.eventIndexer.GetAllEvents()
+	if err != nil {
+		return nil
+	}
+
+	// Events are sorted by name, an event name can be declared by several classes
+	var completionItems []protocol.CompletionItem
+	for _, ev := range events {
+		if len(completionItems) > 0 && completionItems[len(completionItems)-1].Label == ev.Name {
+			last := &completionItems[len(completionItems)-1]
+			last.Documentation.Value += "- " + eventDeclaration(ev) + "\n"
+			continue
+		}
+
+		item := protocol.CompletionItem{
+			Label:  ev.Name,
+			Kind:   int(protocol.EventCompletion),
+			Detail: ev.Class,
+		}
+		item.Documentation.Kind = "markdown"
+		item.Documentation.Value = "**Event**\n\n- " + eventDeclaration(ev) + "\n"
+
+		completionItems = append(completionItems, item)
+	}
+
+	return completionItems
+}
+
+// eventDeclaration describes where an event name is declared
+func eventDeclaration(ev event.Event) string {
+	if ev.Constant != "" {
+		return "`" + ev.Class + "::" + ev.Constant + "`"
+	}
+
+	return "Event class `" + ev.Class + "`"
+}
+
+func (p *EventCompletionProvider) GetTriggerCharacters() []string {
+	return []string{"'", "\""}
+}
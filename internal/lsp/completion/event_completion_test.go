@@ -0,0 +1,120 @@
+package completion
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/event"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestEventCompletionProvider(t *testing.T) {
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	eventIndex, err := event.NewEventIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = eventIndex.Close() }()
+
+	for _, file := range []string{"CheckoutEvents.php", "CheckoutOrderPlacedEvent.php"} {
+		path := filepath.Join("..", "..", "event", "testdata", file)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		tree := phpParser.Parse(content, nil)
+		require.NoError(t, eventIndex.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	provider := &EventCompletionProvider{eventIndexer: eventIndex}
+
+	subscriber := `<?php
+class Subscriber implements EventSubscriberInterface
+{
+    public static function getSubscribedEvents(): array
+    {
+        return [
+            'checkout' => ['onPlaced', 10],
+            '' => 'onOther',
+        ];
+    }
+
+    public function other(): array
+    {
+        return ['checkout' => 'x'];
+    }
+}`
+
+	services := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Foo\Listener">
+            <tag name="kernel.event_listener" event="checkout" method="onPlaced"/>
+        </service>
+    </services>
+</container>`
+
+	tests := []struct {
+		name     string
+		parser   *tree_sitter.Parser
+		uri      string
+		code     string
+		offset   int
+		expected bool
+	}{
+		{name: "subscribed event key", parser: phpParser, uri: "Subscriber.php", code: subscriber, offset: strings.Index(subscriber, "checkout' =>") + 2, expected: true},
+		{name: "empty subscribed event key", parser: phpParser, uri: "Subscriber.php", code: subscriber, offset: strings.Index(subscriber, "'' =>") + 1, expected: true},
+		{name: "listener method", parser: phpParser, uri: "Subscriber.php", code: subscriber, offset: strings.Index(subscriber, "onPlaced") + 2},
+		{name: "array key in other method", parser: phpParser, uri: "Subscriber.php", code: subscriber, offset: strings.Index(subscriber, "checkout' => 'x") + 2},
+		{name: "event attribute of listener tag", parser: xmlParser, uri: "services.xml", code: services, offset: strings.Index(services, `"checkout"`) + 2, expected: true},
+		{name: "method attribute of listener tag", parser: xmlParser, uri: "services.xml", code: services, offset: strings.Index(services, `"onPlaced"`) + 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.code)
+			tree := tt.parser.Parse(content, nil)
+			defer tree.Close()
+
+			node := tree.RootNode().DescendantForByteRange(uint(tt.offset), uint(tt.offset))
+			for tt.parser == xmlParser && node != nil && node.Kind() != "AttValue" {
+				node = node.Parent()
+			}
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/src/" + tt.uri
+
+			items := provider.GetCompletions(context.Background(), params)
+			if !tt.expected {
+				assert.Empty(t, items)
+				return
+			}
+
+			labels := make([]string, 0, len(items))
+			for _, item := range items {
+				labels = append(labels, item.Label)
+			}
+			assert.Equal(t, []string{
+				`Shopware\Core\Checkout\Cart\Event\CheckoutOrderPlacedEvent`,
+				"checkout.order.placed",
+				"checkout.order.placed.criteria",
+			}, labels)
+
+			assert.Equal(t, `Shopware\Core\Checkout\CheckoutEvents`, items[1].Detail)
+			assert.Contains(t, items[1].Documentation.Value, "CheckoutEvents::CHECKOUT_ORDER_PLACED")
+			assert.Contains(t, items[1].Documentation.Value, "CheckoutOrderPlacedEvent::EVENT_NAME")
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package completion
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+)
+
+var (
+	// themeInheritancePattern matches the bundles a theme.json inherits templates, styles, scripts or config from
+	//
+	// Example: {"views": ["@Storefront", "@Plugins", "<caret>"]}
+	themeInheritancePattern = treesitterhelper.JSONArrayEntryPattern("views", "style", "script", "configInheritance")
+
+	// themeConfigInheritancePattern matches the themes whose config a theme.json inherits, which excludes @Plugins
+	themeConfigInheritancePattern = treesitterhelper.JSONArrayEntryPattern("configInheritance")
+
+	// composerRequirePattern matches the package names required by a composer.json
+	//
+	// Example: {"require": {"<caret>": "*"}}
+	composerRequirePattern = treesitterhelper.JSONPropertyNamePattern("require", "require-dev")
+)
+
+// ExtensionCompletionProvider completes the names of the installed plugins, bundles and apps
+type ExtensionCompletionProvider struct {
+	extensionIndexer *extension.ExtensionIndexer
+}
+
+func NewExtensionCompletionProvider(lspServer *lsp.Server) *ExtensionCompletionProvider {
+	extensionIndexer, _ := lspServer.GetIndexer("extension.indexer")
+	return &ExtensionCompletionProvider{
+		extensionIndexer: extensionIndexer.(*extension.ExtensionIndexer),
+	}
+}
+
+func (p *ExtensionCompletionProvider) ID() string {
+	return "completion.extension"
+}
+
+func (p *ExtensionCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil {
+		return nil
+	}
+
+	switch filepath.Base(params.TextDocument.URI) {
+	case "theme.json":
+		// {"views": ["@Storefront", "<caret>"]}
+		if themeInheritancePattern.Matches(params.Node, params.DocumentContent) {
+			return p.themeCompletions(!themeConfigInheritancePattern.Matches(params.Node, params.DocumentContent))
+		}
+	case "composer.json":
+		// {"require": {"<caret>": "*"}}
+		if composerRequirePattern.Matches(params.Node, params.DocumentContent) {
+			return p.composerCompletions(strings.TrimPrefix(params.TextDocument.URI, "file://"))
+		}
+	}
+
+	return nil
+}
+
+// themeCompletions returns the bundle references of a theme.json, @Plugins stands for all plugins
+// and is only allowed for views, styles and scripts
+func (p *ExtensionCompletionProvider) themeCompletions(withPlugins bool) []protocol.CompletionItem {
+	extensions, err := p.extensionIndexer.GetAllExtensions()
+	if err != nil {
+		return nil
+	}
+
+	// The Storefront is a core bundle, which is not indexed
+	items := []protocol.CompletionItem{{
+		Label:  "@Storefront",
+		Kind:   int(protocol.ModuleCompletion),
+		Detail: "Shopware Storefront",
+	}}
+	if withPlugins {
+		items = append(items, protocol.CompletionItem{
+			Label:  "@Plugins",
+			Kind:   int(protocol.ModuleCompletion),
+			Detail: "All installed plugins",
+		})
+	}
+
+	for _, ext := range extensions {
+		if ext.Name == "Storefront" {
+			continue
+		}
+
+		items = append(items, extensionCompletionItem(ext, "@"+ext.Name, ext.Name))
+	}
+
+	return items
+}
+
+// composerCompletions returns the composer package names of the installed plugins and bundles,
+// except the one the composer.json belongs to
+func (p *ExtensionCompletionProvider) composerCompletions(composerPath string) []protocol.CompletionItem {
+	extensions, err := p.extensionIndexer.GetAllExtensions()
+	if err != nil {
+		return nil
+	}
+
+	var items []protocol.CompletionItem
+	seen := make(map[string]bool)
+
+	for _, ext := range extensions {
+		extensionComposerPath, packageName := p.extensionIndexer.GetComposerPackage(ext)
+		if extensionComposerPath == composerPath || packageName == "" || seen[packageName] {
+			continue
+		}
+		seen[packageName] = true
+
+		items = append(items, extensionCompletionItem(ext, packageName, ext.Name))
+	}
+
+	return items
+}
+
+func extensionCompletionItem(ext extension.ShopwareExtension, label, detail string) protocol.CompletionItem {
+	item := protocol.CompletionItem{
+		Label:  label,
+		Kind:   int(protocol.ModuleCompletion),
+		Detail: detail,
+	}
+
+	title := "Bundle"
+	if ext.Type == extension.ShopwareExtensionTypeApp {
+		title = "App"
+	}
+
+	item.Documentation.Kind = "markdown"
+	item.Documentation.Value = "**" + title + ":** `" + ext.Name + "`\n\nDefined in `" + ext.Path + "`"
+
+	return item
+}
+
+func (p *ExtensionCompletionProvider) GetTriggerCharacters() []string {
+	return []string{"\"", "@"}
+}
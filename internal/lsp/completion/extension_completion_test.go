@@ -0,0 +1,98 @@
+package completion
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_json "github.com/tree-sitter/tree-sitter-json/bindings/go"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestExtensionCompletionProvider(t *testing.T) {
+	projectDir := t.TempDir()
+
+	extensionIndexer, err := extension.NewExtensionIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = extensionIndexer.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	jsonParser := tree_sitter.NewParser()
+	defer jsonParser.Close()
+	require.NoError(t, jsonParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_json.Language())))
+
+	indexComposer := func(path, composer string) {
+		tree := jsonParser.Parse([]byte(composer), nil)
+		defer tree.Close()
+		require.NoError(t, extensionIndexer.Index(path, tree.RootNode(), []byte(composer)))
+	}
+
+	plugins := map[string]string{
+		"MyTheme":  `{"name": "acme/my-theme"}`,
+		"MyPlugin": `{"name": "acme/old-name"}`,
+	}
+	for name, composer := range plugins {
+		pluginDir := filepath.Join(projectDir, "custom", "plugins", name)
+		indexComposer(filepath.Join(pluginDir, "composer.json"), composer)
+
+		classPath := filepath.Join(pluginDir, "src", name+".php")
+		code := []byte("<?php\nnamespace Acme;\n\nuse Shopware\\Core\\Framework\\Plugin;\n\nclass " + name + " extends Plugin {}\n")
+		tree := parser.Parse(code, nil)
+		require.NoError(t, extensionIndexer.Index(classPath, tree.RootNode(), code))
+		tree.Close()
+	}
+
+	// Renaming the package only reindexes the composer.json, not the bundle class
+	indexComposer(filepath.Join(projectDir, "custom", "plugins", "MyPlugin", "composer.json"), `{"name": "acme/my-plugin"}`)
+
+	provider := &ExtensionCompletionProvider{extensionIndexer: extensionIndexer}
+
+	tests := []struct {
+		name     string
+		path     string
+		code     string
+		expected []string
+	}{
+		{name: "theme views", path: "custom/plugins/MyTheme/src/Resources/theme.json", code: `{"views": ["@Storefront", "|"]}`, expected: []string{"@Storefront", "@Plugins", "@MyPlugin", "@MyTheme"}},
+		{name: "theme style entry", path: "custom/plugins/MyTheme/src/Resources/theme.json", code: `{"style": ["@|"]}`, expected: []string{"@Storefront", "@Plugins", "@MyPlugin", "@MyTheme"}},
+		{name: "theme config inheritance", path: "custom/plugins/MyTheme/src/Resources/theme.json", code: `{"configInheritance": ["|"]}`, expected: []string{"@Storefront", "@MyPlugin", "@MyTheme"}},
+		{name: "theme nested array", path: "custom/plugins/MyTheme/src/Resources/theme.json", code: `{"config": {"views": ["|"]}}`},
+		{name: "theme name", path: "custom/plugins/MyTheme/src/Resources/theme.json", code: `{"name": "|"}`},
+		{name: "composer require", path: "composer.json", code: `{"require": {"|": "*"}}`, expected: []string{"acme/my-plugin", "acme/my-theme"}},
+		{name: "composer require without version", path: "composer.json", code: `{"require-dev": {"shopware/core": "*", "|"}}`, expected: []string{"acme/my-plugin", "acme/my-theme"}},
+		{name: "composer require of a plugin", path: "custom/plugins/MyTheme/composer.json", code: `{"require": {"|": "*"}}`, expected: []string{"acme/my-plugin"}},
+		{name: "composer require version", path: "composer.json", code: `{"require": {"acme/my-plugin": "|"}}`},
+		{name: "composer autoload", path: "composer.json", code: `{"autoload": {"|": "src/"}}`},
+		{name: "other json", path: "package.json", code: `{"require": {"|": "*"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := strings.Index(tt.code, "|")
+			content := []byte(tt.code[:offset] + tt.code[offset+1:])
+			tree := jsonParser.Parse(content, nil)
+			defer tree.Close()
+
+			params := &protocol.CompletionParams{
+				Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+				DocumentContent: content,
+			}
+			params.TextDocument.URI = "file://" + filepath.Join(projectDir, tt.path)
+
+			var labels []string
+			for _, item := range provider.GetCompletions(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
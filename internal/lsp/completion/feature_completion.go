@@ -20,6 +20,10 @@ func NewFeatureCompletionProvider(lspServer *lsp.Server) *FeatureCompletionProvi
 	}
 }
 
+func (p *FeatureCompletionProvider) ID() string {
+	return "completion.feature"
+}
+
 func (p *FeatureCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return nil
@@ -0,0 +1,758 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxClassCompletionItems limits the classes offered for a typed prefix, the list is refined while typing
+const maxClassCompletionItems = 100
+
+// knownAttributeClasses are offered as attributes even before the vendor directory has been indexed
+var knownAttributeClasses = []string{
+	"AllowDynamicProperties",
+	"Attribute",
+	"Override",
+	"ReturnTypeWillChange",
+	"SensitiveParameter",
+	"Shopware\\Core\\Framework\\DataAbstractionLayer\\Attribute\\Entity",
+	"Shopware\\Core\\Framework\\Log\\Package",
+	"Symfony\\Component\\Console\\Attribute\\AsCommand",
+	"Symfony\\Component\\DependencyInjection\\Attribute\\AsDecorator",
+	"Symfony\\Component\\DependencyInjection\\Attribute\\Autowire",
+	"Symfony\\Component\\EventDispatcher\\Attribute\\AsEventListener",
+	"Symfony\\Component\\Messenger\\Attribute\\AsMessageHandler",
+	"Symfony\\Component\\Routing\\Attribute\\Route",
+	"Symfony\\Contracts\\Service\\Attribute\\Required",
+}
+
+// attributeValue is a value accepted by an attribute argument
+type attributeValue struct {
+	value       string
+	description string
+}
+
+// shopwareDomains are the areas Shopware assigns its code to with #[Package('...')]
+var shopwareDomains = []attributeValue{
+	{"after-sales", "Orders, documents, returns and the communication after the checkout"},
+	{"checkout", "Cart, payment, shipping and the order placement"},
+	{"data-services", "Import/export, usage data and data synchronisation"},
+	{"discovery", "CMS, storefront navigation, search and SEO"},
+	{"framework", "Kernel, DAL, API, administration and storefront infrastructure"},
+	{"fundamentals@after-sales", "Fundamentals maintained by the after-sales team"},
+	{"fundamentals@checkout", "Fundamentals maintained by the checkout team"},
+	{"fundamentals@discovery", "Fundamentals maintained by the discovery team"},
+	{"fundamentals@framework", "Fundamentals maintained by the framework team"},
+	{"fundamentals@inventory", "Fundamentals maintained by the inventory team"},
+	{"inventory", "Products, properties, prices and media"},
+	// Domains of Shopware versions before 6.6
+	{"administration", "Legacy domain, administration"},
+	{"business-ops", "Legacy domain, business operations"},
+	{"buyers-experience", "Legacy domain, buyers experience"},
+	{"core", "Legacy domain, core"},
+	{"customer-order", "Legacy domain, customers and orders"},
+	{"merchant-services", "Legacy domain, merchant services"},
+	{"sales-channel", "Legacy domain, sales channels"},
+	{"services-settings", "Legacy domain, services and settings"},
+	{"storefront", "Legacy domain, storefront"},
+	{"system-settings", "Legacy domain, system settings"},
+}
+
+// knownAttributeArguments are the attribute arguments accepting a fixed set of strings
+var knownAttributeArguments = []struct {
+	pattern treesitterhelper.Pattern
+	values  []attributeValue
+}{
+	// #[Package('<caret>')]
+	{treesitterhelper.IsPHPAttributeArgument(0, "Package"), shopwareDomains},
+}
+
+// PHPCompletionProvider completes class names and class members in PHP files
+type PHPCompletionProvider struct {
+	phpIndex *php.PHPIndex
+}
+
+func NewPHPCompletionProvider(lspServer *lsp.Server) *PHPCompletionProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+	return &PHPCompletionProvider{
+		phpIndex: phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (p *PHPCompletionProvider) ID() string {
+	return "completion.php"
+}
+
+func (p *PHPCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return nil
+	}
+
+	// CheckoutEvents::<caret> or Uuid::<caret>randomHex()
+	if treesitterhelper.IsPHPStaticMemberAccess().Matches(params.Node, params.DocumentContent) {
+		return p.staticMemberCompletions(params)
+	}
+
+	// $this-><caret> or $product->get<caret>Name()
+	if treesitterhelper.IsPHPMemberAccess().Matches(params.Node, params.DocumentContent) {
+		return p.memberCompletions(params)
+	}
+
+	// #[<caret>] or #[Rou<caret>('/store-api/example')]
+	if php.IsAttributeNamePosition(params.Node) {
+		return p.attributeCompletions(params)
+	}
+
+	// #[Package('<caret>')]
+	for _, argument := range knownAttributeArguments {
+		if argument.pattern.Matches(params.Node, params.DocumentContent) {
+			return attributeValueCompletions(argument.values)
+		}
+	}
+
+	// $this->state = <caret> with a property typed as enum
+	if enum := p.assignedEnum(params); enum != nil {
+		return p.enumCaseCompletions(params, enum)
+	}
+
+	// $order->setState(<caret>) or $order->setState(state: <caret>) with a parameter typed as enum
+	if enum := p.argumentEnum(params); enum != nil {
+		completionItems := p.enumCaseCompletions(params, enum)
+		if arguments := callArguments(params); arguments != nil {
+			completionItems = append(completionItems, p.namedArgumentCompletions(params, arguments)...)
+		}
+		return completionItems
+	}
+
+	// $this->load($id, <caret>) or new Criteria(<caret>)
+	if arguments := callArguments(params); arguments != nil {
+		return p.namedArgumentCompletions(params, arguments)
+	}
+
+	// new <caret>Criteria() or function load(<caret>Context $context)
+	if php.IsClassNamePosition(params.Node) {
+		return p.classCompletions(params)
+	}
+
+	return nil
+}
+
+// IsIncomplete marks class name completions as incomplete, as they are searched by the typed prefix
+func (p *PHPCompletionProvider) IsIncomplete(params *protocol.CompletionParams) bool {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return false
+	}
+
+	return php.IsAttributeNamePosition(params.Node) || php.IsClassNamePosition(params.Node)
+}
+
+// classCompletions offers indexed classes by their short name, adding a use statement for classes
+// that are not imported yet
+func (p *PHPCompletionProvider) classCompletions(params *protocol.CompletionParams) []protocol.CompletionItem {
+	prefix := typedPrefix(params)
+	if prefix == "" {
+		return nil
+	}
+
+	rootNode := params.Node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+	imports := php.ParseFileImports(rootNode, params.DocumentContent)
+
+	var completionItems []protocol.CompletionItem
+	for _, className := range p.phpIndex.SearchClassesByShortName(prefix, maxClassCompletionItems) {
+		completionItems = append(completionItems, p.classCompletionItem(className, rootNode, imports))
+	}
+
+	return completionItems
+}
+
+// attributeCompletions offers the indexed attribute classes and the well known ones,
+// right after "#[" all of them are offered as attribute names are short
+func (p *PHPCompletionProvider) attributeCompletions(params *protocol.CompletionParams) []protocol.CompletionItem {
+	prefix := ""
+	if params.Node.Kind() == "name" {
+		prefix = strings.ToLower(typedPrefix(params))
+	}
+
+	rootNode := params.Node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+	imports := php.ParseFileImports(rootNode, params.DocumentContent)
+
+	classNames := append(p.phpIndex.GetAttributeClassNames(), knownAttributeClasses...)
+	sort.Strings(classNames)
+
+	var completionItems []protocol.CompletionItem
+	for i, className := range classNames {
+		if i > 0 && classNames[i-1] == className {
+			continue
+		}
+
+		shortName := className[strings.LastIndex(className, "\\")+1:]
+		if !strings.HasPrefix(strings.ToLower(shortName), prefix) {
+			continue
+		}
+
+		completionItems = append(completionItems, p.classCompletionItem(className, rootNode, imports))
+		if len(completionItems) >= maxClassCompletionItems {
+			break
+		}
+	}
+
+	return completionItems
+}
+
+// attributeValueCompletions offers the values of an attribute argument in the order they are listed
+func attributeValueCompletions(values []attributeValue) []protocol.CompletionItem {
+	completionItems := make([]protocol.CompletionItem, 0, len(values))
+	for i, value := range values {
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:    value.value,
+			Kind:     int(protocol.EnumMemberCompletion),
+			Detail:   value.description,
+			SortText: fmt.Sprintf("%03d", i),
+		})
+	}
+
+	return completionItems
+}
+
+// classCompletionItem inserts the class by the name it is available as in the file,
+// adding a use statement for classes that are not imported yet
+func (p *PHPCompletionProvider) classCompletionItem(className string, rootNode *tree_sitter.Node, imports php.FileImports) protocol.CompletionItem {
+	shortName := className[strings.LastIndex(className, "\\")+1:]
+
+	item := protocol.CompletionItem{
+		Label:      shortName,
+		Kind:       int(protocol.ClassCompletion),
+		Detail:     className,
+		FilterText: shortName,
+	}
+
+	if class := p.phpIndex.GetClass(className); class != nil && class.IsInterface {
+		item.Kind = int(protocol.InterfaceCompletion)
+	}
+
+	switch localName, imported := imports.LocalName(className); {
+	case imported:
+		item.InsertText = localName
+	case imports.IsNameTaken(shortName):
+		// Another class is imported with the same name
+		item.InsertText = "\\" + className
+	default:
+		item.InsertText = shortName
+		item.AdditionalTextEdits = []interface{}{useStatementEdit(rootNode, className)}
+	}
+
+	return item
+}
+
+// typedPrefix returns the part of the name at the cursor which has been typed before the cursor
+func typedPrefix(params *protocol.CompletionParams) string {
+	name := params.Node.Utf8Text(params.DocumentContent)
+	start := params.Node.StartPosition()
+
+	if int(start.Row) == params.Position.Line && params.Position.Character >= int(start.Column) && params.Position.Character-int(start.Column) < len(name) {
+		return name[:params.Position.Character-int(start.Column)]
+	}
+
+	return name
+}
+
+// useStatementEdit adds a use statement below the existing ones, or below the namespace declaration
+func useStatementEdit(rootNode *tree_sitter.Node, className string) protocol.TextEdit {
+	var anchor *tree_sitter.Node
+	text := "\nuse " + className + ";\n"
+
+	for i := uint(0); i < rootNode.NamedChildCount(); i++ {
+		child := rootNode.NamedChild(i)
+
+		switch child.Kind() {
+		case "php_tag", "namespace_definition":
+			if anchor == nil || anchor.Kind() != "namespace_use_declaration" {
+				anchor = child
+			}
+		case "namespace_use_declaration":
+			anchor = child
+			text = "use " + className + ";\n"
+		}
+	}
+
+	position := protocol.Position{}
+	if anchor != nil {
+		position.Line = int(anchor.EndPosition().Row) + 1
+	}
+
+	return protocol.TextEdit{
+		Range:   protocol.Range{Start: position, End: position},
+		NewText: text,
+	}
+}
+
+// assignedEnum returns the enum a $this property is typed with, when the cursor is at the value assigned to it
+func (p *PHPCompletionProvider) assignedEnum(params *protocol.CompletionParams) *php.PHPClass {
+	target := assignmentTarget(params.Node)
+	if target == nil || target.Kind() != "member_access_expression" {
+		return nil
+	}
+
+	object := target.ChildByFieldName("object")
+	name := target.ChildByFieldName("name")
+	if object == nil || name == nil || object.Utf8Text(params.DocumentContent) != "$this" {
+		return nil
+	}
+
+	currentClass := treesitterhelper.GetClassName(params.Node, params.DocumentContent)
+	property := p.phpIndex.GetProperty(currentClass, name.Utf8Text(params.DocumentContent))
+	if property == nil {
+		return nil
+	}
+
+	return p.enumOfType(property.Type)
+}
+
+// argumentEnum returns the enum the parameter of a method or constructor is typed with, when the cursor is
+// at the value of its argument
+func (p *PHPCompletionProvider) argumentEnum(params *protocol.CompletionParams) *php.PHPClass {
+	arguments, parameterName, position := argumentAtCursor(params)
+	if arguments == nil {
+		return nil
+	}
+
+	method, _ := p.phpIndex.ResolveCalledMethod(arguments.Parent(), params.DocumentContent)
+	if method == nil {
+		return nil
+	}
+
+	for i, parameter := range method.Parameters {
+		// Variadic parameters collect the remaining arguments
+		if (parameterName != "" && parameter.Name == parameterName) || (parameterName == "" && (i == position || (parameter.IsVariadic && i < position))) {
+			return p.enumOfType(parameter.Type)
+		}
+	}
+
+	return nil
+}
+
+// argumentAtCursor returns the arguments of the call the cursor is at the value of an argument of, together with
+// the name of a named argument or the position of a positional one
+func argumentAtCursor(params *protocol.CompletionParams) (*tree_sitter.Node, string, int) {
+	node := params.Node
+	if node.Kind() == "name" {
+		argument := node.Parent()
+		arguments := argument.Parent()
+		if argument.Kind() != "argument" || arguments == nil || arguments.Kind() != "arguments" {
+			return nil, "", 0
+		}
+
+		// state: Or<caret>, but not sta<caret> itself
+		if name := argument.ChildByFieldName("name"); name != nil {
+			if name.Id() == node.Id() {
+				return nil, "", 0
+			}
+			return arguments, name.Utf8Text(params.DocumentContent), 0
+		}
+
+		return positionalArgument(arguments, argument.StartByte())
+	}
+
+	arguments := callArguments(params)
+	if arguments == nil {
+		return nil, "", 0
+	}
+
+	offset := node.EndByte()
+	if node.Kind() == ")" {
+		offset = node.StartByte()
+	}
+
+	// The parser puts the ":" of a named argument without value into an error node after the name
+	var last, beforeLast *tree_sitter.Node
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		if child := arguments.NamedChild(i); child.StartByte() < offset {
+			beforeLast, last = last, child
+		}
+	}
+	if last != nil && beforeLast != nil && last.Kind() == "ERROR" && last.Utf8Text(params.DocumentContent) == ":" &&
+		beforeLast.Kind() == "argument" && beforeLast.NamedChildCount() == 1 && beforeLast.NamedChild(0).Kind() == "name" {
+		return arguments, beforeLast.NamedChild(0).Utf8Text(params.DocumentContent), 0
+	}
+
+	return positionalArgument(arguments, offset)
+}
+
+// positionalArgument returns the position of the argument starting at the offset, positional arguments
+// can't follow named ones
+func positionalArgument(arguments *tree_sitter.Node, offset uint) (*tree_sitter.Node, string, int) {
+	position := 0
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		argument := arguments.NamedChild(i)
+		if argument.StartByte() >= offset || argument.Kind() != "argument" {
+			continue
+		}
+		if argument.ChildByFieldName("name") != nil {
+			return nil, "", 0
+		}
+		position++
+	}
+
+	return arguments, "", position
+}
+
+// enumOfType returns the enum of the type, nullable types are unions with null
+func (p *PHPCompletionProvider) enumOfType(phpType php.PHPType) *php.PHPClass {
+	if phpType == nil {
+		return nil
+	}
+
+	for _, typeName := range strings.Split(strings.TrimPrefix(phpType.Name(), "?"), "|") {
+		if class := p.phpIndex.GetClass(strings.TrimPrefix(typeName, "\\")); class != nil && class.IsEnum {
+			return class
+		}
+	}
+
+	return nil
+}
+
+// assignmentTarget returns the left side of the assignment the node is the value of. While the value is
+// missing or the statement is not terminated, the parser puts the "=" into an error node.
+func assignmentTarget(node *tree_sitter.Node) *tree_sitter.Node {
+	var assign *tree_sitter.Node
+
+	switch node.Kind() {
+	case "name":
+		if parent := node.Parent(); parent.Kind() == "assignment_expression" {
+			if right := parent.ChildByFieldName("right"); right != nil && right.Id() == node.Id() {
+				return parent.ChildByFieldName("left")
+			}
+			return nil
+		}
+		assign = node.PrevSibling()
+	case "=":
+		assign = node
+	case ";":
+		// $this->state = <caret>;
+		if prev := node.PrevSibling(); prev != nil && prev.Kind() == "ERROR" && prev.ChildCount() > 0 {
+			assign = prev.Child(prev.ChildCount() - 1)
+		}
+	}
+
+	if assign == nil || assign.Kind() != "=" {
+		return nil
+	}
+
+	if target := assign.PrevSibling(); target != nil {
+		return target
+	}
+
+	if parent := assign.Parent(); parent.Kind() == "ERROR" {
+		return parent.PrevSibling()
+	}
+
+	return nil
+}
+
+// enumCaseCompletions offers the cases of the enum as EnumName::Case, importing the enum if needed
+func (p *PHPCompletionProvider) enumCaseCompletions(params *protocol.CompletionParams, enum *php.PHPClass) []protocol.CompletionItem {
+	rootNode := params.Node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+	imports := php.ParseFileImports(rootNode, params.DocumentContent)
+
+	var completionItems []protocol.CompletionItem
+	for _, enumCase := range enum.Cases() {
+		item := p.classCompletionItem(enum.Name, rootNode, imports)
+		item.Label += "::" + enumCase.Name
+		item.FilterText = item.Label
+		item.InsertText += "::" + enumCase.Name
+		item.Kind = int(protocol.EnumMemberCompletion)
+		item.Detail = enumCase.Value
+
+		completionItems = append(completionItems, item)
+	}
+
+	return completionItems
+}
+
+// callArguments returns the arguments of the call when the cursor is at the start of an argument,
+// either right after "(" or "," or within a name typed as argument
+func callArguments(params *protocol.CompletionParams) *tree_sitter.Node {
+	node := params.Node
+	parent := node.Parent()
+	if parent == nil {
+		return nil
+	}
+
+	switch node.Kind() {
+	case "(", ",":
+		if parent.Kind() == "arguments" {
+			return parent
+		}
+	case ")":
+		// The node ends at the cursor after the call
+		start := node.StartPosition()
+		if parent.Kind() == "arguments" && int(start.Row) == params.Position.Line && int(start.Column) == params.Position.Character {
+			return parent
+		}
+	case "name":
+		arguments := parent.Parent()
+		if parent.Kind() == "argument" && parent.ChildByFieldName("name") == nil && parent.NamedChildCount() == 1 && arguments != nil && arguments.Kind() == "arguments" {
+			return arguments
+		}
+	}
+
+	return nil
+}
+
+// namedArgumentCompletions offers the parameters of the called method as named arguments,
+// skipping the ones already supplied positionally or by name
+func (p *PHPCompletionProvider) namedArgumentCompletions(params *protocol.CompletionParams, arguments *tree_sitter.Node) []protocol.CompletionItem {
+	method, class := p.phpIndex.ResolveCalledMethod(arguments.Parent(), params.DocumentContent)
+	if method == nil {
+		return nil
+	}
+
+	positional := 0
+	named := make(map[string]bool)
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		argument := arguments.NamedChild(i)
+		// The name typed at the cursor is not an argument yet
+		if argument.Kind() != "argument" || argument.Id() == params.Node.Parent().Id() {
+			continue
+		}
+
+		if name := argument.ChildByFieldName("name"); name != nil {
+			named[name.Utf8Text(params.DocumentContent)] = true
+		} else {
+			positional++
+		}
+	}
+
+	labels := make([]string, 0, len(method.Parameters))
+	for _, parameter := range method.Parameters {
+		labels = append(labels, parameter.Label())
+	}
+	signature := "```php\n" + method.Name + "(" + strings.Join(labels, ", ") + ")\n```\n\n" + class.Name
+
+	var completionItems []protocol.CompletionItem
+	for i, parameter := range method.Parameters {
+		// Variadic parameters collect the remaining arguments and can't be named
+		if i < positional || named[parameter.Name] || parameter.IsVariadic {
+			continue
+		}
+
+		item := protocol.CompletionItem{
+			Label:      parameter.Name + ":",
+			Kind:       int(protocol.VariableCompletion),
+			Detail:     parameter.Label(),
+			InsertText: parameter.Name + ": ",
+			// Keep the named arguments in the order of the parameters
+			SortText: fmt.Sprintf("%03d", i),
+		}
+
+		item.Documentation.Kind = "markdown"
+		item.Documentation.Value = signature
+
+		completionItems = append(completionItems, item)
+	}
+
+	return completionItems
+}
+
+// staticMemberCompletions offers the class keyword, constants and static methods of the class left of "::"
+func (p *PHPCompletionProvider) staticMemberCompletions(params *protocol.CompletionParams) []protocol.CompletionItem {
+	scopeNode := treesitterhelper.GetPHPStaticAccessScope(params.Node)
+	className := p.phpIndex.ResolveScopeClass(scopeNode, params.DocumentContent)
+	if className == "" || p.phpIndex.GetClass(className) == nil {
+		return nil
+	}
+
+	currentClass := treesitterhelper.GetClassName(params.Node, params.DocumentContent)
+	// parent::method() calls the overridden instance method, so those are offered as well
+	withInstanceMethods := scopeNode.Utf8Text(params.DocumentContent) == "parent"
+	insideCall := params.Node.Parent().Kind() == "scoped_call_expression"
+
+	constants := make(map[string]protocol.CompletionItem)
+	methods := make(map[string]protocol.CompletionItem)
+
+	// Classes are visited from the class to its parents, so overriding members win
+	p.phpIndex.WalkHierarchy(className, func(class *php.PHPClass) bool {
+		for name, constant := range class.Constants {
+			if _, ok := constants[name]; ok || !p.phpIndex.IsAccessible(constant.Visibility, class.Name, currentClass) {
+				continue
+			}
+
+			constants[name] = constantCompletionItem(constant, class.Name)
+		}
+
+		for name, method := range class.Methods {
+			if _, ok := methods[name]; ok || (!method.IsStatic && !withInstanceMethods) {
+				continue
+			}
+			if !p.phpIndex.IsAccessible(method.Visibility, class.Name, currentClass) {
+				continue
+			}
+
+			methods[name] = methodCompletionItem(method, class.Name, insideCall)
+		}
+
+		return true
+	})
+
+	var completionItems []protocol.CompletionItem
+	// Only methods can be called, Uuid::<caret>() has no use for constants
+	if !insideCall {
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:  "class",
+			Kind:   int(protocol.KeywordCompletion),
+			Detail: className,
+		})
+		completionItems = append(completionItems, sortedCompletionItems(constants)...)
+	}
+	completionItems = append(completionItems, sortedCompletionItems(methods)...)
+
+	return completionItems
+}
+
+// memberCompletions offers the properties and instance methods of the object left of "->",
+// the type of variables is taken from the assignments in the enclosing function
+func (p *PHPCompletionProvider) memberCompletions(params *protocol.CompletionParams) []protocol.CompletionItem {
+	objectNode := treesitterhelper.GetPHPMemberAccessObject(params.Node)
+	className := php.ClassNameOfType(p.phpIndex.TypeOfExpression(objectNode, params.DocumentContent))
+	if className == "" || p.phpIndex.GetClass(className) == nil {
+		return nil
+	}
+
+	currentClass := treesitterhelper.GetClassName(params.Node, params.DocumentContent)
+	parentKind := params.Node.Parent().Kind()
+	insideCall := parentKind == "member_call_expression" || parentKind == "nullsafe_member_call_expression"
+
+	properties := make(map[string]protocol.CompletionItem)
+	methods := make(map[string]protocol.CompletionItem)
+
+	// Classes are visited from the class to its parents, so overriding members win
+	p.phpIndex.WalkHierarchy(className, func(class *php.PHPClass) bool {
+		for name, property := range class.Properties {
+			if _, ok := properties[name]; ok || !p.phpIndex.IsAccessible(property.Visibility, class.Name, currentClass) {
+				continue
+			}
+
+			properties[name] = propertyCompletionItem(property, class.Name)
+		}
+
+		for name, method := range class.Methods {
+			if _, ok := methods[name]; ok || method.IsStatic || name == "__construct" {
+				continue
+			}
+			if !p.phpIndex.IsAccessible(method.Visibility, class.Name, currentClass) {
+				continue
+			}
+
+			methods[name] = methodCompletionItem(method, class.Name, insideCall)
+		}
+
+		return true
+	})
+
+	var completionItems []protocol.CompletionItem
+	// $product->getName<caret>() can only be completed with methods
+	if !insideCall {
+		completionItems = append(completionItems, sortedCompletionItems(properties)...)
+	}
+	completionItems = append(completionItems, sortedCompletionItems(methods)...)
+
+	return completionItems
+}
+
+func propertyCompletionItem(property php.PHPProperty, className string) protocol.CompletionItem {
+	declaration := property.Visibility.String() + " $" + property.Name
+	if property.Type != nil {
+		declaration = property.Visibility.String() + " " + property.Type.Name() + " $" + property.Name
+	}
+
+	item := protocol.CompletionItem{
+		Label:  property.Name,
+		Kind:   int(protocol.PropertyCompletion),
+		Detail: declaration,
+	}
+
+	item.Documentation.Kind = "markdown"
+	item.Documentation.Value = "```php\n" + declaration + "\n```\n\n" + className
+
+	return item
+}
+
+func constantCompletionItem(constant php.PHPConstant, className string) protocol.CompletionItem {
+	item := protocol.CompletionItem{
+		Label:  constant.Name,
+		Kind:   int(protocol.ConstantCompletion),
+		Detail: constant.Value,
+	}
+
+	declaration := constant.Visibility.String() + " const " + constant.Name
+	if constant.IsEnumCase {
+		item.Kind = int(protocol.EnumMemberCompletion)
+		declaration = "case " + constant.Name
+	}
+
+	item.Documentation.Kind = "markdown"
+	item.Documentation.Value = "```php\n" + declaration + "\n```\n\n" + className
+
+	return item
+}
+
+func methodCompletionItem(method php.PHPMethod, className string, insideCall bool) protocol.CompletionItem {
+	declaration := method.Modifiers() + " function " + method.Name + "()"
+	if method.ReturnType != nil {
+		declaration += ": " + method.ReturnType.Name()
+	}
+
+	item := protocol.CompletionItem{
+		Label:  method.Name,
+		Kind:   int(protocol.MethodCompletion),
+		Detail: declaration,
+	}
+
+	// Uuid::<caret>randomHex() already has its arguments
+	if !insideCall {
+		item.InsertText = method.Name + "($0)"
+		item.InsertTextFormat = int(protocol.SnippetTextFormat)
+	}
+
+	item.Documentation.Kind = "markdown"
+	item.Documentation.Value = "```php\n" + declaration + "\n```\n\n" + className
+
+	return item
+}
+
+func sortedCompletionItems(items map[string]protocol.CompletionItem) []protocol.CompletionItem {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, items[name])
+	}
+
+	return sorted
+}
+
+func (p *PHPCompletionProvider) GetTriggerCharacters() []string {
+	return []string{":", "[", ">"}
+}
@@ -0,0 +1,751 @@
+package completion
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestPHPCompletionProvider_StaticMembers(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	library := []byte(`<?php
+
+namespace Shopware\Core\Checkout;
+
+class BaseEvents
+{
+    public const ORDER_PLACED = 'checkout.order.placed';
+    protected const INTERNAL = 'internal';
+    private const SECRET = 'secret';
+
+    public function __construct()
+    {
+    }
+
+    public static function create(): static
+    {
+    }
+
+    protected static function internal(): void
+    {
+    }
+
+    private static function secret(): void
+    {
+    }
+}
+
+class CheckoutEvents extends BaseEvents
+{
+    public const CART_LOADED = 'checkout.cart.loaded';
+    private const PRIORITY = 10;
+
+    public function priority(): int
+    {
+        return self::PRIORITY;
+    }
+}
+
+class OtherEvents extends BaseEvents
+{
+}
+
+enum OrderState: string
+{
+    case Open = 'open';
+    case Done = 'done';
+}
+`)
+	libraryPath := filepath.Join(t.TempDir(), "CheckoutEvents.php")
+	libraryTree := parser.Parse(library, nil)
+	defer libraryTree.Close()
+	require.NoError(t, phpIndex.Index(libraryPath, libraryTree.RootNode(), library))
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "public members including inherited ones",
+			code:     "<?php\nnamespace App;\nuse Shopware\\Core\\Checkout\\CheckoutEvents;\nclass Foo { function a() { $a = CheckoutEvents::<caret>; } }",
+			expected: []string{"class", "CART_LOADED", "ORDER_PLACED", "create"},
+		},
+		{
+			name:     "partially typed member of aliased class",
+			code:     "<?php\nnamespace App;\nuse Shopware\\Core\\Checkout\\CheckoutEvents as Events;\nclass Foo { function a() { foo(Events::CA<caret>); } }",
+			expected: []string{"class", "CART_LOADED", "ORDER_PLACED", "create"},
+		},
+		{
+			name:     "static call only offers methods",
+			code:     "<?php\nnamespace App;\nuse Shopware\\Core\\Checkout\\CheckoutEvents;\nCheckoutEvents::cr<caret>();",
+			expected: []string{"create"},
+		},
+		{
+			name:     "enum cases",
+			code:     "<?php\nnamespace Shopware\\Core\\Checkout;\nclass Foo { function a() { $a = OrderState::<caret>; } }",
+			expected: []string{"class", "Done", "Open"},
+		},
+		{
+			name:     "self scope includes non public members",
+			code:     "<?php\nnamespace Shopware\\Core\\Checkout;\nclass CheckoutEvents extends BaseEvents { function a() { return self::<caret>; } }",
+			expected: []string{"class", "CART_LOADED", "INTERNAL", "ORDER_PLACED", "PRIORITY", "create", "internal"},
+		},
+		{
+			name:     "parent scope includes instance methods",
+			code:     "<?php\nnamespace Shopware\\Core\\Checkout;\nclass CheckoutEvents extends BaseEvents { function a() { return parent::<caret>; } }",
+			expected: []string{"class", "INTERNAL", "ORDER_PLACED", "__construct", "create", "internal"},
+		},
+		{
+			name:     "protected members from another class of the hierarchy",
+			code:     "<?php\nnamespace Shopware\\Core\\Checkout;\nclass OtherEvents extends BaseEvents { function a() { return CheckoutEvents::<caret>; } }",
+			expected: []string{"class", "CART_LOADED", "INTERNAL", "ORDER_PLACED", "create", "internal"},
+		},
+		{
+			name: "unknown class",
+			code: "<?php\nnamespace App;\nclass Foo { function a() { $a = Unknown::<caret>; } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var labels []string
+			for _, item := range phpCompletions(t, parser, provider, tt.code) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+
+	t.Run("item details", func(t *testing.T) {
+		items := phpCompletions(t, parser, provider, "<?php\nnamespace Shopware\\Core\\Checkout;\n$a = OrderState::<caret>;")
+		require.Len(t, items, 3)
+		assert.Equal(t, int(protocol.KeywordCompletion), items[0].Kind)
+		assert.Equal(t, int(protocol.EnumMemberCompletion), items[2].Kind)
+		assert.Equal(t, "open", items[2].Detail)
+		assert.True(t, strings.Contains(items[2].Documentation.Value, "case Open"))
+
+		items = phpCompletions(t, parser, provider, "<?php\nnamespace Shopware\\Core\\Checkout;\n$a = CheckoutEvents::<caret>;")
+		create := items[len(items)-1]
+		assert.Equal(t, int(protocol.MethodCompletion), create.Kind)
+		assert.Equal(t, "public static function create(): static", create.Detail)
+		assert.Equal(t, "create($0)", create.InsertText)
+
+		items = phpCompletions(t, parser, provider, "<?php\nnamespace Shopware\\Core\\Checkout;\nCheckoutEvents::<caret>create();")
+		require.Len(t, items, 1)
+		assert.Empty(t, items[0].InsertText)
+	})
+}
+
+func phpCompletions(t *testing.T, parser *tree_sitter.Parser, provider *PHPCompletionProvider, code string) []protocol.CompletionItem {
+	offset := strings.Index(code, "<caret>")
+	content := []byte(strings.Replace(code, "<caret>", "", 1))
+	tree := parser.Parse(content, nil)
+	t.Cleanup(tree.Close)
+
+	lines := strings.Split(string(content[:offset]), "\n")
+
+	params := &protocol.CompletionParams{
+		Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+		DocumentContent: content,
+	}
+	params.TextDocument.URI = "file:///project/src/Foo.php"
+	params.Position = protocol.Position{Line: len(lines) - 1, Character: len(lines[len(lines)-1])}
+
+	return provider.GetCompletions(context.Background(), params)
+}
+
+func TestPHPCompletionProvider_ClassNames(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	library := []byte(`<?php
+
+namespace Shopware\Core\Framework\DataAbstractionLayer\Search;
+
+class Criteria
+{
+}
+
+interface CriteriaAware
+{
+}
+`)
+	other := []byte("<?php\n\nnamespace App\\Search;\n\nclass Criteria\n{\n}\n\nclass CriteriaBuilder\n{\n}\n")
+
+	for path, content := range map[string][]byte{"/project/vendor/Criteria.php": library, "/project/src/Search/Criteria.php": other} {
+		tree := parser.Parse(content, nil)
+		require.NoError(t, phpIndex.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	complete := func(code string) ([]protocol.CompletionItem, *protocol.CompletionParams) {
+		offset := strings.Index(code, "<caret>")
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := parser.Parse(content, nil)
+		t.Cleanup(tree.Close)
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Foo.php"
+		params.Position.Line = strings.Count(code[:offset], "\n")
+		params.Position.Character = offset - strings.LastIndex(code[:offset], "\n") - 1
+
+		return provider.GetCompletions(context.Background(), params), params
+	}
+
+	byDetail := func(items []protocol.CompletionItem) map[string]protocol.CompletionItem {
+		result := make(map[string]protocol.CompletionItem)
+		for _, item := range items {
+			result[item.Detail] = item
+		}
+		return result
+	}
+
+	t.Run("adds a use statement below the existing ones", func(t *testing.T) {
+		items, params := complete("<?php\n\nnamespace App\\Controller;\n\nuse Shopware\\Core\\Framework\\Context;\n\nclass Foo\n{\n    public function load(Context $context): void\n    {\n        $criteria = new Crit<caret>();\n    }\n}\n")
+		assert.True(t, provider.IsIncomplete(params))
+
+		found := byDetail(items)
+		require.Len(t, found, 4)
+
+		criteria := found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"]
+		assert.Equal(t, "Criteria", criteria.Label)
+		assert.Equal(t, int(protocol.ClassCompletion), criteria.Kind)
+		assert.Equal(t, "Criteria", criteria.InsertText)
+		require.Len(t, criteria.AdditionalTextEdits, 1)
+		edit := criteria.AdditionalTextEdits[0].(protocol.TextEdit)
+		assert.Equal(t, 5, edit.Range.Start.Line)
+		assert.Equal(t, 0, edit.Range.Start.Character)
+		assert.Equal(t, "use Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria;\n", edit.NewText)
+
+		assert.Equal(t, int(protocol.InterfaceCompletion), found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware"].Kind)
+	})
+
+	t.Run("adds a use statement below the namespace", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nclass Foo\n{\n    public function load(crit<caret> $criteria): void\n    {\n    }\n}\n")
+
+		criteria := byDetail(items)["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"]
+		require.Len(t, criteria.AdditionalTextEdits, 1)
+		edit := criteria.AdditionalTextEdits[0].(protocol.TextEdit)
+		assert.Equal(t, 3, edit.Range.Start.Line)
+		assert.Equal(t, "\nuse Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria;\n", edit.NewText)
+	})
+
+	t.Run("imported, aliased and same namespace classes need no use statement", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Search;\n\nuse Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware as Aware;\n\nclass Foo implements Crit<caret>\n{\n}\n")
+		found := byDetail(items)
+
+		assert.Equal(t, "Aware", found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware"].InsertText)
+		assert.Empty(t, found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware"].AdditionalTextEdits)
+
+		assert.Equal(t, "CriteriaBuilder", found["App\\Search\\CriteriaBuilder"].InsertText)
+		assert.Empty(t, found["App\\Search\\CriteriaBuilder"].AdditionalTextEdits)
+
+		assert.Equal(t, "Criteria", found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].InsertText)
+		assert.Len(t, found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].AdditionalTextEdits, 1)
+	})
+
+	t.Run("group imports need no use statement", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nuse Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\{Criteria, CriteriaAware as Aware};\n\nclass Foo implements Crit<caret>\n{\n}\n")
+		found := byDetail(items)
+
+		assert.Equal(t, "Criteria", found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].InsertText)
+		assert.Empty(t, found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].AdditionalTextEdits)
+		assert.Equal(t, "Aware", found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware"].InsertText)
+		assert.Empty(t, found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\CriteriaAware"].AdditionalTextEdits)
+
+		assert.Equal(t, "\\App\\Search\\Criteria", found["App\\Search\\Criteria"].InsertText)
+		assert.Empty(t, found["App\\Search\\Criteria"].AdditionalTextEdits)
+	})
+
+	t.Run("classes shadowed by an import are fully qualified", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nuse App\\Search\\Criteria;\n\nclass Foo\n{\n    public function load(): void\n    {\n        Crit<caret>\n    }\n}\n")
+		found := byDetail(items)
+
+		assert.Equal(t, "Criteria", found["App\\Search\\Criteria"].InsertText)
+		assert.Equal(t, "\\Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria", found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].InsertText)
+		assert.Empty(t, found["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria"].AdditionalTextEdits)
+	})
+
+	t.Run("no class completion in use statements and method calls", func(t *testing.T) {
+		items, params := complete("<?php\n\nnamespace App;\n\nuse Crit<caret>;\n")
+		assert.Empty(t, items)
+		assert.False(t, provider.IsIncomplete(params))
+
+		items, _ = complete("<?php\n\nnamespace App;\n\n$a->crit<caret>();\n")
+		assert.Empty(t, items)
+	})
+}
+
+func TestPHPCompletionProvider_Attributes(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	library := []byte(`<?php
+
+namespace App\Attribute;
+
+use Attribute;
+
+#[Attribute]
+final class Cached
+{
+}
+
+final class CacheWarmer
+{
+}
+`)
+	tree := parser.Parse(library, nil)
+	require.NoError(t, phpIndex.Index("/project/src/Attribute/Cached.php", tree.RootNode(), library))
+	tree.Close()
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	complete := func(code string) ([]protocol.CompletionItem, *protocol.CompletionParams) {
+		offset := strings.Index(code, "<caret>")
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := parser.Parse(content, nil)
+		t.Cleanup(tree.Close)
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Controller/ExampleController.php"
+		params.Position.Line = strings.Count(code[:offset], "\n")
+		params.Position.Character = offset - strings.LastIndex(code[:offset], "\n") - 1
+
+		return provider.GetCompletions(context.Background(), params), params
+	}
+
+	details := func(items []protocol.CompletionItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Detail)
+		}
+		return result
+	}
+
+	t.Run("offers attribute classes for the typed prefix", func(t *testing.T) {
+		items, params := complete("<?php\n\nnamespace App\\Controller;\n\nclass ExampleController\n{\n    #[Ca<caret>]\n    public function index(): void\n    {\n    }\n}\n")
+		assert.True(t, provider.IsIncomplete(params))
+
+		require.Len(t, items, 1)
+		assert.Equal(t, "Cached", items[0].Label)
+		assert.Equal(t, "App\\Attribute\\Cached", items[0].Detail)
+		require.Len(t, items[0].AdditionalTextEdits, 1)
+		assert.Equal(t, "use App\\Attribute\\Cached;\n", strings.TrimPrefix(items[0].AdditionalTextEdits[0].(protocol.TextEdit).NewText, "\n"))
+	})
+
+	t.Run("offers all attributes after the opening bracket", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nuse Symfony\\Component\\Routing\\Attribute\\Route;\n\n#[<caret>]\nclass ExampleController\n{\n}\n")
+		found := details(items)
+
+		assert.Contains(t, found, "App\\Attribute\\Cached")
+		assert.Contains(t, found, "Shopware\\Core\\Framework\\Log\\Package")
+		assert.Contains(t, found, "Override")
+		assert.NotContains(t, found, "App\\Attribute\\CacheWarmer")
+
+		for _, item := range items {
+			if item.Detail == "Symfony\\Component\\Routing\\Attribute\\Route" {
+				assert.Equal(t, "Route", item.InsertText)
+				assert.Empty(t, item.AdditionalTextEdits)
+			}
+		}
+	})
+
+	t.Run("offers attributes after a comma", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nclass ExampleController\n{\n    #[Route('/example'), <caret>]\n    public function index(): void\n    {\n    }\n}\n")
+		assert.Contains(t, details(items), "Shopware\\Core\\Framework\\Log\\Package")
+
+		items, _ = complete("<?php\n\nnamespace App\\Controller;\n\n#[Package('core'), AsEvent<caret>]\nclass ExampleController\n{\n}\n")
+		assert.Equal(t, []string{"Symfony\\Component\\EventDispatcher\\Attribute\\AsEventListener"}, details(items))
+	})
+
+	t.Run("no attribute completion in attribute arguments", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\n#[Package(Ca<caret>)]\nclass ExampleController\n{\n}\n")
+		assert.NotContains(t, details(items), "Symfony\\Component\\Routing\\Attribute\\Route")
+	})
+
+	labels := func(items []protocol.CompletionItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Label)
+		}
+		return result
+	}
+
+	t.Run("offers Shopware domains in the Package attribute", func(t *testing.T) {
+		for _, code := range []string{
+			"<?php\n\nnamespace App\\Controller;\n\n#[Package('<caret>')]\nclass ExampleController\n{\n}\n",
+			"<?php\n\nnamespace App\\Controller;\n\n#[Package('check<caret>')]\nclass ExampleController\n{\n}\n",
+			"<?php\n\nnamespace App\\Controller;\n\n#[\\Shopware\\Core\\Framework\\Log\\Package(\"<caret>\")]\nclass ExampleController\n{\n}\n",
+		} {
+			items, _ := complete(code)
+			found := labels(items)
+
+			assert.Contains(t, found, "checkout", code)
+			assert.Contains(t, found, "fundamentals@framework", code)
+			assert.Equal(t, int(protocol.EnumMemberCompletion), items[0].Kind)
+		}
+	})
+
+	t.Run("no domains in other attributes", func(t *testing.T) {
+		items, _ := complete("<?php\n\nnamespace App\\Controller;\n\nclass ExampleController\n{\n    #[Route('<caret>')]\n    public function index(): void\n    {\n    }\n}\n")
+		assert.NotContains(t, labels(items), "checkout")
+	})
+}
+
+func TestPHPCompletionProvider_EnumAssignments(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	for name, code := range map[string]string{
+		"OrderState.php": `<?php
+
+namespace Shopware\Core\Checkout\Order;
+
+enum OrderState: string
+{
+    case Open = 'open';
+    case InProgress = 'in_progress';
+    case Done = 'done';
+}
+`,
+		"Order.php": `<?php
+
+namespace App\Order;
+
+use Shopware\Core\Checkout\Order\OrderState;
+
+class Order
+{
+    private OrderState $state;
+    private ?OrderState $previousState = null;
+    private string $comment;
+
+    public function __construct(?OrderState $state = null)
+    {
+    }
+
+    public function transition(string $comment, OrderState ...$states): void
+    {
+    }
+}
+`,
+	} {
+		content := []byte(code)
+		tree := parser.Parse(content, nil)
+		require.NoError(t, phpIndex.Index(filepath.Join(t.TempDir(), name), tree.RootNode(), content))
+		tree.Close()
+	}
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	labels := func(items []protocol.CompletionItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Label)
+		}
+		return result
+	}
+
+	cases := []string{"OrderState::Open", "OrderState::InProgress", "OrderState::Done"}
+
+	prefix := "<?php\nnamespace App\\Order;\nuse Shopware\\Core\\Checkout\\Order\\OrderState;\nclass Order { function a() { "
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"$this->state = <caret>; } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"$this->state = Or<caret>; } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"$this->previousState = Or<caret> } }")))
+	assert.Empty(t, phpCompletions(t, parser, provider, prefix+"$this->comment = <caret>; } }"))
+
+	// Arguments of parameters typed as enum
+	assert.Equal(t, append(cases, "state:"), labels(phpCompletions(t, parser, provider, prefix+"new Order(<caret>); } }")))
+	assert.Equal(t, append(cases, "state:"), labels(phpCompletions(t, parser, provider, prefix+"new Order(Or<caret>); } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"new Order(state: <caret>); } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"new Order(state: Or<caret>); } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"$this->transition('shipped', <caret>); } }")))
+	assert.Equal(t, cases, labels(phpCompletions(t, parser, provider, prefix+"$this->transition('shipped', OrderState::Open, Or<caret>); } }")))
+	assert.Equal(t, []string{"comment:"}, labels(phpCompletions(t, parser, provider, prefix+"$this->transition(<caret>); } }")))
+
+	items := phpCompletions(t, parser, provider, prefix+"$this->state = <caret>; } }")
+	require.Len(t, items, 3)
+	assert.Equal(t, int(protocol.EnumMemberCompletion), items[0].Kind)
+	assert.Equal(t, "OrderState::Open", items[0].InsertText)
+	assert.Equal(t, "open", items[0].Detail)
+	assert.Empty(t, items[0].AdditionalTextEdits)
+
+	// The enum is imported when the file does not use it yet
+	items = phpCompletions(t, parser, provider, "<?php\nnamespace App\\Order;\nclass Order { function a() { $this->state = <caret>; } }")
+	require.Len(t, items, 3)
+	assert.Equal(t, "OrderState::Open", items[0].InsertText)
+	require.Len(t, items[0].AdditionalTextEdits, 1)
+	assert.Equal(t, "\nuse Shopware\\Core\\Checkout\\Order\\OrderState;\n", items[0].AdditionalTextEdits[0].(protocol.TextEdit).NewText)
+}
+
+func TestPHPCompletionProvider_NamedArguments(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	library := []byte(`<?php
+
+namespace App\Repository;
+
+class ProductRepository
+{
+    public function __construct(string $entityName, bool $versionAware = false)
+    {
+    }
+
+    public function search(string $term, int $limit = 10, bool $withVariants = false, string ...$fields): array
+    {
+    }
+}
+`)
+	tree := parser.Parse(library, nil)
+	require.NoError(t, phpIndex.Index("/project/src/Repository/ProductRepository.php", tree.RootNode(), library))
+	tree.Close()
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	prefix := "<?php\nnamespace App\\Repository;\nclass ProductRepository { function a() { "
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "empty arguments",
+			code:     "$this->search(<caret>); } }",
+			expected: []string{"term:", "limit:", "withVariants:"},
+		},
+		{
+			name:     "after positional argument",
+			code:     "$this->search('shirt', <caret>); } }",
+			expected: []string{"limit:", "withVariants:"},
+		},
+		{
+			name:     "after named argument",
+			code:     "$this->search('shirt', withVariants: true, <caret>); } }",
+			expected: []string{"limit:"},
+		},
+		{
+			name:     "partially typed name",
+			code:     "$this->search('shirt', wi<caret>); } }",
+			expected: []string{"limit:", "withVariants:"},
+		},
+		{
+			name:     "constructor",
+			code:     "new ProductRepository(<caret>); } }",
+			expected: []string{"entityName:", "versionAware:"},
+		},
+		{
+			name: "after the call",
+			code: "$this->search()<caret>; } }",
+		},
+		{
+			name: "argument value",
+			code: "$this->search($te<caret>); } }",
+		},
+		{
+			name: "unknown method",
+			code: "$this->unknown(<caret>); } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var labels []string
+			for _, item := range phpCompletions(t, parser, provider, prefix+tt.code) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+
+	items := phpCompletions(t, parser, provider, prefix+"$this->search(<caret>); } }")
+	require.Len(t, items, 3)
+	assert.Equal(t, "limit: ", items[1].InsertText)
+	assert.Equal(t, "int $limit = ...", items[1].Detail)
+	assert.Equal(t, int(protocol.VariableCompletion), items[1].Kind)
+}
+
+func TestPHPCompletionProvider_Members(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	library := []byte(`<?php
+
+namespace App\Entity;
+
+class Entity
+{
+    protected string $id;
+
+    public function getId(): string
+    {
+    }
+}
+
+class Product extends Entity
+{
+    public ?string $name;
+    private int $stock;
+
+    public function getName(): ?string
+    {
+    }
+
+    public function getManufacturer(): Manufacturer
+    {
+    }
+
+    public static function create(): static
+    {
+    }
+}
+
+class Manufacturer extends Entity
+{
+    public function getLink(): string
+    {
+    }
+}
+`)
+	tree := parser.Parse(library, nil)
+	require.NoError(t, phpIndex.Index("/project/src/Entity/Product.php", tree.RootNode(), library))
+	tree.Close()
+
+	provider := &PHPCompletionProvider{phpIndex: phpIndex}
+
+	prefix := "<?php\nnamespace App\\Service;\nuse App\\Entity\\Product;\nclass ProductService { function a(Product $product, ?Product $other, $untyped) { "
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "typed parameter",
+			code:     "$product-><caret>; } }",
+			expected: []string{"name", "getId", "getManufacturer", "getName"},
+		},
+		{
+			name:     "nullable parameter with nullsafe access",
+			code:     "$other?->getN<caret>; } }",
+			expected: []string{"name", "getId", "getManufacturer", "getName"},
+		},
+		{
+			name:     "variable assigned from new",
+			code:     "$created = new Product(); $created-><caret>; } }",
+			expected: []string{"name", "getId", "getManufacturer", "getName"},
+		},
+		{
+			name:     "variable assigned from method return type",
+			code:     "$manufacturer = $product->getManufacturer(); $manufacturer->get<caret>(); } }",
+			expected: []string{"getId", "getLink"},
+		},
+		{
+			name:     "variable assigned from static return type",
+			code:     "$created = Product::create(); $created->getName()->x; $created-><caret>; } }",
+			expected: []string{"name", "getId", "getManufacturer", "getName"},
+		},
+		{
+			name:     "method call chain",
+			code:     "$product->getManufacturer()-><caret>; } }",
+			expected: []string{"getId", "getLink"},
+		},
+		{
+			name:     "reassigned variable",
+			code:     "$product = $product->getManufacturer(); $product-><caret>; } }",
+			expected: []string{"getId", "getLink"},
+		},
+		{
+			name: "assignment after the cursor",
+			code: "$later-><caret>; $later = new Product(); } }",
+		},
+		{
+			name: "untyped parameter",
+			code: "$untyped-><caret>; } }",
+		},
+		{
+			name: "assigned from unknown expression",
+			code: "$value = foo(); $value-><caret>; } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var labels []string
+			for _, item := range phpCompletions(t, parser, provider, prefix+tt.code) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+
+	t.Run("variables of closures are not visible outside", func(t *testing.T) {
+		items := phpCompletions(t, parser, provider, prefix+"$fn = function () { $inner = new Product(); }; $inner-><caret>; } }")
+		assert.Empty(t, items)
+	})
+
+	t.Run("protected members within the hierarchy", func(t *testing.T) {
+		var labels []string
+		for _, item := range phpCompletions(t, parser, provider, "<?php\nnamespace App\\Entity;\nclass Manufacturer extends Entity { function a() { $this-><caret>; } }") {
+			labels = append(labels, item.Label)
+		}
+
+		assert.Equal(t, []string{"id", "getId", "getLink"}, labels)
+	})
+}
@@ -2,9 +2,8 @@ package completion
 
 import (
 	"context"
-	"path/filepath"
-	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/symfony"
@@ -22,12 +21,16 @@ func NewRouteCompletionProvider(server *lsp.Server) *RouteCompletionProvider {
 	}
 }
 
+func (p *RouteCompletionProvider) ID() string {
+	return "completion.route"
+}
+
 func (p *RouteCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".php":
 		return p.phpCompletions(ctx, params)
 	case ".twig":
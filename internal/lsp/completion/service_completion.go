@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
@@ -32,13 +34,17 @@ func NewServiceCompletionProvider(server *lsp.Server) *SymfonyCompletionProvider
 	}
 }
 
+func (p *SymfonyCompletionProvider) ID() string {
+	return "completion.service"
+}
+
 // GetCompletions returns completion items based on the provider type
 func (p *SymfonyCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	fileExt := strings.ToLower(filepath.Ext(params.TextDocument.URI))
+	fileExt := indexer.FileType(params.TextDocument.URI)
 
 	switch fileExt {
 	case ".yaml", ".yml":
@@ -54,7 +60,7 @@ func (p *SymfonyCompletionProvider) xmlCompletion(ctx context.Context, params *p
 
 	// Check if we're in an XML file
 	uri := params.TextDocument.URI
-	if !strings.HasSuffix(strings.ToLower(uri), ".xml") {
+	if indexer.FileType(uri) != ".xml" {
 		return []protocol.CompletionItem{}
 	}
 
@@ -109,6 +115,21 @@ func (p *SymfonyCompletionProvider) xmlCompletion(ctx context.Context, params *p
 		return items
 	}
 
+	// <argument type="<caret>"/>
+	if treesitterhelper.SymfonyServiceIsArgumentType(params.Node, params.DocumentContent) {
+		items := make([]protocol.CompletionItem, 0, len(argumentTypes))
+		for _, argumentType := range argumentTypes {
+			item := protocol.CompletionItem{
+				Label: argumentType.name,
+				Kind:  int(protocol.EnumMemberCompletion),
+			}
+			item.Documentation.Kind = "markdown"
+			item.Documentation.Value = argumentType.description
+			items = append(items, item)
+		}
+		return items
+	}
+
 	// <argument type="tagged" tag="<caret>"/>
 	if treesitterhelper.SymfonyServiceIsArgumentTag(params.Node, params.DocumentContent) {
 		items := make([]protocol.CompletionItem, 0)
@@ -161,6 +182,41 @@ func (p *SymfonyCompletionProvider) xmlCompletion(ctx context.Context, params *p
 		return items
 	}
 
+	// <service id="foo" decorates="<caret>"> or <service id="foo" parent="<caret>">
+	if treesitterhelper.SymfonyServiceIsServiceReferenceAttribute(params.Node, params.DocumentContent) {
+		currentServiceId := treesitterhelper.SymfonyGetCurrentServiceIdFromArgument(params.Node, params.DocumentContent)
+
+		items := make([]protocol.CompletionItem, 0)
+		for _, serviceID := range p.serviceIndex.GetAllServices() {
+			if serviceID == currentServiceId {
+				continue
+			}
+
+			item := protocol.CompletionItem{
+				Label: serviceID,
+				Kind:  6, // 6 = Class
+			}
+
+			if service, found := p.serviceIndex.GetServiceByID(serviceID); found {
+				item.Detail = service.Class
+			}
+
+			items = append(items, item)
+		}
+
+		return items
+	}
+
+	// <call method="<caret>"/>
+	if treesitterhelper.SymfonyServiceIsCallMethod(params.Node, params.DocumentContent) {
+		return p.callMethodCompletions(params)
+	}
+
+	// <service id="foo" class="<caret>">
+	if treesitterhelper.SymfonyServiceIsServiceClass(params.Node, params.DocumentContent) {
+		return serviceClassCompletionItems(p.phpIndex.GetClasses(), pluginSourceDir(strings.TrimPrefix(uri, "file://")))
+	}
+
 	// <service id="<caret>">
 	if treesitterhelper.SymfonyServiceIsServiceId(params.Node, params.DocumentContent) {
 		classNames := p.phpIndex.GetClassNames()
@@ -179,6 +235,99 @@ func (p *SymfonyCompletionProvider) xmlCompletion(ctx context.Context, params *p
 	return []protocol.CompletionItem{}
 }
 
+// callMethodCompletions offers the public methods of the service class, including inherited ones,
+// which the container can call after creating the service
+func (p *SymfonyCompletionProvider) callMethodCompletions(params *protocol.CompletionParams) []protocol.CompletionItem {
+	className := strings.TrimPrefix(treesitterhelper.SymfonyGetEnclosingServiceClass(params.Node, params.DocumentContent), "\\")
+	if className == "" || p.phpIndex.GetClass(className) == nil {
+		return []protocol.CompletionItem{}
+	}
+
+	methods := make(map[string]protocol.CompletionItem)
+
+	// Classes are visited from the class to its parents, so overriding methods win
+	p.phpIndex.WalkHierarchy(className, func(class *php.PHPClass) bool {
+		for name, method := range class.Methods {
+			if _, ok := methods[name]; ok || method.IsStatic || method.Visibility != php.Public || strings.HasPrefix(name, "__") {
+				continue
+			}
+
+			methods[name] = methodCompletionItem(method, class.Name, true)
+		}
+
+		return true
+	})
+
+	return sortedCompletionItems(methods)
+}
+
+// argumentTypes are the values of the type attribute of an argument in service XML
+var argumentTypes = []struct {
+	name        string
+	description string
+}{
+	{"service", "Reference to the service given in `id`"},
+	{"string", "The content is passed as string without resolving parameters like `%kernel.debug%` to their type"},
+	{"constant", "Value of the PHP constant given as content, e.g. `PHP_INT_MAX` or `App\\Foo::BAR`"},
+	{"collection", "Array built from the nested `<argument>` elements, use `key` on them for an associative array"},
+	{"tagged_iterator", "Iterable of all services tagged with the tag given in `tag`"},
+	{"tagged_locator", "Service locator of all services tagged with the tag given in `tag`"},
+	{"expression", "Result of the Symfony expression given as content"},
+	{"abstract", "Placeholder of an abstract service, which has to be replaced by a compiler pass"},
+	{"binary", "Base64 encoded binary string given as content"},
+	{"service_closure", "Closure returning the service given in `id`, so it is created lazily"},
+	{"service_locator", "Service locator of the nested `<argument type=\"service\">` elements"},
+	{"iterator", "Lazy iterable of the nested `<argument>` elements"},
+}
+
+// serviceClassCompletionItems builds class completions labeled with the short class name.
+// Classes located below preferredDir are sorted before all other classes.
+func serviceClassCompletionItems(classes map[string]php.PHPClass, preferredDir string) []protocol.CompletionItem {
+	items := make([]protocol.CompletionItem, 0, len(classes))
+	for _, class := range classes {
+		if class.IsInterface {
+			continue
+		}
+
+		fqcn := strings.TrimPrefix(class.Name, "\\")
+		shortName := fqcn
+		if idx := strings.LastIndex(fqcn, "\\"); idx != -1 {
+			shortName = fqcn[idx+1:]
+		}
+
+		rank := "1"
+		if preferredDir != "" && strings.HasPrefix(class.Path, preferredDir+string(filepath.Separator)) {
+			rank = "0"
+		}
+
+		items = append(items, protocol.CompletionItem{
+			Label:      shortName,
+			Kind:       7, // 7 = Class
+			Detail:     fqcn,
+			InsertText: fqcn,
+			FilterText: fqcn,
+			SortText:   rank + fqcn,
+		})
+	}
+
+	slices.SortFunc(items, func(a, b protocol.CompletionItem) int {
+		return strings.Compare(a.SortText, b.SortText)
+	})
+
+	return items
+}
+
+// pluginSourceDir returns the source directory of the plugin or bundle the
+// service file belongs to, e.g. custom/plugins/Foo/src for
+// custom/plugins/Foo/src/Resources/config/services.xml
+func pluginSourceDir(path string) string {
+	if idx := strings.LastIndex(path, string(filepath.Separator)+"Resources"+string(filepath.Separator)); idx != -1 {
+		return path[:idx]
+	}
+
+	return filepath.Dir(path)
+}
+
 func (p *SymfonyCompletionProvider) yamlCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if treesitterhelper.IsYamlServiceId(params.Node, params.DocumentContent) || treesitterhelper.IsYamlClassPropertyInServiceToType().Matches(params.Node, params.DocumentContent) {
 		classNames := p.phpIndex.GetClassNames()
@@ -0,0 +1,270 @@
+package completion
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func findAttValueNode(root *tree_sitter.Node, content []byte, attrValue string) *tree_sitter.Node {
+	var result *tree_sitter.Node
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		if node.Kind() == "AttValue" && treesitterhelper.GetNodeText(node, content) == attrValue {
+			result = node
+			return
+		}
+		for i := uint(0); i < node.ChildCount(); i++ {
+			visit(node.Child(i))
+			if result != nil {
+				return
+			}
+		}
+	}
+	visit(root)
+	return result
+}
+
+func TestSymfonyServiceIsServiceClass(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	content := []byte(`<container><services>
+<service id="foo" class="App\Foo"><argument type="service" id="bar"/></service>
+</services></container>`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	classNode := findAttValueNode(tree.RootNode(), content, `App\Foo`)
+	require.NotNil(t, classNode)
+	assert.True(t, treesitterhelper.SymfonyServiceIsServiceClass(classNode, content))
+
+	idNode := findAttValueNode(tree.RootNode(), content, "foo")
+	require.NotNil(t, idNode)
+	assert.False(t, treesitterhelper.SymfonyServiceIsServiceClass(idNode, content))
+
+	argumentNode := findAttValueNode(tree.RootNode(), content, "bar")
+	require.NotNil(t, argumentNode)
+	assert.False(t, treesitterhelper.SymfonyServiceIsServiceClass(argumentNode, content))
+}
+
+func TestServiceClassCompletionItems(t *testing.T) {
+	pluginSrc := filepath.Join("project", "custom", "plugins", "MyPlugin", "src")
+	classes := map[string]php.PHPClass{
+		`Shopware\Core\Framework\Foo`: {Name: `Shopware\Core\Framework\Foo`, Path: filepath.Join("project", "vendor", "Foo.php")},
+		`MyPlugin\Service\Bar`:        {Name: `MyPlugin\Service\Bar`, Path: filepath.Join(pluginSrc, "Service", "Bar.php")},
+		`MyPlugin\Service\BarInterface`: {
+			Name:        `MyPlugin\Service\BarInterface`,
+			Path:        filepath.Join(pluginSrc, "Service", "BarInterface.php"),
+			IsInterface: true,
+		},
+	}
+
+	items := serviceClassCompletionItems(classes, pluginSrc)
+	require.Len(t, items, 2)
+
+	assert.Equal(t, "Bar", items[0].Label)
+	assert.Equal(t, `MyPlugin\Service\Bar`, items[0].Detail)
+	assert.Equal(t, `MyPlugin\Service\Bar`, items[0].InsertText)
+	assert.Equal(t, `MyPlugin\Service\Bar`, items[0].FilterText)
+
+	assert.Equal(t, "Foo", items[1].Label)
+	assert.Equal(t, `Shopware\Core\Framework\Foo`, items[1].InsertText)
+	assert.True(t, strings.Compare(items[0].SortText, items[1].SortText) < 0)
+}
+
+func TestPluginSourceDir(t *testing.T) {
+	pluginSrc := filepath.Join("project", "custom", "plugins", "MyPlugin", "src")
+
+	assert.Equal(t, pluginSrc, pluginSourceDir(filepath.Join(pluginSrc, "Resources", "config", "services.xml")))
+	assert.Equal(t, filepath.Join("project", "config"), pluginSourceDir(filepath.Join("project", "config", "services.xml")))
+}
+
+func TestServiceCompletion_DecoratesAndParent(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	servicesXml := []byte(`<container><services>
+<service id="original.service" class="App\Original"/>
+<service id="other.service" class="App\Other"/>
+</services></container>`)
+	servicesTree := parser.Parse(servicesXml, nil)
+	defer servicesTree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", servicesTree.RootNode(), servicesXml))
+
+	provider := &SymfonyCompletionProvider{serviceIndex: serviceIndex}
+
+	for _, attr := range []string{"decorates", "parent"} {
+		t.Run(attr, func(t *testing.T) {
+			content := []byte(`<container><services>
+<service id="my.decorator" ` + attr + `="original.service"/>
+</services></container>`)
+			tree := parser.Parse(content, nil)
+			defer tree.Close()
+
+			node := findAttValueNode(tree.RootNode(), content, "original.service")
+			require.NotNil(t, node)
+			assert.True(t, treesitterhelper.SymfonyServiceIsServiceReferenceAttribute(node, content))
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/decorators.xml"
+
+			items := provider.xmlCompletion(context.Background(), params)
+
+			details := make(map[string]string)
+			for _, item := range items {
+				details[item.Label] = item.Detail
+			}
+
+			assert.Equal(t, `App\Original`, details["original.service"])
+			assert.Equal(t, `App\Other`, details["other.service"])
+			assert.NotContains(t, details, "my.decorator")
+		})
+	}
+}
+
+func TestServiceCompletion_ArgumentTypeAndTag(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	servicesXml := []byte(`<container><services>
+<service id="App\Handler"><tag name="app.handler"/></service>
+</services></container>`)
+	servicesTree := parser.Parse(servicesXml, nil)
+	defer servicesTree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", servicesTree.RootNode(), servicesXml))
+
+	provider := &SymfonyCompletionProvider{serviceIndex: serviceIndex}
+
+	content := []byte(`<container><services>
+<service id="App\Registry">
+    <argument type="tagged_iterator" tag="app"/>
+</service>
+</services></container>`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	complete := func(node *tree_sitter.Node) []string {
+		params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+		params.TextDocument.URI = "file:///project/services.xml"
+
+		var labels []string
+		for _, item := range provider.xmlCompletion(context.Background(), params) {
+			labels = append(labels, item.Label)
+		}
+		return labels
+	}
+
+	typeNode := findAttValueNode(tree.RootNode(), content, "tagged_iterator")
+	require.NotNil(t, typeNode)
+	types := complete(typeNode)
+	assert.Contains(t, types, "service")
+	assert.Contains(t, types, "tagged_iterator")
+	assert.Contains(t, types, "constant")
+
+	tagNode := findAttValueNode(tree.RootNode(), content, "app")
+	require.NotNil(t, tagNode)
+	assert.Equal(t, []string{"app.handler"}, complete(tagNode))
+}
+
+func TestServiceCompletion_CallMethod(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpContent := []byte(`<?php
+
+namespace App;
+
+abstract class AbstractMailer
+{
+    public function setLogger(Logger $logger): void {}
+    protected function log(): void {}
+}
+
+class Mailer extends AbstractMailer
+{
+    public function __construct() {}
+    public function setTransport(Transport $transport): void {}
+    public static function create(): self {}
+    private function send(): void {}
+}
+`)
+	phpTree := phpParser.Parse(phpContent, nil)
+	defer phpTree.Close()
+	require.NoError(t, phpIndex.Index("/project/src/Mailer.php", phpTree.RootNode(), phpContent))
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	provider := &SymfonyCompletionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name     string
+		xml      string
+		expected []string
+	}{
+		{
+			name:     "service class",
+			xml:      `<container><services><service id="mailer" class="App\Mailer"><call method="set"/></service></services></container>`,
+			expected: []string{"setLogger", "setTransport"},
+		},
+		{
+			name:     "service id as class",
+			xml:      `<container><services><service id="App\Mailer"><call method="set"/></service></services></container>`,
+			expected: []string{"setLogger", "setTransport"},
+		},
+		{
+			name: "unknown class",
+			xml:  `<container><services><service id="App\Unknown"><call method="set"/></service></services></container>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.xml)
+			tree := xmlParser.Parse(content, nil)
+			defer tree.Close()
+
+			node := findAttValueNode(tree.RootNode(), content, "set")
+			require.NotNil(t, node)
+
+			params := &protocol.CompletionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/services.xml"
+
+			var labels []string
+			for _, item := range provider.xmlCompletion(context.Background(), params) {
+				labels = append(labels, item.Label)
+			}
+
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
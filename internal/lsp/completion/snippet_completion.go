@@ -2,9 +2,8 @@ package completion
 
 import (
 	"context"
-	"path/filepath"
-	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/snippet"
@@ -23,12 +22,16 @@ func NewSnippetCompletionProvider(lsp *lsp.Server) *SnippetCompletionProvider {
 	}
 }
 
+func (s *SnippetCompletionProvider) ID() string {
+	return "completion.snippet"
+}
+
 func (s *SnippetCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".twig":
 		return s.twigCompletion(ctx, params)
 	case ".php":
@@ -41,11 +44,16 @@ func (s *SnippetCompletionProvider) GetCompletions(ctx context.Context, params *
 }
 
 func (s *SnippetCompletionProvider) twigCompletion(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
-	// Check for frontend snippet pattern: {{ 'key'|trans }}
+	// Check for frontend snippet pattern: {{ 'key'|trans }} or {{ 'key'|trans|sw_sanitize }}
 	if treesitterhelper.TwigTransPattern().Matches(params.Node, params.DocumentContent) {
 		return s.getFrontendSnippetCompletions()
 	}
 
+	// Check for frontend snippet in a trans tag: {% trans %}key{% endtrans %}
+	if treesitterhelper.TwigTransTagContentPattern().Matches(params.Node, params.DocumentContent) {
+		return s.getFrontendSnippetCompletions()
+	}
+
 	// Check for admin snippet pattern: {{ $tc('key') }} or {{ $t('key') }}
 	if treesitterhelper.TwigAdminSnippetPattern().Matches(params.Node, params.DocumentContent) {
 		return s.getAdminSnippetCompletions()
@@ -50,6 +50,31 @@ func TestSnippetCompletionPatternMatching(t *testing.T) {
 			expectFrontend: true,
 			expectAdmin:    false,
 		},
+		{
+			name:           "trans filter followed by sw_sanitize",
+			code:           `{{ 'snippet.key'|trans|sw_sanitize }}`,
+			expectFrontend: true,
+		},
+		{
+			name:           "trans filter with parameters followed by sw_sanitize",
+			code:           `{{ 'snippet.key'|trans({'%name%': name})|sw_sanitize }}`,
+			expectFrontend: true,
+		},
+		{
+			name:           "filter chained before trans",
+			code:           `{{ 'snippet.key'|lower|trans }}`,
+			expectFrontend: true,
+		},
+		{
+			name:           "empty string with trans filter",
+			code:           `{{ ''|trans }}`,
+			expectFrontend: true,
+		},
+		{
+			name:           "trans parameter key is no snippet",
+			code:           `{{ key|trans({'%name%': name}) }}`,
+			expectFrontend: false,
+		},
 		{
 			name:           "admin $tc function",
 			code:           `{{ $tc('snippet.key') }}`,
@@ -95,3 +120,54 @@ func TestSnippetCompletionPatternMatching(t *testing.T) {
 		})
 	}
 }
+
+func TestTwigTransTagContentPattern(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		offset   uint
+		expected bool
+	}{
+		{
+			name:     "snippet key in trans tag",
+			code:     `{% trans %}snippet.key{% endtrans %}`,
+			offset:   15,
+			expected: true,
+		},
+		{
+			name:     "empty trans tag",
+			code:     `{% trans %}{% endtrans %}`,
+			offset:   11,
+			expected: true,
+		},
+		{
+			name:     "trans tag with parameters",
+			code:     `{% trans with {'%name%': name} %}snippet.key{% endtrans %}`,
+			offset:   36,
+			expected: true,
+		},
+		{
+			name:     "content after endtrans",
+			code:     `{% trans %}snippet.key{% endtrans %}text`,
+			offset:   38,
+			expected: false,
+		},
+		{
+			name:     "content after other tag",
+			code:     `{% block foo %}text{% endblock %}`,
+			offset:   17,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseTwig(t, tt.code)
+			defer tree.Close()
+			defer parser.Close()
+
+			node := findFirstNodeAtOffset(tree.RootNode(), tt.offset)
+			assert.Equal(t, tt.expected, treesitterhelper.TwigTransTagContentPattern().Matches(node, []byte(tt.code)), "node %s", node.Kind())
+		})
+	}
+}
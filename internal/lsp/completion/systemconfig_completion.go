@@ -2,9 +2,9 @@ package completion
 
 import (
 	"context"
-	"path/filepath"
 	"slices"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
@@ -38,7 +38,7 @@ func (s *SystemConfigCompletionProvider) GetCompletions(ctx context.Context, par
 		return completionItems
 	}
 
-	if filepath.Ext(params.TextDocument.URI) == ".php" {
+	if indexer.FileType(params.TextDocument.URI) == ".php" {
 		return s.phpCompletion(ctx, params)
 	}
 
@@ -102,3 +102,7 @@ func NewSystemConfigCompletion(lspServer *lsp.Server) *SystemConfigCompletionPro
 		phpIndex: phpIndexer.(*php.PHPIndex),
 	}
 }
+
+func (s *SystemConfigCompletionProvider) ID() string {
+	return "completion.systemconfig"
+}
@@ -2,9 +2,9 @@ package completion
 
 import (
 	"context"
-	"path/filepath"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
@@ -22,12 +22,16 @@ func NewThemeCompletionProvider(lspServer *lsp.Server) *ThemeCompletionProvider
 	}
 }
 
+func (p *ThemeCompletionProvider) ID() string {
+	return "completion.theme"
+}
+
 func (p *ThemeCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".scss":
 		return p.scssCompletions(ctx, params)
 	case ".twig":
@@ -40,7 +44,7 @@ func (p *ThemeCompletionProvider) GetCompletions(ctx context.Context, params *pr
 func (p *ThemeCompletionProvider) scssCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	var completionItems []protocol.CompletionItem
 
-	elements, _ := p.themeIndexer.GetAllThemeConfigFields()
+	elements := p.configFields(params.TextDocument.URI)
 	uniqueElements := make(map[string]struct{})
 
 	for _, element := range elements {
@@ -61,15 +65,16 @@ func (p *ThemeCompletionProvider) scssCompletions(ctx context.Context, params *p
 
 func (p *ThemeCompletionProvider) twigCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if treesitterhelper.TwigStringInFunctionPattern("theme_config").Matches(params.Node, params.DocumentContent) {
-		themes, _ := p.themeIndexer.GetThemeConfigFields()
+		fields := p.configFields(params.TextDocument.URI)
 
-		uniqueThemes := make(map[string]struct{})
+		uniqueFields := make(map[string]struct{})
 		var completionItems []protocol.CompletionItem
-		for _, theme := range themes {
-			if _, exists := uniqueThemes[theme]; !exists {
-				uniqueThemes[theme] = struct{}{}
+		for _, field := range fields {
+			if _, exists := uniqueFields[field.Key]; !exists {
+				uniqueFields[field.Key] = struct{}{}
 				completionItems = append(completionItems, protocol.CompletionItem{
-					Label: theme,
+					Label:  field.Key,
+					Detail: field.Value,
 				})
 			}
 		}
@@ -80,6 +85,18 @@ func (p *ThemeCompletionProvider) twigCompletions(ctx context.Context, params *p
 	return []protocol.CompletionItem{}
 }
 
+// configFields returns the config fields of the theme the file belongs to including the inherited ones,
+// files outside of a theme get the fields of all themes
+func (p *ThemeCompletionProvider) configFields(uri string) []theme.ThemeConfigField {
+	if current, _ := p.themeIndexer.GetThemeForFile(strings.TrimPrefix(uri, "file://")); current != nil {
+		fields, _ := p.themeIndexer.GetConfigWithInheritance(current.Path)
+		return fields
+	}
+
+	fields, _ := p.themeIndexer.GetAllThemeConfigFields()
+	return fields
+}
+
 func (p *ThemeCompletionProvider) GetTriggerCharacters() []string {
 	return []string{}
 }
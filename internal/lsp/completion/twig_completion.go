@@ -2,17 +2,37 @@ package completion
 
 import (
 	"context"
-	"path/filepath"
+	"fmt"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
 	"github.com/shopware/shopware-lsp/internal/twig"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
+// coreTwigTests are the tests Twig itself provides, extensions register further ones with TwigTest
+var coreTwigTests = []struct {
+	name        string
+	description string
+}{
+	{"constant", "Checks if a variable has the exact same value as a constant"},
+	{"defined", "Checks if a variable is defined"},
+	{"divisible by", "Checks if a variable is divisible by a number"},
+	{"empty", "Checks if a variable is an empty string, array, hash, false or null"},
+	{"even", "Returns true if the given number is even"},
+	{"iterable", "Checks if a variable is an array or a traversable object"},
+	{"mapping", "Checks if a variable is a mapping (a hash or an object)"},
+	{"null", "Returns true if the variable is null"},
+	{"odd", "Returns true if the given number is odd"},
+	{"same as", "Checks if a variable is the same as another variable (===)"},
+	{"sequence", "Checks if a variable is a sequence (a list)"},
+}
+
 type TwigCompletionProvider struct {
 	twigIndexer  *twig.TwigIndexer
 	iconProvider *theme.IconProvider
@@ -30,12 +50,16 @@ func NewTwigCompletionProvider(projectRoot string, lspServer *lsp.Server) *TwigC
 	}
 }
 
+func (p *TwigCompletionProvider) ID() string {
+	return "completion.twig"
+}
+
 func (p *TwigCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
 	if params.Node == nil {
 		return []protocol.CompletionItem{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".php":
 		return p.phpCompletions(ctx, params)
 	case ".twig":
@@ -60,6 +84,16 @@ func (p *TwigCompletionProvider) twigCompletions(ctx context.Context, params *pr
 		return completionItems
 	}
 
+	// {% sw_include '@Storefront/storefront/component/foo.html.twig' with { <caret> } %}
+	if object, template := treesitterhelper.TwigIncludeWithObject(params.Node, params.DocumentContent); object != nil {
+		return p.includeVariableCompletions(params.Node, object, template, params.DocumentContent)
+	}
+
+	// {% if foo is <caret> %} or {{ foo is not <caret> }}
+	if treesitterhelper.TwigIsTestName(params.DocumentContent, lsp.ByteOffset(params.DocumentContent, params.Position)) {
+		return p.testCompletions()
+	}
+
 	if treesitterhelper.TwigAutocompleteFilterPattern().Matches(params.Node, params.DocumentContent) {
 		filters, _ := p.twigIndexer.GetAllTwigFilters()
 		uniqueFilters := make(map[string]struct{})
@@ -115,6 +149,18 @@ func (p *TwigCompletionProvider) twigCompletions(ctx context.Context, params *pr
 		return completionItems
 	}
 
+	// {{ page.product.<caret> }}
+	if path, ok := treesitterhelper.TwigOutputVariablePath(params.Node, params.DocumentContent); ok {
+		// {% import '@Storefront/storefront/macros.twig' as macros %}{{ macros.<caret> }}
+		if len(path) == 1 {
+			if template := treesitterhelper.TwigImportedTemplate(params.Node, path[0], params.DocumentContent); template != "" {
+				return p.macroCompletions(params, template)
+			}
+		}
+
+		return templateVariableCompletions(params.TextDocument.URI, path)
+	}
+
 	if params.Node.Kind() == "template" {
 		functions, _ := p.twigIndexer.GetAllTwigFunctions()
 		uniqueFunctions := make(map[string]struct{})
@@ -143,23 +189,205 @@ func (p *TwigCompletionProvider) twigCompletions(ctx context.Context, params *pr
 	return []protocol.CompletionItem{}
 }
 
-func (p *TwigCompletionProvider) phpCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
-	if treesitterhelper.IsPHPThisMethodCall("renderStorefront").Matches(params.Node, params.DocumentContent) {
-		files, _ := p.twigIndexer.GetAllTemplateFiles()
+// testCompletions offers the core Twig tests and the tests registered by indexed extensions
+func (p *TwigCompletionProvider) testCompletions() []protocol.CompletionItem {
+	seen := make(map[string]struct{})
+
+	var completionItems []protocol.CompletionItem
+	for _, test := range coreTwigTests {
+		seen[test.name] = struct{}{}
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:  test.name,
+			Kind:   int(protocol.FunctionCompletion),
+			Detail: test.description,
+		})
+	}
+
+	tests, _ := p.twigIndexer.GetAllTwigTests()
+	for _, test := range tests {
+		if _, ok := seen[test.Name]; ok {
+			continue
+		}
+		seen[test.Name] = struct{}{}
+
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:  test.Name,
+			Kind:   int(protocol.FunctionCompletion),
+			Detail: test.FilePath,
+		})
+	}
+
+	return completionItems
+}
+
+// includeVariableCompletions offers the variables used by the included template, which are not passed yet
+func (p *TwigCompletionProvider) includeVariableCompletions(node, object, template *tree_sitter.Node, content []byte) []protocol.CompletionItem {
+	if template == nil || template.Kind() != "string" {
+		return []protocol.CompletionItem{}
+	}
+
+	templateName := strings.Trim(template.Utf8Text(content), "'\"")
+
+	// Variables of extended templates are expected as well
+	files, _ := p.twigIndexer.GetTemplateChain(templateName, "")
+
+	passed := make(map[string]bool)
+	for i := uint(0); i < object.NamedChildCount(); i++ {
+		child := object.NamedChild(i)
+		if child.Kind() == "pair" {
+			child = child.ChildByFieldName("key")
+		}
+
+		// The key being typed is not passed yet
+		if child != nil && child.Kind() == "variable" && !child.Equals(*node) {
+			passed[child.Utf8Text(content)] = true
+		}
+	}
+
+	completionItems := []protocol.CompletionItem{}
+	for _, file := range files {
+		for _, variable := range file.Variables {
+			if passed[variable] {
+				continue
+			}
+			passed[variable] = true
 
-		var completionItems []protocol.CompletionItem
-		for _, file := range files {
 			completionItems = append(completionItems, protocol.CompletionItem{
-				Label: file,
+				Label:      variable,
+				Kind:       int(protocol.VariableCompletion),
+				Detail:     templateName,
+				InsertText: variable + ": ",
 			})
 		}
+	}
 
-		return completionItems
+	return completionItems
+}
+
+// macroCompletions offers the macros of the imported template, _self refers to the current document
+func (p *TwigCompletionProvider) macroCompletions(params *protocol.CompletionParams, template string) []protocol.CompletionItem {
+	var macros []twig.TwigMacro
+
+	if template == "_self" {
+		rootNode := params.Node
+		for rootNode.Parent() != nil {
+			rootNode = rootNode.Parent()
+		}
+
+		file, err := twig.ParseTwig(strings.TrimPrefix(params.TextDocument.URI, "file://"), rootNode, params.DocumentContent)
+		if err != nil {
+			return []protocol.CompletionItem{}
+		}
+		macros = file.Macros
+	} else {
+		macros = p.twigIndexer.GetTwigMacros(template)
+	}
+
+	completionItems := make([]protocol.CompletionItem, 0, len(macros))
+	for _, macro := range macros {
+		placeholders := make([]string, 0, len(macro.Arguments))
+		for i, argument := range macro.Arguments {
+			placeholders = append(placeholders, fmt.Sprintf("${%d:%s}", i+1, argument))
+		}
+
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:            macro.Name,
+			Kind:             int(protocol.FunctionCompletion),
+			Detail:           fmt.Sprintf("%s(%s)", macro.Name, strings.Join(macro.Arguments, ", ")),
+			InsertText:       fmt.Sprintf("%s(%s)", macro.Name, strings.Join(placeholders, ", ")),
+			InsertTextFormat: int(protocol.SnippetTextFormat),
+		})
+	}
+
+	return completionItems
+}
+
+// templateVariableCompletions offers the variables Shopware passes to the template or the properties of the
+// variable at the given path
+func templateVariableCompletions(uri string, path []string) []protocol.CompletionItem {
+	templatePath := strings.TrimPrefix(uri, "file://")
+	if strings.Contains(templatePath, "Resources/app/administration") {
+		return []protocol.CompletionItem{}
+	}
+
+	candidates := twig.GetTemplateVariables(templatePath)
+	kind := protocol.VariableCompletion
+
+	if len(path) > 0 {
+		variable, ok := twig.FindTemplateVariable(candidates, path)
+		if !ok {
+			return []protocol.CompletionItem{}
+		}
+
+		candidates = variable.Properties
+		kind = protocol.PropertyCompletion
+	}
+
+	completionItems := make([]protocol.CompletionItem, 0, len(candidates))
+	for _, candidate := range candidates {
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:  candidate.Name,
+			Kind:   int(kind),
+			Detail: candidate.Type,
+		})
+	}
+
+	return completionItems
+}
+
+func (p *TwigCompletionProvider) phpCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	// $this->renderStorefront('<caret>'), $this->render('<caret>') or $this->renderView('<caret>')
+	if treesitterhelper.IsPHPTemplateRenderCall().Matches(params.Node, params.DocumentContent) {
+		files, _ := p.twigIndexer.GetAllTwigFiles()
+
+		return renderTemplateCompletionItems(files)
 	}
 
 	return []protocol.CompletionItem{}
 }
 
+// renderTemplateCompletionItems offers every template in the shared @Storefront namespace
+// and, for templates of other bundles, in the namespace of the owning bundle
+func renderTemplateCompletionItems(files []twig.TwigFile) []protocol.CompletionItem {
+	bundlesByRelPath := make(map[string][]string)
+	var relPaths []string
+	for _, file := range files {
+		if _, ok := bundlesByRelPath[file.RelPath]; !ok {
+			relPaths = append(relPaths, file.RelPath)
+		}
+		bundlesByRelPath[file.RelPath] = append(bundlesByRelPath[file.RelPath], file.BundleName)
+	}
+
+	completionItems := make([]protocol.CompletionItem, 0, len(files))
+	seen := make(map[string]bool)
+	for _, relPath := range relPaths {
+		seen[relPath] = true
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:      relPath,
+			Kind:       int(protocol.FileCompletion),
+			Detail:     strings.Join(bundlesByRelPath[relPath], ", "),
+			InsertText: relPath,
+		})
+	}
+
+	for _, file := range files {
+		name := twig.BundleTemplateName(file)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		completionItems = append(completionItems, protocol.CompletionItem{
+			Label:      name,
+			Kind:       int(protocol.FileCompletion),
+			Detail:     file.BundleName,
+			InsertText: name,
+		})
+	}
+
+	return completionItems
+}
+
 func (p *TwigCompletionProvider) GetTriggerCharacters() []string {
-	return []string{"\"", "'", "|"}
+	return []string{"\"", "'", "|", "."}
 }
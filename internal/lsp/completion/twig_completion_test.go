@@ -0,0 +1,327 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestTwigCompletionProvider_PHPRenderCalls(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	for _, path := range []string{
+		"/project/vendor/shopware/storefront/Resources/views/storefront/page/example.html.twig",
+		"/project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/example.html.twig",
+		"/project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/custom.html.twig",
+	} {
+		content := []byte(`{% block page %}{% endblock %}`)
+		tree := twigParser.Parse(content, nil)
+		require.NoError(t, twigIndexer.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	provider := &TwigCompletionProvider{twigIndexer: twigIndexer}
+
+	complete := func(code string) map[string]string {
+		content := []byte(code)
+		tree := phpParser.Parse(content, nil)
+		defer tree.Close()
+
+		offset := uint(len(code) - 3)
+		params := &protocol.CompletionParams{Node: tree.RootNode().DescendantForByteRange(offset, offset), DocumentContent: content}
+		params.TextDocument.URI = "file:///project/src/Controller/ExampleController.php"
+
+		details := make(map[string]string)
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			assert.Equal(t, item.Label, item.InsertText)
+			details[item.Label] = item.Detail
+		}
+
+		return details
+	}
+
+	for _, code := range []string{
+		`<?php $this->renderStorefront('');`,
+		`<?php $this->render('');`,
+		`<?php $this->renderView("");`,
+	} {
+		details := complete(code)
+		assert.Equal(t, map[string]string{
+			"@Storefront/storefront/page/example.html.twig": "storefront, MyPlugin",
+			"@Storefront/storefront/page/custom.html.twig":  "MyPlugin",
+			"@MyPlugin/storefront/page/example.html.twig":   "MyPlugin",
+			"@MyPlugin/storefront/page/custom.html.twig":    "MyPlugin",
+		}, details, code)
+	}
+
+	assert.Empty(t, complete(`<?php $this->trans('');`))
+}
+
+func TestTwigCompletionProvider_IncludeVariables(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	for path, code := range map[string]string{
+		"/project/vendor/shopware/storefront/Resources/views/storefront/component/card.html.twig":  `{{ title }}{{ product.name }}{# @var showImage bool #}`,
+		"/project/custom/plugins/MyPlugin/src/Resources/views/storefront/component/card.html.twig": `{% sw_extends '@Storefront/storefront/component/card.html.twig' %}{% block card %}{{ badge }}{% endblock %}`,
+	} {
+		content := []byte(code)
+		tree := twigParser.Parse(content, nil)
+		require.NoError(t, twigIndexer.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	provider := &TwigCompletionProvider{twigIndexer: twigIndexer}
+
+	complete := func(code string) []string {
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := twigParser.Parse(content, nil)
+		defer tree.Close()
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(strings.Index(code, "<caret>"))),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Resources/views/storefront/page/index.html.twig"
+
+		var labels []string
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			assert.Equal(t, protocol.VariableCompletion, protocol.CompletionItemKind(item.Kind))
+			assert.Equal(t, item.Label+": ", item.InsertText)
+			labels = append(labels, item.Label)
+		}
+
+		return labels
+	}
+
+	all := []string{"badge", "product", "showImage", "title"}
+
+	assert.ElementsMatch(t, all, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with { <caret> } %}`))
+	assert.ElementsMatch(t, all, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with {<caret>} %}`))
+	assert.ElementsMatch(t, all, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with { ti<caret> } %}`))
+	assert.ElementsMatch(t, all, complete(`{% include '@Storefront/storefront/component/card.html.twig' with { <caret> } %}`))
+	assert.ElementsMatch(t, []string{"badge", "showImage", "title"}, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with { product: page.product,<caret> } %}`))
+	assert.ElementsMatch(t, []string{"showImage", "title"}, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with { product: product, badge, <caret> } %}`))
+	assert.ElementsMatch(t, []string{"product", "showImage", "title"}, complete(`{% sw_include '@MyPlugin/storefront/component/card.html.twig' with { badge: 'new', <caret> } %}`))
+
+	assert.Empty(t, complete(`{% sw_include '@Storefront/storefront/component/card.html.twig' with { product: pa<caret> } %}`))
+	assert.Empty(t, complete(`{% sw_include '@Storefront/storefront/component/unknown.html.twig' with { <caret> } %}`))
+	assert.Empty(t, complete(`{% sw_icon 'star' style { <caret> } %}`))
+}
+
+func TestTwigCompletionProvider_Macros(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	macroFile := []byte(`{% macro price(amount, currency = 'EUR') %}{{ amount }}{% endmacro %}{% macro divider() %}<hr>{% endmacro %}`)
+	macroTree := twigParser.Parse(macroFile, nil)
+	require.NoError(t, twigIndexer.Index("/project/custom/plugins/MyPlugin/src/Resources/views/storefront/macros.twig", macroTree.RootNode(), macroFile))
+	macroTree.Close()
+
+	provider := &TwigCompletionProvider{twigIndexer: twigIndexer}
+
+	complete := func(code string) map[string]protocol.CompletionItem {
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := twigParser.Parse(content, nil)
+		defer tree.Close()
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(strings.Index(code, "<caret>"))),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Resources/views/storefront/page/index.html.twig"
+
+		items := make(map[string]protocol.CompletionItem)
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			items[item.Label] = item
+		}
+
+		return items
+	}
+
+	items := complete(`{% import '@MyPlugin/storefront/macros.twig' as m %}{{ m.<caret> }}`)
+	require.Len(t, items, 2)
+	assert.Equal(t, "price(${1:amount}, ${2:currency})", items["price"].InsertText)
+	assert.Equal(t, "price(amount, currency)", items["price"].Detail)
+	assert.Equal(t, int(protocol.SnippetTextFormat), items["price"].InsertTextFormat)
+	assert.Equal(t, protocol.FunctionCompletion, protocol.CompletionItemKind(items["price"].Kind))
+	assert.Equal(t, "divider()", items["divider"].InsertText)
+
+	items = complete(`{% block content %}{% import '@MyPlugin/storefront/macros.twig' as m %}{{ m.pr<caret> }}{% endblock %}`)
+	assert.Contains(t, items, "price")
+
+	items = complete(`{% macro local(name) %}{% endmacro %}{% import _self as self %}{{ self.<caret> }}`)
+	require.Len(t, items, 1)
+	assert.Equal(t, "local(${1:name})", items["local"].InsertText)
+
+	items = complete(`{% import '@MyPlugin/storefront/macros.twig' as m %}{{ other.<caret> }}`)
+	assert.NotContains(t, items, "price")
+}
+
+func TestTwigCompletionProvider_TemplateVariables(t *testing.T) {
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	provider := &TwigCompletionProvider{}
+
+	complete := func(uri, code string) map[string]protocol.CompletionItem {
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := twigParser.Parse(content, nil)
+		defer tree.Close()
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(strings.Index(code, "<caret>"))),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = uri
+
+		items := make(map[string]protocol.CompletionItem)
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			items[item.Label] = item
+		}
+
+		return items
+	}
+
+	productPage := "file:///project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/product-detail/index.html.twig"
+	checkoutPage := "file:///project/vendor/shopware/storefront/Resources/views/storefront/page/checkout/cart/index.html.twig"
+
+	items := complete(productPage, `<div>{{ pa<caret> }}</div>`)
+	require.Contains(t, items, "page")
+	assert.Equal(t, "ProductPage", items["page"].Detail)
+	assert.Equal(t, protocol.VariableCompletion, protocol.CompletionItemKind(items["page"].Kind))
+	assert.Contains(t, items, "context")
+	assert.Contains(t, items, "app")
+
+	items = complete(productPage, `{{ page.<caret> }}`)
+	assert.Contains(t, items, "product")
+	assert.Contains(t, items, "metaInformation", "properties of the general page are kept")
+	assert.Equal(t, protocol.PropertyCompletion, protocol.CompletionItemKind(items["product"].Kind))
+
+	items = complete(productPage, `{{ page.product.na<caret> }}`)
+	assert.Contains(t, items, "productNumber")
+	assert.Contains(t, items, "calculatedPrice")
+
+	items = complete(checkoutPage, `{% if true %}{{ page.<caret>`)
+	assert.Contains(t, items, "cart")
+	assert.NotContains(t, items, "product")
+
+	items = complete(checkoutPage, `{{ context.currency.<caret> }}`)
+	assert.Contains(t, items, "isoCode")
+
+	assert.Empty(t, complete(checkoutPage, `{{ unknown.<caret> }}`))
+	assert.Empty(t, complete(checkoutPage, `{% set foo = pa<caret> %}`))
+	assert.Empty(t, complete("file:///project/src/Resources/app/administration/src/module/sw-foo/sw-foo.html.twig", `{{ pa<caret> }}`))
+}
+
+func TestTwigCompletionProvider_Tests(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	extension := []byte(`<?php
+
+namespace Shopware\Core\Framework\Adapter\Twig\Extension;
+
+use Twig\Extension\AbstractExtension;
+use Twig\TwigTest;
+
+class InstanceOfExtension extends AbstractExtension
+{
+    public function getTests(): array
+    {
+        return [
+            new TwigTest('instanceof', $this->isInstanceOf(...)),
+            new TwigTest('defined', $this->isDefined(...)),
+        ];
+    }
+}
+`)
+	extensionTree := phpParser.Parse(extension, nil)
+	require.NoError(t, twigIndexer.Index("/project/vendor/shopware/core/InstanceOfExtension.php", extensionTree.RootNode(), extension))
+	extensionTree.Close()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	provider := &TwigCompletionProvider{twigIndexer: twigIndexer}
+
+	complete := func(code string) []string {
+		offset := strings.Index(code, "<caret>")
+		content := []byte(strings.Replace(code, "<caret>", "", 1))
+		tree := twigParser.Parse(content, nil)
+		defer tree.Close()
+
+		params := &protocol.CompletionParams{
+			Node:            findFirstNodeAtOffset(tree.RootNode(), uint(offset)),
+			DocumentContent: content,
+		}
+		params.TextDocument.URI = "file:///project/src/Resources/views/storefront/page/index.html.twig"
+		params.Position.Line = strings.Count(code[:offset], "\n")
+		params.Position.Character = offset - strings.LastIndex(code[:offset], "\n") - 1
+
+		var labels []string
+		for _, item := range provider.GetCompletions(context.Background(), params) {
+			labels = append(labels, item.Label)
+		}
+
+		return labels
+	}
+
+	for _, code := range []string{
+		"{{ product is <caret> }}",
+		"{% if product is not <caret> %}{% endif %}",
+		"{% if product.cover is emp<caret> %}{% endif %}",
+	} {
+		labels := complete(code)
+		assert.Contains(t, labels, "defined", code)
+		assert.Contains(t, labels, "same as", code)
+		assert.Contains(t, labels, "instanceof", code)
+	}
+
+	// Tests registered by extensions don't duplicate the core ones
+	labels := complete("{{ product is <caret> }}")
+	count := 0
+	for _, label := range labels {
+		if label == "defined" {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+
+	assert.NotContains(t, complete("{{ 'this is <caret>' }}"), "defined")
+}
@@ -2,8 +2,8 @@ package lsp
 
 import (
 	"context"
-	"path/filepath"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
 )
@@ -11,11 +11,12 @@ import (
 // definition handles textDocument/definition requests
 func (s *Server) definition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
 	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
 	if ok {
 		params.Node = node
 		params.DocumentContent = docText.Text
 
-		if filepath.Ext(params.TextDocument.URI) == ".php" {
+		if indexer.FileType(params.TextDocument.URI) == ".php" {
 			phpIndex, _ := s.GetIndexer("php.index")
 			ctx = phpIndex.(*php.PHPIndex).AddContext(ctx, node, docText.Text)
 		}
@@ -24,6 +25,10 @@ func (s *Server) definition(ctx context.Context, params *protocol.DefinitionPara
 	// Collect definition locations from all providers
 	var locations []protocol.Location
 	for _, provider := range s.definitionProviders {
+		if ctx.Err() != nil {
+			break
+		}
+
 		providerLocations := provider.GetDefinition(ctx, params)
 		locations = append(locations, providerLocations...)
 	}
@@ -3,10 +3,11 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
+	"os"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -33,7 +34,7 @@ func (p *AdminDefinitionProvider) GetDefinition(ctx context.Context, params *pro
 		return []protocol.Location{}
 	}
 
-	ext := strings.ToLower(filepath.Ext(params.TextDocument.URI))
+	ext := indexer.FileType(params.TextDocument.URI)
 
 	// Handle JS/TS files
 	if ext == ".js" || ext == ".ts" {
@@ -415,6 +416,11 @@ func (p *AdminDefinitionProvider) jsDefinition(ctx context.Context, params *prot
 		return []protocol.Location{}
 	}
 
+	// () => import('<caret>') - cursor on a dynamic import path
+	if importPath := p.getDynamicImportPath(node, content); importPath != "" {
+		return p.importPathDefinition(params.TextDocument.URI, importPath)
+	}
+
 	// Check if this string is in a Component.extend or Component.register call
 	if !p.isInComponentCall(node, content) {
 		return []protocol.Location{}
@@ -467,6 +473,62 @@ func (p *AdminDefinitionProvider) jsDefinition(ctx context.Context, params *prot
 	return locations
 }
 
+// getDynamicImportPath returns the import path if the node is the string argument of a dynamic import()
+// () => import('src/app/component/sw-button<caret>')
+func (p *AdminDefinitionProvider) getDynamicImportPath(node *tree_sitter.Node, content []byte) string {
+	stringNode := node
+	if stringNode.Kind() == "string_fragment" {
+		stringNode = node.Parent()
+	}
+	if stringNode == nil || stringNode.Kind() != "string" {
+		return ""
+	}
+
+	argsNode := stringNode.Parent()
+	if argsNode == nil || argsNode.Kind() != "arguments" {
+		return ""
+	}
+
+	callNode := argsNode.Parent()
+	if callNode == nil || callNode.Kind() != "call_expression" || treesitterhelper.GetFirstNodeOfKind(callNode, "import") == nil {
+		return ""
+	}
+
+	return p.extractComponentName(stringNode, content)
+}
+
+// importPathDefinition resolves a dynamic import path relative to the current file
+// and returns the location of the resolved definition file
+func (p *AdminDefinitionProvider) importPathDefinition(uri, importPath string) []protocol.Location {
+	filePath := strings.TrimPrefix(uri, "file://")
+
+	targetPath := admin.ResolveImportPath(filePath, importPath)
+	if targetPath == "" {
+		return []protocol.Location{}
+	}
+
+	// The resolver falls back to a guessed index.js, only navigate to files that exist
+	if _, err := os.Stat(targetPath); err != nil {
+		return []protocol.Location{}
+	}
+
+	return []protocol.Location{
+		{
+			URI: fmt.Sprintf("file://%s", targetPath),
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      0,
+					Character: 0,
+				},
+				End: protocol.Position{
+					Line:      0,
+					Character: 0,
+				},
+			},
+		},
+	}
+}
+
 // isInComponentCall checks if the node is within a Component.register/extend call
 // Component.extend('<caret>', 'parent', ...) or Component.register('<caret>', ...)
 func (p *AdminDefinitionProvider) isInComponentCall(node *tree_sitter.Node, content []byte) bool {
@@ -1,10 +1,15 @@
 package definition
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
 )
@@ -210,3 +215,66 @@ func TestKebabToCamel(t *testing.T) {
 		})
 	}
 }
+
+func TestImportPathDefinition(t *testing.T) {
+	tempDir := t.TempDir()
+	adminRoot := filepath.Join(tempDir, "src", "Resources", "app", "administration")
+	registrationFile := filepath.Join(adminRoot, "src", "app", "main.js")
+	definitionFile := filepath.Join(adminRoot, "src", "app", "component", "sw-button", "index.js")
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(definitionFile), 0o755))
+	require.NoError(t, os.WriteFile(definitionFile, []byte("export default {};"), 0o644))
+
+	provider := &AdminDefinitionProvider{}
+
+	tests := []struct {
+		name     string
+		code     string
+		col      uint
+		expected string
+	}{
+		{
+			name:     "absolute src import",
+			code:     `Component.register('sw-button', () => import('src/app/component/sw-button'));`,
+			col:      50,
+			expected: "file://" + definitionFile,
+		},
+		{
+			name:     "relative import",
+			code:     `Component.register('sw-button', () => import('./component/sw-button'));`,
+			col:      50,
+			expected: "file://" + definitionFile,
+		},
+		{
+			name: "missing file",
+			code: `Component.register('sw-missing', () => import('src/app/component/sw-missing'));`,
+			col:  52,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseJS(t, tt.code)
+			defer tree.Close()
+			defer parser.Close()
+
+			node := findNodeAtPosition(tree.RootNode(), 0, tt.col)
+			require.NotNil(t, node)
+
+			params := &protocol.DefinitionParams{}
+			params.TextDocument.URI = "file://" + registrationFile
+			params.Node = node
+			params.DocumentContent = []byte(tt.code)
+
+			locations := provider.GetDefinition(context.Background(), params)
+
+			if tt.expected == "" {
+				assert.Empty(t, locations)
+				return
+			}
+
+			require.Len(t, locations, 1)
+			assert.Equal(t, tt.expected, locations[0].URI)
+		})
+	}
+}
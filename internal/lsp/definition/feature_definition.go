@@ -3,10 +3,9 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
-	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/feature"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -28,7 +27,7 @@ func (p *FeatureDefinitionProvider) GetDefinition(ctx context.Context, params *p
 		return []protocol.Location{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".twig":
 		return p.twigDefinition(ctx, params)
 	case ".php":
@@ -0,0 +1,79 @@
+package definition
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+)
+
+// PHPDefinitionProvider resolves class references and class constant accesses like CheckoutEvents::ORDER_PLACED
+type PHPDefinitionProvider struct {
+	phpIndex *php.PHPIndex
+}
+
+func NewPHPDefinitionProvider(lspServer *lsp.Server) *PHPDefinitionProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+
+	return &PHPDefinitionProvider{
+		phpIndex: phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (p *PHPDefinitionProvider) GetDefinition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return []protocol.Location{}
+	}
+
+	// CheckoutEvents::<caret>ORDER_PLACED
+	if treesitterhelper.IsPHPClassConstantName().Matches(params.Node, params.DocumentContent) {
+		return p.constantDefinition(params)
+	}
+
+	// use App\Foo\<caret>Bar; or new <caret>Bar()
+	if className := php.ResolveClassReference(params.Node, params.DocumentContent); className != "" {
+		phpClass := p.phpIndex.GetClass(className)
+		if phpClass == nil {
+			return []protocol.Location{}
+		}
+
+		return []protocol.Location{phpLocation(phpClass.Path, phpClass.Line)}
+	}
+
+	return []protocol.Location{}
+}
+
+func (p *PHPDefinitionProvider) constantDefinition(params *protocol.DefinitionParams) []protocol.Location {
+	className := p.phpIndex.ResolveScopeClass(params.Node.Parent().NamedChild(0), params.DocumentContent)
+	if className == "" {
+		return []protocol.Location{}
+	}
+
+	constant, class := p.phpIndex.GetConstantWithDeclaringClass(className, params.Node.Utf8Text(params.DocumentContent))
+	if constant == nil {
+		return []protocol.Location{}
+	}
+
+	return []protocol.Location{phpLocation(class.Path, constant.Line)}
+}
+
+// phpLocation points to the start of a line of the index, which is 1-based
+func phpLocation(path string, line int) protocol.Location {
+	return protocol.Location{
+		URI: fmt.Sprintf("file://%s", path),
+		Range: protocol.Range{
+			Start: protocol.Position{
+				Line:      line - 1,
+				Character: 0,
+			},
+			End: protocol.Position{
+				Line:      line - 1,
+				Character: 0,
+			},
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package definition
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestPHPDefinition_Constants(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	eventsPath := filepath.Join(t.TempDir(), "CheckoutEvents.php")
+	eventsContent := []byte(`<?php
+
+namespace Shopware\Core\Checkout;
+
+class BaseEvents
+{
+    public const ORDER_PLACED = 'checkout.order.placed';
+}
+
+class CheckoutEvents extends BaseEvents
+{
+    public const CART_LOADED = 'checkout.cart.loaded';
+}
+`)
+	eventsTree := parser.Parse(eventsContent, nil)
+	defer eventsTree.Close()
+	require.NoError(t, phpIndex.Index(eventsPath, eventsTree.RootNode(), eventsContent))
+
+	subscriberContent := []byte(`<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Checkout\CheckoutEvents;
+use Shopware\Core\Checkout\BaseEvents as Base;
+
+class OrderSubscriber extends Base
+{
+    public static function getSubscribedEvents(): array
+    {
+        return [
+            CheckoutEvents::CART_LOADED => 'onCartLoaded',
+            Base::ORDER_PLACED => 'onOrderPlaced',
+            parent::ORDER_PLACED => 'onOrderPlaced',
+            \Shopware\Core\Checkout\CheckoutEvents::ORDER_PLACED => 'onOrderPlaced',
+            CheckoutEvents::UNKNOWN => 'onUnknown',
+            CheckoutEvents::class => 'onClass',
+        ];
+    }
+}
+`)
+	subscriberPath := filepath.Join(t.TempDir(), "OrderSubscriber.php")
+	subscriberTree := parser.Parse(subscriberContent, nil)
+	defer subscriberTree.Close()
+	require.NoError(t, phpIndex.Index(subscriberPath, subscriberTree.RootNode(), subscriberContent))
+
+	provider := &PHPDefinitionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name   string
+		needle string
+		line   int
+	}{
+		{name: "imported class", needle: "CheckoutEvents::CART_LOADED", line: 11},
+		{name: "aliased class with inherited constant", needle: "Base::ORDER_PLACED", line: 6},
+		{name: "parent scope", needle: "parent::ORDER_PLACED", line: 6},
+		{name: "fully qualified class", needle: "\\Shopware\\Core\\Checkout\\CheckoutEvents::ORDER_PLACED", line: 6},
+		{name: "unknown constant", needle: "CheckoutEvents::UNKNOWN", line: -1},
+		{name: "class keyword", needle: "CheckoutEvents::class", line: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Place the cursor on the constant name after the "::"
+			offset := bytes.Index(subscriberContent, []byte(tt.needle))
+			require.NotEqual(t, -1, offset)
+			offset += bytes.Index([]byte(tt.needle), []byte("::")) + 3
+
+			line := bytes.Count(subscriberContent[:offset], []byte("\n"))
+			col := offset - bytes.LastIndex(subscriberContent[:offset], []byte("\n")) - 1
+
+			node := findNodeAtPosition(subscriberTree.RootNode(), uint(line), uint(col))
+			require.NotNil(t, node)
+
+			locations := provider.GetDefinition(context.Background(), &protocol.DefinitionParams{
+				TextDocument: struct {
+					URI string `json:"uri"`
+				}{URI: "file://" + subscriberPath},
+				Node:            node,
+				DocumentContent: subscriberContent,
+			})
+
+			if tt.line == -1 {
+				assert.Empty(t, locations)
+				return
+			}
+
+			require.Len(t, locations, 1)
+			assert.Equal(t, "file://"+eventsPath, locations[0].URI)
+			assert.Equal(t, tt.line, locations[0].Range.Start.Line)
+		})
+	}
+}
+
+func TestPHPDefinition_ClassReferences(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	libraryPath := filepath.Join(t.TempDir(), "Context.php")
+	library := []byte("<?php\n\nnamespace Shopware\\Core\\Framework;\n\nclass Context\n{\n}\n\nclass Uuid\n{\n}\n")
+	libraryTree := parser.Parse(library, nil)
+	defer libraryTree.Close()
+	require.NoError(t, phpIndex.Index(libraryPath, libraryTree.RootNode(), library))
+
+	content := []byte(`<?php
+
+namespace App\Service;
+
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\Uuid as Id;
+
+class Foo
+{
+    public function load(Context $context, \Shopware\Core\Framework\Uuid $uuid, Missing $missing): void
+    {
+        $id = Id::randomHex();
+    }
+}
+`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	provider := &PHPDefinitionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name   string
+		needle string
+		line   int
+	}{
+		{name: "use statement", needle: "Context;", line: 4},
+		{name: "use statement namespace segment", needle: "Framework\\Uuid as", line: 8},
+		{name: "imported short name", needle: "Context $context", line: 4},
+		{name: "fully qualified name", needle: "Uuid $uuid", line: 8},
+		{name: "aliased static call scope", needle: "Id::randomHex", line: 8},
+		{name: "unknown class", needle: "Missing", line: -1},
+		{name: "method name", needle: "load", line: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := bytes.Index(content, []byte(tt.needle))
+			require.NotEqual(t, -1, offset)
+
+			node := tree.RootNode().DescendantForByteRange(uint(offset), uint(offset))
+			locations := provider.GetDefinition(context.Background(), &protocol.DefinitionParams{
+				TextDocument: struct {
+					URI string `json:"uri"`
+				}{URI: "file:///project/src/Foo.php"},
+				Node:            node,
+				DocumentContent: content,
+			})
+
+			if tt.line == -1 {
+				assert.Empty(t, locations)
+				return
+			}
+
+			require.Len(t, locations, 1)
+			assert.Equal(t, "file://"+libraryPath, locations[0].URI)
+			assert.Equal(t, tt.line, locations[0].Range.Start.Line)
+		})
+	}
+}
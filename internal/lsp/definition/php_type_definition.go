@@ -0,0 +1,93 @@
+package definition
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPTypeDefinitionProvider resolves variables, parameters and properties to the classes of their types
+type PHPTypeDefinitionProvider struct {
+	phpIndex *php.PHPIndex
+}
+
+func NewPHPTypeDefinitionProvider(lspServer *lsp.Server) *PHPTypeDefinitionProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+
+	return &PHPTypeDefinitionProvider{
+		phpIndex: phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (p *PHPTypeDefinitionProvider) GetTypeDefinition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return []protocol.Location{}
+	}
+
+	expression := typedExpression(params.Node)
+	if expression == nil {
+		return []protocol.Location{}
+	}
+
+	// $product = new Product() is typed by the assigned value, the variable is assigned after it
+	expressionType := p.phpIndex.TypeOfExpression(expression, params.DocumentContent)
+	if parent := expression.Parent(); parent != nil {
+		switch parent.Kind() {
+		case "assignment_expression":
+			if left := parent.ChildByFieldName("left"); left != nil && left.Id() == expression.Id() {
+				expressionType = p.phpIndex.TypeOfExpression(parent.ChildByFieldName("right"), params.DocumentContent)
+			}
+		case "property_element":
+			// private ?Product $<caret>product;
+			className := treesitterhelper.GetClassName(expression, params.DocumentContent)
+			name := strings.TrimPrefix(expression.Utf8Text(params.DocumentContent), "$")
+			if property := p.phpIndex.GetProperty(className, name); property != nil && property.Type != nil {
+				expressionType = property.Type
+			}
+		}
+	}
+
+	// Nullable and union types may refer to several classes
+	locations := []protocol.Location{}
+	for _, typeName := range strings.Split(strings.TrimPrefix(expressionType.Name(), "?"), "|") {
+		class := p.phpIndex.GetClass(strings.TrimPrefix(typeName, "\\"))
+		if class == nil {
+			continue
+		}
+
+		locations = append(locations, phpLocation(class.Path, class.Line))
+	}
+
+	return locations
+}
+
+// typedExpression returns the variable or property access the cursor is on
+func typedExpression(node *tree_sitter.Node) *tree_sitter.Node {
+	switch node.Kind() {
+	case "variable_name":
+		return node
+	case "name", "$":
+		parent := node.Parent()
+		if parent == nil {
+			return nil
+		}
+
+		switch parent.Kind() {
+		case "variable_name":
+			return parent
+		case "member_access_expression", "nullsafe_member_access_expression":
+			// $this->re<caret>pository
+			if name := parent.ChildByFieldName("name"); name != nil && name.Id() == node.Id() {
+				return parent
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package definition
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestPHPTypeDefinition(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	libraryPath := filepath.Join(t.TempDir(), "Product.php")
+	library := []byte(`<?php
+
+namespace App\Entity;
+
+class Product
+{
+    public function getManufacturer(): Manufacturer
+    {
+    }
+}
+
+class Manufacturer
+{
+}
+`)
+	libraryTree := parser.Parse(library, nil)
+	defer libraryTree.Close()
+	require.NoError(t, phpIndex.Index(libraryPath, libraryTree.RootNode(), library))
+
+	servicePath := filepath.Join(t.TempDir(), "ProductService.php")
+	service := []byte(`<?php
+
+namespace App\Service;
+
+use App\Entity\Product;
+
+class ProductService
+{
+    private ?Product $product;
+
+    public function load(Product $loaded, Product|Manufacturer $either, int $count)
+    {
+        $manufacturer = $loaded->getManufacturer();
+        echo $manufacturer;
+        echo $this->product;
+        echo $count;
+    }
+}
+`)
+	serviceTree := parser.Parse(service, nil)
+	defer serviceTree.Close()
+	require.NoError(t, phpIndex.Index(servicePath, serviceTree.RootNode(), service))
+
+	provider := &PHPTypeDefinitionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name   string
+		needle string
+		lines  []int
+	}{
+		{name: "parameter", needle: "$loaded,", lines: []int{4}},
+		{name: "assigned variable", needle: "$manufacturer = ", lines: []int{11}},
+		{name: "variable usage", needle: "$manufacturer;", lines: []int{11}},
+		{name: "property declaration", needle: "$product;", lines: []int{4}},
+		{name: "nullable property", needle: ">product;", lines: []int{4}},
+		{name: "unknown class of union", needle: "$either", lines: []int{4}},
+		{name: "primitive type", needle: "$count;"},
+		{name: "class name", needle: "ProductService"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := bytes.Index(service, []byte(tt.needle)) + 1
+			require.Positive(t, offset)
+
+			line := bytes.Count(service[:offset], []byte("\n"))
+			col := offset - bytes.LastIndex(service[:offset], []byte("\n")) - 1
+
+			node := findNodeAtPosition(serviceTree.RootNode(), uint(line), uint(col))
+			require.NotNil(t, node)
+
+			locations := provider.GetTypeDefinition(context.Background(), &protocol.DefinitionParams{
+				TextDocument: struct {
+					URI string `json:"uri"`
+				}{URI: "file://" + servicePath},
+				Node:            node,
+				DocumentContent: service,
+			})
+
+			var lines []int
+			for _, location := range locations {
+				assert.Equal(t, "file://"+libraryPath, location.URI)
+				lines = append(lines, location.Range.Start.Line)
+			}
+			assert.Equal(t, tt.lines, lines)
+		})
+	}
+}
@@ -3,9 +3,8 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
-	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/symfony"
@@ -28,7 +27,7 @@ func (p *RouteDefinitionProvider) GetDefinition(ctx context.Context, params *pro
 		return []protocol.Location{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".php":
 		return p.phpDefinition(ctx, params)
 	case ".twig":
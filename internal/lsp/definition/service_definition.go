@@ -3,28 +3,33 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
 	"github.com/shopware/shopware-lsp/internal/symfony"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
 type serviceXMLDefinitionProvider struct {
-	serviceIndex *symfony.ServiceIndex
-	phpIndex     *php.PHPIndex
+	serviceIndex   *symfony.ServiceIndex
+	phpIndex       *php.PHPIndex
+	extensionIndex *extension.ExtensionIndexer
 }
 
 func NewServiceXMLDefinitionProvider(lsp *lsp.Server) *serviceXMLDefinitionProvider {
 	serviceIndex, _ := lsp.GetIndexer("symfony.service")
 	phpIndex, _ := lsp.GetIndexer("php.index")
+	extensionIndex, _ := lsp.GetIndexer("extension.indexer")
 
 	return &serviceXMLDefinitionProvider{
-		serviceIndex: serviceIndex.(*symfony.ServiceIndex),
-		phpIndex:     phpIndex.(*php.PHPIndex),
+		serviceIndex:   serviceIndex.(*symfony.ServiceIndex),
+		phpIndex:       phpIndex.(*php.PHPIndex),
+		extensionIndex: extensionIndex.(*extension.ExtensionIndexer),
 	}
 }
 
@@ -33,7 +38,7 @@ func (p *serviceXMLDefinitionProvider) GetDefinition(ctx context.Context, params
 		return []protocol.Location{}
 	}
 
-	fileExt := strings.ToLower(filepath.Ext(params.TextDocument.URI))
+	fileExt := indexer.FileType(params.TextDocument.URI)
 
 	switch fileExt {
 	case ".php":
@@ -81,22 +86,15 @@ func (p *serviceXMLDefinitionProvider) xmlDefinition(ctx context.Context, params
 		}
 	}
 
-	// <argument type="tagged" tag="x"/>
+	// <argument type="tagged_iterator" tag="x"/> or <tag name="x"/> lists all services carrying the tag
 	if treesitterhelper.SymfonyServiceIsArgumentTag(params.Node, params.DocumentContent) || treesitterhelper.SymfonyServiceIsTagElement(params.Node, params.DocumentContent) {
-		serviceID := treesitterhelper.GetNodeText(params.Node, params.DocumentContent)
-		if serviceID == "" {
+		tagName := treesitterhelper.GetNodeText(params.Node, params.DocumentContent)
+		if tagName == "" {
 			return []protocol.Location{}
 		}
 
-		services := p.serviceIndex.GetServicesByTag(serviceID)
-
 		var locations []protocol.Location
-		for _, serviceName := range services {
-			service, found := p.serviceIndex.GetServiceByID(serviceName)
-			if !found {
-				continue
-			}
-
+		for _, service := range p.serviceIndex.GetTaggedServices(tagName) {
 			locations = append(locations, protocol.Location{
 				URI: fmt.Sprintf("file://%s", service.Path),
 				Range: protocol.Range{
@@ -162,9 +160,31 @@ func (p *serviceXMLDefinitionProvider) xmlDefinition(ctx context.Context, params
 		}
 	}
 
-	// <service id="<caret>">
-	if treesitterhelper.SymfonyServiceIsServiceId(params.Node, params.DocumentContent) {
-		nodeText := treesitterhelper.GetNodeText(params.Node, params.DocumentContent)
+	// <import resource="<caret>"/>
+	if treesitterhelper.SymfonyServiceIsImportResource(params.Node, params.DocumentContent) {
+		resource := treesitterhelper.GetNodeText(params.Node, params.DocumentContent)
+		path := symfony.ResolveImportResource(strings.TrimPrefix(params.TextDocument.URI, "file://"), resource, p.bundleDir)
+		if path == "" {
+			return []protocol.Location{}
+		}
+
+		var locations []protocol.Location
+		for _, file := range symfony.ImportResourceFiles(path) {
+			locations = append(locations, protocol.Location{
+				URI: fmt.Sprintf("file://%s", file),
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 0, Character: 0},
+					End:   protocol.Position{Line: 0, Character: 0},
+				},
+			})
+		}
+
+		return locations
+	}
+
+	// <service id="<caret>" class="<caret>"> or <factory class="<caret>"/>
+	if treesitterhelper.SymfonyServiceIsServiceId(params.Node, params.DocumentContent) || treesitterhelper.SymfonyServiceIsFactoryClass(params.Node, params.DocumentContent) {
+		nodeText := strings.TrimLeft(treesitterhelper.GetNodeText(params.Node, params.DocumentContent), "\\")
 
 		phpClass := p.phpIndex.GetClass(nodeText)
 		if phpClass != nil {
@@ -189,6 +209,15 @@ func (p *serviceXMLDefinitionProvider) xmlDefinition(ctx context.Context, params
 	return []protocol.Location{}
 }
 
+// bundleDir resolves the @BundleName prefix of import resources
+func (p *serviceXMLDefinitionProvider) bundleDir(name string) string {
+	if p.extensionIndex == nil {
+		return ""
+	}
+
+	return p.extensionIndex.GetBundleDir(name)
+}
+
 func (p *serviceXMLDefinitionProvider) yamlDefinition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
 	if treesitterhelper.IsYamlServiceId(params.Node, params.DocumentContent) || treesitterhelper.IsYamlClassPropertyInService().Matches(params.Node, params.DocumentContent) {
 		value := treesitterhelper.GetYAMLValue(params.Node, params.DocumentContent)
@@ -269,5 +298,64 @@ func (p *serviceXMLDefinitionProvider) phpDefinition(ctx context.Context, params
 		return locations
 	}
 
+	// public function __construct(private <caret>FooInterface $foo)
+	if isConstructorParameterType(params.Node, params.DocumentContent) {
+		className := php.ResolveClassReference(params.Node, params.DocumentContent)
+
+		service, found := p.serviceIndex.ResolveServiceForType(className)
+		if !found {
+			return []protocol.Location{}
+		}
+
+		return []protocol.Location{
+			{
+				URI: fmt.Sprintf("file://%s", service.Path),
+				Range: protocol.Range{
+					Start: protocol.Position{
+						Line:      service.Line - 1, // LSP uses 0-based line numbers
+						Character: 0,
+					},
+					End: protocol.Position{
+						Line:      service.Line - 1,
+						Character: 0,
+					},
+				},
+			},
+		}
+	}
+
 	return []protocol.Location{}
 }
+
+// isConstructorParameterType checks whether the node is the class name in the type of a constructor parameter,
+// these are the arguments autowiring resolves to services
+func isConstructorParameterType(node *tree_sitter.Node, content []byte) bool {
+	if node.Kind() != "name" {
+		return false
+	}
+
+	parameter := node.Parent()
+	for parameter != nil && typeNodeKinds[parameter.Kind()] {
+		parameter = parameter.Parent()
+	}
+
+	if parameter == nil || (parameter.Kind() != "simple_parameter" && parameter.Kind() != "property_promotion_parameter") {
+		return false
+	}
+
+	method := parameter.Parent().Parent()
+	if method == nil || method.Kind() != "method_declaration" {
+		return false
+	}
+
+	name := method.ChildByFieldName("name")
+	return name != nil && strings.EqualFold(name.Utf8Text(content), "__construct")
+}
+
+var typeNodeKinds = map[string]bool{
+	"named_type":     true,
+	"optional_type":  true,
+	"union_type":     true,
+	"qualified_name": true,
+	"namespace_name": true,
+}
@@ -0,0 +1,278 @@
+package definition
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestServiceXMLDefinition_ClassAttributes(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	phpPath := filepath.Join(t.TempDir(), "Foo.php")
+	phpContent := []byte("<?php\n\nnamespace App\\Service;\n\nclass Foo\n{\n}\n")
+	phpTree := phpParser.Parse(phpContent, nil)
+	defer phpTree.Close()
+	require.NoError(t, phpIndex.Index(phpPath, phpTree.RootNode(), phpContent))
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	provider := &serviceXMLDefinitionProvider{phpIndex: phpIndex}
+
+	tests := []struct {
+		name  string
+		xml   string
+		col   uint
+		found bool
+	}{
+		{
+			name:  "service class",
+			xml:   `<service id="foo" class="App\Service\Foo"/>`,
+			col:   28,
+			found: true,
+		},
+		{
+			name:  "service class with leading backslash",
+			xml:   `<service id="foo" class="\App\Service\Foo"/>`,
+			col:   28,
+			found: true,
+		},
+		{
+			name:  "factory class",
+			xml:   `<factory class="App\Service\Foo" method="create"/>`,
+			col:   20,
+			found: true,
+		},
+		{
+			name:  "unknown class",
+			xml:   `<service id="foo" class="App\Service\Bar"/>`,
+			col:   28,
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.xml)
+			tree := xmlParser.Parse(content, nil)
+			defer tree.Close()
+
+			node := findNodeAtPosition(tree.RootNode(), 0, tt.col)
+			for node != nil && node.Kind() != "AttValue" {
+				node = node.Parent()
+			}
+			require.NotNil(t, node)
+
+			locations := provider.xmlDefinition(context.Background(), &protocol.DefinitionParams{
+				Node:            node,
+				DocumentContent: content,
+			})
+
+			if !tt.found {
+				assert.Empty(t, locations)
+				return
+			}
+
+			require.Len(t, locations, 1)
+			assert.Equal(t, "file://"+phpPath, locations[0].URI)
+			assert.Equal(t, 4, locations[0].Range.Start.Line)
+		})
+	}
+}
+
+func TestServiceXMLDefinition_ConstructorParameterAlias(t *testing.T) {
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	xmlContent := []byte(`<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="App\Mailer\SmtpMailer"/>
+        <service id="App\Mailer\MailerInterface" alias="App\Mailer\SmtpMailer"/>
+    </services>
+</container>`)
+	xmlTree := xmlParser.Parse(xmlContent, nil)
+	defer xmlTree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", xmlTree.RootNode(), xmlContent))
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	provider := &serviceXMLDefinitionProvider{serviceIndex: serviceIndex}
+
+	phpContent := []byte(`<?php
+
+namespace App\Controller;
+
+use App\Mailer\MailerInterface;
+
+class MailController
+{
+    public function __construct(private readonly MailerInterface $mailer, ?MailerInterface $fallback = null)
+    {
+    }
+
+    public function send(MailerInterface $mailer): void
+    {
+    }
+}
+`)
+	phpTree := phpParser.Parse(phpContent, nil)
+	defer phpTree.Close()
+
+	tests := []struct {
+		name  string
+		line  uint
+		col   uint
+		found bool
+	}{
+		{name: "promoted parameter", line: 8, col: 50, found: true},
+		{name: "nullable parameter", line: 8, col: 77, found: true},
+		{name: "other method", line: 12, col: 30, found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &protocol.DefinitionParams{
+				Node:            findNodeAtPosition(phpTree.RootNode(), tt.line, tt.col),
+				DocumentContent: phpContent,
+			}
+
+			locations := provider.phpDefinition(context.Background(), params)
+
+			if !tt.found {
+				assert.Empty(t, locations)
+				return
+			}
+
+			require.Len(t, locations, 1)
+			assert.Equal(t, "file:///project/services.xml", locations[0].URI)
+			assert.Equal(t, 3, locations[0].Range.Start.Line)
+		})
+	}
+}
+
+func TestServiceXMLDefinition_TaggedIterator(t *testing.T) {
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	xmlContent := []byte(`<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="App\Handler\B">
+            <tag name="app.handler"/>
+        </service>
+        <service id="App\Handler\A">
+            <tag name="app.handler"/>
+        </service>
+        <service id="App\Other"/>
+        <service id="App\Registry">
+            <argument type="tagged_iterator" tag="app.handler"/>
+        </service>
+    </services>
+</container>`)
+	xmlTree := xmlParser.Parse(xmlContent, nil)
+	defer xmlTree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", xmlTree.RootNode(), xmlContent))
+
+	provider := &serviceXMLDefinitionProvider{serviceIndex: serviceIndex}
+
+	node := findNodeAtPosition(xmlTree.RootNode(), 11, 52)
+	for node != nil && node.Kind() != "AttValue" {
+		node = node.Parent()
+	}
+	require.NotNil(t, node)
+
+	locations := provider.xmlDefinition(context.Background(), &protocol.DefinitionParams{
+		Node:            node,
+		DocumentContent: xmlContent,
+	})
+
+	require.Len(t, locations, 2)
+	assert.Equal(t, "file:///project/services.xml", locations[0].URI)
+	assert.Equal(t, 6, locations[0].Range.Start.Line)
+	assert.Equal(t, 3, locations[1].Range.Start.Line)
+}
+
+func TestServiceXMLDefinition_ImportResource(t *testing.T) {
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "packages"), 0o755))
+	for _, file := range []string{"other.xml", "packages/a.xml", "packages/b.xml"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("<container/>"), 0o644))
+	}
+
+	xmlContent := []byte(`<?xml version="1.0" ?>
+<container>
+    <imports>
+        <import resource="other.xml"/>
+        <import resource="packages/*.xml"/>
+        <import resource="missing.xml"/>
+        <import resource="@Unknown/Resources/config/services.xml"/>
+    </imports>
+</container>`)
+	xmlTree := xmlParser.Parse(xmlContent, nil)
+	defer xmlTree.Close()
+
+	provider := &serviceXMLDefinitionProvider{}
+
+	definition := func(line uint) []protocol.Location {
+		node := findNodeAtPosition(xmlTree.RootNode(), line, 30)
+		for node != nil && node.Kind() != "AttValue" {
+			node = node.Parent()
+		}
+		require.NotNil(t, node)
+
+		params := &protocol.DefinitionParams{
+			Node:            node,
+			DocumentContent: xmlContent,
+		}
+		params.TextDocument.URI = "file://" + filepath.Join(dir, "services.xml")
+
+		return provider.xmlDefinition(context.Background(), params)
+	}
+
+	locations := definition(3)
+	require.Len(t, locations, 1)
+	assert.Equal(t, "file://"+filepath.Join(dir, "other.xml"), locations[0].URI)
+
+	locations = definition(4)
+	require.Len(t, locations, 2)
+	assert.Equal(t, "file://"+filepath.Join(dir, "packages", "a.xml"), locations[0].URI)
+	assert.Equal(t, "file://"+filepath.Join(dir, "packages", "b.xml"), locations[1].URI)
+
+	assert.Empty(t, definition(5))
+	assert.Empty(t, definition(6))
+}
@@ -3,9 +3,8 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
-	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/snippet"
@@ -28,7 +27,7 @@ func (s *SnippetDefinitionProvider) GetDefinition(ctx context.Context, params *p
 		return []protocol.Location{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".twig":
 		return s.twigDefinitions(ctx, params)
 	case ".php":
@@ -3,8 +3,8 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
@@ -59,7 +59,7 @@ func (s *SystemConfigDefinitionProvider) GetDefinition(ctx context.Context, para
 		return locations
 	}
 
-	if filepath.Ext(params.TextDocument.URI) == ".php" {
+	if indexer.FileType(params.TextDocument.URI) == ".php" {
 		return s.phpDefinition(ctx, params)
 	}
 
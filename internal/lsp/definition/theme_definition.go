@@ -3,9 +3,9 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
@@ -28,7 +28,7 @@ func (p *ThemeDefinitionProvider) GetDefinition(ctx context.Context, params *pro
 		return []protocol.Location{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".scss":
 		return p.scssDefinition(ctx, params)
 	case ".twig":
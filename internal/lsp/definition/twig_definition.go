@@ -3,16 +3,17 @@ package definition
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
 	"github.com/shopware/shopware-lsp/internal/twig"
 
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
 type TwigDefinitionProvider struct {
@@ -37,7 +38,7 @@ func (p *TwigDefinitionProvider) GetDefinition(ctx context.Context, params *prot
 		return []protocol.Location{}
 	}
 
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".php":
 		return p.phpDefinitions(ctx, params)
 	case ".twig":
@@ -165,8 +166,16 @@ func (p *TwigDefinitionProvider) twigDefinitions(ctx context.Context, params *pr
 }
 
 func (p *TwigDefinitionProvider) phpDefinitions(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
-	if treesitterhelper.IsPHPThisMethodCall("renderStorefront").Matches(params.Node, params.DocumentContent) {
-		files, _ := p.twigIndexer.GetTwigFilesByRelPath(treesitterhelper.GetNodeText(params.Node, params.DocumentContent))
+	// $this->renderStorefront('<caret>'), $this->render('<caret>') or $this->renderView('<caret>')
+	if treesitterhelper.IsPHPTemplateRenderCall().Matches(params.Node, params.DocumentContent) {
+		templateName := treesitterhelper.GetNodeText(params.Node, params.DocumentContent)
+
+		// Interpolated or concatenated strings can't be resolved to a single template
+		if isDynamicString(params.Node) {
+			return []protocol.Location{}
+		}
+
+		files, _ := p.twigIndexer.GetTwigFilesByTemplateName(templateName)
 
 		var locations []protocol.Location
 		for _, file := range files {
@@ -190,3 +199,32 @@ func (p *TwigDefinitionProvider) phpDefinitions(ctx context.Context, params *pro
 
 	return []protocol.Location{}
 }
+
+// isDynamicString reports whether the string at the node interpolates variables or is part of a concatenation
+func isDynamicString(node *tree_sitter.Node) bool {
+	for node != nil && node.Kind() != "string" && node.Kind() != "encapsed_string" {
+		switch node.Kind() {
+		case "string_content", "'", `"`:
+			node = node.Parent()
+		default:
+			return false
+		}
+	}
+	if node == nil {
+		return false
+	}
+
+	if parent := node.Parent(); parent != nil && parent.Kind() == "binary_expression" {
+		return true
+	}
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		switch node.NamedChild(i).Kind() {
+		case "string_content", "escape_sequence":
+		default:
+			return true
+		}
+	}
+
+	return false
+}
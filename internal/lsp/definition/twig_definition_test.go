@@ -0,0 +1,104 @@
+package definition
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestTwigDefinitionProvider_PHPRenderCalls(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	twigParser := tree_sitter.NewParser()
+	defer twigParser.Close()
+	require.NoError(t, twigParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	storefrontTemplate := "/project/vendor/shopware/storefront/Resources/views/storefront/page/example.html.twig"
+	pluginTemplate := "/project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/example.html.twig"
+	for _, path := range []string{storefrontTemplate, pluginTemplate} {
+		content := []byte(`{% block page %}{% endblock %}`)
+		tree := twigParser.Parse(content, nil)
+		require.NoError(t, twigIndexer.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	provider := &TwigDefinitionProvider{twigIndexer: twigIndexer}
+
+	tests := []struct {
+		name     string
+		code     string
+		expected []string
+	}{
+		{
+			name:     "renderStorefront with storefront namespace",
+			code:     `<?php $this->renderStorefront('@Storefront/storefront/page/example.html.twig');`,
+			expected: []string{storefrontTemplate, pluginTemplate},
+		},
+		{
+			name:     "render with plugin namespace",
+			code:     `<?php $this->render('@MyPlugin/storefront/page/example.html.twig');`,
+			expected: []string{pluginTemplate},
+		},
+		{
+			name:     "renderView with unknown template",
+			code:     `<?php $this->renderView('@Storefront/storefront/page/unknown.html.twig');`,
+			expected: nil,
+		},
+		{
+			name:     "interpolated template name",
+			code:     `<?php $this->renderStorefront("@Storefront/storefront/page/{$page}.html.twig");`,
+			expected: nil,
+		},
+		{
+			name:     "concatenated template name",
+			code:     `<?php $this->renderStorefront('@Storefront/storefront/page/' . $page . '.html.twig');`,
+			expected: nil,
+		},
+		{
+			name:     "double quoted template name",
+			code:     `<?php $this->renderStorefront("@Storefront/storefront/page/example.html.twig");`,
+			expected: []string{storefrontTemplate, pluginTemplate},
+		},
+		{
+			name:     "other method",
+			code:     `<?php $this->trans('@Storefront/storefront/page/example.html.twig');`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(tt.code)
+			tree := phpParser.Parse(content, nil)
+			defer tree.Close()
+
+			offset := uint(strings.Index(tt.code, "@"))
+			node := tree.RootNode().DescendantForByteRange(offset, offset)
+
+			params := &protocol.DefinitionParams{Node: node, DocumentContent: content}
+			params.TextDocument.URI = "file:///project/src/Controller/ExampleController.php"
+
+			var paths []string
+			for _, location := range provider.GetDefinition(context.Background(), params) {
+				assert.Equal(t, 0, location.Range.Start.Line)
+				paths = append(paths, strings.TrimPrefix(location.URI, "file://"))
+			}
+
+			assert.ElementsMatch(t, tt.expected, paths)
+		})
+	}
+}
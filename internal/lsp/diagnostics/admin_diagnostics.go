@@ -3,10 +3,10 @@ package diagnostics
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -27,6 +27,10 @@ func NewAdminDiagnosticsProvider(lspServer *lsp.Server) *AdminDiagnosticsProvide
 	}
 }
 
+func (p *AdminDiagnosticsProvider) ID() string {
+	return "diagnostics.admin"
+}
+
 // GetDiagnostics returns diagnostics for admin component files
 func (p *AdminDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
 	// Safety check for nil node
@@ -39,7 +43,7 @@ func (p *AdminDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri strin
 		return []protocol.Diagnostic{}, nil
 	}
 
-	ext := strings.ToLower(filepath.Ext(uri))
+	ext := indexer.FileType(uri)
 
 	// Handle JS/TS files
 	if ext == ".js" || ext == ".ts" {
@@ -54,8 +58,8 @@ func (p *AdminDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri strin
 	return []protocol.Diagnostic{}, nil
 }
 
-func (p *AdminDiagnosticsProvider) jsDiagnostics(_ context.Context, _ string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
-	var diagnostics []protocol.Diagnostic
+func (p *AdminDiagnosticsProvider) jsDiagnostics(_ context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	diagnostics := p.nameFolderMismatchDiagnostics(uri, rootNode, content)
 
 	// Find all Component.extend calls
 	extendCalls := treesitterhelper.FindAll(rootNode, admin.JSComponentCallPattern, content)
@@ -117,6 +121,53 @@ func (p *AdminDiagnosticsProvider) jsDiagnostics(_ context.Context, _ string, ro
 	return diagnostics, nil
 }
 
+// nameFolderMismatchDiagnostics warns when a registered component name differs from its folder name
+func (p *AdminDiagnosticsProvider) nameFolderMismatchDiagnostics(uri string, rootNode *tree_sitter.Node, content []byte) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+	filePath := strings.TrimPrefix(uri, "file://")
+
+	for _, callNode := range treesitterhelper.FindAll(rootNode, admin.JSComponentCallPattern, content) {
+		argsNode := callNode.ChildByFieldName("arguments")
+		if argsNode == nil || argsNode.NamedChildCount() == 0 {
+			continue
+		}
+
+		nameNode := argsNode.NamedChild(0)
+		if nameNode.Kind() != "string" {
+			continue
+		}
+
+		name := extractStringContent(nameNode, content)
+		expected := admin.ConventionalComponentName(callNode, content, filePath)
+		if name == "" || expected == "" || name == expected {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(nameNode.StartPosition().Row),
+					Character: int(nameNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(nameNode.EndPosition().Row),
+					Character: int(nameNode.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Component name '%s' does not match its folder name '%s'", name, expected),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     "admin.component.name-folder-mismatch",
+			Data: map[string]any{
+				"componentName": name,
+				"expectedName":  expected,
+			},
+		})
+	}
+
+	return diagnostics
+}
+
 // getSecondStringArg returns the second string argument from an arguments node
 func (p *AdminDiagnosticsProvider) getSecondStringArg(argsNode *tree_sitter.Node, content []byte) *tree_sitter.Node {
 	stringCount := 0
@@ -147,19 +198,195 @@ func extractStringContent(node *tree_sitter.Node, content []byte) string {
 	return strings.Trim(text, "\"'")
 }
 
-// twigDiagnostics checks Twig templates for missing required props on components
+// twigDiagnostics checks Twig templates for missing required and unknown props on components
 func (p *AdminDiagnosticsProvider) twigDiagnostics(_ context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
 	var diagnostics []protocol.Diagnostic
 
+	// Components registered locally (`components: { ... }`) by the component owning this template
+	localComponents := make(map[string]admin.LocalComponent)
+	if locals, err := p.adminIndexer.GetLocalComponents(strings.TrimPrefix(uri, "file://")); err == nil {
+		for _, local := range locals {
+			localComponents[local.Name] = local
+		}
+	}
+
 	// Find all html_start_tag nodes
-	p.findHTMLStartTags(rootNode, content, &diagnostics)
+	p.findHTMLStartTags(rootNode, content, localComponents, &diagnostics)
 
 	// Check for invalid block references in component overrides
 	p.checkBlockReferences(uri, rootNode, content, &diagnostics)
 
+	// Check for slot names that the parent component does not declare
+	p.checkSlotReferences(rootNode, content, &diagnostics)
+
 	return diagnostics, nil
 }
 
+// checkSlotReferences checks that <template #slot> and <template v-slot:slot> used inside a
+// component tag reference a slot declared by that component (or one of its parents)
+func (p *AdminDiagnosticsProvider) checkSlotReferences(rootNode *tree_sitter.Node, content []byte, diagnostics *[]protocol.Diagnostic) {
+	// html_tag nodes are siblings in the Twig AST, so the open tags are tracked in document order
+	var openTags []string
+
+	var visit func(node *tree_sitter.Node)
+	visit = func(node *tree_sitter.Node) {
+		if node == nil {
+			return
+		}
+
+		if node.Kind() == "html_start_tag" {
+			tagName := p.getTagName(node, content)
+
+			if tagName == "template" && len(openTags) > 0 {
+				parentName := openTags[len(openTags)-1]
+				if admin.IsComponentTag(parentName) {
+					p.checkSlotOnTemplate(node, parentName, content, diagnostics)
+				}
+			}
+
+			if tagName != "" && !p.isClosedOnSameTag(node, tagName, content) {
+				openTags = append(openTags, tagName)
+			}
+		}
+
+		if node.Kind() == "html_end_tag" {
+			tagName := admin.GetTagNameFromEndTag(node, content)
+			for i := len(openTags) - 1; i >= 0; i-- {
+				if openTags[i] == tagName {
+					openTags = openTags[:i]
+					break
+				}
+			}
+		}
+
+		for i := uint(0); i < node.ChildCount(); i++ {
+			visit(node.Child(i))
+		}
+	}
+
+	visit(rootNode)
+}
+
+// isClosedOnSameTag reports whether a start tag never opens a new element: self-closing tags,
+// void elements, and <template #slot></template> where the Twig parser swallows the end tag
+// into the inline_comment of the slot shorthand
+func (p *AdminDiagnosticsProvider) isClosedOnSameTag(startTag *tree_sitter.Node, tagName string, content []byte) bool {
+	text := string(startTag.Utf8Text(content))
+	if strings.HasSuffix(strings.TrimSpace(text), "/>") {
+		return true
+	}
+
+	switch tagName {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr":
+		return true
+	}
+
+	for i := uint(0); i < startTag.ChildCount(); i++ {
+		child := startTag.Child(i)
+		if child.Kind() == "inline_comment" && strings.Contains(string(child.Utf8Text(content)), "</"+tagName+">") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkSlotOnTemplate validates the slot referenced by a <template> start tag against the parent component
+func (p *AdminDiagnosticsProvider) checkSlotOnTemplate(startTag *tree_sitter.Node, componentName string, content []byte, diagnostics *[]protocol.Diagnostic) {
+	slotName, slotRange, ok := p.getTemplateSlot(startTag, content)
+	if !ok || slotName == "default" {
+		return
+	}
+
+	components, err := p.adminIndexer.GetComponentWithInheritance(componentName)
+	if err != nil || len(components) == 0 {
+		return
+	}
+
+	validSlots := make(map[string]bool)
+	for _, slot := range components[0].Slots {
+		validSlots[slot.Name] = true
+	}
+
+	// Without any known slot the template could not be parsed, don't guess
+	if len(validSlots) == 0 || validSlots[slotName] {
+		return
+	}
+
+	*diagnostics = append(*diagnostics, protocol.Diagnostic{
+		Range:    slotRange,
+		Message:  fmt.Sprintf("Slot '%s' does not exist on component '%s'", slotName, componentName),
+		Source:   "shopware",
+		Severity: protocol.DiagnosticSeverityWarning,
+		Code:     "admin.component.unknown-slot",
+		Data: map[string]any{
+			"componentName": componentName,
+			"slotName":      slotName,
+		},
+	})
+}
+
+// getTemplateSlot extracts the slot name and its range from a <template> start tag
+// Supports the shorthand (#slot, parsed as inline_comment by the Twig parser) and v-slot:slot
+func (p *AdminDiagnosticsProvider) getTemplateSlot(startTag *tree_sitter.Node, content []byte) (string, protocol.Range, bool) {
+	for i := uint(0); i < startTag.ChildCount(); i++ {
+		child := startTag.Child(i)
+
+		var nameNode *tree_sitter.Node
+		var prefix string
+
+		switch child.Kind() {
+		case "inline_comment":
+			nameNode = child
+			prefix = "#"
+		case "html_attribute":
+			nameNode = treesitterhelper.GetFirstNodeOfKind(child, "html_attribute_name")
+			if nameNode == nil {
+				continue
+			}
+			text := string(nameNode.Utf8Text(content))
+			if strings.HasPrefix(text, "#") {
+				prefix = "#"
+			} else if strings.HasPrefix(text, "v-slot:") {
+				prefix = "v-slot:"
+			}
+		}
+
+		if nameNode == nil || prefix == "" {
+			continue
+		}
+
+		text := string(nameNode.Utf8Text(content))
+		if !strings.HasPrefix(text, prefix) {
+			continue
+		}
+
+		slotName := text[len(prefix):]
+		if end := strings.IndexAny(slotName, "=> \t\r\n/"); end != -1 {
+			slotName = slotName[:end]
+		}
+
+		// Dynamic slot names (#[name]) can't be checked statically
+		if slotName == "" || strings.HasPrefix(slotName, "[") {
+			return "", protocol.Range{}, false
+		}
+
+		start := nameNode.StartPosition()
+		return slotName, protocol.Range{
+			Start: protocol.Position{
+				Line:      int(start.Row),
+				Character: int(start.Column) + len(prefix),
+			},
+			End: protocol.Position{
+				Line:      int(start.Row),
+				Character: int(start.Column) + len(prefix) + len(slotName),
+			},
+		}, true
+	}
+
+	return "", protocol.Range{}, false
+}
+
 // checkBlockReferences checks if blocks referenced in an override template exist in the parent component
 func (p *AdminDiagnosticsProvider) checkBlockReferences(uri string, rootNode *tree_sitter.Node, content []byte, diagnostics *[]protocol.Diagnostic) {
 	// Get the file path from URI
@@ -279,24 +506,24 @@ func (p *AdminDiagnosticsProvider) getBlockIdentifierNode(blockNode *tree_sitter
 }
 
 // findHTMLStartTags recursively finds all html_start_tag nodes and checks for missing required props
-func (p *AdminDiagnosticsProvider) findHTMLStartTags(node *tree_sitter.Node, content []byte, diagnostics *[]protocol.Diagnostic) {
+func (p *AdminDiagnosticsProvider) findHTMLStartTags(node *tree_sitter.Node, content []byte, localComponents map[string]admin.LocalComponent, diagnostics *[]protocol.Diagnostic) {
 	if node == nil {
 		return
 	}
 
 	if node.Kind() == "html_start_tag" {
-		p.checkComponentProps(node, content, diagnostics)
+		p.checkComponentProps(node, content, localComponents, diagnostics)
 	}
 
 	// Recurse into children
 	for i := uint(0); i < node.ChildCount(); i++ {
-		p.findHTMLStartTags(node.Child(i), content, diagnostics)
+		p.findHTMLStartTags(node.Child(i), content, localComponents, diagnostics)
 	}
 }
 
 // checkComponentProps checks if a component tag has all required props
 // <sw-button<caret>> - checks that all required props are present
-func (p *AdminDiagnosticsProvider) checkComponentProps(startTag *tree_sitter.Node, content []byte, diagnostics *[]protocol.Diagnostic) {
+func (p *AdminDiagnosticsProvider) checkComponentProps(startTag *tree_sitter.Node, content []byte, localComponents map[string]admin.LocalComponent, diagnostics *[]protocol.Diagnostic) {
 	// Get the tag name
 	tagName := p.getTagName(startTag, content)
 	if tagName == "" {
@@ -308,13 +535,23 @@ func (p *AdminDiagnosticsProvider) checkComponentProps(startTag *tree_sitter.Nod
 		return
 	}
 
-	// Get the component definition
-	components, err := p.adminIndexer.GetComponentWithDefinition(tagName)
-	if err != nil || len(components) == 0 {
-		return // Component not found - could add a diagnostic for this too
+	// Get the component definition including inherited props, locally registered components take precedence
+	var comp admin.VueComponent
+	if local, ok := localComponents[tagName]; ok {
+		localComp, err := p.adminIndexer.GetLocalComponentDefinition(local)
+		if err != nil || localComp == nil {
+			return
+		}
+		comp = p.adminIndexer.ResolveInheritance(*localComp)
+	} else {
+		components, err := p.adminIndexer.GetComponentWithInheritance(tagName)
+		if err != nil || len(components) == 0 {
+			return // Component not found - could add a diagnostic for this too
+		}
+		comp = components[0]
 	}
 
-	comp := components[0]
+	p.checkUnknownProps(startTag, tagName, comp, content, diagnostics)
 
 	// Get the attributes present on the tag
 	presentAttrs := p.getTagAttributes(startTag, content)
@@ -359,6 +596,154 @@ func (p *AdminDiagnosticsProvider) checkComponentProps(startTag *tree_sitter.Nod
 	}
 }
 
+// standardAttributes are passed through to the root element of a component and are never props:
+// the global HTML attributes, the attributes of form elements and the special Vue attributes
+var standardAttributes = map[string]bool{
+	// Vue
+	"key":  true,
+	"ref":  true,
+	"is":   true,
+	"slot": true,
+
+	// Global HTML attributes
+	"accesskey":       true,
+	"autocapitalize":  true,
+	"autofocus":       true,
+	"class":           true,
+	"contenteditable": true,
+	"dir":             true,
+	"draggable":       true,
+	"enterkeyhint":    true,
+	"hidden":          true,
+	"id":              true,
+	"inert":           true,
+	"inputmode":       true,
+	"lang":            true,
+	"nonce":           true,
+	"part":            true,
+	"popover":         true,
+	"role":            true,
+	"spellcheck":      true,
+	"style":           true,
+	"tabindex":        true,
+	"title":           true,
+	"translate":       true,
+
+	// Form and input attributes, validation is forwarded by the Shopware form fields
+	"accept":         true,
+	"autocomplete":   true,
+	"checked":        true,
+	"cols":           true,
+	"disabled":       true,
+	"for":            true,
+	"form":           true,
+	"formnovalidate": true,
+	"list":           true,
+	"max":            true,
+	"maxlength":      true,
+	"min":            true,
+	"minlength":      true,
+	"multiple":       true,
+	"name":           true,
+	"novalidate":     true,
+	"pattern":        true,
+	"placeholder":    true,
+	"readonly":       true,
+	"required":       true,
+	"rows":           true,
+	"selected":       true,
+	"size":           true,
+	"step":           true,
+	"type":           true,
+	"validation":     true,
+	"value":          true,
+	"wrap":           true,
+
+	// Link and media attributes
+	"alt":    true,
+	"height": true,
+	"href":   true,
+	"rel":    true,
+	"src":    true,
+	"target": true,
+	"width":  true,
+}
+
+// checkUnknownProps reports attributes on a component tag that are neither a prop of the component
+// nor a standard HTML/Vue attribute
+// <sw-button lable="Save"> - hints that lable is not a prop of sw-button
+func (p *AdminDiagnosticsProvider) checkUnknownProps(startTag *tree_sitter.Node, tagName string, comp admin.VueComponent, content []byte, diagnostics *[]protocol.Diagnostic) {
+	// Props of mixins are not indexed and inheritAttrs components handle any attribute
+	if comp.UnlistedProps {
+		return
+	}
+
+	validProps := make(map[string]bool)
+	for _, prop := range comp.Props {
+		validProps[prop.Name] = true
+	}
+
+	// Without any known prop the definition could not be parsed, don't guess
+	if len(validProps) == 0 {
+		return
+	}
+
+	for i := uint(0); i < startTag.ChildCount(); i++ {
+		child := startTag.Child(i)
+		if child.Kind() != "html_attribute" {
+			continue
+		}
+
+		nameNode := p.getAttributeNameNode(child)
+		if nameNode == nil {
+			continue
+		}
+
+		attrName := string(nameNode.Utf8Text(content))
+		if p.isPassThroughAttribute(attrName) {
+			continue
+		}
+
+		// Event handlers and directives normalize to an empty name
+		propName := admin.NormalizePropName(attrName)
+		if propName == "" || validProps[propName] || standardAttributes[camelToKebab(propName)] {
+			continue
+		}
+
+		*diagnostics = append(*diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(nameNode.StartPosition().Row),
+					Character: int(nameNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(nameNode.EndPosition().Row),
+					Character: int(nameNode.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Unknown prop '%s' on component '%s'", attrName, tagName),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityHint,
+			Code:     "admin.component.unknown-prop",
+			Data: map[string]any{
+				"componentName": tagName,
+				"propName":      propName,
+			},
+		})
+	}
+}
+
+// isPassThroughAttribute reports whether an attribute is never matched against props:
+// data-* and aria-* attributes, slot shorthands and names built by Twig expressions
+func (p *AdminDiagnosticsProvider) isPassThroughAttribute(attrName string) bool {
+	name := strings.TrimPrefix(strings.TrimPrefix(attrName, "v-bind:"), ":")
+
+	return strings.HasPrefix(name, "data-") ||
+		strings.HasPrefix(name, "aria-") ||
+		strings.HasPrefix(name, "#") ||
+		strings.ContainsAny(name, "{[")
+}
+
 // getTagName extracts the tag name from an html_start_tag node
 func (p *AdminDiagnosticsProvider) getTagName(startTag *tree_sitter.Node, content []byte) string {
 	return admin.GetTagNameFromStartTag(startTag, content)
@@ -394,13 +779,21 @@ func (p *AdminDiagnosticsProvider) getTagAttributes(startTag *tree_sitter.Node,
 
 // getAttributeName extracts the attribute name from an html_attribute node
 func (p *AdminDiagnosticsProvider) getAttributeName(attrNode *tree_sitter.Node, content []byte) string {
+	if nameNode := p.getAttributeNameNode(attrNode); nameNode != nil {
+		return string(nameNode.Utf8Text(content))
+	}
+	return ""
+}
+
+// getAttributeNameNode returns the html_attribute_name or vue_directive node of an html_attribute
+func (p *AdminDiagnosticsProvider) getAttributeNameNode(attrNode *tree_sitter.Node) *tree_sitter.Node {
 	for i := uint(0); i < attrNode.ChildCount(); i++ {
 		child := attrNode.Child(i)
 		if child.Kind() == "html_attribute_name" || child.Kind() == "vue_directive" {
-			return string(child.Utf8Text(content))
+			return child
 		}
 	}
-	return ""
+	return nil
 }
 
 // isPropPresent checks if a prop is present in the attributes
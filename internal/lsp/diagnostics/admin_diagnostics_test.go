@@ -3,9 +3,11 @@ package diagnostics
 import (
 	"context"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -87,6 +89,71 @@ func TestAdminDiagnosticsProvider_UndefinedParent(t *testing.T) {
 	}
 }
 
+func TestAdminDiagnosticsProvider_NameFolderMismatch(t *testing.T) {
+	provider := &AdminDiagnosticsProvider{}
+	adminDir := "file:///project/src/Resources/app/administration/src"
+
+	tests := []struct {
+		name           string
+		code           string
+		uri            string
+		expectMismatch bool
+	}{
+		{
+			name:           "lazy import folder differs from name",
+			code:           `Component.register('sw-foo', () => import('./component/sw-foo-bar'));`,
+			uri:            adminDir + "/module/sw-foo/index.js",
+			expectMismatch: true,
+		},
+		{
+			name: "lazy import folder matches name",
+			code: `Component.register('sw-foo-bar', () => import('./component/sw-foo-bar'));`,
+			uri:  adminDir + "/module/sw-foo/index.js",
+		},
+		{
+			name:           "inline definition in differently named folder",
+			code:           `Component.register('sw-other', {});`,
+			uri:            adminDir + "/component/sw-foo-bar/index.js",
+			expectMismatch: true,
+		},
+		{
+			name: "inline definition in matching folder",
+			code: `Component.extend('sw-foo-bar', 'sw-parent', {});`,
+			uri:  adminDir + "/component/sw-foo-bar/index.js",
+		},
+		{
+			name: "folder without convention is ignored",
+			code: `Component.register('sw-foo', () => import('./index'));`,
+			uri:  adminDir + "/main.js",
+		},
+		{
+			name: "dynamic import path is ignored",
+			code: "Component.register('sw-foo', () => import(`./component/${name}`));",
+			uri:  adminDir + "/module/sw-foo/index.js",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseJS(t, tt.code)
+			defer tree.Close()
+			defer parser.Close()
+
+			diagnostics := provider.nameFolderMismatchDiagnostics(tt.uri, tree.RootNode(), []byte(tt.code))
+
+			if !tt.expectMismatch {
+				assert.Empty(t, diagnostics)
+				return
+			}
+
+			require.Len(t, diagnostics, 1)
+			assert.Equal(t, "admin.component.name-folder-mismatch", diagnostics[0].Code)
+			assert.Equal(t, 0, diagnostics[0].Range.Start.Line)
+			assert.Contains(t, diagnostics[0].Message, "does not match its folder name")
+		})
+	}
+}
+
 func TestAdminDiagnosticsProvider_DefinedParent(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -564,3 +631,353 @@ func TestAdminDiagnosticsProvider_BlockReferences_NoOverride(t *testing.T) {
 
 	assert.Empty(t, diagnostics, "Regular components should not produce block reference diagnostics")
 }
+
+func TestAdminDiagnosticsProvider_UnknownSlots(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	err = adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-card",
+		FilePath: "/admin/sw-card/index.js",
+		Slots: []admin.VueComponentSlot{
+			{Name: "default", Line: 1},
+			{Name: "header", Line: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	err = adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-custom-card",
+		FilePath:         "/admin/sw-custom-card/index.js",
+		ExtendsComponent: "sw-card",
+		Slots: []admin.VueComponentSlot{
+			{Name: "toolbar", Line: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	provider := &AdminDiagnosticsProvider{
+		adminIndexer: adminIndexer,
+	}
+
+	tests := []struct {
+		name            string
+		twigCode        string
+		expectSlotNames []string
+	}{
+		{
+			name: "known slots",
+			twigCode: `<sw-card>
+    <template #header>
+        <p>Header</p>
+    </template>
+    <template #default>content</template>
+</sw-card>`,
+		},
+		{
+			name: "unknown shorthand slot",
+			twigCode: `<sw-card>
+    <template #footer>
+        <p>Footer</p>
+    </template>
+</sw-card>`,
+			expectSlotNames: []string{"footer"},
+		},
+		{
+			name: "unknown v-slot",
+			twigCode: `<sw-card>
+    <template v-slot:actions="{ item }">x</template>
+</sw-card>`,
+			expectSlotNames: []string{"actions"},
+		},
+		{
+			name: "default is always allowed",
+			twigCode: `<sw-custom-card>
+    <template #default>content</template>
+</sw-custom-card>`,
+		},
+		{
+			name: "inherited slots are known",
+			twigCode: `<sw-custom-card>
+    <template #toolbar></template>
+    <template #header></template>
+    <template #missing></template>
+</sw-custom-card>`,
+			expectSlotNames: []string{"missing"},
+		},
+		{
+			name: "template outside of a component",
+			twigCode: `<div>
+    <template #anything>x</template>
+</div>`,
+		},
+		{
+			name: "unknown component is ignored",
+			twigCode: `<sw-unknown>
+    <template #anything>x</template>
+</sw-unknown>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseTwig(t, tt.twigCode)
+			defer tree.Close()
+			defer parser.Close()
+
+			uri := "file:///project/src/Resources/app/administration/src/module/sw-test/sw-test.html.twig"
+			diagnostics, err := provider.GetDiagnostics(context.Background(), uri, tree.RootNode(), []byte(tt.twigCode))
+			require.NoError(t, err)
+
+			var slotNames []string
+			for _, diag := range diagnostics {
+				if diag.Code != "admin.component.unknown-slot" {
+					continue
+				}
+				data := diag.Data.(map[string]any)
+				slotNames = append(slotNames, data["slotName"].(string))
+
+				// The range covers exactly the slot name
+				lines := strings.Split(tt.twigCode, "\n")
+				line := lines[diag.Range.Start.Line]
+				assert.Equal(t, data["slotName"], line[diag.Range.Start.Character:diag.Range.End.Character])
+			}
+
+			assert.Equal(t, tt.expectSlotNames, slotNames)
+		})
+	}
+}
+
+func TestAdminDiagnosticsProvider_LocalComponentRequiredProps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	adminDir := filepath.Join(tempDir, "src", "Resources", "app", "administration", "src", "component")
+	parentTemplatePath := filepath.Join(adminDir, "sw-local-parent", "sw-local-parent.html.twig")
+	localDefinitionPath := filepath.Join(adminDir, "sw-local-parent", "sw-local-child", "index.js")
+
+	err = adminIndexer.SaveComponent(admin.VueComponent{
+		Name:         "sw-local-parent",
+		FilePath:     filepath.Join(adminDir, "sw-local-parent", "index.js"),
+		TemplatePath: parentTemplatePath,
+		LocalComponents: []admin.LocalComponent{
+			{Name: "sw-local-child", Identifier: "SwLocalChild", ImportPath: localDefinitionPath},
+		},
+	})
+	require.NoError(t, err)
+
+	err = adminIndexer.SaveComponentDefinition(filepath.Join(adminDir, "sw-local-parent", "sw-local-child"), admin.ComponentDefinition{
+		FilePath: localDefinitionPath,
+		Props: []admin.VueComponentProp{
+			{Name: "title", Type: "String", Required: true},
+		},
+	})
+	require.NoError(t, err)
+
+	provider := &AdminDiagnosticsProvider{
+		adminIndexer: adminIndexer,
+	}
+
+	code := `<sw-local-child></sw-local-child>`
+	tree, parser := parseTwig(t, code)
+	defer tree.Close()
+	defer parser.Close()
+
+	// Inside the owning component's template the local component is known
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file://"+parentTemplatePath, tree.RootNode(), []byte(code))
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "admin.component.missing-required-prop", diagnostics[0].Code)
+	assert.Equal(t, "Missing required prop 'title' on component 'sw-local-child'", diagnostics[0].Message)
+
+	// Other templates don't see the local registration
+	otherTemplate := "file://" + filepath.Join(adminDir, "sw-other", "sw-other.html.twig")
+	diagnostics, err = provider.GetDiagnostics(context.Background(), otherTemplate, tree.RootNode(), []byte(code))
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
+
+func TestAdminDiagnosticsProvider_UnknownProps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	jsParser := tree_sitter.NewParser()
+	require.NoError(t, jsParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_javascript.Language())))
+	defer jsParser.Close()
+
+	components := map[string]string{
+		"sw-button": `
+Component.register('sw-button', {
+	props: {
+		label: {
+			type: String,
+			required: false,
+		},
+		isLoading: {
+			type: Boolean,
+			required: false,
+		},
+	},
+});
+`,
+		"sw-mixin-button": `
+Component.register('sw-mixin-button', {
+	mixins: [Mixin.getByName('notification')],
+	props: {
+		label: String,
+	},
+});
+`,
+		"sw-attrs-field": `
+Component.register('sw-attrs-field', {
+	inheritAttrs: false,
+	props: {
+		label: String,
+	},
+});
+`,
+		"sw-custom-button": `
+Component.extend('sw-custom-button', 'sw-button', {
+	props: {
+		icon: {
+			type: String,
+			required: false,
+		},
+	},
+});
+`,
+	}
+
+	for name, code := range components {
+		tree := jsParser.Parse([]byte(code), nil)
+		filePath := filepath.Join(tempDir, "src", "Resources", "app", "administration", "src", "component", name, "index.js")
+		require.NoError(t, adminIndexer.Index(filePath, tree.RootNode(), []byte(code)))
+		tree.Close()
+	}
+
+	provider := &AdminDiagnosticsProvider{
+		adminIndexer: adminIndexer,
+	}
+
+	tests := []struct {
+		name        string
+		twigCode    string
+		expectProps []string
+	}{
+		{
+			name:     "known props",
+			twigCode: `<sw-button label="Save" :is-loading="isLoading"></sw-button>`,
+		},
+		{
+			name:        "misspelled prop",
+			twigCode:    `<sw-button lable="Save"></sw-button>`,
+			expectProps: []string{"lable"},
+		},
+		{
+			name:        "unknown bound prop",
+			twigCode:    `<sw-button :variant="variant"></sw-button>`,
+			expectProps: []string{"variant"},
+		},
+		{
+			name:     "standard attributes, events and directives",
+			twigCode: `<sw-button class="btn" style="color: red" v-if="show" @click="onClick" v-on:focus="onFocus" :class="classes"></sw-button>`,
+		},
+		{
+			name:     "form attributes",
+			twigCode: `<sw-button name="save" type="submit" disabled placeholder="Save" validation="required"></sw-button>`,
+		},
+		{
+			name:     "component with mixins",
+			twigCode: `<sw-mixin-button variant="primary"></sw-mixin-button>`,
+		},
+		{
+			name:     "component with inheritAttrs",
+			twigCode: `<sw-attrs-field variant="primary"></sw-attrs-field>`,
+		},
+		{
+			name:     "data and aria attributes",
+			twigCode: `<sw-button data-test="button" aria-label="Save" :data-id="id"></sw-button>`,
+		},
+		{
+			name:     "inherited props",
+			twigCode: `<sw-custom-button icon="regular-plus" label="Add"></sw-custom-button>`,
+		},
+		{
+			name:        "unknown prop on extended component",
+			twigCode:    `<sw-custom-button variant="primary"></sw-custom-button>`,
+			expectProps: []string{"variant"},
+		},
+		{
+			name:     "unknown component ignored",
+			twigCode: `<sw-unknown foo="bar"></sw-unknown>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, parser := parseTwig(t, tt.twigCode)
+			defer tree.Close()
+			defer parser.Close()
+
+			uri := "file:///project/src/Resources/app/administration/src/views/test.html.twig"
+			diagnostics, err := provider.GetDiagnostics(context.Background(), uri, tree.RootNode(), []byte(tt.twigCode))
+			require.NoError(t, err)
+
+			var props []string
+			for _, diag := range diagnostics {
+				if diag.Code != "admin.component.unknown-prop" {
+					continue
+				}
+				assert.Equal(t, protocol.DiagnosticSeverityHint, diag.Severity)
+				props = append(props, diag.Data.(map[string]any)["propName"].(string))
+			}
+
+			assert.Equal(t, tt.expectProps, props)
+		})
+	}
+}
+
+func TestAdminDiagnosticsProvider_InheritedRequiredProps(t *testing.T) {
+	tempDir := t.TempDir()
+
+	adminIndexer, err := admin.NewAdminComponentIndexer(tempDir)
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-button",
+		FilePath: "/admin/sw-button/index.js",
+		Props:    []admin.VueComponentProp{{Name: "label", Required: true}},
+	}))
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-custom-button",
+		ExtendsComponent: "sw-button",
+		FilePath:         "/admin/sw-custom-button/index.js",
+		Props:            []admin.VueComponentProp{{Name: "icon"}},
+	}))
+
+	provider := &AdminDiagnosticsProvider{
+		adminIndexer: adminIndexer,
+	}
+
+	code := `<sw-custom-button icon="regular-plus"></sw-custom-button>`
+	tree, parser := parseTwig(t, code)
+	defer tree.Close()
+	defer parser.Close()
+
+	uri := "file:///project/src/Resources/app/administration/src/views/test.html.twig"
+	diagnostics, err := provider.GetDiagnostics(context.Background(), uri, tree.RootNode(), []byte(code))
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "admin.component.missing-required-prop", diagnostics[0].Code)
+	assert.Equal(t, "Missing required prop 'label' on component 'sw-custom-button'", diagnostics[0].Message)
+}
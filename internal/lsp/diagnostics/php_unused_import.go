@@ -0,0 +1,61 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPUnusedImportProvider reports use statements importing classes which are never referenced in the file
+type PHPUnusedImportProvider struct{}
+
+func NewPHPUnusedImportProvider() *PHPUnusedImportProvider {
+	return &PHPUnusedImportProvider{}
+}
+
+func (p *PHPUnusedImportProvider) ID() string {
+	return "diagnostics.php-unused-import"
+}
+
+func (p *PHPUnusedImportProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".php" {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	var diagnostics []protocol.Diagnostic
+	for _, clause := range php.UnusedUseClauses(rootNode, content) {
+		// A use statement with a single import is marked completely, otherwise only the clause
+		node := clause.Clause
+		if clause.Declaration.ChildByFieldName("body") == nil && clause.Declaration.NamedChildCount() == 1 {
+			node = clause.Declaration
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(node.StartPosition().Row),
+					Character: int(node.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(node.EndPosition().Row),
+					Character: int(node.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Import '%s' is never used", clause.FQCN),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityHint,
+			Code:     "php.unused-use",
+			Tags:     []protocol.DiagnosticTag{protocol.DiagnosticTagUnnecessary},
+			Data: map[string]any{
+				"fqcn": clause.FQCN,
+				"name": clause.Name,
+			},
+		})
+	}
+
+	return diagnostics, nil
+}
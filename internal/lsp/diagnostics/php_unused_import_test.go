@@ -0,0 +1,93 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestPHPUnusedImportProvider(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	code := `<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\Uuid\Uuid;
+use Shopware\Core\Checkout\Cart\Cart;
+use Shopware\Core\Content\Product\ProductEntity;
+use Shopware\Core\Content\Product\ProductCollection as Products;
+use Shopware\Core\Framework\Log\Package;
+use Shopware\Core\Framework\DataAbstractionLayer\{EntityRepository, Search\Criteria, Search\Filter\EqualsFilter as Equals};
+use Shopware\Core\Framework\Struct\Subscriber;
+use Symfony\Component\EventDispatcher\EventSubscriberInterface;
+use Shopware\Core\Framework\Routing\Exception;
+use Psr\Log\LoggerInterface;
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape;
+
+#[Package('checkout')]
+class ProductSubscriber implements EventSubscriberInterface
+{
+    /**
+     * @param Products<ProductEntity> $products
+     */
+    public function __construct(private readonly EntityRepository $repository, $products)
+    {
+    }
+
+    public function onLoad(Context $context): void
+    {
+        $criteria = new Criteria([Uuid::randomHex()]);
+        $this->Cart;
+        $Equals = Exception\Handler::class;
+    }
+}
+`
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	provider := NewPHPUnusedImportProvider()
+
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/src/Subscriber/ProductSubscriber.php", tree.RootNode(), []byte(code))
+	require.NoError(t, err)
+
+	unused := make(map[string]protocol.Diagnostic)
+	for _, diagnostic := range diagnostics {
+		unused[diagnostic.Data.(map[string]any)["fqcn"].(string)] = diagnostic
+	}
+
+	assert.Len(t, unused, 4)
+	assert.Contains(t, unused, "Shopware\\Core\\Checkout\\Cart\\Cart")
+	assert.Contains(t, unused, "Shopware\\Core\\Framework\\Struct\\Subscriber")
+	assert.Contains(t, unused, "Psr\\Log\\LoggerInterface")
+
+	cart := unused["Shopware\\Core\\Checkout\\Cart\\Cart"]
+	assert.Equal(t, "php.unused-use", cart.Code)
+	assert.Equal(t, protocol.DiagnosticSeverityHint, cart.Severity)
+	assert.Equal(t, []protocol.DiagnosticTag{protocol.DiagnosticTagUnnecessary}, cart.Tags)
+	assert.Equal(t, "Import 'Shopware\\Core\\Checkout\\Cart\\Cart' is never used", cart.Message)
+	assert.Equal(t, protocol.Range{Start: protocol.Position{Line: 6}, End: protocol.Position{Line: 6, Character: 37}}, cart.Range)
+
+	// Unused imports of a group use statement only mark the clause
+	equals, ok := unused["Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Filter\\EqualsFilter"]
+	require.True(t, ok)
+	assert.Equal(t, "Equals", equals.Data.(map[string]any)["name"])
+	assert.Equal(t, protocol.Range{Start: protocol.Position{Line: 10, Character: 85}, End: protocol.Position{Line: 10, Character: 121}}, equals.Range)
+}
+
+func TestPHPUnusedImportProvider_SkipsOtherFiles(t *testing.T) {
+	provider := NewPHPUnusedImportProvider()
+
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/src/Resources/config/services.xml", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
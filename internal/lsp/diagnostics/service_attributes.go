@@ -0,0 +1,194 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// requiredAttribute is an attribute the container needs on an element of a service file
+type requiredAttribute struct {
+	element   string
+	attribute string
+	// applies reports whether the attribute is required for this occurrence of the element, nil means always
+	applies func(element *tree_sitter.Node, attributes map[string]string, content []byte) bool
+}
+
+var requiredServiceAttributes = []requiredAttribute{
+	{
+		// Inline services passed as an argument don't need an id
+		element:   "service",
+		attribute: "id",
+		applies: func(element *tree_sitter.Node, attributes map[string]string, content []byte) bool {
+			return xmlParentElementName(element, content) == "services"
+		},
+	},
+	{
+		// The name can also be given as text, e.g. <tag>kernel.event_subscriber</tag>
+		element:   "tag",
+		attribute: "name",
+		applies: func(element *tree_sitter.Node, attributes map[string]string, content []byte) bool {
+			return xmlElementText(element, content) == ""
+		},
+	},
+	{
+		element:   "argument",
+		attribute: "id",
+		applies: func(element *tree_sitter.Node, attributes map[string]string, content []byte) bool {
+			return (attributes["type"] == "service" || attributes["type"] == "service_closure") &&
+				!slices.Contains(xmlChildElementNames(element, content), "service")
+		},
+	},
+	{
+		element:   "argument",
+		attribute: "tag",
+		applies: func(element *tree_sitter.Node, attributes map[string]string, content []byte) bool {
+			return attributes["type"] == "tagged" || attributes["type"] == "tagged_iterator" || attributes["type"] == "tagged_locator"
+		},
+	},
+	{element: "alias", attribute: "id"},
+	{element: "alias", attribute: "service"},
+	{element: "call", attribute: "method"},
+}
+
+// ServiceAttributeDiagnosticsProvider warns about elements in service files missing an attribute
+// the container requires, which otherwise only shows up as an error when the container is built
+type ServiceAttributeDiagnosticsProvider struct{}
+
+func NewServiceAttributeDiagnosticsProvider() *ServiceAttributeDiagnosticsProvider {
+	return &ServiceAttributeDiagnosticsProvider{}
+}
+
+func (p *ServiceAttributeDiagnosticsProvider) ID() string {
+	return "diagnostics.service-attributes"
+}
+
+func (p *ServiceAttributeDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".xml" || !symfony.IsServiceXML(content) {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	var diagnostics []protocol.Diagnostic
+
+	for _, element := range treesitterhelper.FindAll(rootNode, treesitterhelper.NodeKind("element"), content) {
+		startTag := element.NamedChild(0)
+		if startTag == nil {
+			continue
+		}
+
+		nameNode := treesitterhelper.GetFirstNodeOfKind(startTag, "Name")
+		if nameNode == nil {
+			continue
+		}
+
+		name := nameNode.Utf8Text(content)
+		attributes := treesitterhelper.GetXmlAttributeValues(startTag, content)
+
+		for _, required := range requiredServiceAttributes {
+			if required.element != name || attributes[required.attribute] != "" {
+				continue
+			}
+			if required.applies != nil && !required.applies(element, attributes, content) {
+				continue
+			}
+
+			diagnostics = append(diagnostics, protocol.Diagnostic{
+				Range: protocol.Range{
+					Start: protocol.Position{
+						Line:      int(nameNode.StartPosition().Row),
+						Character: int(nameNode.StartPosition().Column),
+					},
+					End: protocol.Position{
+						Line:      int(nameNode.EndPosition().Row),
+						Character: int(nameNode.EndPosition().Column),
+					},
+				},
+				Message:  fmt.Sprintf("Element '<%s>' requires the attribute '%s'", name, required.attribute),
+				Source:   "shopware",
+				Severity: protocol.DiagnosticSeverityWarning,
+				Code:     fmt.Sprintf("symfony.%s.missing-%s", name, required.attribute),
+				Data: map[string]any{
+					"element":   name,
+					"attribute": required.attribute,
+				},
+			})
+		}
+	}
+
+	return diagnostics, nil
+}
+
+// xmlParentElementName returns the tag name of the element containing the given element
+func xmlParentElementName(element *tree_sitter.Node, content []byte) string {
+	parent := element.Parent()
+	if parent != nil && parent.Kind() == "content" {
+		parent = parent.Parent()
+	}
+	if parent == nil || parent.Kind() != "element" || parent.NamedChild(0) == nil {
+		return ""
+	}
+
+	name := treesitterhelper.GetFirstNodeOfKind(parent.NamedChild(0), "Name")
+	if name == nil {
+		return ""
+	}
+
+	return name.Utf8Text(content)
+}
+
+// xmlElementContent returns the content node of an element, nil for empty elements
+func xmlElementContent(element *tree_sitter.Node) *tree_sitter.Node {
+	for i := uint(0); i < element.NamedChildCount(); i++ {
+		if child := element.NamedChild(i); child.Kind() == "content" {
+			return child
+		}
+	}
+
+	return nil
+}
+
+// xmlElementText returns the trimmed text directly inside an element
+func xmlElementText(element *tree_sitter.Node, content []byte) string {
+	body := xmlElementContent(element)
+	if body == nil {
+		return ""
+	}
+
+	var text strings.Builder
+	for i := uint(0); i < body.NamedChildCount(); i++ {
+		if child := body.NamedChild(i); child.Kind() == "CharData" {
+			text.WriteString(child.Utf8Text(content))
+		}
+	}
+
+	return strings.TrimSpace(text.String())
+}
+
+// xmlChildElementNames returns the tag names of the elements directly inside an element
+func xmlChildElementNames(element *tree_sitter.Node, content []byte) []string {
+	body := xmlElementContent(element)
+	if body == nil {
+		return nil
+	}
+
+	var names []string
+	for i := uint(0); i < body.NamedChildCount(); i++ {
+		child := body.NamedChild(i)
+		if child.Kind() != "element" || child.NamedChild(0) == nil {
+			continue
+		}
+
+		if name := treesitterhelper.GetFirstNodeOfKind(child.NamedChild(0), "Name"); name != nil {
+			names = append(names, name.Utf8Text(content))
+		}
+	}
+
+	return names
+}
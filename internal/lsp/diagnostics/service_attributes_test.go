@@ -0,0 +1,75 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestServiceAttributeDiagnosticsProvider(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	content := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service class="Foo\Mailer"/>
+
+        <service id="Foo\Newsletter">
+            <argument type="service"/>
+            <argument type="service">
+                <service class="Foo\Inline"/>
+            </argument>
+            <argument type="tagged_iterator"/>
+            <argument type="collection"/>
+            <call/>
+            <tag/>
+            <tag>kernel.event_subscriber</tag>
+            <tag name="kernel.event_listener"/>
+        </service>
+
+        <alias id="foo.mailer"/>
+    </services>
+</container>`
+
+	tree := parser.Parse([]byte(content), nil)
+	defer tree.Close()
+
+	provider := NewServiceAttributeDiagnosticsProvider()
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/src/Resources/config/services.xml", tree.RootNode(), []byte(content))
+	require.NoError(t, err)
+
+	type result struct {
+		line    int
+		code    any
+		message string
+	}
+
+	var results []result
+	for _, diagnostic := range diagnostics {
+		results = append(results, result{line: diagnostic.Range.Start.Line, code: diagnostic.Code, message: diagnostic.Message})
+	}
+
+	assert.Equal(t, []result{
+		{line: 3, code: "symfony.service.missing-id", message: "Element '<service>' requires the attribute 'id'"},
+		{line: 6, code: "symfony.argument.missing-id", message: "Element '<argument>' requires the attribute 'id'"},
+		{line: 10, code: "symfony.argument.missing-tag", message: "Element '<argument>' requires the attribute 'tag'"},
+		{line: 12, code: "symfony.call.missing-method", message: "Element '<call>' requires the attribute 'method'"},
+		{line: 13, code: "symfony.tag.missing-name", message: "Element '<tag>' requires the attribute 'name'"},
+		{line: 18, code: "symfony.alias.missing-service", message: "Element '<alias>' requires the attribute 'service'"},
+	}, results)
+
+	// The range covers the element name
+	assert.Equal(t, 9, diagnostics[0].Range.Start.Character)
+	assert.Equal(t, 16, diagnostics[0].Range.End.Character)
+
+	// Other XML files are not checked
+	diagnostics, err = provider.GetDiagnostics(context.Background(), "file:///project/src/Resources/config/config.xml", tree.RootNode(), []byte("<config><tag/></config>"))
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
@@ -0,0 +1,271 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ServiceDiagnosticsProvider provides diagnostics for Symfony service definitions
+type ServiceDiagnosticsProvider struct {
+	projectRoot    string
+	serviceIndex   *symfony.ServiceIndex
+	phpIndex       *php.PHPIndex
+	extensionIndex *extension.ExtensionIndexer
+}
+
+func NewServiceDiagnosticsProvider(projectRoot string, lspServer *lsp.Server) *ServiceDiagnosticsProvider {
+	serviceIndex, _ := lspServer.GetIndexer("symfony.service")
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+	extensionIndex, _ := lspServer.GetIndexer("extension.indexer")
+
+	return &ServiceDiagnosticsProvider{
+		projectRoot:    projectRoot,
+		serviceIndex:   serviceIndex.(*symfony.ServiceIndex),
+		phpIndex:       phpIndex.(*php.PHPIndex),
+		extensionIndex: extensionIndex.(*extension.ExtensionIndexer),
+	}
+}
+
+func (s *ServiceDiagnosticsProvider) ID() string {
+	return "diagnostics.service"
+}
+
+func (s *ServiceDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".xml" {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	diagnostics := s.duplicateServiceDiagnostics(uri, rootNode, content)
+	diagnostics = append(diagnostics, s.unknownCallMethodDiagnostics(rootNode, content)...)
+	diagnostics = append(diagnostics, s.unresolvedImportDiagnostics(uri, rootNode, content)...)
+
+	return diagnostics, nil
+}
+
+// duplicateServiceDiagnostics warns about service ids which are defined elsewhere as well,
+// as the container silently keeps only the definition loaded last
+func (s *ServiceDiagnosticsProvider) duplicateServiceDiagnostics(uri string, rootNode *tree_sitter.Node, content []byte) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+	filePath := strings.TrimPrefix(uri, "file://")
+
+	idNodes := treesitterhelper.FindAll(rootNode, treesitterhelper.FuncPattern(treesitterhelper.SymfonyServiceIsDefinitionId), content)
+
+	// The index of this file may be outdated while it is edited, its definitions are taken from the tree
+	fileDefinitions := make(map[string][]*tree_sitter.Node)
+	for _, idNode := range idNodes {
+		serviceID := strings.Trim(idNode.Utf8Text(content), "\"'")
+		fileDefinitions[serviceID] = append(fileDefinitions[serviceID], idNode)
+	}
+
+	for _, idNode := range idNodes {
+		serviceID := strings.Trim(idNode.Utf8Text(content), "\"'")
+		if serviceID == "" {
+			continue
+		}
+
+		var otherDefinitions []string
+		var related []protocol.DiagnosticRelatedInformation
+		addDefinition := func(path string, line int) {
+			otherDefinitions = append(otherDefinitions, fmt.Sprintf("%s:%d", s.relativePath(path), line))
+			related = append(related, protocol.DiagnosticRelatedInformation{
+				Location: protocol.Location{
+					URI: "file://" + path,
+					Range: protocol.Range{
+						Start: protocol.Position{Line: line - 1},
+						End:   protocol.Position{Line: line - 1},
+					},
+				},
+				Message: fmt.Sprintf("Service '%s' is also defined here", serviceID),
+			})
+		}
+
+		for _, other := range fileDefinitions[serviceID] {
+			if other.StartByte() != idNode.StartByte() {
+				addDefinition(filePath, int(other.StartPosition().Row)+1)
+			}
+		}
+
+		for _, definition := range s.serviceIndex.GetServiceDefinitions(serviceID) {
+			if definition.Path != filePath {
+				addDefinition(definition.Path, definition.Line)
+			}
+		}
+
+		if len(otherDefinitions) == 0 {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(idNode.StartPosition().Row),
+					Character: int(idNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(idNode.EndPosition().Row),
+					Character: int(idNode.EndPosition().Column),
+				},
+			},
+			Message:            fmt.Sprintf("Service '%s' is also defined in %s", serviceID, strings.Join(otherDefinitions, ", ")),
+			Source:             "shopware",
+			Severity:           protocol.DiagnosticSeverityWarning,
+			Code:               "symfony.service.duplicate-id",
+			RelatedInformation: related,
+			Data: map[string]any{
+				"serviceId": serviceID,
+			},
+		})
+	}
+
+	return diagnostics
+}
+
+// unknownCallMethodDiagnostics warns about <call method="..."> naming a method the service class doesn't have
+func (s *ServiceDiagnosticsProvider) unknownCallMethodDiagnostics(rootNode *tree_sitter.Node, content []byte) []protocol.Diagnostic {
+	if s.phpIndex == nil {
+		return nil
+	}
+
+	var diagnostics []protocol.Diagnostic
+
+	for _, methodNode := range treesitterhelper.FindAll(rootNode, treesitterhelper.FuncPattern(treesitterhelper.SymfonyServiceIsCallMethod), content) {
+		method := treesitterhelper.GetNodeText(methodNode, content)
+		className := strings.TrimPrefix(treesitterhelper.SymfonyGetEnclosingServiceClass(methodNode, content), "\\")
+		if method == "" || className == "" {
+			continue
+		}
+
+		if found, resolved := s.classHasMethod(className, method); found || !resolved {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(methodNode.StartPosition().Row),
+					Character: int(methodNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(methodNode.EndPosition().Row),
+					Character: int(methodNode.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Method '%s' does not exist in class '%s'", method, className),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     "symfony.service.unknown-method",
+			Data: map[string]any{
+				"class":  className,
+				"method": method,
+			},
+		})
+	}
+
+	return diagnostics
+}
+
+// unresolvedImportDiagnostics reports <import resource="..."/> whose file or directory doesn't exist,
+// which happens when a config file is renamed without updating its imports
+func (s *ServiceDiagnosticsProvider) unresolvedImportDiagnostics(uri string, rootNode *tree_sitter.Node, content []byte) []protocol.Diagnostic {
+	var diagnostics []protocol.Diagnostic
+	filePath := strings.TrimPrefix(uri, "file://")
+
+	for _, resourceNode := range treesitterhelper.FindAll(rootNode, treesitterhelper.FuncPattern(treesitterhelper.SymfonyServiceIsImportResource), content) {
+		resource := treesitterhelper.GetNodeText(resourceNode, content)
+		// Parameters are only known when the container is built
+		if resource == "" || strings.Contains(resource, "%") {
+			continue
+		}
+
+		// ignore-errors="not_found" or "true" allows missing resources
+		attributes := treesitterhelper.GetXmlAttributeValues(resourceNode.Parent().Parent(), content)
+		if ignoreErrors := attributes["ignore-errors"]; ignoreErrors == "not_found" || ignoreErrors == "true" {
+			continue
+		}
+
+		// Bundles which are not indexed, like the Shopware core bundles, can't be checked
+		path := symfony.ResolveImportResource(filePath, resource, s.bundleDir)
+		if path == "" || symfony.ImportResourceExists(path) {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(resourceNode.StartPosition().Row),
+					Character: int(resourceNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(resourceNode.EndPosition().Row),
+					Character: int(resourceNode.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Imported resource '%s' does not exist", resource),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityError,
+			Code:     "symfony.import.unresolved",
+			Data: map[string]any{
+				"resource": resource,
+				"path":     path,
+			},
+		})
+	}
+
+	return diagnostics
+}
+
+// bundleDir resolves the @BundleName prefix of import resources
+func (s *ServiceDiagnosticsProvider) bundleDir(name string) string {
+	if s.extensionIndex == nil {
+		return ""
+	}
+
+	return s.extensionIndex.GetBundleDir(name)
+}
+
+// classHasMethod reports whether the class or one of its parents declares the method. Resolved is false when
+// the class hierarchy is not indexed completely or uses traits, as their methods are not known.
+func (s *ServiceDiagnosticsProvider) classHasMethod(className, method string) (found, resolved bool) {
+	if s.phpIndex.GetClass(className) == nil {
+		return false, false
+	}
+
+	resolved = true
+	s.phpIndex.WalkHierarchy(className, func(class *php.PHPClass) bool {
+		for name := range class.Methods {
+			// PHP method names are case-insensitive, __call accepts any method
+			if strings.EqualFold(name, method) || name == "__call" {
+				found = true
+				return false
+			}
+		}
+
+		if (class.Parent != "" && s.phpIndex.GetClass(class.Parent) == nil) || len(class.Traits) > 0 {
+			resolved = false
+		}
+
+		return true
+	})
+
+	return found, resolved
+}
+
+// relativePath shortens paths inside the project for the diagnostic message
+func (s *ServiceDiagnosticsProvider) relativePath(path string) string {
+	if rel, err := filepath.Rel(s.projectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+
+	return path
+}
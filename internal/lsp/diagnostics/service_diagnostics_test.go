@@ -0,0 +1,262 @@
+package diagnostics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestServiceDiagnosticsProvider_DuplicateServiceIds(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	pluginA := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Foo\Mailer"/>
+        <service id="Foo\Newsletter"/>
+        <alias id="foo.mailer" service="Foo\Mailer"/>
+    </services>
+</container>`
+
+	pluginB := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Bar\Other"/>
+
+        <service id="Foo\Mailer"/>
+    </services>
+</container>`
+
+	trees := map[string]*tree_sitter.Tree{}
+	for path, code := range map[string]string{"/project/custom/plugins/A/services.xml": pluginA, "/project/custom/plugins/B/services.xml": pluginB} {
+		tree := parser.Parse([]byte(code), nil)
+		defer tree.Close()
+		require.NoError(t, serviceIndex.Index(path, tree.RootNode(), []byte(code)))
+		trees[path] = tree
+	}
+
+	provider := &ServiceDiagnosticsProvider{projectRoot: "/project", serviceIndex: serviceIndex}
+
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/custom/plugins/A/services.xml", trees["/project/custom/plugins/A/services.xml"].RootNode(), []byte(pluginA))
+	require.NoError(t, err)
+
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "symfony.service.duplicate-id", diagnostics[0].Code)
+	assert.Equal(t, "Service 'Foo\\Mailer' is also defined in custom/plugins/B/services.xml:6", diagnostics[0].Message)
+	assert.Equal(t, 3, diagnostics[0].Range.Start.Line)
+
+	require.Len(t, diagnostics[0].RelatedInformation, 1)
+	assert.Equal(t, "file:///project/custom/plugins/B/services.xml", diagnostics[0].RelatedInformation[0].Location.URI)
+	assert.Equal(t, 5, diagnostics[0].RelatedInformation[0].Location.Range.Start.Line)
+
+	t.Run("repeated definition in the same file", func(t *testing.T) {
+		code := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Baz\Mailer"/>
+        <service id="Baz\Mailer"/>
+    </services>
+</container>`
+		tree := parser.Parse([]byte(code), nil)
+		defer tree.Close()
+		require.NoError(t, serviceIndex.Index("/project/custom/plugins/C/services.xml", tree.RootNode(), []byte(code)))
+
+		diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/custom/plugins/C/services.xml", tree.RootNode(), []byte(code))
+		require.NoError(t, err)
+
+		require.Len(t, diagnostics, 2)
+		assert.Equal(t, "Service 'Baz\\Mailer' is also defined in custom/plugins/C/services.xml:5", diagnostics[0].Message)
+		assert.Equal(t, "Service 'Baz\\Mailer' is also defined in custom/plugins/C/services.xml:4", diagnostics[1].Message)
+	})
+
+	t.Run("unsaved changes of the file", func(t *testing.T) {
+		indexed := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Qux\Mailer"/>
+    </services>
+</container>`
+		indexedTree := parser.Parse([]byte(indexed), nil)
+		defer indexedTree.Close()
+		require.NoError(t, serviceIndex.Index("/project/custom/plugins/D/services.xml", indexedTree.RootNode(), []byte(indexed)))
+
+		// A line was inserted above the service, the index still has the old line
+		code := `<?xml version="1.0" ?>
+<container>
+    <services>
+
+        <service id="Qux\Mailer"/>
+    </services>
+</container>`
+		tree := parser.Parse([]byte(code), nil)
+		defer tree.Close()
+
+		diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/custom/plugins/D/services.xml", tree.RootNode(), []byte(code))
+		require.NoError(t, err)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("non XML files are ignored", func(t *testing.T) {
+		diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/services.yaml", trees["/project/custom/plugins/A/services.xml"].RootNode(), []byte(pluginA))
+		require.NoError(t, err)
+		assert.Empty(t, diagnostics)
+	})
+}
+
+func TestServiceDiagnosticsProvider_UnknownCallMethod(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	srcDir := t.TempDir()
+	for name, code := range map[string]string{
+		"Mailer.php": `<?php
+
+namespace App;
+
+abstract class AbstractMailer
+{
+    public function setLogger(Logger $logger): void {}
+}
+
+class Mailer extends AbstractMailer
+{
+    public function setTransport(Transport $transport): void {}
+}
+`,
+		"TraitUser.php": `<?php
+
+namespace App;
+
+class TraitUser
+{
+    use ContainerAwareTrait;
+}
+`,
+		"External.php": `<?php
+
+namespace App;
+
+use Vendor\Base;
+
+class External extends Base
+{
+}
+`,
+	} {
+		path := filepath.Join(srcDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(code), 0o644))
+
+		tree := phpParser.Parse([]byte(code), nil)
+		require.NoError(t, phpIndex.Index(path, tree.RootNode(), []byte(code)))
+		tree.Close()
+	}
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	content := []byte(`<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="mailer" class="App\Mailer">
+            <call method="setLogger"/>
+            <call method="SETTRANSPORT"/>
+            <call method="setMailer"/>
+        </service>
+        <service id="App\TraitUser">
+            <call method="setContainer"/>
+        </service>
+        <service id="App\External">
+            <call method="setContainer"/>
+        </service>
+        <service id="Vendor\Unknown">
+            <call method="setContainer"/>
+        </service>
+    </services>
+</container>`)
+	tree := xmlParser.Parse(content, nil)
+	defer tree.Close()
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	provider := &ServiceDiagnosticsProvider{projectRoot: "/project", serviceIndex: serviceIndex, phpIndex: phpIndex}
+
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file:///project/services.xml", tree.RootNode(), content)
+	require.NoError(t, err)
+
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "symfony.service.unknown-method", diagnostics[0].Code)
+	assert.Equal(t, "Method 'setMailer' does not exist in class 'App\\Mailer'", diagnostics[0].Message)
+	assert.Equal(t, protocol.Range{
+		Start: protocol.Position{Line: 6, Character: 25},
+		End:   protocol.Position{Line: 6, Character: 36},
+	}, diagnostics[0].Range)
+}
+
+func TestServiceDiagnosticsProvider_UnresolvedImport(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "packages"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.xml"), []byte("<container/>"), 0o644))
+
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	content := []byte(`<?xml version="1.0" ?>
+<container>
+    <imports>
+        <import resource="other.xml"/>
+        <import resource="renamed.xml"/>
+        <import resource="packages/*.xml"/>
+        <import resource="packages/"/>
+        <import resource="missing/*.xml"/>
+        <import resource="optional.xml" ignore-errors="not_found"/>
+        <import resource="%kernel.project_dir%/config/services.xml"/>
+        <import resource="@Unknown/Resources/config/services.xml"/>
+    </imports>
+</container>`)
+	tree := xmlParser.Parse(content, nil)
+	defer tree.Close()
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	provider := &ServiceDiagnosticsProvider{projectRoot: dir, serviceIndex: serviceIndex}
+
+	diagnostics, err := provider.GetDiagnostics(context.Background(), "file://"+filepath.Join(dir, "services.xml"), tree.RootNode(), content)
+	require.NoError(t, err)
+
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, "symfony.import.unresolved", diagnostics[0].Code)
+	assert.Equal(t, protocol.DiagnosticSeverityError, diagnostics[0].Severity)
+	assert.Equal(t, "Imported resource 'renamed.xml' does not exist", diagnostics[0].Message)
+	assert.Equal(t, protocol.Range{
+		Start: protocol.Position{Line: 4, Character: 25},
+		End:   protocol.Position{Line: 4, Character: 38},
+	}, diagnostics[0].Range)
+	assert.Equal(t, "Imported resource 'missing/*.xml' does not exist", diagnostics[1].Message)
+}
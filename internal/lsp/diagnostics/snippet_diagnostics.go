@@ -3,9 +3,9 @@ package diagnostics
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/snippet"
@@ -24,8 +24,12 @@ func NewSnippetDiagnosticsProvider(lspServer *lsp.Server) *SnippetDiagnosticsPro
 	}
 }
 
+func (s *SnippetDiagnosticsProvider) ID() string {
+	return "diagnostics.snippet"
+}
+
 func (s *SnippetDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
-	switch strings.ToLower(filepath.Ext(uri)) {
+	switch indexer.FileType(uri) {
 	case ".twig":
 		return s.twigDiagnostics(ctx, uri, rootNode, content)
 	case ".js", ".ts":
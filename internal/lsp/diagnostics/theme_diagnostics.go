@@ -3,10 +3,10 @@ package diagnostics
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
@@ -34,8 +34,12 @@ func NewThemeDiagnosticsProvider(projectRoot string, lspServer *lsp.Server) *The
 	}
 }
 
+func (t *ThemeDiagnosticsProvider) ID() string {
+	return "diagnostics.theme"
+}
+
 func (t *ThemeDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
-	switch strings.ToLower(filepath.Ext(uri)) {
+	switch indexer.FileType(uri) {
 	case ".twig":
 		return t.twigDiagnostics(ctx, uri, rootNode, content)
 	default:
@@ -0,0 +1,105 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TwigBlockDiagnosticsProvider reports blocks of storefront templates overriding a block which none of the
+// extended templates define, Twig silently never renders such a block
+type TwigBlockDiagnosticsProvider struct {
+	twigIndexer *twig.TwigIndexer
+}
+
+func NewTwigBlockDiagnosticsProvider(lspServer *lsp.Server) *TwigBlockDiagnosticsProvider {
+	twigIndexer, _ := lspServer.GetIndexer("twig.indexer")
+
+	return &TwigBlockDiagnosticsProvider{
+		twigIndexer: twigIndexer.(*twig.TwigIndexer),
+	}
+}
+
+func (p *TwigBlockDiagnosticsProvider) ID() string {
+	return "diagnostics.twig-block"
+}
+
+func (p *TwigBlockDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".twig" {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	overrides := findOverridingBlocks(rootNode)
+	if len(overrides) == 0 {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	currentFile, parentBlocks, err := extendedBlockNames(p.twigIndexer, uri, rootNode, content)
+	if err != nil {
+		return nil, err
+	}
+	if parentBlocks == nil {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	diagnostics := []protocol.Diagnostic{}
+	for _, nameNode := range overrides {
+		blockName := nameNode.Utf8Text(content)
+		if parentBlocks[blockName] {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(nameNode.StartPosition().Row),
+					Character: int(nameNode.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(nameNode.EndPosition().Row),
+					Character: int(nameNode.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("Block '%s' does not exist in '%s'", blockName, currentFile.ExtendsFile),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityWarning,
+			Code:     "twig.block-not-found",
+			Data: map[string]any{
+				"blockName": blockName,
+			},
+		})
+	}
+
+	return diagnostics, nil
+}
+
+// findOverridingBlocks returns the name nodes of the outermost blocks, which override blocks of the extended
+// template. Blocks nested in them define new blocks and blocks of embed tags belong to the embedded template.
+func findOverridingBlocks(node *tree_sitter.Node) []*tree_sitter.Node {
+	var names []*tree_sitter.Node
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		switch node.Kind() {
+		case "embed":
+			return
+		case "block":
+			if name := node.ChildByFieldName("name"); name != nil {
+				names = append(names, name)
+			}
+			return
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(node)
+
+	return names
+}
@@ -0,0 +1,74 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestTwigBlockDiagnosticsProvider(t *testing.T) {
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = twigIndexer.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	pluginPath := "/project/custom/plugins/MyPlugin/src/Resources/views/storefront/page/index.html.twig"
+
+	for path, code := range map[string]string{
+		"/project/vendor/shopware/storefront/Resources/views/storefront/base.html.twig":       `{% block base_body %}{% block base_main %}{% endblock %}{% endblock %}`,
+		"/project/vendor/shopware/storefront/Resources/views/storefront/page/index.html.twig": `{% sw_extends '@Storefront/storefront/base.html.twig' %}{% block page_content %}{% endblock %}`,
+		// The indexed state of the file under test must not count as its own parent
+		pluginPath: `{% sw_extends '@Storefront/storefront/page/index.html.twig' %}{% block page_custom %}{% endblock %}`,
+	} {
+		content := []byte(code)
+		tree := parser.Parse(content, nil)
+		require.NoError(t, twigIndexer.Index(path, tree.RootNode(), content))
+		tree.Close()
+	}
+
+	provider := &TwigBlockDiagnosticsProvider{twigIndexer: twigIndexer}
+
+	diagnose := func(path, code string) []protocol.Diagnostic {
+		content := []byte(code)
+		tree := parser.Parse(content, nil)
+		defer tree.Close()
+
+		diagnostics, err := provider.GetDiagnostics(context.Background(), "file://"+path, tree.RootNode(), content)
+		require.NoError(t, err)
+		return diagnostics
+	}
+
+	diagnostics := diagnose(pluginPath, `{% sw_extends '@Storefront/storefront/page/index.html.twig' %}
+{% block page_content %}
+    {% block page_content_new %}{% endblock %}
+{% endblock %}
+{% block base_main %}{% endblock %}
+{% block page_custom %}{% endblock %}`)
+
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "twig.block-not-found", diagnostics[0].Code)
+	assert.Equal(t, protocol.DiagnosticSeverityWarning, diagnostics[0].Severity)
+	assert.Equal(t, "Block 'page_custom' does not exist in '@Storefront/storefront/page/index.html.twig'", diagnostics[0].Message)
+	assert.Equal(t, protocol.Range{
+		Start: protocol.Position{Line: 5, Character: 9},
+		End:   protocol.Position{Line: 5, Character: 20},
+	}, diagnostics[0].Range)
+
+	// Templates which are not indexed, embedded templates, traits and templates without parent may define the block
+	assert.Empty(t, diagnose(pluginPath, `{% sw_extends '@Storefront/storefront/page/unknown.html.twig' %}{% block foo %}{% endblock %}`))
+	assert.Empty(t, diagnose(pluginPath, `{% sw_extends '@Storefront/storefront/base.html.twig' %}{% block base_main %}{% embed 'card.html.twig' %}{% block card %}{% endblock %}{% endembed %}{% endblock %}`))
+	assert.Empty(t, diagnose(pluginPath, `{% sw_extends '@Storefront/storefront/base.html.twig' %}{% use 'blocks.html.twig' %}{% block foo %}{% endblock %}`))
+	assert.Empty(t, diagnose(pluginPath, `{% block foo %}{% endblock %}`))
+
+	// Admin templates are left to the admin diagnostics
+	assert.Empty(t, diagnose("/project/custom/plugins/MyPlugin/src/Resources/app/administration/src/sw-foo.html.twig", `{% sw_extends '@Storefront/storefront/base.html.twig' %}{% block foo %}{% endblock %}`))
+}
@@ -0,0 +1,157 @@
+package diagnostics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TwigParentBlockDiagnosticsProvider reports parent() calls in blocks which none of the extended templates define,
+// rendering such a block fails at runtime
+type TwigParentBlockDiagnosticsProvider struct {
+	twigIndexer *twig.TwigIndexer
+}
+
+func NewTwigParentBlockDiagnosticsProvider(lspServer *lsp.Server) *TwigParentBlockDiagnosticsProvider {
+	twigIndexer, _ := lspServer.GetIndexer("twig.indexer")
+
+	return &TwigParentBlockDiagnosticsProvider{
+		twigIndexer: twigIndexer.(*twig.TwigIndexer),
+	}
+}
+
+func (p *TwigParentBlockDiagnosticsProvider) ID() string {
+	return "diagnostics.twig-parent-block"
+}
+
+func (p *TwigParentBlockDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".twig" || !bytes.Contains(content, []byte("parent(")) {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	calls := findParentCalls(rootNode, content)
+	if len(calls) == 0 {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	currentFile, parentBlocks, err := extendedBlockNames(p.twigIndexer, uri, rootNode, content)
+	if err != nil {
+		return nil, err
+	}
+	if parentBlocks == nil {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	diagnostics := []protocol.Diagnostic{}
+	for _, call := range calls {
+		if parentBlocks[call.blockName] {
+			continue
+		}
+
+		diagnostics = append(diagnostics, protocol.Diagnostic{
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      int(call.node.StartPosition().Row),
+					Character: int(call.node.StartPosition().Column),
+				},
+				End: protocol.Position{
+					Line:      int(call.node.EndPosition().Row),
+					Character: int(call.node.EndPosition().Column),
+				},
+			},
+			Message:  fmt.Sprintf("parent() is called in block '%s', which does not exist in '%s'", call.blockName, currentFile.ExtendsFile),
+			Source:   "shopware",
+			Severity: protocol.DiagnosticSeverityError,
+			Code:     "twig.parent-without-parent-block",
+		})
+	}
+
+	return diagnostics, nil
+}
+
+type parentCall struct {
+	node      *tree_sitter.Node
+	blockName string
+}
+
+// findParentCalls returns the parent() calls with the name of their innermost block. Blocks of embed tags
+// override the embedded template instead of the extended one and are skipped.
+func findParentCalls(node *tree_sitter.Node, content []byte) []parentCall {
+	var calls []parentCall
+
+	var walk func(node *tree_sitter.Node, blockName string)
+	walk = func(node *tree_sitter.Node, blockName string) {
+		switch node.Kind() {
+		case "embed":
+			return
+		case "block":
+			if name := node.ChildByFieldName("name"); name != nil {
+				blockName = name.Utf8Text(content)
+			}
+		case "call_expression":
+			if name := node.ChildByFieldName("name"); name != nil && name.Utf8Text(content) == "parent" && blockName != "" {
+				calls = append(calls, parentCall{node: node, blockName: blockName})
+			}
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i), blockName)
+		}
+	}
+	walk(node, "")
+
+	return calls
+}
+
+// extendedBlockNames returns the storefront template of the uri and the names of the blocks defined by the
+// templates it extends. The names are nil when the blocks can't be known: for admin templates, which are checked
+// against the component index, for templates extending nothing or using traits and when the chain of extended
+// templates is not fully indexed.
+func extendedBlockNames(twigIndexer *twig.TwigIndexer, uri string, rootNode *tree_sitter.Node, content []byte) (*twig.TwigFile, map[string]bool, error) {
+	path := strings.TrimPrefix(uri, "file://")
+	if strings.Contains(path, "Resources/app/administration") {
+		return nil, nil, nil
+	}
+
+	currentFile, err := twig.ParseTwig(path, rootNode, content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if currentFile.ExtendsFile == "" || usesTraits(rootNode) {
+		return currentFile, nil, nil
+	}
+
+	// Without the whole chain a block could be defined in a template which is not indexed
+	chain, complete := twigIndexer.GetTemplateChain(currentFile.ExtendsFile, currentFile.Path)
+	if !complete {
+		return currentFile, nil, nil
+	}
+
+	blocks := make(map[string]bool)
+	for _, file := range chain {
+		for name := range file.Blocks {
+			blocks[name] = true
+		}
+	}
+
+	return currentFile, blocks, nil
+}
+
+// usesTraits checks for {% use %} tags, which import blocks of other templates
+func usesTraits(rootNode *tree_sitter.Node) bool {
+	for i := uint(0); i < rootNode.NamedChildCount(); i++ {
+		if rootNode.NamedChild(i).Kind() == "use" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"path/filepath"
 	"unicode/utf8"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/twig"
@@ -29,8 +29,12 @@ func NewTwigVersioningDiagnosticsProvider(lspServer *lsp.Server) *TwigVersioning
 	return &TwigVersioningDiagnosticsProvider{twigIndexer: twigIndexer}
 }
 
+func (p *TwigVersioningDiagnosticsProvider) ID() string {
+	return "diagnostics.twig-versioning"
+}
+
 func (p *TwigVersioningDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
-	if filepath.Ext(uri) != ".twig" {
+	if indexer.FileType(uri) != ".twig" {
 		return []protocol.Diagnostic{}, nil
 	}
 
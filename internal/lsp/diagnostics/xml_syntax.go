@@ -0,0 +1,163 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// maxSyntaxErrorText is the length of the invalid text quoted in the diagnostic message
+const maxSyntaxErrorText = 40
+
+// XMLSyntaxDiagnosticsProvider reports malformed XML in service files, which the container only notices at runtime
+type XMLSyntaxDiagnosticsProvider struct{}
+
+func NewXMLSyntaxDiagnosticsProvider() *XMLSyntaxDiagnosticsProvider {
+	return &XMLSyntaxDiagnosticsProvider{}
+}
+
+func (p *XMLSyntaxDiagnosticsProvider) ID() string {
+	return "diagnostics.xml-syntax"
+}
+
+func (p *XMLSyntaxDiagnosticsProvider) GetDiagnostics(ctx context.Context, uri string, rootNode *tree_sitter.Node, content []byte) ([]protocol.Diagnostic, error) {
+	if rootNode == nil || indexer.FileType(uri) != ".xml" || !rootNode.HasError() || !symfony.IsServiceXML(content) {
+		return []protocol.Diagnostic{}, nil
+	}
+
+	var diagnostics []protocol.Diagnostic
+	collectSyntaxErrors(rootNode, content, &diagnostics)
+
+	return diagnostics, nil
+}
+
+// collectSyntaxErrors adds a diagnostic for every ERROR and MISSING node. ERROR nodes containing
+// further errors are reported by their innermost errors, as they often span the whole document.
+func collectSyntaxErrors(node *tree_sitter.Node, content []byte, diagnostics *[]protocol.Diagnostic) {
+	if node.IsMissing() {
+		*diagnostics = append(*diagnostics, syntaxErrorDiagnostic(node, node, fmt.Sprintf("Invalid XML: missing '%s'", node.Kind())))
+		return
+	}
+
+	found := len(*diagnostics)
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		// Subtrees without errors can be skipped
+		if child.HasError() || child.IsMissing() {
+			collectSyntaxErrors(child, content, diagnostics)
+		}
+	}
+
+	if !node.IsError() || len(*diagnostics) > found {
+		return
+	}
+
+	// Unbalanced tags leave the start and end tags as flat children of the ERROR node
+	if tagDiagnostics := unbalancedTagDiagnostics(node, content); len(tagDiagnostics) > 0 {
+		*diagnostics = append(*diagnostics, tagDiagnostics...)
+		return
+	}
+
+	*diagnostics = append(*diagnostics, syntaxErrorDiagnostic(node, node, fmt.Sprintf("Invalid XML: unexpected '%s'", syntaxErrorText(node, content))))
+}
+
+// unbalancedTagDiagnostics matches the start and end tags among the children of an ERROR node
+// and reports the elements which are not closed and the end tags without a start tag
+func unbalancedTagDiagnostics(errorNode *tree_sitter.Node, content []byte) []protocol.Diagnostic {
+	type openTag struct {
+		name string
+		node *tree_sitter.Node
+	}
+
+	var diagnostics []protocol.Diagnostic
+	var stack []openTag
+
+	notClosed := func(tag openTag) protocol.Diagnostic {
+		return syntaxErrorDiagnostic(tag.node, tag.node, fmt.Sprintf("Invalid XML: element '%s' is not closed", tag.name))
+	}
+
+	for i := uint(0); i < errorNode.ChildCount(); i++ {
+		child := errorNode.Child(i)
+
+		switch child.Kind() {
+		case "STag":
+			if name := treesitterhelper.GetFirstNodeOfKind(child, "Name"); name != nil {
+				stack = append(stack, openTag{name: name.Utf8Text(content), node: child})
+			}
+		case "</":
+			nameNode := errorNode.Child(i + 1)
+			if nameNode == nil || nameNode.Kind() != "Name" {
+				continue
+			}
+			name := nameNode.Utf8Text(content)
+
+			end := nameNode
+			if closing := errorNode.Child(i + 2); closing != nil && closing.Kind() == ">" {
+				end = closing
+			}
+
+			open := -1
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j].name == name {
+					open = j
+					break
+				}
+			}
+
+			if open == -1 {
+				diagnostics = append(diagnostics, syntaxErrorDiagnostic(child, end, fmt.Sprintf("Invalid XML: unexpected end tag '%s'", name)))
+				continue
+			}
+
+			for _, tag := range stack[open+1:] {
+				diagnostics = append(diagnostics, notClosed(tag))
+			}
+			stack = stack[:open]
+		}
+	}
+
+	for _, tag := range stack {
+		diagnostics = append(diagnostics, notClosed(tag))
+	}
+
+	return diagnostics
+}
+
+// syntaxErrorText returns the first line of the invalid text, shortened for the message
+func syntaxErrorText(node *tree_sitter.Node, content []byte) string {
+	text := strings.TrimSpace(node.Utf8Text(content))
+	if i := strings.IndexByte(text, '\n'); i != -1 {
+		text = strings.TrimSpace(text[:i])
+	}
+	if len(text) > maxSyntaxErrorText {
+		text = text[:maxSyntaxErrorText] + "..."
+	}
+
+	return text
+}
+
+// syntaxErrorDiagnostic creates a diagnostic ranging from the start of the first to the end of the last node
+func syntaxErrorDiagnostic(first, last *tree_sitter.Node, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: protocol.Position{
+				Line:      int(first.StartPosition().Row),
+				Character: int(first.StartPosition().Column),
+			},
+			End: protocol.Position{
+				Line:      int(last.EndPosition().Row),
+				Character: int(last.EndPosition().Column),
+			},
+		},
+		Message:  message,
+		Source:   "shopware",
+		Severity: protocol.DiagnosticSeverityError,
+		Code:     "xml.syntax-error",
+	}
+}
@@ -0,0 +1,115 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestXMLSyntaxDiagnosticsProvider(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	tests := []struct {
+		name     string
+		uri      string
+		content  string
+		expected []protocol.Diagnostic
+	}{
+		{
+			name: "valid",
+			uri:  "file:///project/src/Resources/config/services.xml",
+			content: `<container>
+    <services>
+        <service id="Foo\Bar"/>
+    </services>
+</container>`,
+		},
+		{
+			name: "unclosed element",
+			uri:  "file:///project/src/Resources/config/services.xml",
+			content: `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="Foo\Bar">
+    </services>
+</container>`,
+			expected: []protocol.Diagnostic{
+				xmlSyntaxError(3, 8, 3, 30, "Invalid XML: element 'service' is not closed"),
+			},
+		},
+		{
+			name: "misspelled end tag",
+			uri:  "file:///project/src/Resources/config/services.xml",
+			content: `<container>
+    <services>
+        <service id="Foo\Bar"></servic>
+    </services>
+</container>`,
+			expected: []protocol.Diagnostic{
+				xmlSyntaxError(2, 30, 2, 39, "Invalid XML: unexpected end tag 'servic'"),
+				xmlSyntaxError(2, 8, 2, 30, "Invalid XML: element 'service' is not closed"),
+			},
+		},
+		{
+			name: "invalid attribute",
+			uri:  "file:///project/src/Resources/config/services.xml",
+			content: `<container>
+    <services>
+        <service id="Foo\Bar" class=>
+        </service>
+    </services>
+</container>`,
+			expected: []protocol.Diagnostic{
+				xmlSyntaxError(2, 30, 2, 36, "Invalid XML: unexpected 'class='"),
+			},
+		},
+		{
+			name:    "no service file",
+			uri:     "file:///project/src/Resources/config/config.xml",
+			content: `<config><card></config>`,
+		},
+		{
+			name:    "no xml file",
+			uri:     "file:///project/templates/index.html.twig",
+			content: `<container><services></container>`,
+		},
+	}
+
+	provider := NewXMLSyntaxDiagnosticsProvider()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := parser.Parse([]byte(tt.content), nil)
+			defer tree.Close()
+
+			diagnostics, err := provider.GetDiagnostics(context.Background(), tt.uri, tree.RootNode(), []byte(tt.content))
+			require.NoError(t, err)
+
+			if len(tt.expected) == 0 {
+				assert.Empty(t, diagnostics)
+				return
+			}
+			assert.Equal(t, tt.expected, diagnostics)
+		})
+	}
+}
+
+func xmlSyntaxError(startLine, startChar, endLine, endChar int, message string) protocol.Diagnostic {
+	return protocol.Diagnostic{
+		Range: protocol.Range{
+			Start: protocol.Position{Line: startLine, Character: startChar},
+			End:   protocol.Position{Line: endLine, Character: endChar},
+		},
+		Message:  message,
+		Source:   "shopware",
+		Severity: protocol.DiagnosticSeverityError,
+		Code:     "xml.syntax-error",
+	}
+}
@@ -1,20 +1,59 @@
 package lsp
 
 import (
-	"path/filepath"
+	"bytes"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode/utf8"
 
 	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
-// TextDocument represents a document open in the editor
+// TextDocument represents a document open in the editor. A TextDocument is an immutable
+// snapshot, changes to the document replace the snapshot in the DocumentManager.
+// Snapshots returned by the DocumentManager must be released by the caller.
 type TextDocument struct {
 	URI     string
 	Text    []byte
 	Version int
 	Tree    *tree_sitter.Tree
+
+	// refs counts the readers of the snapshot, the DocumentManager holds one reference
+	// as long as the snapshot is the current version of the document
+	refs atomic.Int32
+}
+
+// newTextDocument creates a snapshot referenced by the DocumentManager
+func newTextDocument(uri string, text []byte, version int) *TextDocument {
+	doc := &TextDocument{
+		URI:     uri,
+		Text:    text,
+		Version: version,
+	}
+	doc.refs.Store(1)
+
+	return doc
+}
+
+// acquire adds a reference for a reader of the snapshot
+func (d *TextDocument) acquire() *TextDocument {
+	d.refs.Add(1)
+	return d
+}
+
+// Release drops a reference to the snapshot. The syntax tree is closed once the snapshot
+// has been replaced and no reader holds it anymore. Releasing a nil document is a no-op.
+func (d *TextDocument) Release() {
+	if d == nil {
+		return
+	}
+
+	if d.refs.Add(-1) == 0 && d.Tree != nil {
+		d.Tree.Close()
+	}
 }
 
 // DocumentManager manages text documents
@@ -37,51 +76,168 @@ func (m *DocumentManager) OpenDocument(uri string, text string, version int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	doc := &TextDocument{
-		URI:     uri,
-		Text:    []byte(text),
-		Version: version,
-	}
+	doc := newTextDocument(uri, []byte(text), version)
 
-	fileType := strings.ToLower(filepath.Ext(uri))
+	fileType := indexer.FileType(uri)
 
 	if parser, ok := m.parsers[fileType]; ok {
 		doc.Tree = parser.Parse(doc.Text, nil)
 	}
 
-	m.documents[uri] = doc
+	m.replaceDocument(uri, doc)
 }
 
-// UpdateDocument updates an existing document
+// UpdateDocument replaces the content of a document
 func (m *DocumentManager) UpdateDocument(uri string, text string, version int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if doc, ok := m.documents[uri]; ok {
-		doc.Text = []byte(text)
-		doc.Version = version
+	doc := newTextDocument(uri, []byte(text), version)
 
-		fileType := strings.ToLower(filepath.Ext(uri))
+	fileType := indexer.FileType(uri)
 
-		if parser, ok := m.parsers[fileType]; ok {
-			doc.Tree = parser.Parse(doc.Text, nil)
+	if parser, ok := m.parsers[fileType]; ok {
+		doc.Tree = parser.Parse(doc.Text, nil)
+	}
+
+	m.replaceDocument(uri, doc)
+}
+
+// ApplyChanges applies the content changes of a didChange notification to a document.
+// Ranged changes are applied in order and the syntax tree is re-parsed incrementally,
+// a change without a range replaces the whole document.
+func (m *DocumentManager) ApplyChanges(uri string, changes []protocol.TextDocumentContentChangeEvent, version int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var text []byte
+	var oldTree *tree_sitter.Tree
+	if current, ok := m.documents[uri]; ok {
+		text = current.Text
+		if current.Tree != nil {
+			// Edit a copy, so nodes of the current tree handed out to readers stay valid
+			oldTree = current.Tree.Clone()
 		}
-	} else {
-		// If the document doesn't exist, create it
-		doc := &TextDocument{
-			URI:     uri,
-			Text:    []byte(text),
-			Version: version,
+	}
+
+	for _, change := range changes {
+		if change.Range == nil {
+			text = []byte(change.Text)
+			if oldTree != nil {
+				oldTree.Close()
+				oldTree = nil
+			}
+			continue
 		}
 
-		fileType := strings.ToLower(filepath.Ext(uri))
+		startByte := positionToByteOffset(text, change.Range.Start)
+		oldEndByte := positionToByteOffset(text, change.Range.End)
+		if oldEndByte < startByte {
+			oldEndByte = startByte
+		}
 
-		if parser, ok := m.parsers[fileType]; ok {
-			doc.Tree = parser.Parse(doc.Text, nil)
+		newText := make([]byte, 0, len(text)-(oldEndByte-startByte)+len(change.Text))
+		newText = append(newText, text[:startByte]...)
+		newText = append(newText, change.Text...)
+		newText = append(newText, text[oldEndByte:]...)
+
+		if oldTree != nil {
+			newEndByte := startByte + len(change.Text)
+			oldTree.Edit(&tree_sitter.InputEdit{
+				StartByte:      uint(startByte),
+				OldEndByte:     uint(oldEndByte),
+				NewEndByte:     uint(newEndByte),
+				StartPosition:  byteOffsetToPoint(text, startByte),
+				OldEndPosition: byteOffsetToPoint(text, oldEndByte),
+				NewEndPosition: byteOffsetToPoint(newText, newEndByte),
+			})
 		}
 
-		m.documents[uri] = doc
+		text = newText
 	}
+
+	doc := newTextDocument(uri, text, version)
+
+	fileType := indexer.FileType(uri)
+
+	if parser, ok := m.parsers[fileType]; ok {
+		doc.Tree = parser.Parse(doc.Text, oldTree)
+	}
+
+	if oldTree != nil {
+		oldTree.Close()
+	}
+
+	m.replaceDocument(uri, doc)
+}
+
+// positionToByteOffset converts an LSP position (UTF-16 based character offset)
+// into a byte offset into text. Positions past the end are clamped.
+func positionToByteOffset(text []byte, pos protocol.Position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		next := bytes.IndexByte(text[offset:], '\n')
+		if next == -1 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+
+	units := 0
+	for offset < len(text) && text[offset] != '\n' && units < pos.Character {
+		r, size := utf8.DecodeRune(text[offset:])
+		if r >= 0x10000 {
+			units += 2
+		} else {
+			units++
+		}
+		offset += size
+	}
+
+	return offset
+}
+
+// ByteOffset converts a position into a byte offset into text. Like the node lookup of the documents, the
+// character is taken as byte column. Characters past the end of the line are clamped to it.
+func ByteOffset(text []byte, pos protocol.Position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		next := bytes.IndexByte(text[offset:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+
+	lineEnd := bytes.IndexByte(text[offset:], '\n')
+	if lineEnd < 0 {
+		lineEnd = len(text) - offset
+	}
+
+	return offset + min(pos.Character, lineEnd)
+}
+
+// byteOffsetToPoint converts a byte offset into a tree-sitter point (row, byte column)
+func byteOffsetToPoint(text []byte, offset int) tree_sitter.Point {
+	row := 0
+	lineStart := 0
+	for i := 0; i < offset && i < len(text); i++ {
+		if text[i] == '\n' {
+			row++
+			lineStart = i + 1
+		}
+	}
+
+	return tree_sitter.Point{Row: uint(row), Column: uint(offset - lineStart)}
+}
+
+// replaceDocument makes doc the current snapshot of the document and releases the previous one
+func (m *DocumentManager) replaceDocument(uri string, doc *TextDocument) {
+	if current, ok := m.documents[uri]; ok {
+		current.Release()
+	}
+
+	m.documents[uri] = doc
 }
 
 // CloseDocument removes a document
@@ -89,21 +245,36 @@ func (m *DocumentManager) CloseDocument(uri string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Close the tree if it exists
-	if doc, ok := m.documents[uri]; ok && doc.Tree != nil {
-		doc.Tree.Close()
+	// The tree is closed once the last reader released the document
+	if doc, ok := m.documents[uri]; ok {
+		doc.Release()
 	}
 
 	delete(m.documents, uri)
 }
 
-// GetDocument returns a document by URI
+// GetDocument returns the current snapshot of a document by URI, the caller must release it
 func (m *DocumentManager) GetDocument(uri string) (*TextDocument, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	doc, ok := m.documents[uri]
-	return doc, ok
+	if !ok {
+		return nil, false
+	}
+	return doc.acquire(), true
+}
+
+// GetDocuments returns the snapshots of all open documents, the caller must release them
+func (m *DocumentManager) GetDocuments() []*TextDocument {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	docs := make([]*TextDocument, 0, len(m.documents))
+	for _, doc := range m.documents {
+		docs = append(docs, doc.acquire())
+	}
+	return docs
 }
 
 // GetDocumentText returns the text of a document by URI
@@ -117,6 +288,8 @@ func (m *DocumentManager) GetDocumentText(uri string) ([]byte, bool) {
 	return nil, false
 }
 
+// GetNodeAtPosition returns the most specific node at the position together with the snapshot
+// of the document it belongs to, the caller must release the snapshot
 func (m *DocumentManager) GetNodeAtPosition(uri string, line int, character int) (*tree_sitter.Node, *TextDocument, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -136,12 +309,12 @@ func (m *DocumentManager) GetNodeAtPosition(uri string, line int, character int)
 	// Manual tree traversal to find the most specific node at position
 	node := m.findNodeAtPosition(doc.Tree.RootNode(), treeSitterPos, doc.Text)
 	if node != nil {
-		return node, doc, true
+		return node, doc.acquire(), true
 	}
 
 	// Fallback to standard method
 	node = doc.Tree.RootNode().NamedDescendantForPointRange(treeSitterPos, treeSitterPos)
-	return node, doc, true
+	return node, doc.acquire(), true
 }
 
 func (m *DocumentManager) findNodeAtPosition(node *tree_sitter.Node, pos tree_sitter.Point, text []byte) *tree_sitter.Node {
@@ -181,30 +354,15 @@ func (m *DocumentManager) findNodeAtPosition(node *tree_sitter.Node, pos tree_si
 	return nil
 }
 
-func (m *DocumentManager) GetRootNode(uri string) *tree_sitter.Node {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	// Check if the document exists
-	doc, ok := m.documents[uri]
-	if !ok || doc.Tree == nil {
-		return nil
-	}
-
-	return doc.Tree.RootNode()
-}
-
 // Close closes the document manager and frees resources
 func (m *DocumentManager) Close() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Close all trees
-	for _, doc := range m.documents {
-		if doc.Tree != nil {
-			doc.Tree.Close()
-			doc.Tree = nil
-		}
+	// Trees still held by readers are closed once they are released
+	for uri, doc := range m.documents {
+		doc.Release()
+		delete(m.documents, uri)
 	}
 
 	indexer.CloseTreesitterParsers(m.parsers)
@@ -0,0 +1,28 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// documentHighlight handles textDocument/documentHighlight requests
+func (s *Server) documentHighlight(ctx context.Context, params *protocol.DocumentHighlightParams) []protocol.DocumentHighlight {
+	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
+	if !ok || node == nil {
+		return []protocol.DocumentHighlight{}
+	}
+
+	params.Node = node
+	params.DocumentContent = docText.Text
+
+	// Highlights of different symbols would be confusing, so the first provider with a result wins
+	for _, provider := range s.highlightProviders {
+		if highlights := provider.GetDocumentHighlights(ctx, params); len(highlights) > 0 {
+			return highlights
+		}
+	}
+
+	return []protocol.DocumentHighlight{}
+}
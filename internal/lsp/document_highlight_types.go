@@ -0,0 +1,13 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// DocumentHighlightProvider is an interface for highlighting the occurrences of a symbol within a document
+type DocumentHighlightProvider interface {
+	// GetDocumentHighlights returns the occurrences of the symbol at the given position
+	GetDocumentHighlights(ctx context.Context, params *protocol.DocumentHighlightParams) []protocol.DocumentHighlight
+}
@@ -0,0 +1,108 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func textRange(startLine, startChar, endLine, endChar int) *protocol.Range {
+	return &protocol.Range{
+		Start: protocol.Position{Line: startLine, Character: startChar},
+		End:   protocol.Position{Line: endLine, Character: endChar},
+	}
+}
+
+func TestDocumentManager_ApplyChanges(t *testing.T) {
+	m := NewDocumentManager()
+	defer m.Close()
+
+	uri := "file:///project/src/Foo.php"
+	m.OpenDocument(uri, "<?php\n\nclass Foo\n{\n}\n", 1)
+
+	m.ApplyChanges(uri, []protocol.TextDocumentContentChangeEvent{
+		{Range: textRange(2, 6, 2, 9), Text: "Bar"},
+		{Range: textRange(3, 1, 3, 1), Text: "\n    public function test() {}"},
+	}, 2)
+
+	doc, ok := m.GetDocument(uri)
+	require.True(t, ok)
+	assert.Equal(t, 2, doc.Version)
+	assert.Equal(t, "<?php\n\nclass Bar\n{\n    public function test() {}\n}\n", string(doc.Text))
+
+	// The incrementally parsed tree must match the new text
+	require.NotNil(t, doc.Tree)
+	root := doc.Tree.RootNode()
+	assert.False(t, root.HasError())
+	assert.Equal(t, string(doc.Text), root.Utf8Text(doc.Text))
+	assert.Contains(t, root.ToSexp(), "method_declaration")
+
+	// A change without a range replaces the whole document
+	m.ApplyChanges(uri, []protocol.TextDocumentContentChangeEvent{
+		{Text: "<?php\n\ninterface Baz {}\n"},
+	}, 3)
+
+	doc, ok = m.GetDocument(uri)
+	require.True(t, ok)
+	assert.Equal(t, "<?php\n\ninterface Baz {}\n", string(doc.Text))
+	assert.Contains(t, doc.Tree.RootNode().ToSexp(), "interface_declaration")
+}
+
+func TestDocumentManager_ApplyChangesKeepsSnapshots(t *testing.T) {
+	m := NewDocumentManager()
+	defer m.Close()
+
+	uri := "file:///project/src/Foo.php"
+	m.OpenDocument(uri, "<?php\n\nclass Foo {}\n", 1)
+
+	before, ok := m.GetDocument(uri)
+	require.True(t, ok)
+
+	m.ApplyChanges(uri, []protocol.TextDocumentContentChangeEvent{
+		{Range: textRange(2, 6, 2, 9), Text: "Bar"},
+	}, 2)
+
+	// Readers keep the snapshot they got, a change replaces the document
+	assert.Equal(t, 1, before.Version)
+	assert.Equal(t, "<?php\n\nclass Foo {}\n", string(before.Text))
+	assert.Equal(t, string(before.Text), before.Tree.RootNode().Utf8Text(before.Text))
+
+	after, ok := m.GetDocument(uri)
+	require.True(t, ok)
+	defer after.Release()
+	assert.Equal(t, 2, after.Version)
+	assert.Equal(t, "<?php\n\nclass Bar {}\n", string(after.Text))
+
+	// The replaced snapshot is only referenced by its reader anymore
+	assert.Equal(t, int32(1), before.refs.Load())
+	before.Release()
+	assert.Equal(t, int32(0), before.refs.Load())
+	assert.Equal(t, int32(2), after.refs.Load())
+}
+
+func TestPositionToByteOffset(t *testing.T) {
+	text := []byte("äb\n😀c\nd")
+
+	// "ä" is one UTF-16 code unit but two bytes
+	assert.Equal(t, 2, positionToByteOffset(text, protocol.Position{Line: 0, Character: 1}))
+	// "😀" is two UTF-16 code units and four bytes
+	assert.Equal(t, 4+4, positionToByteOffset(text, protocol.Position{Line: 1, Character: 2}))
+	// Characters past the end of a line are clamped to the line end
+	assert.Equal(t, 3, positionToByteOffset(text, protocol.Position{Line: 0, Character: 10}))
+	// Lines past the end are clamped to the document end
+	assert.Equal(t, len(text), positionToByteOffset(text, protocol.Position{Line: 5, Character: 0}))
+}
+
+func TestByteOffset(t *testing.T) {
+	text := []byte("äb\ncd\ne")
+
+	// The character is a byte column
+	assert.Equal(t, 2, ByteOffset(text, protocol.Position{Line: 0, Character: 2}))
+	assert.Equal(t, 5, ByteOffset(text, protocol.Position{Line: 1, Character: 1}))
+	// Characters past the end of a line are clamped to the line end
+	assert.Equal(t, 3, ByteOffset(text, protocol.Position{Line: 0, Character: 10}))
+	// Lines past the end are clamped to the document end
+	assert.Equal(t, len(text), ByteOffset(text, protocol.Position{Line: 5, Character: 0}))
+}
@@ -0,0 +1,137 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// executeCommand handles workspace/executeCommand requests for the commands of the command providers.
+// Commands take their parameters as one object, so a single argument is passed as it is and several
+// arguments as an array.
+func (s *Server) executeCommand(ctx context.Context, params *protocol.CommandRequest) (interface{}, error) {
+	if editorCmd, ok := s.editorCommands()[params.Command]; ok {
+		return editorCmd(ctx, params.Arguments)
+	}
+
+	cmd, ok := s.commandMap[params.Command]
+	if !ok {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "Unknown command: " + params.Command}
+	}
+
+	var args *json.RawMessage
+	switch len(params.Arguments) {
+	case 0:
+	case 1:
+		args = &params.Arguments[0]
+	default:
+		raw, err := json.Marshal(params.Arguments)
+		if err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+		}
+		args = (*json.RawMessage)(&raw)
+	}
+
+	return cmd(ctx, args)
+}
+
+// commandNames returns the names of the registered commands sorted, they are advertised to the client
+func (s *Server) commandNames() []string {
+	names := make([]string, 0, len(s.commandMap))
+	for name := range s.commandMap {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// editorCommandFunc runs a command of a code action or code lens with its positional arguments
+type editorCommandFunc func(ctx context.Context, args []json.RawMessage) (interface{}, error)
+
+// editorCommands are the commands emitted by code actions and code lenses. The VSCode extension implements
+// them with pickers and prompts, other clients send them with workspace/executeCommand and get the result
+// of the registered command instead. They are not advertised, the language client of the VSCode extension
+// would register them a second time.
+func (s *Server) editorCommands() map[string]editorCommandFunc {
+	return map[string]editorCommandFunc{
+		"shopware.createSnippet":      s.runWithArguments("shopware/snippet/storefront/create", "snippetKey", "fileUri"),
+		"shopware.createAdminSnippet": s.runWithArguments("shopware/snippet/admin/create", "snippetKey", "fileUri"),
+		"shopware.twig.extendBlock":   s.runWithArguments("shopware/twig/extendBlock", "textUri", "blockName", "extension"),
+		"shopware.twig.showBlockDiff": s.runWithArguments("shopware/twig/getBlockDiff", "textUri", "blockName"),
+		"shopware.insertSnippet":      s.insertSnippetCommand,
+		"shopware.openReferences":     s.openReferencesCommand,
+	}
+}
+
+// runWithArguments maps the positional arguments to the named parameters of a registered command
+func (s *Server) runWithArguments(command string, names ...string) editorCommandFunc {
+	return func(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+		cmd, ok := s.commandMap[command]
+		if !ok {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "Unknown command: " + command}
+		}
+
+		params := make(map[string]json.RawMessage, len(names))
+		for i, name := range names {
+			if i < len(args) {
+				params[name] = args[i]
+			}
+		}
+
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+		}
+
+		return cmd(ctx, (*json.RawMessage)(&raw))
+	}
+}
+
+// insertSnippetCommand returns the snippets which can be inserted into the document of the optional file URI
+func (s *Server) insertSnippetCommand(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+	var fileURI string
+	if len(args) > 0 {
+		_ = json.Unmarshal(args[0], &fileURI)
+	}
+
+	if strings.Contains(fileURI, "/Resources/app/administration/") {
+		return s.runWithArguments("shopware/snippet/admin/all")(ctx, nil)
+	}
+
+	return s.runWithArguments("shopware/snippet/storefront/all")(ctx, nil)
+}
+
+// openReferencesCommand returns the locations of the references of a code lens, given as file:///path#line
+func (s *Server) openReferencesCommand(ctx context.Context, args []json.RawMessage) (interface{}, error) {
+	var references []string
+	if len(args) > 0 {
+		if err := json.Unmarshal(args[0], &references); err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+		}
+	}
+
+	locations := make([]protocol.Location, 0, len(references))
+	for _, reference := range references {
+		uri, lineText, _ := strings.Cut(reference, "#")
+
+		line, err := strconv.Atoi(lineText)
+		if err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: fmt.Sprintf("Invalid reference: %s", reference)}
+		}
+
+		position := protocol.Position{Line: max(line-1, 0)}
+		locations = append(locations, protocol.Location{
+			URI:   uri,
+			Range: protocol.Range{Start: position, End: position},
+		})
+	}
+
+	return locations, nil
+}
@@ -0,0 +1,197 @@
+package highlight
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPDocumentHighlightProvider highlights the usages of the variable under the cursor within its function
+// and the usages of a property accessed with $this within its class
+type PHPDocumentHighlightProvider struct{}
+
+func NewPHPDocumentHighlightProvider() *PHPDocumentHighlightProvider {
+	return &PHPDocumentHighlightProvider{}
+}
+
+func (p *PHPDocumentHighlightProvider) GetDocumentHighlights(ctx context.Context, params *protocol.DocumentHighlightParams) []protocol.DocumentHighlight {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return nil
+	}
+
+	node := params.Node
+	if parent := node.Parent(); parent != nil && parent.Kind() == "variable_name" {
+		node = parent
+	}
+
+	if property := thisPropertyName(node, params.DocumentContent); property != "" {
+		return propertyHighlights(node, property, params.DocumentContent)
+	}
+
+	if node.Kind() != "variable_name" || isPropertyVariable(node) {
+		return nil
+	}
+
+	return variableHighlights(node, params.DocumentContent)
+}
+
+// functionScopeKinds are the nodes starting a new variable scope. Arrow functions are missing on purpose,
+// they capture the variables of their surrounding function.
+var functionScopeKinds = map[string]bool{
+	"function_definition": true,
+	"method_declaration":  true,
+	"anonymous_function":  true,
+}
+
+var classScopeKinds = map[string]bool{
+	"class_declaration": true,
+	"trait_declaration": true,
+	"enum_declaration":  true,
+}
+
+// variableHighlights returns the usages of the variable in the function it is used in, or in the global scope
+// outside of any function. Variables imported by use() of a closure count to both scopes.
+func variableHighlights(variable *tree_sitter.Node, content []byte) []protocol.DocumentHighlight {
+	name := variable.Utf8Text(content)
+
+	scope := variable.Parent()
+	if scope.Kind() == "anonymous_function_use_clause" {
+		scope = scope.Parent().Parent()
+	}
+	for scope.Parent() != nil && !functionScopeKinds[scope.Kind()] {
+		scope = scope.Parent()
+	}
+
+	var highlights []protocol.DocumentHighlight
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		if node.Kind() == "variable_name" {
+			if node.Utf8Text(content) == name && !isPropertyVariable(node) {
+				kind := protocol.DocumentHighlightRead
+				if isVariableWrite(node) {
+					kind = protocol.DocumentHighlightWrite
+				}
+				highlights = append(highlights, newHighlight(node, kind))
+			}
+			return
+		}
+
+		if node.Id() != scope.Id() && (functionScopeKinds[node.Kind()] || classScopeKinds[node.Kind()]) {
+			// The imported variables of a nested closure still belong to this scope
+			if node.Kind() == "anonymous_function" {
+				for i := uint(0); i < node.NamedChildCount(); i++ {
+					if child := node.NamedChild(i); child.Kind() == "anonymous_function_use_clause" {
+						walk(child)
+					}
+				}
+			}
+			return
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(scope)
+
+	return highlights
+}
+
+// propertyHighlights returns the declaration and the $this accesses of a property in the surrounding class
+func propertyHighlights(node *tree_sitter.Node, property string, content []byte) []protocol.DocumentHighlight {
+	class := node.Parent()
+	for class != nil && !classScopeKinds[class.Kind()] {
+		class = class.Parent()
+	}
+	if class == nil {
+		return nil
+	}
+
+	var highlights []protocol.DocumentHighlight
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		switch node.Kind() {
+		case "member_access_expression", "nullsafe_member_access_expression":
+			if name := node.ChildByFieldName("name"); name != nil && isThis(node.ChildByFieldName("object"), content) && name.Utf8Text(content) == property {
+				kind := protocol.DocumentHighlightRead
+				if isVariableWrite(node) {
+					kind = protocol.DocumentHighlightWrite
+				}
+				highlights = append(highlights, newHighlight(name, kind))
+			}
+		case "property_element", "property_promotion_parameter":
+			if name := node.ChildByFieldName("name"); name != nil && name.Utf8Text(content) == "$"+property {
+				highlights = append(highlights, newHighlight(name, protocol.DocumentHighlightWrite))
+			}
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			if child := node.NamedChild(i); !classScopeKinds[child.Kind()] {
+				walk(child)
+			}
+		}
+	}
+	walk(class)
+
+	return highlights
+}
+
+// thisPropertyName returns the property name when the cursor is on a $this->property access or a property declaration
+func thisPropertyName(node *tree_sitter.Node, content []byte) string {
+	parent := node.Parent()
+	if parent == nil {
+		return ""
+	}
+
+	switch parent.Kind() {
+	case "member_access_expression", "nullsafe_member_access_expression":
+		if name := parent.ChildByFieldName("name"); name != nil && name.Id() == node.Id() && isThis(parent.ChildByFieldName("object"), content) {
+			return node.Utf8Text(content)
+		}
+	case "property_element", "property_promotion_parameter":
+		if node.Kind() == "variable_name" {
+			return node.Utf8Text(content)[1:]
+		}
+	}
+
+	return ""
+}
+
+func isThis(node *tree_sitter.Node, content []byte) bool {
+	return node != nil && node.Kind() == "variable_name" && node.Utf8Text(content) == "$this"
+}
+
+// isPropertyVariable checks for variable names which are properties, like static::$property or a declaration
+func isPropertyVariable(node *tree_sitter.Node) bool {
+	switch node.Parent().Kind() {
+	case "scoped_property_access_expression", "property_element", "property_promotion_parameter":
+		return true
+	}
+
+	return false
+}
+
+// isVariableWrite checks whether the node gets a value assigned, as target of an assignment,
+// as parameter, as loop variable of foreach or as caught exception
+func isVariableWrite(node *tree_sitter.Node) bool {
+	parent := node.Parent()
+
+	switch parent.Kind() {
+	case "assignment_expression", "reference_assignment_expression", "augmented_assignment_expression":
+		left := parent.ChildByFieldName("left")
+		return left != nil && left.Id() == node.Id()
+	case "list_literal", "simple_parameter", "variadic_parameter", "catch_clause", "static_variable_declaration":
+		return true
+	case "by_ref", "pair":
+		return isVariableWrite(parent)
+	case "foreach_statement":
+		// The first expression is the iterated value, the following ones are the loop variables
+		return parent.NamedChild(0).Id() != node.Id()
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package highlight
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func phpHighlights(t *testing.T, content string, cursor string) []protocol.DocumentHighlight {
+	t.Helper()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(content), nil)
+	t.Cleanup(tree.Close)
+
+	offset := strings.Index(content, cursor)
+	require.GreaterOrEqual(t, offset, 0, "cursor %q not found", cursor)
+
+	params := &protocol.DocumentHighlightParams{
+		DocumentContent: []byte(content),
+		Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+	}
+	params.TextDocument.URI = "file:///project/src/Service.php"
+
+	return NewPHPDocumentHighlightProvider().GetDocumentHighlights(context.Background(), params)
+}
+
+func highlightLines(highlights []protocol.DocumentHighlight) []int {
+	lines := make([]int, 0, len(highlights))
+	for _, highlight := range highlights {
+		lines = append(lines, highlight.Range.Start.Line)
+	}
+
+	return lines
+}
+
+const phpHighlightContent = `<?php
+class Service
+{
+    private int $count = 0;
+
+    public function add(array $items): int
+    {
+        $total = 0;
+        foreach ($items as $item) {
+            $total += $item;
+        }
+        $this->count = $total;
+        $log = function () use ($total) { return $total; };
+
+        return $this->count + count($items);
+    }
+
+    public function reset(): void
+    {
+        $total = 1;
+        $this?->count;
+        $this->count();
+    }
+}
+`
+
+func TestPHPDocumentHighlightProvider_Variable(t *testing.T) {
+	highlights := phpHighlights(t, phpHighlightContent, "total = 0")
+
+	assert.Equal(t, []int{7, 9, 11, 12}, highlightLines(highlights))
+	assert.Equal(t, protocol.DocumentHighlightWrite, highlights[0].Kind)
+	assert.Equal(t, protocol.DocumentHighlightWrite, highlights[1].Kind)
+	assert.Equal(t, protocol.DocumentHighlightRead, highlights[2].Kind)
+	assert.Equal(t, protocol.DocumentHighlightRead, highlights[3].Kind)
+
+	parameter := phpHighlights(t, phpHighlightContent, "items)")
+	assert.Equal(t, []int{5, 8, 14}, highlightLines(parameter))
+	assert.Equal(t, protocol.DocumentHighlightWrite, parameter[0].Kind)
+
+	loopVariable := phpHighlights(t, phpHighlightContent, "item) {")
+	assert.Equal(t, []int{8, 9}, highlightLines(loopVariable))
+	assert.Equal(t, protocol.DocumentHighlightWrite, loopVariable[0].Kind)
+}
+
+func TestPHPDocumentHighlightProvider_ClosureScope(t *testing.T) {
+	highlights := phpHighlights(t, phpHighlightContent, "total; }")
+
+	assert.Equal(t, []int{12, 12}, highlightLines(highlights))
+}
+
+func TestPHPDocumentHighlightProvider_Property(t *testing.T) {
+	for _, cursor := range []string{"count = 0", "count = $total", "count + count"} {
+		highlights := phpHighlights(t, phpHighlightContent, cursor)
+
+		assert.Equal(t, []int{3, 11, 14, 20}, highlightLines(highlights), cursor)
+		assert.Equal(t, protocol.DocumentHighlightWrite, highlights[0].Kind)
+		assert.Equal(t, protocol.DocumentHighlightWrite, highlights[1].Kind)
+		assert.Equal(t, protocol.DocumentHighlightRead, highlights[2].Kind)
+		assert.Equal(t, 22, highlights[2].Range.Start.Character)
+		assert.Equal(t, 27, highlights[2].Range.End.Character)
+	}
+}
+
+func TestPHPDocumentHighlightProvider_NoVariable(t *testing.T) {
+	assert.Empty(t, phpHighlights(t, phpHighlightContent, "count($items)"))
+}
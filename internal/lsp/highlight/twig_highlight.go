@@ -0,0 +1,178 @@
+package highlight
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TwigDocumentHighlightProvider highlights the definitions, end tags, parent() calls and block() functions
+// of the block under the cursor. Only the current file is looked at, so no index is needed.
+type TwigDocumentHighlightProvider struct{}
+
+func NewTwigDocumentHighlightProvider() *TwigDocumentHighlightProvider {
+	return &TwigDocumentHighlightProvider{}
+}
+
+func (p *TwigDocumentHighlightProvider) GetDocumentHighlights(ctx context.Context, params *protocol.DocumentHighlightParams) []protocol.DocumentHighlight {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".twig" {
+		return nil
+	}
+
+	blockName := highlightedBlockName(params.Node, params.DocumentContent)
+	if blockName == "" {
+		return nil
+	}
+
+	rootNode := params.Node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	var highlights []protocol.DocumentHighlight
+
+	var walk func(node *tree_sitter.Node, currentBlock string)
+	walk = func(node *tree_sitter.Node, currentBlock string) {
+		switch node.Kind() {
+		case "block":
+			currentBlock = blockNameOf(node, params.DocumentContent)
+			if currentBlock == blockName {
+				highlights = append(highlights, blockHighlights(node, params.DocumentContent)...)
+			}
+		case "call_expression":
+			switch functionName(node, params.DocumentContent) {
+			case "parent":
+				if currentBlock == blockName {
+					highlights = append(highlights, newHighlight(node, protocol.DocumentHighlightRead))
+				}
+			case "block":
+				if argument := blockFunctionArgument(node); argument != nil && stringValue(argument, params.DocumentContent) == blockName {
+					highlights = append(highlights, newHighlight(argument, protocol.DocumentHighlightRead))
+				}
+			}
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i), currentBlock)
+		}
+	}
+	walk(rootNode, "")
+
+	return highlights
+}
+
+// highlightedBlockName resolves the block the cursor refers to, it can be placed on the name or keywords
+// of a block tag, inside the string of a block() function or on a parent() call
+func highlightedBlockName(node *tree_sitter.Node, content []byte) string {
+	parent := node.Parent()
+	if parent == nil {
+		return ""
+	}
+
+	switch {
+	case parent.Kind() == "block" && (node.Kind() == "identifier" || node.Kind() == "keyword"):
+		return blockNameOf(parent, content)
+	case node.Kind() == "string" && parent.Kind() == "arguments":
+		if call := parent.Parent(); call != nil && call.Kind() == "call_expression" && functionName(call, content) == "block" {
+			return stringValue(node, content)
+		}
+	case node.Kind() == "function" && parent.Kind() == "call_expression" && node.Utf8Text(content) == "parent":
+		for current := parent.Parent(); current != nil; current = current.Parent() {
+			if current.Kind() == "block" {
+				return blockNameOf(current, content)
+			}
+		}
+	}
+
+	return ""
+}
+
+// blockHighlights marks the name of the block as written and its end tag as plain text.
+// The end tag is the name after endblock when it is repeated, otherwise the endblock keyword.
+func blockHighlights(block *tree_sitter.Node, content []byte) []protocol.DocumentHighlight {
+	var highlights []protocol.DocumentHighlight
+	var endKeyword *tree_sitter.Node
+	names := 0
+
+	for i := uint(0); i < block.ChildCount(); i++ {
+		child := block.Child(i)
+
+		switch child.Kind() {
+		case "identifier":
+			kind := protocol.DocumentHighlightWrite
+			if names > 0 {
+				kind = protocol.DocumentHighlightText
+				endKeyword = nil
+			}
+			highlights = append(highlights, newHighlight(child, kind))
+			names++
+		case "keyword":
+			if child.Utf8Text(content) == "endblock" {
+				endKeyword = child
+			}
+		}
+	}
+
+	if endKeyword != nil {
+		highlights = append(highlights, newHighlight(endKeyword, protocol.DocumentHighlightText))
+	}
+
+	return highlights
+}
+
+func blockNameOf(block *tree_sitter.Node, content []byte) string {
+	if name := block.ChildByFieldName("name"); name != nil {
+		return name.Utf8Text(content)
+	}
+
+	return ""
+}
+
+func functionName(call *tree_sitter.Node, content []byte) string {
+	if name := call.ChildByFieldName("name"); name != nil {
+		return name.Utf8Text(content)
+	}
+
+	return ""
+}
+
+// blockFunctionArgument returns the string naming the block of a block('name') call
+func blockFunctionArgument(call *tree_sitter.Node) *tree_sitter.Node {
+	arguments := call.ChildByFieldName("arguments")
+	if arguments == nil || arguments.NamedChildCount() == 0 {
+		return nil
+	}
+
+	if argument := arguments.NamedChild(0); argument.Kind() == "string" {
+		return argument
+	}
+
+	return nil
+}
+
+func stringValue(node *tree_sitter.Node, content []byte) string {
+	value := node.Utf8Text(content)
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+func newHighlight(node *tree_sitter.Node, kind protocol.DocumentHighlightKind) protocol.DocumentHighlight {
+	return protocol.DocumentHighlight{
+		Range: protocol.Range{
+			Start: protocol.Position{
+				Line:      int(node.StartPosition().Row),
+				Character: int(node.StartPosition().Column),
+			},
+			End: protocol.Position{
+				Line:      int(node.EndPosition().Row),
+				Character: int(node.EndPosition().Column),
+			},
+		},
+		Kind: kind,
+	}
+}
@@ -0,0 +1,98 @@
+package highlight
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func twigHighlights(t *testing.T, uri, content, cursor string) []protocol.DocumentHighlight {
+	t.Helper()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(content), nil)
+	t.Cleanup(tree.Close)
+
+	offset := strings.Index(content, cursor)
+	require.GreaterOrEqual(t, offset, 0, "cursor %q not found", cursor)
+
+	params := &protocol.DocumentHighlightParams{
+		DocumentContent: []byte(content),
+		Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+	}
+	params.TextDocument.URI = uri
+
+	return NewTwigDocumentHighlightProvider().GetDocumentHighlights(context.Background(), params)
+}
+
+func highlightTexts(content string, highlights []protocol.DocumentHighlight) []string {
+	lines := strings.Split(content, "\n")
+
+	var texts []string
+	for _, highlight := range highlights {
+		line := lines[highlight.Range.Start.Line]
+		texts = append(texts, line[highlight.Range.Start.Character:highlight.Range.End.Character])
+	}
+
+	return texts
+}
+
+func TestTwigDocumentHighlightProvider(t *testing.T) {
+	content := `{% block page %}
+    {% block content %}
+        {{ parent() }}
+    {% endblock content %}
+    {% block sidebar %}
+        {{ block('content') }}
+    {% endblock %}
+{% endblock %}
+{% block content %}{% endblock %}`
+
+	expectedTexts := []string{"content", "content", "parent()", "'content'", "content", "endblock"}
+	expectedKinds := []protocol.DocumentHighlightKind{
+		protocol.DocumentHighlightWrite,
+		protocol.DocumentHighlightText,
+		protocol.DocumentHighlightRead,
+		protocol.DocumentHighlightRead,
+		protocol.DocumentHighlightWrite,
+		protocol.DocumentHighlightText,
+	}
+
+	cursors := map[string]string{
+		"block name":      "content %}\n        {{",
+		"endblock name":   "content %}\n    {% block sidebar",
+		"parent function": "parent()",
+		"block function":  "content') }}",
+		"block keyword":   "block content %}{% endblock",
+	}
+
+	for name, cursor := range cursors {
+		t.Run(name, func(t *testing.T) {
+			highlights := twigHighlights(t, "file:///project/base.html.twig", content, cursor)
+
+			assert.Equal(t, expectedTexts, highlightTexts(content, highlights))
+
+			kinds := make([]protocol.DocumentHighlightKind, 0, len(highlights))
+			for _, highlight := range highlights {
+				kinds = append(kinds, highlight.Kind)
+			}
+			assert.Equal(t, expectedKinds, kinds)
+		})
+	}
+}
+
+func TestTwigDocumentHighlightProvider_NoBlock(t *testing.T) {
+	content := `{% block page %}{{ product.name }}{% endblock %}`
+
+	assert.Empty(t, twigHighlights(t, "file:///project/base.html.twig", content, "product"))
+	assert.Empty(t, twigHighlights(t, "file:///project/base.html", content, "page"))
+}
@@ -2,8 +2,8 @@ package lsp
 
 import (
 	"context"
-	"path/filepath"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/php"
 )
@@ -11,11 +11,12 @@ import (
 // hover handles textDocument/hover requests
 func (s *Server) hover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
 	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
 	if ok {
 		params.Node = node
 		params.DocumentContent = docText.Text
 
-		if filepath.Ext(params.TextDocument.URI) == ".php" {
+		if indexer.FileType(params.TextDocument.URI) == ".php" {
 			phpIndex, _ := s.GetIndexer("php.index")
 			ctx = phpIndex.(*php.PHPIndex).AddContext(ctx, node, docText.Text)
 		}
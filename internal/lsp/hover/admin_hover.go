@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
@@ -35,7 +36,7 @@ func (p *AdminHoverProvider) GetHover(ctx context.Context, params *protocol.Hove
 		return nil, nil
 	}
 
-	ext := strings.ToLower(filepath.Ext(params.TextDocument.URI))
+	ext := indexer.FileType(params.TextDocument.URI)
 
 	// Handle JS/TS files
 	if ext == ".js" || ext == ".ts" {
@@ -159,21 +160,17 @@ func (p *AdminHoverProvider) buildHoverContent(components []admin.VueComponent)
 			sb.WriteString(fmt.Sprintf("**Extends**: `%s`\n\n", comp.ExtendsComponent))
 		}
 
-		// Props section
-		if len(comp.Props) > 0 {
+		parents := p.parentComponents(comp)
+
+		// Props section, including the props inherited from parent components
+		inheritedProps := inheritedPropLines(comp, parents)
+		if len(comp.Props) > 0 || len(inheritedProps) > 0 {
 			sb.WriteString("### Props\n\n")
 			for _, prop := range comp.Props {
-				propLine := fmt.Sprintf("- `%s`", prop.Name)
-				if prop.Type != "" {
-					propLine += fmt.Sprintf(": **%s**", prop.Type)
-				}
-				if prop.Required {
-					propLine += " *(required)*"
-				}
-				if prop.Default != "" {
-					propLine += fmt.Sprintf(" = `%s`", prop.Default)
-				}
-				sb.WriteString(propLine + "\n")
+				sb.WriteString(formatPropLine(prop) + "\n")
+			}
+			for _, line := range inheritedProps {
+				sb.WriteString(line + "\n")
 			}
 			sb.WriteString("\n")
 		}
@@ -205,15 +202,24 @@ func (p *AdminHoverProvider) buildHoverContent(components []admin.VueComponent)
 			sb.WriteString("\n")
 		}
 
-		// Slots section
-		if len(comp.Slots) > 0 {
+		// Slots section, including the slots inherited from parent components
+		inheritedSlots := inheritedSlotLines(comp, parents)
+		if len(comp.Slots) > 0 || len(inheritedSlots) > 0 {
 			sb.WriteString("### Slots\n\n")
 			for _, slot := range comp.Slots {
 				sb.WriteString(fmt.Sprintf("- `%s`\n", slot.Name))
 			}
+			for _, line := range inheritedSlots {
+				sb.WriteString(line + "\n")
+			}
 			sb.WriteString("\n")
 		}
 
+		// The definition can't be located when it is imported with a path built at runtime
+		if comp.DynamicImportPath {
+			sb.WriteString(fmt.Sprintf("*Dynamic import*: `%s…` (definition not resolved)\n\n", comp.ImportPath))
+		}
+
 		// File path (relative to project root)
 		if comp.DefinitionPath != "" {
 			displayPath := p.makeRelativePath(comp.DefinitionPath)
@@ -227,6 +233,88 @@ func (p *AdminHoverProvider) buildHoverContent(components []admin.VueComponent)
 	return sb.String()
 }
 
+// formatPropLine renders a prop as markdown list item with its type, required flag and default value
+func formatPropLine(prop admin.VueComponentProp) string {
+	propLine := fmt.Sprintf("- `%s`", prop.Name)
+	if prop.Type != "" {
+		propLine += fmt.Sprintf(": **%s**", prop.Type)
+	}
+	if prop.Required {
+		propLine += " *(required)*"
+	}
+	if prop.Default != "" {
+		propLine += fmt.Sprintf(" = `%s`", prop.Default)
+	}
+	return propLine
+}
+
+// parentComponents walks the ExtendsComponent chain and returns the parents of the component,
+// closest parent first
+func (p *AdminHoverProvider) parentComponents(comp admin.VueComponent) []admin.VueComponent {
+	var parents []admin.VueComponent
+	if p.adminIndexer == nil {
+		return parents
+	}
+
+	visited := map[string]bool{comp.Name: true}
+	current := comp
+	for current.ExtendsComponent != "" && !visited[current.ExtendsComponent] {
+		visited[current.ExtendsComponent] = true
+
+		components, err := p.adminIndexer.GetComponentWithDefinition(current.ExtendsComponent)
+		if err != nil || len(components) == 0 {
+			break
+		}
+
+		current = components[0]
+		parents = append(parents, current)
+	}
+
+	return parents
+}
+
+// inheritedPropLines returns the props of the parents which are not overridden by the component
+func inheritedPropLines(comp admin.VueComponent, parents []admin.VueComponent) []string {
+	seen := make(map[string]bool)
+	for _, prop := range comp.Props {
+		seen[prop.Name] = true
+	}
+
+	var lines []string
+	for _, parent := range parents {
+		for _, prop := range parent.Props {
+			if seen[prop.Name] {
+				continue
+			}
+			seen[prop.Name] = true
+			lines = append(lines, fmt.Sprintf("%s *(inherited from `%s`)*", formatPropLine(prop), parent.Name))
+		}
+	}
+
+	return lines
+}
+
+// inheritedSlotLines returns the slots of the parents which are not redefined by the component
+func inheritedSlotLines(comp admin.VueComponent, parents []admin.VueComponent) []string {
+	seen := make(map[string]bool)
+	for _, slot := range comp.Slots {
+		seen[slot.Name] = true
+	}
+
+	var lines []string
+	for _, parent := range parents {
+		for _, slot := range parent.Slots {
+			if seen[slot.Name] {
+				continue
+			}
+			seen[slot.Name] = true
+			lines = append(lines, fmt.Sprintf("- `%s` *(inherited from `%s`)*", slot.Name, parent.Name))
+		}
+	}
+
+	return lines
+}
+
 // makeRelativePath converts an absolute path to a path relative to the project root
 func (p *AdminHoverProvider) makeRelativePath(absPath string) string {
 	if p.projectRoot == "" {
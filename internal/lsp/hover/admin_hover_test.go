@@ -1,10 +1,14 @@
 package hover
 
 import (
+	"context"
 	"testing"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 	tree_sitter_javascript "github.com/tree-sitter/tree-sitter-javascript/bindings/go"
 )
@@ -297,6 +301,21 @@ func TestBuildHoverContent(t *testing.T) {
 				"*Defined in*: `/path/to/sw-data-grid/index.js`",
 			},
 		},
+		{
+			name: "component with dynamic import path",
+			components: []admin.VueComponent{
+				{
+					Name:              "sw-dynamic",
+					FilePath:          "/path/to/index.js",
+					ImportPath:        "src/app/component/",
+					DynamicImportPath: true,
+				},
+			},
+			contains: []string{
+				"*Dynamic import*: `src/app/component/…` (definition not resolved)",
+				"*Registered in*: `/path/to/index.js`",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -309,3 +328,61 @@ func TestBuildHoverContent(t *testing.T) {
 		})
 	}
 }
+
+func TestAdminHoverTwigComponentTag(t *testing.T) {
+	adminIndexer, err := admin.NewAdminComponentIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = adminIndexer.Close() }()
+
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:     "sw-base-field",
+		FilePath: "/project/admin/sw-base-field/index.js",
+		Props: []admin.VueComponentProp{
+			{Name: "label", Type: "String"},
+			{Name: "disabled", Type: "Boolean", Default: "false"},
+		},
+		Slots: []admin.VueComponentSlot{{Name: "label"}, {Name: "hint"}},
+	}))
+	require.NoError(t, adminIndexer.SaveComponent(admin.VueComponent{
+		Name:             "sw-text-field",
+		FilePath:         "/project/admin/sw-text-field/index.js",
+		ExtendsComponent: "sw-base-field",
+		Props: []admin.VueComponentProp{
+			{Name: "value", Type: "String", Required: true},
+			{Name: "label", Type: "String", Required: true},
+		},
+		Slots: []admin.VueComponentSlot{{Name: "suffix"}},
+	}))
+
+	provider := &AdminHoverProvider{adminIndexer: adminIndexer, projectRoot: "/project"}
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	code := `<sw-text-field label="Name"></sw-text-field>`
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	params := &protocol.HoverParams{Node: findNodeAtPosition(tree.RootNode(), 0, 3), DocumentContent: []byte(code)}
+	params.TextDocument.URI = "file:///project/src/Resources/app/administration/src/module/sw-foo/sw-foo.html.twig"
+
+	hover, err := provider.GetHover(context.Background(), params)
+	require.NoError(t, err)
+	require.NotNil(t, hover)
+
+	for _, expected := range []string{
+		"## `sw-text-field`",
+		"**Extends**: `sw-base-field`",
+		"- `value`: **String** *(required)*\n",
+		"- `label`: **String** *(required)*\n",
+		"- `disabled`: **Boolean** = `false` *(inherited from `sw-base-field`)*",
+		"- `suffix`\n",
+		"- `label` *(inherited from `sw-base-field`)*",
+		"- `hint` *(inherited from `sw-base-field`)*",
+		"*Registered in*: `admin/sw-text-field/index.js`",
+	} {
+		assert.Contains(t, hover.Contents.Value, expected)
+	}
+	assert.NotContains(t, hover.Contents.Value, "`label`: **String** *(inherited")
+}
@@ -0,0 +1,235 @@
+package hover
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPHoverProvider provides hover information for PHP methods and class references
+type PHPHoverProvider struct {
+	projectRoot string
+	phpIndex    *php.PHPIndex
+}
+
+// NewPHPHoverProvider creates a new PHP hover provider
+func NewPHPHoverProvider(projectRoot string, lspServer *lsp.Server) *PHPHoverProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+
+	return &PHPHoverProvider{
+		projectRoot: projectRoot,
+		phpIndex:    phpIndex.(*php.PHPIndex),
+	}
+}
+
+// GetHover returns the signature of the method declared, called with $this-> or called statically at the cursor,
+// or the hierarchy of the class referenced at the cursor
+func (p *PHPHoverProvider) GetHover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+	if params.Node == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return nil, nil
+	}
+
+	node := params.Node
+	if node.Kind() != "name" || node.Parent() == nil {
+		return nil, nil
+	}
+
+	var className string
+	switch {
+	case isMethodDeclarationName(node) || isThisMethodCallName(node, params.DocumentContent):
+		phpCtx, ok := ctx.Value(php.PHPContextKey).(*php.PHPContext)
+		if !ok || phpCtx == nil || phpCtx.InsideClass == nil {
+			return nil, nil
+		}
+		className = phpCtx.InsideClass.Name
+	case isStaticMethodCallName(node):
+		className = p.phpIndex.ResolveScopeClass(node.Parent().ChildByFieldName("scope"), params.DocumentContent)
+	default:
+		return p.classHover(node, params.DocumentContent), nil
+	}
+
+	methodName := node.Utf8Text(params.DocumentContent)
+	method, declaringClass := p.phpIndex.GetMethodWithDeclaringClass(className, methodName)
+	if method == nil {
+		return nil, nil
+	}
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: buildMethodHoverContent(method, declaringClass, className),
+		},
+		Range: &protocol.Range{
+			Start: protocol.Position{
+				Line:      int(node.StartPosition().Row),
+				Character: int(node.StartPosition().Column),
+			},
+			End: protocol.Position{
+				Line:      int(node.EndPosition().Row),
+				Character: int(node.EndPosition().Column),
+			},
+		},
+	}, nil
+}
+
+// isMethodDeclarationName checks for the name of a method declaration
+// public function <caret>()
+func isMethodDeclarationName(node *tree_sitter.Node) bool {
+	parent := node.Parent()
+	if parent.Kind() != "method_declaration" {
+		return false
+	}
+
+	nameNode := parent.ChildByFieldName("name")
+	return nameNode != nil && nameNode.Id() == node.Id()
+}
+
+// isThisMethodCallName checks for the method name of a call on $this
+// $this-><caret>()
+func isThisMethodCallName(node *tree_sitter.Node, content []byte) bool {
+	parent := node.Parent()
+	if parent.Kind() != "member_call_expression" {
+		return false
+	}
+
+	nameNode := parent.ChildByFieldName("name")
+	if nameNode == nil || nameNode.Id() != node.Id() {
+		return false
+	}
+
+	object := parent.ChildByFieldName("object")
+	return object != nil && object.Kind() == "variable_name" && object.Utf8Text(content) == "$this"
+}
+
+// isStaticMethodCallName checks for the method name of a static call
+// Foo::<caret>(), self::<caret>() or parent::<caret>()
+func isStaticMethodCallName(node *tree_sitter.Node) bool {
+	parent := node.Parent()
+	if parent.Kind() != "scoped_call_expression" {
+		return false
+	}
+
+	nameNode := parent.ChildByFieldName("name")
+	return nameNode != nil && nameNode.Id() == node.Id()
+}
+
+func buildMethodHoverContent(method *php.PHPMethod, declaringClass *php.PHPClass, currentClass string) string {
+	returnType := "mixed"
+	if method.ReturnType != nil {
+		returnType = method.ReturnType.Name()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```php\n")
+	sb.WriteString(fmt.Sprintf("%s function %s(): %s\n", method.Modifiers(), method.Name, returnType))
+	sb.WriteString("```\n\n")
+
+	if declaringClass.Name != currentClass {
+		sb.WriteString(fmt.Sprintf("**Inherited from:** `%s`\n", declaringClass.Name))
+	} else {
+		sb.WriteString(fmt.Sprintf("**Class:** `%s`\n", declaringClass.Name))
+	}
+
+	return sb.String()
+}
+
+// classHover describes the class referenced at the node with its parents, interfaces and file
+func (p *PHPHoverProvider) classHover(node *tree_sitter.Node, content []byte) *protocol.Hover {
+	className := php.ResolveClassReference(node, content)
+	if className == "" {
+		return nil
+	}
+
+	class := p.phpIndex.GetClass(className)
+	if class == nil {
+		return nil
+	}
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: p.buildClassHoverContent(class),
+		},
+		Range: &protocol.Range{
+			Start: protocol.Position{
+				Line:      int(node.StartPosition().Row),
+				Character: int(node.StartPosition().Column),
+			},
+			End: protocol.Position{
+				Line:      int(node.EndPosition().Row),
+				Character: int(node.EndPosition().Column),
+			},
+		},
+	}
+}
+
+func (p *PHPHoverProvider) buildClassHoverContent(class *php.PHPClass) string {
+	var parents []string
+	interfaces := append([]string{}, class.Interfaces...)
+
+	// The hierarchy is walked along the parent chain first, so parents are in inheritance order
+	p.phpIndex.WalkHierarchy(class.Name, func(ancestor *php.PHPClass) bool {
+		if ancestor.Name == class.Name {
+			return true
+		}
+
+		if ancestor.IsInterface {
+			if !slices.Contains(interfaces, ancestor.Name) {
+				interfaces = append(interfaces, ancestor.Name)
+			}
+		} else {
+			parents = append(parents, ancestor.Name)
+		}
+
+		return true
+	})
+
+	kind := "class"
+	if class.IsInterface {
+		kind = "interface"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```php\n")
+	sb.WriteString(fmt.Sprintf("%s %s\n", kind, class.Name))
+	sb.WriteString("```\n\n")
+
+	if len(parents) > 0 {
+		sb.WriteString(fmt.Sprintf("**Extends:** `%s`\n\n", strings.Join(parents, "` → `")))
+	}
+
+	if len(interfaces) > 0 {
+		// Interfaces extend other interfaces instead of implementing them
+		label := "Implements"
+		if class.IsInterface {
+			label = "Extends"
+		}
+		sb.WriteString(fmt.Sprintf("**%s:** `%s`\n\n", label, strings.Join(interfaces, "`, `")))
+	}
+
+	sb.WriteString(fmt.Sprintf("**File:** `%s:%d`\n", p.makeRelativePath(class.Path), class.Line))
+
+	return sb.String()
+}
+
+// makeRelativePath converts an absolute path to a path relative to the project root
+func (p *PHPHoverProvider) makeRelativePath(absPath string) string {
+	if p.projectRoot == "" {
+		return absPath
+	}
+
+	relPath, err := filepath.Rel(p.projectRoot, absPath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return absPath
+	}
+
+	return relPath
+}
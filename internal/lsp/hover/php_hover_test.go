@@ -0,0 +1,183 @@
+package hover
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestPHPHoverProvider_Methods(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	parentContent := []byte(`<?php
+
+namespace App\Service;
+
+abstract class BaseService
+{
+    protected function getName(): string
+    {
+        return 'base';
+    }
+
+    final public static function create(): static
+    {
+    }
+}
+`)
+	parentTree := parser.Parse(parentContent, nil)
+	defer parentTree.Close()
+	require.NoError(t, phpIndex.Index("/project/BaseService.php", parentTree.RootNode(), parentContent))
+
+	content := []byte(`<?php
+
+namespace App\Service;
+
+class FooService extends BaseService
+{
+    private function load(): int
+    {
+        return $this->getName();
+    }
+
+    public function copy(): self
+    {
+        return self::create();
+    }
+}
+`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+	require.NoError(t, phpIndex.Index("/project/FooService.php", tree.RootNode(), content))
+
+	provider := &PHPHoverProvider{phpIndex: phpIndex}
+
+	hoverAt := func(needle string) *protocol.Hover {
+		offset := uint(strings.Index(string(content), needle))
+		node := tree.RootNode().DescendantForByteRange(offset, offset)
+		require.NotNil(t, node)
+
+		params := &protocol.HoverParams{Node: node, DocumentContent: content}
+		params.TextDocument.URI = "file:///project/FooService.php"
+
+		hover, err := provider.GetHover(phpIndex.AddContext(context.Background(), node, content), params)
+		require.NoError(t, err)
+		return hover
+	}
+
+	declaration := hoverAt("load")
+	require.NotNil(t, declaration)
+	assert.Contains(t, declaration.Contents.Value, "private function load(): int")
+	assert.Contains(t, declaration.Contents.Value, "**Class:** `App\\Service\\FooService`")
+
+	call := hoverAt("getName")
+	require.NotNil(t, call)
+	assert.Contains(t, call.Contents.Value, "protected function getName(): string")
+	assert.Contains(t, call.Contents.Value, "**Inherited from:** `App\\Service\\BaseService`")
+
+	staticCall := hoverAt("create")
+	require.NotNil(t, staticCall)
+	assert.Contains(t, staticCall.Contents.Value, "final public static function create(): static")
+	assert.Contains(t, staticCall.Contents.Value, "**Inherited from:** `App\\Service\\BaseService`")
+
+	assert.Nil(t, hoverAt("FooService"))
+}
+
+func TestPHPHoverProvider_ClassReferences(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	library := []byte(`<?php
+
+namespace Shopware\Core\Framework\Event;
+
+interface ShopwareEvent
+{
+}
+
+interface ShopwareSalesChannelEvent extends ShopwareEvent
+{
+}
+
+abstract class NestedEvent implements ShopwareEvent
+{
+}
+
+class CheckoutOrderPlacedEvent extends NestedEvent implements ShopwareSalesChannelEvent
+{
+}
+`)
+	libraryTree := parser.Parse(library, nil)
+	defer libraryTree.Close()
+	require.NoError(t, phpIndex.Index("/project/vendor/shopware/core/Events.php", libraryTree.RootNode(), library))
+
+	content := []byte(`<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Framework\Event\CheckoutOrderPlacedEvent;
+use Shopware\Core\Framework\Event\ShopwareSalesChannelEvent;
+
+class OrderSubscriber
+{
+    public function onOrderPlaced(CheckoutOrderPlacedEvent $event, ShopwareSalesChannelEvent $other, Unknown $unknown): void
+    {
+    }
+}
+`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	provider := &PHPHoverProvider{projectRoot: "/project", phpIndex: phpIndex}
+
+	hoverAt := func(needle string) *protocol.Hover {
+		offset := uint(strings.Index(string(content), needle))
+		node := tree.RootNode().DescendantForByteRange(offset, offset)
+		require.NotNil(t, node)
+
+		params := &protocol.HoverParams{Node: node, DocumentContent: content}
+		params.TextDocument.URI = "file:///project/src/OrderSubscriber.php"
+
+		hover, err := provider.GetHover(context.Background(), params)
+		require.NoError(t, err)
+		return hover
+	}
+
+	class := hoverAt("CheckoutOrderPlacedEvent $event")
+	require.NotNil(t, class)
+	assert.Contains(t, class.Contents.Value, "class Shopware\\Core\\Framework\\Event\\CheckoutOrderPlacedEvent\n")
+	assert.Contains(t, class.Contents.Value, "**Extends:** `Shopware\\Core\\Framework\\Event\\NestedEvent`")
+	assert.Contains(t, class.Contents.Value, "**Implements:** `Shopware\\Core\\Framework\\Event\\ShopwareSalesChannelEvent`, `Shopware\\Core\\Framework\\Event\\ShopwareEvent`")
+	assert.Contains(t, class.Contents.Value, "**File:** `vendor/shopware/core/Events.php:17`")
+
+	useStatement := hoverAt("CheckoutOrderPlacedEvent;")
+	require.NotNil(t, useStatement)
+	assert.Equal(t, class.Contents.Value, useStatement.Contents.Value)
+
+	iface := hoverAt("ShopwareSalesChannelEvent $other")
+	require.NotNil(t, iface)
+	assert.Contains(t, iface.Contents.Value, "interface Shopware\\Core\\Framework\\Event\\ShopwareSalesChannelEvent\n")
+	assert.Contains(t, iface.Contents.Value, "**Extends:** `Shopware\\Core\\Framework\\Event\\ShopwareEvent`")
+	assert.NotContains(t, iface.Contents.Value, "Implements")
+
+	assert.Nil(t, hoverAt("Unknown $unknown"))
+	assert.Nil(t, hoverAt("OrderSubscriber"))
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/snippet"
@@ -32,7 +33,7 @@ func (p *SnippetHoverProvider) GetHover(ctx context.Context, params *protocol.Ho
 	}
 
 	// Handle .twig, .php, .js, and .ts files
-	switch strings.ToLower(filepath.Ext(params.TextDocument.URI)) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".twig":
 		return p.twigHover(ctx, params)
 	case ".php":
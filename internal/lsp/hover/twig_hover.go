@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/extension"
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/theme"
@@ -35,7 +36,7 @@ func (p *TwigHoverProvider) GetHover(ctx context.Context, params *protocol.Hover
 	}
 
 	// Only process .twig files
-	if !strings.HasSuffix(strings.ToLower(params.TextDocument.URI), ".twig") {
+	if indexer.FileType(params.TextDocument.URI) != ".twig" {
 		return nil, nil
 	}
 
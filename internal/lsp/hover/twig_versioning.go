@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/twig"
@@ -28,7 +29,7 @@ func NewTwigVersioningHoverProvider(lspServer *lsp.Server) *TwigVersioningHoverP
 }
 
 func (p *TwigVersioningHoverProvider) GetHover(ctx context.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
-	if !strings.HasSuffix(strings.ToLower(params.TextDocument.URI), ".twig") {
+	if indexer.FileType(params.TextDocument.URI) != ".twig" {
 		return nil, nil
 	}
 
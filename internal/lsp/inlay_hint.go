@@ -0,0 +1,48 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// inlayHint handles textDocument/inlayHint requests
+func (s *Server) inlayHint(ctx context.Context, params *protocol.InlayHintParams) []protocol.InlayHint {
+	document, ok := s.documentManager.GetDocument(params.TextDocument.URI)
+	defer document.Release()
+
+	if !ok || document.Tree == nil {
+		return []protocol.InlayHint{}
+	}
+
+	params.DocumentContent = document.Text
+	params.RootNode = document.Tree.RootNode()
+
+	hints := []protocol.InlayHint{}
+	for _, provider := range s.inlayHintProviders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		for _, hint := range provider.GetInlayHints(ctx, params) {
+			if positionInRange(hint.Position, params.Range) {
+				hints = append(hints, hint)
+			}
+		}
+	}
+
+	return hints
+}
+
+// positionInRange checks whether the position is within the range, including its end
+func positionInRange(position protocol.Position, r protocol.Range) bool {
+	if position.Line < r.Start.Line || position.Line > r.End.Line {
+		return false
+	}
+
+	if position.Line == r.Start.Line && position.Character < r.Start.Character {
+		return false
+	}
+
+	return position.Line != r.End.Line || position.Character <= r.End.Character
+}
@@ -0,0 +1,13 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// InlayHintProvider is an interface for providing inline labels, like parameter names at call sites
+type InlayHintProvider interface {
+	// GetInlayHints returns the hints of the document, hints outside of the requested range are dropped by the server
+	GetInlayHints(ctx context.Context, params *protocol.InlayHintParams) []protocol.InlayHint
+}
@@ -0,0 +1,106 @@
+package inlayhint
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// PHPInlayHintProvider shows the parameter names of methods and constructors before the arguments of a call
+type PHPInlayHintProvider struct {
+	phpIndex *php.PHPIndex
+}
+
+func NewPHPInlayHintProvider(lspServer *lsp.Server) *PHPInlayHintProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+
+	return &PHPInlayHintProvider{
+		phpIndex: phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (p *PHPInlayHintProvider) GetInlayHints(ctx context.Context, params *protocol.InlayHintParams) []protocol.InlayHint {
+	if params.RootNode == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return nil
+	}
+
+	startLine := uint(max(params.Range.Start.Line, 0))
+	endLine := uint(max(params.Range.End.Line, 0))
+
+	var hints []protocol.InlayHint
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		if node.EndPosition().Row < startLine || node.StartPosition().Row > endLine {
+			return
+		}
+
+		switch node.Kind() {
+		case "member_call_expression", "nullsafe_member_call_expression", "scoped_call_expression", "object_creation_expression":
+			hints = append(hints, p.callHints(node, params.DocumentContent)...)
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(params.RootNode)
+
+	return hints
+}
+
+// callHints labels the positional arguments of a call with the parameter names of the called method.
+// Calls with a single argument and variables named like their parameter don't get a hint.
+func (p *PHPInlayHintProvider) callHints(call *tree_sitter.Node, content []byte) []protocol.InlayHint {
+	arguments := call.ChildByFieldName("arguments")
+	if arguments == nil {
+		// The arguments of a new expression are not exposed as a field
+		for i := uint(0); i < call.NamedChildCount(); i++ {
+			if call.NamedChild(i).Kind() == "arguments" {
+				arguments = call.NamedChild(i)
+			}
+		}
+	}
+	if arguments == nil || arguments.NamedChildCount() < 2 {
+		return nil
+	}
+
+	method, _ := p.phpIndex.ResolveCalledMethod(call, content)
+	if method == nil || len(method.Parameters) == 0 {
+		return nil
+	}
+
+	var hints []protocol.InlayHint
+	for i := uint(0); i < arguments.NamedChildCount() && int(i) < len(method.Parameters); i++ {
+		argument := arguments.NamedChild(i)
+		if argument.Kind() != "argument" {
+			continue
+		}
+
+		// Named arguments and unpacked arrays end the positional arguments
+		if argument.ChildByFieldName("name") != nil || argument.NamedChildCount() == 0 || argument.NamedChild(0).Kind() == "variadic_unpacking" {
+			break
+		}
+
+		parameterName := method.Parameters[i].Name
+		if value := argument.NamedChild(0); value.Kind() == "variable_name" && value.Utf8Text(content) == "$"+parameterName {
+			continue
+		}
+
+		hints = append(hints, protocol.InlayHint{
+			Position: protocol.Position{
+				Line:      int(argument.StartPosition().Row),
+				Character: int(argument.StartPosition().Column),
+			},
+			Label:        parameterName + ":",
+			Kind:         protocol.InlayHintParameter,
+			PaddingRight: true,
+		})
+	}
+
+	return hints
+}
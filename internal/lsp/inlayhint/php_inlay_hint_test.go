@@ -0,0 +1,87 @@
+package inlayhint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const phpService = `<?php
+
+namespace App\Service;
+
+class ProductRepository
+{
+    public function __construct(string $entityName, bool $versionAware, int $limit = 100)
+    {
+    }
+
+    public static function create(string $entityName, bool $versionAware): self
+    {
+    }
+}
+
+class ProductService
+{
+    private ProductRepository $repository;
+
+    public function load(string $id, bool $withVariants, int ...$fields): void
+    {
+        $this->load('id', true, 1, 2);
+        $this->load($id, withVariants: true);
+        $this->repository = new ProductRepository('product', false);
+        ProductRepository::create(...$arguments);
+        $this->load('id');
+        $this->unknown(1, 2);
+    }
+}
+`
+
+func TestPHPInlayHintProvider(t *testing.T) {
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = phpIndex.Close() })
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(phpService), nil)
+	defer tree.Close()
+	require.NoError(t, phpIndex.Index("/project/src/Service/ProductService.php", tree.RootNode(), []byte(phpService)))
+
+	provider := &PHPInlayHintProvider{phpIndex: phpIndex}
+
+	params := &protocol.InlayHintParams{
+		Range:           protocol.Range{End: protocol.Position{Line: 100}},
+		DocumentContent: []byte(phpService),
+		RootNode:        tree.RootNode(),
+	}
+	params.TextDocument.URI = "file:///project/src/Service/ProductService.php"
+
+	lines := strings.Split(phpService, "\n")
+
+	var hints []string
+	for _, hint := range provider.GetInlayHints(context.Background(), params) {
+		assert.Equal(t, protocol.InlayHintParameter, hint.Kind)
+		hints = append(hints, hint.Label+lines[hint.Position.Line][hint.Position.Character:][:3])
+	}
+
+	assert.Equal(t, []string{
+		"id:'id",
+		"withVariants:tru",
+		"fields:1, ",
+		"entityName:'pr",
+		"versionAware:fal",
+	}, hints)
+
+	params.TextDocument.URI = "file:///project/src/Resources/views/base.html.twig"
+	assert.Empty(t, provider.GetInlayHints(context.Background(), params))
+}
@@ -0,0 +1,138 @@
+package inlayhint
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TwigInlayHintProvider shows the parameter names of Twig functions and filters before their arguments
+type TwigInlayHintProvider struct {
+	twigIndexer *twig.TwigIndexer
+}
+
+func NewTwigInlayHintProvider(lspServer *lsp.Server) *TwigInlayHintProvider {
+	twigIndexer, _ := lspServer.GetIndexer("twig.indexer")
+
+	return &TwigInlayHintProvider{
+		twigIndexer: twigIndexer.(*twig.TwigIndexer),
+	}
+}
+
+func (p *TwigInlayHintProvider) GetInlayHints(ctx context.Context, params *protocol.InlayHintParams) []protocol.InlayHint {
+	if params.RootNode == nil || indexer.FileType(params.TextDocument.URI) != ".twig" {
+		return nil
+	}
+
+	startLine := uint(max(params.Range.Start.Line, 0))
+	endLine := uint(max(params.Range.End.Line, 0))
+
+	var hints []protocol.InlayHint
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		if node.EndPosition().Row < startLine || node.StartPosition().Row > endLine {
+			return
+		}
+
+		switch node.Kind() {
+		case "call_expression":
+			hints = append(hints, p.callHints(node, params.DocumentContent, false)...)
+		case "filter_expression":
+			hints = append(hints, p.callHints(node, params.DocumentContent, true)...)
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(params.RootNode)
+
+	return hints
+}
+
+// callHints labels the positional arguments of a function call or a filter with their parameter names.
+// Calls with a single argument are left out, just like arguments which are a variable of the same name.
+func (p *TwigInlayHintProvider) callHints(call *tree_sitter.Node, content []byte, isFilter bool) []protocol.InlayHint {
+	name := call.ChildByFieldName("name")
+	arguments := call.ChildByFieldName("arguments")
+	if name == nil || arguments == nil || arguments.NamedChildCount() < 2 {
+		return nil
+	}
+
+	parameters := p.parameters(name.Utf8Text(content), isFilter)
+	if len(parameters) == 0 {
+		return nil
+	}
+
+	var hints []protocol.InlayHint
+	for i := uint(0); i < arguments.NamedChildCount() && int(i) < len(parameters); i++ {
+		argument := arguments.NamedChild(i)
+
+		// Positional arguments may not follow named ones
+		if argument.Kind() == "named_argument" {
+			break
+		}
+
+		parameterName := strings.TrimPrefix(parameters[i].Name, "$")
+		if argument.Kind() == "variable" && argument.Utf8Text(content) == parameterName {
+			continue
+		}
+
+		hints = append(hints, protocol.InlayHint{
+			Position: protocol.Position{
+				Line:      int(argument.StartPosition().Row),
+				Character: int(argument.StartPosition().Column),
+			},
+			Label:        parameterName + ":",
+			Kind:         protocol.InlayHintParameter,
+			PaddingRight: true,
+		})
+	}
+
+	return hints
+}
+
+// parameters returns the parameters of a function or filter which are passed in the template. The environment and
+// context are passed by Twig itself, the first parameter of a filter is the filtered value.
+func (p *TwigInlayHintProvider) parameters(name string, isFilter bool) []twig.TwigParameter {
+	var parameters []twig.TwigParameter
+
+	if isFilter {
+		filters, _ := p.twigIndexer.GetTwigFilter(name)
+		for _, filter := range filters {
+			if len(filter.Parameters) > 0 {
+				parameters = filter.Parameters
+				break
+			}
+		}
+	} else {
+		functions, _ := p.twigIndexer.GetTwigFunction(name)
+		for _, function := range functions {
+			if len(function.Parameters) > 0 {
+				parameters = function.Parameters
+				break
+			}
+		}
+	}
+
+	for len(parameters) > 0 && isImplicitParameter(parameters[0]) {
+		parameters = parameters[1:]
+	}
+
+	if isFilter && len(parameters) > 0 {
+		parameters = parameters[1:]
+	}
+
+	return parameters
+}
+
+// isImplicitParameter checks for the parameters of needs_environment and needs_context callables
+func isImplicitParameter(parameter twig.TwigParameter) bool {
+	return strings.HasSuffix(parameter.Type, "Environment") || (parameter.Name == "$context" && parameter.Type == "array")
+}
@@ -0,0 +1,138 @@
+package inlayhint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/shopware/shopware-lsp/internal/twig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const twigExtension = `<?php
+
+namespace App\Twig;
+
+use Twig\Environment;
+use Twig\Extension\AbstractExtension;
+use Twig\TwigFilter;
+use Twig\TwigFunction;
+
+class AppExtension extends AbstractExtension
+{
+    public function getFunctions(): array
+    {
+        return [
+            new TwigFunction('price', [$this, 'price']),
+            new TwigFunction('thumbnail', [$this, 'thumbnail'], ['needs_environment' => true, 'needs_context' => true]),
+        ];
+    }
+
+    public function getFilters(): array
+    {
+        return [
+            new TwigFilter('truncate', [$this, 'truncate']),
+        ];
+    }
+
+    public function price(float $amount, string $currency, int $decimals = 2): string
+    {
+    }
+
+    public function thumbnail(Environment $twig, array $context, string $media, int $width): string
+    {
+    }
+
+    public function truncate(string $value, int $length, string $suffix = '...'): string
+    {
+    }
+}
+`
+
+func newTestProvider(t *testing.T) *TwigInlayHintProvider {
+	t.Helper()
+
+	twigIndexer, err := twig.NewTwigIndexer(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = twigIndexer.Close() })
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(twigExtension), nil)
+	defer tree.Close()
+	require.NoError(t, twigIndexer.Index("/project/src/Twig/AppExtension.php", tree.RootNode(), []byte(twigExtension)))
+
+	return &TwigInlayHintProvider{twigIndexer: twigIndexer}
+}
+
+func twigInlayHints(t *testing.T, provider *TwigInlayHintProvider, content string, r protocol.Range) []string {
+	t.Helper()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(content), nil)
+	defer tree.Close()
+
+	params := &protocol.InlayHintParams{
+		Range:           r,
+		DocumentContent: []byte(content),
+		RootNode:        tree.RootNode(),
+	}
+	params.TextDocument.URI = "file:///project/src/Resources/views/storefront/page/index.html.twig"
+
+	lines := strings.Split(content, "\n")
+
+	var hints []string
+	for _, hint := range provider.GetInlayHints(context.Background(), params) {
+		assert.Equal(t, protocol.InlayHintParameter, hint.Kind)
+		hints = append(hints, hint.Label+lines[hint.Position.Line][hint.Position.Character:][:3])
+	}
+
+	return hints
+}
+
+func TestTwigInlayHintProvider(t *testing.T) {
+	provider := newTestProvider(t)
+	all := protocol.Range{End: protocol.Position{Line: 100}}
+
+	content := `{{ price(19.99, 'EUR', 0) }}
+{{ thumbnail(cover, 400) }}
+{{ title|truncate(30, '..') }}
+{{ price(amount, currency) }}
+{{ price(19.99, currency = 'EUR') }}
+{{ price(19.99) }} {{ title|truncate(30) }} {{ unknown(1, 2) }}`
+
+	assert.Equal(t, []string{
+		"amount:19.",
+		"currency:'EU",
+		"decimals:0) ",
+		"media:cov",
+		"width:400",
+		"length:30,",
+		"suffix:'..",
+		"amount:19.",
+	}, twigInlayHints(t, provider, content, all))
+
+	assert.Equal(t, []string{"media:cov", "width:400"}, twigInlayHints(t, provider, content, protocol.Range{
+		Start: protocol.Position{Line: 1},
+		End:   protocol.Position{Line: 1, Character: 30},
+	}))
+}
+
+func TestTwigInlayHintProvider_OtherFiles(t *testing.T) {
+	provider := newTestProvider(t)
+
+	params := &protocol.InlayHintParams{}
+	params.TextDocument.URI = "file:///project/src/Twig/AppExtension.php"
+
+	assert.Empty(t, provider.GetInlayHints(context.Background(), params))
+}
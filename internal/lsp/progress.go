@@ -0,0 +1,95 @@
+package lsp
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// indexingRuns numbers the indexing runs, so runs overlapping each other get their own progress token
+var indexingRuns atomic.Int64
+
+// newIndexingProgressToken returns the work done progress token of a new indexing run
+func newIndexingProgressToken() string {
+	return fmt.Sprintf("shopware/indexing/%d", indexingRuns.Add(1))
+}
+
+// workDoneProgress reports the progress of a long running operation with $/progress notifications
+type workDoneProgress struct {
+	server      *Server
+	token       string
+	mu          sync.Mutex
+	lastPercent int
+}
+
+// startWorkDoneProgress asks the client to create a progress token and sends the begin notification.
+// It returns nil when the client does not support work done progress.
+// It must not be called from the message loop, as it waits for the client's response.
+func (s *Server) startWorkDoneProgress(ctx context.Context, token, title string) *workDoneProgress {
+	if s.conn == nil || !s.supportsWorkDoneProgress {
+		return nil
+	}
+
+	if err := s.conn.Call(ctx, "window/workDoneProgress/create", map[string]interface{}{
+		"token": token,
+	}, nil); err != nil {
+		log.Printf("Error creating work done progress: %v", err)
+		return nil
+	}
+
+	p := &workDoneProgress{server: s, token: token}
+	p.notify(ctx, map[string]interface{}{
+		"kind":        "begin",
+		"title":       title,
+		"cancellable": false,
+		"percentage":  0,
+	})
+
+	return p
+}
+
+// report sends the processed file count, notifications are only sent when the percentage changes
+func (p *workDoneProgress) report(ctx context.Context, processed, total int) {
+	if p == nil || total == 0 {
+		return
+	}
+
+	percent := processed * 100 / total
+
+	p.mu.Lock()
+	if percent <= p.lastPercent {
+		p.mu.Unlock()
+		return
+	}
+	p.lastPercent = percent
+	p.mu.Unlock()
+
+	p.notify(ctx, map[string]interface{}{
+		"kind":       "report",
+		"message":    fmt.Sprintf("%d/%d files", processed, total),
+		"percentage": percent,
+	})
+}
+
+// end sends the end notification
+func (p *workDoneProgress) end(ctx context.Context, message string) {
+	if p == nil {
+		return
+	}
+
+	p.notify(ctx, map[string]interface{}{
+		"kind":    "end",
+		"message": message,
+	})
+}
+
+func (p *workDoneProgress) notify(ctx context.Context, value map[string]interface{}) {
+	if err := p.server.conn.Notify(ctx, "$/progress", map[string]interface{}{
+		"token": p.token,
+		"value": value,
+	}); err != nil {
+		log.Printf("Error sending progress: %v", err)
+	}
+}
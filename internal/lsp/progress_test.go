@@ -0,0 +1,12 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIndexingProgressToken(t *testing.T) {
+	// A reindex during the initial index must not end the progress of the other run
+	assert.NotEqual(t, newIndexingProgressToken(), newIndexingProgressToken())
+}
@@ -38,6 +38,8 @@ const (
 	CodeActionSource CodeActionKind = "source"
 	// CodeActionSourceOrganizeImports represents an organize imports action
 	CodeActionSourceOrganizeImports CodeActionKind = "source.organizeImports"
+	// CodeActionSourceRemoveUnusedImports represents a remove unused imports action
+	CodeActionSourceRemoveUnusedImports CodeActionKind = "source.removeUnusedImports"
 )
 
 // CodeAction represents a code action
@@ -1,6 +1,10 @@
 package protocol
 
-import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+import (
+	"encoding/json"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
 
 // CompletionList represents a list of completion items
 type CompletionList struct {
@@ -10,9 +14,31 @@ type CompletionList struct {
 
 // InitializeParams represents the parameters for the 'initialize' request
 type InitializeParams struct {
-	RootPath         string            `json:"rootPath,omitempty"`
-	RootURI          string            `json:"rootUri,omitempty"`
-	WorkspaceFolders []WorkspaceFolder `json:"workspaceFolders,omitempty"`
+	RootPath              string             `json:"rootPath,omitempty"`
+	RootURI               string             `json:"rootUri,omitempty"`
+	WorkspaceFolders      []WorkspaceFolder  `json:"workspaceFolders,omitempty"`
+	Capabilities          ClientCapabilities `json:"capabilities"`
+	InitializationOptions json.RawMessage    `json:"initializationOptions,omitempty"`
+}
+
+// ClientCapabilities represents the subset of client capabilities used by the server
+type ClientCapabilities struct {
+	Workspace struct {
+		// The client supports the workspace/configuration request
+		Configuration bool `json:"configuration,omitempty"`
+	} `json:"workspace,omitempty"`
+	Window struct {
+		// The client supports server initiated progress with window/workDoneProgress/create
+		WorkDoneProgress bool `json:"workDoneProgress,omitempty"`
+	} `json:"window,omitempty"`
+	TextDocument struct {
+		CodeAction struct {
+			// The properties of a code action the client can resolve lazily with codeAction/resolve
+			ResolveSupport struct {
+				Properties []string `json:"properties"`
+			} `json:"resolveSupport,omitempty"`
+		} `json:"codeAction,omitempty"`
+	} `json:"textDocument,omitempty"`
 }
 
 // WorkspaceFolder represents a workspace folder
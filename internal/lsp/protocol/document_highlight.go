@@ -0,0 +1,33 @@
+package protocol
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// DocumentHighlightParams represents the parameters for a document highlight request
+type DocumentHighlightParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"position"`
+	// Custom fields for internal use (not part of LSP spec)
+	// These fields are used to pass document content to document highlight providers
+	DocumentContent []byte            `json:"-"`
+	Node            *tree_sitter.Node `json:"-"`
+}
+
+// DocumentHighlightKind distinguishes reading and writing occurrences of a symbol
+type DocumentHighlightKind int
+
+const (
+	DocumentHighlightText  DocumentHighlightKind = 1
+	DocumentHighlightRead  DocumentHighlightKind = 2
+	DocumentHighlightWrite DocumentHighlightKind = 3
+)
+
+// DocumentHighlight is an occurrence of the symbol at the cursor in the same document
+type DocumentHighlight struct {
+	Range Range                 `json:"range"`
+	Kind  DocumentHighlightKind `json:"kind,omitempty"`
+}
@@ -106,3 +106,20 @@ type DeleteFilesParams struct {
 type FileDelete struct {
 	URI string `json:"uri"`
 }
+
+// TextDocumentSyncKind defines how the client syncs document changes to the server
+type TextDocumentSyncKind int
+
+const (
+	// TextDocumentSyncFull sends the full content of the document on every change
+	TextDocumentSyncFull TextDocumentSyncKind = 1
+	// TextDocumentSyncIncremental sends only the changed ranges of the document
+	TextDocumentSyncIncremental TextDocumentSyncKind = 2
+)
+
+// TextDocumentContentChangeEvent represents a change to a text document.
+// If Range is nil the Text is the full new content of the document.
+type TextDocumentContentChangeEvent struct {
+	Range *Range `json:"range,omitempty"`
+	Text  string `json:"text"`
+}
@@ -0,0 +1,33 @@
+package protocol
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// InlayHintParams represents the parameters for an inlay hint request
+type InlayHintParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	// Range is the visible part of the document hints are requested for
+	Range Range `json:"range"`
+	// Custom fields for internal use (not part of LSP spec)
+	// These fields are used to pass the parsed document to inlay hint providers
+	DocumentContent []byte            `json:"-"`
+	RootNode        *tree_sitter.Node `json:"-"`
+}
+
+// InlayHintKind distinguishes hints for types and for parameter names
+type InlayHintKind int
+
+const (
+	InlayHintType      InlayHintKind = 1
+	InlayHintParameter InlayHintKind = 2
+)
+
+// InlayHint is a label rendered inline before the given position
+type InlayHint struct {
+	Position     Position      `json:"position"`
+	Label        string        `json:"label"`
+	Kind         InlayHintKind `json:"kind,omitempty"`
+	PaddingLeft  bool          `json:"paddingLeft,omitempty"`
+	PaddingRight bool          `json:"paddingRight,omitempty"`
+}
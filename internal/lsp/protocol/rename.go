@@ -0,0 +1,40 @@
+package protocol
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// PrepareRenameParams represents the parameters for a prepare rename request
+type PrepareRenameParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"position"`
+	// Custom fields for internal use (not part of LSP spec)
+	// These fields are used to pass document content to rename providers
+	DocumentContent []byte            `json:"-"`
+	Node            *tree_sitter.Node `json:"-"`
+}
+
+// PrepareRenameResult is the range of the symbol which would be renamed and its current name
+type PrepareRenameResult struct {
+	Range       Range  `json:"range"`
+	Placeholder string `json:"placeholder"`
+}
+
+// RenameParams represents the parameters for a rename request
+type RenameParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position struct {
+		Line      int `json:"line"`
+		Character int `json:"character"`
+	} `json:"position"`
+	NewName string `json:"newName"`
+	// Custom fields for internal use (not part of LSP spec)
+	// These fields are used to pass document content to rename providers
+	DocumentContent []byte            `json:"-"`
+	Node            *tree_sitter.Node `json:"-"`
+}
@@ -0,0 +1,34 @@
+package protocol
+
+import tree_sitter "github.com/tree-sitter/go-tree-sitter"
+
+// SignatureHelpParams represents the parameters for a signature help request
+type SignatureHelpParams struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Position Position `json:"position"`
+	// Custom fields for internal use (not part of LSP spec)
+	// These fields are used to pass the parsed document to signature help providers
+	DocumentContent []byte            `json:"-"`
+	Node            *tree_sitter.Node `json:"-"`
+}
+
+// SignatureHelp lists the signatures of the called function, the active parameter is the argument at the cursor
+type SignatureHelp struct {
+	Signatures      []SignatureInformation `json:"signatures"`
+	ActiveSignature int                    `json:"activeSignature"`
+	ActiveParameter int                    `json:"activeParameter"`
+}
+
+// SignatureInformation is a single signature, each parameter label is contained in the signature label
+type SignatureInformation struct {
+	Label         string                 `json:"label"`
+	Documentation string                 `json:"documentation,omitempty"`
+	Parameters    []ParameterInformation `json:"parameters"`
+}
+
+// ParameterInformation is a parameter of a signature
+type ParameterInformation struct {
+	Label string `json:"label"`
+}
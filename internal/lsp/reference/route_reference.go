@@ -3,8 +3,8 @@ package reference
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
+	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
 	"github.com/shopware/shopware-lsp/internal/symfony"
@@ -30,7 +30,7 @@ func (r *RouteReferenceProvider) GetReferences(ctx context.Context, params *prot
 		return nil
 	}
 
-	switch filepath.Ext(params.TextDocument.URI) {
+	switch indexer.FileType(params.TextDocument.URI) {
 	case ".php":
 		return r.getReferencesForPHP(ctx, params)
 	default:
@@ -0,0 +1,138 @@
+package reference
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+)
+
+type ServiceReferenceProvider struct {
+	serviceIndex *symfony.ServiceIndex
+	phpIndex     *php.PHPIndex
+}
+
+func NewServiceReferenceProvider(lspServer *lsp.Server) *ServiceReferenceProvider {
+	serviceIndex, _ := lspServer.GetIndexer("symfony.service")
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+	return &ServiceReferenceProvider{
+		serviceIndex: serviceIndex.(*symfony.ServiceIndex),
+		phpIndex:     phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (r *ServiceReferenceProvider) GetReferences(ctx context.Context, params *protocol.ReferenceParams) []protocol.Location {
+	if params.Node == nil {
+		return nil
+	}
+
+	switch indexer.FileType(params.TextDocument.URI) {
+	case ".xml":
+		return r.getReferencesForXML(params)
+	case ".php":
+		return r.getReferencesForPHP(params)
+	default:
+		return nil
+	}
+}
+
+func (r *ServiceReferenceProvider) getReferencesForXML(params *protocol.ReferenceParams) []protocol.Location {
+	node := params.Node
+	content := params.DocumentContent
+
+	// <service id="foo" class="<caret>">
+	if treesitterhelper.SymfonyServiceIsServiceClass(node, content) {
+		return r.classReferences(strings.TrimLeft(treesitterhelper.GetNodeText(node, content), "\\"))
+	}
+
+	// <service id="<caret>">, <argument type="service" id="<caret>"/>, <alias id="<caret>" service="<caret>"/>
+	// and <service decorates="<caret>" parent="<caret>">
+	if treesitterhelper.SymfonyServiceIsServiceId(node, content) ||
+		treesitterhelper.SymfonyServiceIsServiceTag(node, content) ||
+		treesitterhelper.SymfonyServiceIsAliasAttribute(node, content) ||
+		treesitterhelper.SymfonyServiceIsServiceReferenceAttribute(node, content) {
+		serviceID := treesitterhelper.GetNodeText(node, content)
+		if serviceID == "" {
+			return nil
+		}
+
+		return toLocations(r.serviceIndex.GetServiceReferences(serviceID))
+	}
+
+	return nil
+}
+
+func (r *ServiceReferenceProvider) getReferencesForPHP(params *protocol.ReferenceParams) []protocol.Location {
+	// class <caret>Foo
+	if params.Node.Kind() != "name" || params.Node.Parent() == nil || params.Node.Parent().Kind() != "class_declaration" {
+		return nil
+	}
+
+	className := treesitterhelper.GetClassName(params.Node, params.DocumentContent)
+	if className == "" {
+		return nil
+	}
+
+	return r.classReferences(className)
+}
+
+// classReferences returns the references of all services using the class and the
+// constructors of other classes injecting it
+func (r *ServiceReferenceProvider) classReferences(className string) []protocol.Location {
+	var locations []symfony.Location
+
+	for _, serviceID := range r.serviceIndex.GetAllServices() {
+		service, ok := r.serviceIndex.GetServiceByID(serviceID)
+		if !ok || service.Class != className {
+			continue
+		}
+
+		locations = append(locations, r.serviceIndex.GetServiceReferences(serviceID)...)
+	}
+
+	// Promoted and plain constructor parameters both inject the class
+	for _, phpClass := range r.phpIndex.GetClasses() {
+		constructor, ok := phpClass.Methods["__construct"]
+		if !ok {
+			continue
+		}
+
+		for _, parameter := range constructor.Parameters {
+			if parameter.Type == nil || strings.TrimLeft(parameter.Type.Name(), "?\\") != className {
+				continue
+			}
+
+			locations = append(locations, symfony.Location{Path: phpClass.Path, Line: parameter.Line})
+		}
+	}
+
+	return toLocations(locations)
+}
+
+func toLocations(locations []symfony.Location) []protocol.Location {
+	result := make([]protocol.Location, 0, len(locations))
+
+	for _, location := range locations {
+		result = append(result, protocol.Location{
+			URI: fmt.Sprintf("file://%s", location.Path),
+			Range: protocol.Range{
+				Start: protocol.Position{
+					Line:      location.Line - 1,
+					Character: 0,
+				},
+				End: protocol.Position{
+					Line:      location.Line - 1,
+					Character: 0,
+				},
+			},
+		})
+	}
+
+	return result
+}
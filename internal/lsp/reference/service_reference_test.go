@@ -0,0 +1,152 @@
+package reference
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/shopware/shopware-lsp/internal/symfony"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const servicesXML = `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="App\Service\Mailer"/>
+        <alias id="app.mailer" service="App\Service\Mailer"/>
+        <service id="App\Service\Newsletter">
+            <argument type="service" id="App\Service\Mailer"/>
+            <call method="setFallback">
+                <argument type="service" id="App\Service\Mailer"/>
+            </call>
+        </service>
+    </services>
+</container>`
+
+const newsletterPHP = `<?php
+namespace App\Service;
+
+class Newsletter
+{
+    public function __construct(
+        private readonly Mailer $mailer
+    ) {
+    }
+}
+`
+
+const mailerPHP = `<?php
+namespace App\Service;
+
+class Mailer
+{
+}
+`
+
+const reportPHP = `<?php
+namespace App\Service;
+
+class Report
+{
+    private ?Mailer $fallback = null;
+
+    private Mailer $mailer;
+
+    public function __construct(Mailer $mailer)
+    {
+        $this->mailer = $mailer;
+    }
+}
+`
+
+func TestServiceReferenceProvider(t *testing.T) {
+	xmlParser := tree_sitter.NewParser()
+	defer xmlParser.Close()
+	require.NoError(t, xmlParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	phpParser := tree_sitter.NewParser()
+	defer phpParser.Close()
+	require.NoError(t, phpParser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	serviceIndex, err := symfony.NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = phpIndex.Close() }()
+
+	xmlTree := xmlParser.Parse([]byte(servicesXML), nil)
+	defer xmlTree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", xmlTree.RootNode(), []byte(servicesXML)))
+
+	for path, code := range map[string]string{"/project/src/Newsletter.php": newsletterPHP, "/project/src/Mailer.php": mailerPHP, "/project/src/Report.php": reportPHP} {
+		tree := phpParser.Parse([]byte(code), nil)
+		require.NoError(t, phpIndex.Index(path, tree.RootNode(), []byte(code)))
+		tree.Close()
+	}
+
+	provider := &ServiceReferenceProvider{serviceIndex: serviceIndex, phpIndex: phpIndex}
+
+	lines := func(locations []protocol.Location) []string {
+		var result []string
+		for _, location := range locations {
+			result = append(result, fmt.Sprintf("%s:%d", strings.TrimPrefix(location.URI, "file://"), location.Range.Start.Line+1))
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	t.Run("service id in XML", func(t *testing.T) {
+		offset := uint(strings.Index(servicesXML, `id="App\Service\Mailer"/>`) + 4)
+		node := xmlTree.RootNode().DescendantForByteRange(offset, offset)
+		for node != nil && node.Kind() != "AttValue" {
+			node = node.Parent()
+		}
+		require.NotNil(t, node)
+
+		params := &protocol.ReferenceParams{Node: node, DocumentContent: []byte(servicesXML)}
+		params.TextDocument.URI = "file:///project/services.xml"
+
+		assert.Equal(t, []string{
+			"/project/services.xml:4",
+			"/project/services.xml:5",
+			"/project/services.xml:7",
+			"/project/services.xml:9",
+		}, lines(provider.GetReferences(context.Background(), params)))
+	})
+
+	t.Run("class declaration in PHP", func(t *testing.T) {
+		tree := phpParser.Parse([]byte(mailerPHP), nil)
+		defer tree.Close()
+
+		offset := uint(strings.Index(mailerPHP, "Mailer\n"))
+		params := &protocol.ReferenceParams{Node: tree.RootNode().DescendantForByteRange(offset, offset), DocumentContent: []byte(mailerPHP)}
+		params.TextDocument.URI = "file:///project/src/Mailer.php"
+
+		assert.Equal(t, []string{
+			"/project/services.xml:4",
+			"/project/services.xml:5",
+			"/project/services.xml:7",
+			"/project/services.xml:9",
+			"/project/src/Newsletter.php:7",
+			"/project/src/Report.php:10",
+		}, lines(provider.GetReferences(context.Background(), params)))
+	})
+
+	t.Run("unrelated node", func(t *testing.T) {
+		offset := uint(strings.Index(servicesXML, "setFallback"))
+		params := &protocol.ReferenceParams{Node: xmlTree.RootNode().DescendantForByteRange(offset, offset), DocumentContent: []byte(servicesXML)}
+		params.TextDocument.URI = "file:///project/services.xml"
+
+		assert.Empty(t, provider.GetReferences(context.Background(), params))
+	})
+}
@@ -9,6 +9,7 @@ import (
 // references handles textDocument/references requests
 func (s *Server) references(ctx context.Context, params *protocol.ReferenceParams) []protocol.Location {
 	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
 	if ok {
 		params.Node = node
 		params.DocumentContent = docText.Text
@@ -0,0 +1,52 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// prepareRename handles textDocument/prepareRename requests. A nil result tells the client
+// that the position is not a renameable symbol.
+func (s *Server) prepareRename(ctx context.Context, params *protocol.PrepareRenameParams) *protocol.PrepareRenameResult {
+	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
+	if !ok || node == nil {
+		return nil
+	}
+
+	params.Node = node
+	params.DocumentContent = docText.Text
+
+	for _, provider := range s.renameProviders {
+		if result := provider.PrepareRename(ctx, params); result != nil {
+			return result
+		}
+	}
+
+	return nil
+}
+
+// rename handles textDocument/rename requests. A nil result tells the client that nothing changes.
+func (s *Server) rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
+	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
+	if !ok || node == nil {
+		return nil, nil
+	}
+
+	params.Node = node
+	params.DocumentContent = docText.Text
+
+	for _, provider := range s.renameProviders {
+		edit, err := provider.Rename(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if edit != nil {
+			return edit, nil
+		}
+	}
+
+	return nil, nil
+}
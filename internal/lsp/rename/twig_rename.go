@@ -0,0 +1,67 @@
+package rename
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TwigRenameProvider prepares renaming the blocks of Twig templates. Only the name of a block tag
+// or the repeated name after endblock can be renamed, keywords and expressions are rejected.
+// The rename itself is not supported yet, as the templates extending or overriding the block
+// would break.
+type TwigRenameProvider struct{}
+
+func NewTwigRenameProvider() *TwigRenameProvider {
+	return &TwigRenameProvider{}
+}
+
+func (p *TwigRenameProvider) PrepareRename(ctx context.Context, params *protocol.PrepareRenameParams) *protocol.PrepareRenameResult {
+	node := blockNameNode(params.TextDocument.URI, params.Node)
+	if node == nil {
+		return nil
+	}
+
+	return &protocol.PrepareRenameResult{
+		Range:       nodeRange(node),
+		Placeholder: node.Utf8Text(params.DocumentContent),
+	}
+}
+
+// Rename rejects renaming blocks, templates extending or overriding the block would have to be updated as well
+func (p *TwigRenameProvider) Rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
+	if blockNameNode(params.TextDocument.URI, params.Node) == nil {
+		return nil, nil
+	}
+
+	return nil, errors.New("renaming blocks across templates is not supported yet")
+}
+
+// blockNameNode returns the node if it is the name of a block tag in a Twig template
+func blockNameNode(uri string, node *tree_sitter.Node) *tree_sitter.Node {
+	if node == nil || indexer.FileType(uri) != ".twig" {
+		return nil
+	}
+
+	if node.Kind() != "identifier" || node.Parent() == nil || node.Parent().Kind() != "block" {
+		return nil
+	}
+
+	return node
+}
+
+func nodeRange(node *tree_sitter.Node) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{
+			Line:      int(node.StartPosition().Row),
+			Character: int(node.StartPosition().Column),
+		},
+		End: protocol.Position{
+			Line:      int(node.EndPosition().Row),
+			Character: int(node.EndPosition().Column),
+		},
+	}
+}
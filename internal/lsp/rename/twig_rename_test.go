@@ -0,0 +1,124 @@
+package rename
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func twigPrepareRename(t *testing.T, uri, content, cursor string) *protocol.PrepareRenameResult {
+	t.Helper()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(content), nil)
+	t.Cleanup(tree.Close)
+
+	offset := strings.Index(content, cursor)
+	require.GreaterOrEqual(t, offset, 0, "cursor %q not found", cursor)
+
+	params := &protocol.PrepareRenameParams{
+		DocumentContent: []byte(content),
+		Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+	}
+	params.TextDocument.URI = uri
+
+	return NewTwigRenameProvider().PrepareRename(context.Background(), params)
+}
+
+func TestTwigRenameProvider_PrepareRename(t *testing.T) {
+	content := `{% block page %}
+    {% block content %}
+        {{ parent() }}
+    {% endblock content %}
+    {{ block('content') }}
+    {{ page_title }}
+{% endblock %}`
+
+	tests := []struct {
+		name     string
+		uri      string
+		cursor   string
+		expected *protocol.PrepareRenameResult
+	}{
+		{
+			name:   "block name",
+			uri:    "file:///test.html.twig",
+			cursor: "content %}\n        {{",
+			expected: &protocol.PrepareRenameResult{
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 1, Character: 13},
+					End:   protocol.Position{Line: 1, Character: 20},
+				},
+				Placeholder: "content",
+			},
+		},
+		{
+			name:   "name after endblock",
+			uri:    "file:///test.html.twig",
+			cursor: "content %}\n    {{ block",
+			expected: &protocol.PrepareRenameResult{
+				Range: protocol.Range{
+					Start: protocol.Position{Line: 3, Character: 16},
+					End:   protocol.Position{Line: 3, Character: 23},
+				},
+				Placeholder: "content",
+			},
+		},
+		{name: "block keyword", uri: "file:///test.html.twig", cursor: "block content"},
+		{name: "endblock keyword", uri: "file:///test.html.twig", cursor: "endblock %}"},
+		{name: "parent call", uri: "file:///test.html.twig", cursor: "parent()"},
+		{name: "variable", uri: "file:///test.html.twig", cursor: "page_title"},
+		{name: "non twig file", uri: "file:///test.html", cursor: "content %}\n        {{"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, twigPrepareRename(t, tt.uri, content, tt.cursor))
+		})
+	}
+}
+
+func TestTwigRenameProvider_Rename(t *testing.T) {
+	content := `{% block page %}
+    {% block content %}{% endblock content %}
+{% endblock %}`
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(content), nil)
+	defer tree.Close()
+
+	rename := func(cursor string) (*protocol.WorkspaceEdit, error) {
+		offset := strings.Index(content, cursor)
+		require.GreaterOrEqual(t, offset, 0, "cursor %q not found", cursor)
+
+		params := &protocol.RenameParams{
+			NewName:         "main",
+			DocumentContent: []byte(content),
+			Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+		}
+		params.TextDocument.URI = "file:///test.html.twig"
+
+		return NewTwigRenameProvider().Rename(context.Background(), params)
+	}
+
+	// Templates overriding the block would break, so block names are not renamed
+	edit, err := rename("content %}{%")
+	assert.EqualError(t, err, "renaming blocks across templates is not supported yet")
+	assert.Nil(t, edit)
+
+	edit, err = rename("block content")
+	require.NoError(t, err)
+	assert.Nil(t, edit)
+}
@@ -0,0 +1,16 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// RenameProvider is an interface for renaming symbols
+type RenameProvider interface {
+	// PrepareRename returns the range of the renameable symbol at the given position, nil if it can't be renamed
+	PrepareRename(ctx context.Context, params *protocol.PrepareRenameParams) *protocol.PrepareRenameResult
+
+	// Rename returns the edits renaming the symbol at the given position, nil if it can't be renamed
+	Rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error)
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -19,42 +20,65 @@ import (
 
 // Server represents the LSP server
 type Server struct {
-	rootPath             string
-	conn                 *jsonrpc2.Conn
-	completionProviders  []CompletionProvider
-	definitionProviders  []GotoDefinitionProvider
-	referencesProviders  []ReferencesProvider
-	codeLensProviders    []CodeLensProvider
-	diagnosticsProviders []DiagnosticsProvider
-	codeActionProviders  []CodeActionProvider
-	hoverProviders       []HoverProvider
-	commandProviders     []CommandProvider
-	indexers             map[string]indexer.Indexer
-	commandMap           map[string]CommandFunc
-	indexerMu            sync.RWMutex
-	documentManager      *DocumentManager
-	fileScanner          *indexer.FileScanner
-	cacheDir             string
-	version              string
+	rootPath                 string
+	conn                     *jsonrpc2.Conn
+	completionProviders      []CompletionProvider
+	definitionProviders      []GotoDefinitionProvider
+	typeDefinitionProviders  []TypeDefinitionProvider
+	referencesProviders      []ReferencesProvider
+	codeLensProviders        []CodeLensProvider
+	diagnosticsProviders     []DiagnosticsProvider
+	codeActionProviders      []CodeActionProvider
+	hoverProviders           []HoverProvider
+	highlightProviders       []DocumentHighlightProvider
+	inlayHintProviders       []InlayHintProvider
+	signatureHelpProviders   []SignatureHelpProvider
+	renameProviders          []RenameProvider
+	commandProviders         []CommandProvider
+	indexers                 map[string]indexer.Indexer
+	commandMap               map[string]CommandFunc
+	indexerMu                sync.RWMutex
+	requests                 map[jsonrpc2.ID]context.CancelFunc
+	requestsMu               sync.Mutex
+	documentManager          *DocumentManager
+	settings                 Settings
+	settingsMu               sync.RWMutex
+	supportsConfiguration    bool
+	supportsWorkDoneProgress bool
+	supportsCodeActionEdit   bool
+	fileWatcherEnabled       bool
+	lastIndexDuration        time.Duration
+	lastIndexedAt            time.Time
+	indexStatsMu             sync.RWMutex
+	fileScanner              *indexer.FileScanner
+	cacheDir                 string
+	version                  string
 }
 
 // NewServer creates a new LSP server
 func NewServer(filescanner *indexer.FileScanner, cacheDir, version string) *Server {
 	s := &Server{
-		completionProviders:  make([]CompletionProvider, 0),
-		definitionProviders:  make([]GotoDefinitionProvider, 0),
-		referencesProviders:  make([]ReferencesProvider, 0),
-		codeLensProviders:    make([]CodeLensProvider, 0),
-		diagnosticsProviders: make([]DiagnosticsProvider, 0),
-		codeActionProviders:  make([]CodeActionProvider, 0),
-		hoverProviders:       make([]HoverProvider, 0),
-		commandProviders:     make([]CommandProvider, 0),
-		indexers:             make(map[string]indexer.Indexer),
-		commandMap:           make(map[string]CommandFunc),
-		documentManager:      NewDocumentManager(),
-		fileScanner:          filescanner,
-		cacheDir:             cacheDir,
-		version:              version,
+		completionProviders:     make([]CompletionProvider, 0),
+		definitionProviders:     make([]GotoDefinitionProvider, 0),
+		typeDefinitionProviders: make([]TypeDefinitionProvider, 0),
+		referencesProviders:     make([]ReferencesProvider, 0),
+		codeLensProviders:       make([]CodeLensProvider, 0),
+		diagnosticsProviders:    make([]DiagnosticsProvider, 0),
+		codeActionProviders:     make([]CodeActionProvider, 0),
+		hoverProviders:          make([]HoverProvider, 0),
+		highlightProviders:      make([]DocumentHighlightProvider, 0),
+		inlayHintProviders:      make([]InlayHintProvider, 0),
+		signatureHelpProviders:  make([]SignatureHelpProvider, 0),
+		renameProviders:         make([]RenameProvider, 0),
+		commandProviders:        make([]CommandProvider, 0),
+		indexers:                make(map[string]indexer.Indexer),
+		commandMap:              make(map[string]CommandFunc),
+		requests:                make(map[jsonrpc2.ID]context.CancelFunc),
+		documentManager:         NewDocumentManager(),
+		fileWatcherEnabled:      true,
+		fileScanner:             filescanner,
+		cacheDir:                cacheDir,
+		version:                 version,
 	}
 
 	// Set the update callback to publish diagnostics
@@ -76,6 +100,11 @@ func (s *Server) RegisterDefinitionProvider(provider GotoDefinitionProvider) {
 	s.definitionProviders = append(s.definitionProviders, provider)
 }
 
+// RegisterTypeDefinitionProvider registers a type definition provider with the server
+func (s *Server) RegisterTypeDefinitionProvider(provider TypeDefinitionProvider) {
+	s.typeDefinitionProviders = append(s.typeDefinitionProviders, provider)
+}
+
 // RegisterReferencesProvider registers a references provider with the server
 func (s *Server) RegisterReferencesProvider(provider ReferencesProvider) {
 	s.referencesProviders = append(s.referencesProviders, provider)
@@ -96,6 +125,26 @@ func (s *Server) RegisterHoverProvider(provider HoverProvider) {
 	s.hoverProviders = append(s.hoverProviders, provider)
 }
 
+// RegisterDocumentHighlightProvider registers a document highlight provider with the server
+func (s *Server) RegisterDocumentHighlightProvider(provider DocumentHighlightProvider) {
+	s.highlightProviders = append(s.highlightProviders, provider)
+}
+
+// RegisterInlayHintProvider registers an inlay hint provider with the server
+func (s *Server) RegisterInlayHintProvider(provider InlayHintProvider) {
+	s.inlayHintProviders = append(s.inlayHintProviders, provider)
+}
+
+// RegisterRenameProvider registers a rename provider with the server
+func (s *Server) RegisterRenameProvider(provider RenameProvider) {
+	s.renameProviders = append(s.renameProviders, provider)
+}
+
+// RegisterSignatureHelpProvider registers a signature help provider with the server
+func (s *Server) RegisterSignatureHelpProvider(provider SignatureHelpProvider) {
+	s.signatureHelpProviders = append(s.signatureHelpProviders, provider)
+}
+
 // RegisterCommandProvider registers a command provider with the server
 func (s *Server) RegisterCommandProvider(provider CommandProvider) {
 	s.commandProviders = append(s.commandProviders, provider)
@@ -164,18 +213,32 @@ func (s *Server) indexAll(ctx context.Context, forceReindex bool) error {
 		}
 	}
 
+	progress := s.startWorkDoneProgress(ctx, newIndexingProgressToken(), "Shopware: Indexing")
+
 	if forceReindex {
 		if err := s.fileScanner.ClearHashes(); err != nil {
+			progress.end(ctx, "Indexing failed")
 			return err
 		}
 	}
 
-	if err := s.fileScanner.IndexAll(ctx); err != nil {
+	err := s.fileScanner.IndexAll(ctx, func(processed, total int) {
+		progress.report(ctx, processed, total)
+	})
+	if err != nil {
+		progress.end(ctx, "Indexing failed")
 		return err
 	}
 
 	elapsedTime := time.Since(startTime)
 
+	s.indexStatsMu.Lock()
+	s.lastIndexDuration = elapsedTime
+	s.lastIndexedAt = time.Now()
+	s.indexStatsMu.Unlock()
+
+	progress.end(ctx, fmt.Sprintf("Indexing completed in %.2fs", elapsedTime.Seconds()))
+
 	// Send notification that indexing has completed
 	if s.conn != nil {
 		if err := s.conn.Notify(ctx, "shopware/indexingCompleted", map[string]interface{}{
@@ -189,8 +252,25 @@ func (s *Server) indexAll(ctx context.Context, forceReindex bool) error {
 	return nil
 }
 
+// startFileWatcher starts the server side file watcher unless it was disabled by the client
+func (s *Server) startFileWatcher() {
+	if !s.fileWatcherEnabled {
+		log.Println("File watcher disabled, relying on client file events")
+		return
+	}
+
+	if err := s.fileScanner.StartWatcher(); err != nil {
+		log.Printf("Error starting file watcher: %v", err)
+	} else {
+		log.Println("File watcher started successfully")
+	}
+}
+
 // CloseAll closes all registered indexers and resources
 func (s *Server) CloseAll() error {
+	// Stop watching before the indexers are closed
+	s.fileScanner.StopWatcher()
+
 	// Close document manager first
 	if s.documentManager != nil {
 		s.documentManager.Close()
@@ -209,16 +289,14 @@ func (s *Server) CloseAll() error {
 }
 
 func (s *Server) Start(in io.Reader, out io.Writer) error {
-	// Register commands
-	for _, provider := range s.commandProviders {
-		for command, fn := range provider.GetCommands(context.Background()) {
-			s.commandMap[command] = fn
-		}
-	}
+	s.registerCommands()
 
 	// Create a new JSON-RPC connection
 	stream := jsonrpc2.NewBufferedStream(rwc{in, out}, jsonrpc2.VSCodeObjectCodec{})
-	conn := jsonrpc2.NewConn(context.Background(), stream, jsonrpc2.HandlerWithError(s.handle))
+	conn := jsonrpc2.NewConn(context.Background(), stream, &requestHandler{
+		server:  s,
+		handler: jsonrpc2.HandlerWithError(s.handleCancellable),
+	})
 	s.conn = conn
 
 	// Wait for the connection to close
@@ -238,6 +316,101 @@ func (rwc) Close() error {
 	return nil
 }
 
+// codeRequestCancelled is the LSP error code for requests cancelled by the client
+const codeRequestCancelled int64 = -32800
+
+// cancellableMethods are the requests which run concurrently to the message loop
+// and can be cancelled by the client with $/cancelRequest
+var cancellableMethods = map[string]bool{
+	"textDocument/completion":        true,
+	"textDocument/definition":        true,
+	"textDocument/typeDefinition":    true,
+	"textDocument/references":        true,
+	"textDocument/codeLens":          true,
+	"textDocument/hover":             true,
+	"textDocument/diagnostic":        true,
+	"textDocument/codeAction":        true,
+	"codeLens/resolve":               true,
+	"textDocument/documentHighlight": true,
+	"textDocument/inlayHint":         true,
+	"textDocument/signatureHelp":     true,
+	"textDocument/prepareRename":     true,
+	"textDocument/rename":            true,
+	"codeAction/resolve":             true,
+}
+
+// clientFileEvents are the notifications the client sends for changed files
+var clientFileEvents = map[string]bool{
+	"workspace/didCreateFiles":        true,
+	"workspace/didRenameFiles":        true,
+	"workspace/didDeleteFiles":        true,
+	"workspace/didChangeWatchedFiles": true,
+}
+
+// requestHandler dispatches incoming messages. Cancellable requests are handled in
+// their own goroutine with a context registered under the request ID, everything
+// else is handled in order on the read loop of the connection.
+type requestHandler struct {
+	server  *Server
+	handler jsonrpc2.Handler
+}
+
+// Handle implements jsonrpc2.Handler
+func (h *requestHandler) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	if req.Notif || !cancellableMethods[req.Method] {
+		h.handler.Handle(ctx, conn, req)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	h.server.trackRequest(req.ID, cancel)
+
+	go func() {
+		defer h.server.finishRequest(req.ID)
+		h.handler.Handle(ctx, conn, req)
+	}()
+}
+
+// trackRequest registers the cancel function of an in-flight request
+func (s *Server) trackRequest(id jsonrpc2.ID, cancel context.CancelFunc) {
+	s.requestsMu.Lock()
+	defer s.requestsMu.Unlock()
+	s.requests[id] = cancel
+}
+
+// finishRequest releases the context of a completed request
+func (s *Server) finishRequest(id jsonrpc2.ID) {
+	s.requestsMu.Lock()
+	cancel, ok := s.requests[id]
+	delete(s.requests, id)
+	s.requestsMu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// cancelRequest cancels the context of an in-flight request, unknown IDs are ignored
+func (s *Server) cancelRequest(id jsonrpc2.ID) {
+	s.requestsMu.Lock()
+	cancel, ok := s.requests[id]
+	s.requestsMu.Unlock()
+
+	if ok {
+		cancel()
+	}
+}
+
+// handleCancellable wraps handle and replies with a RequestCancelled error
+// when the request was cancelled while it was processed
+func (s *Server) handleCancellable(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (interface{}, error) {
+	result, err := s.handle(ctx, conn, req)
+	if !req.Notif && ctx.Err() != nil {
+		return nil, &jsonrpc2.Error{Code: codeRequestCancelled, Message: "Request cancelled"}
+	}
+	return result, err
+}
+
 // handle processes incoming JSON-RPC requests and notifications
 func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (interface{}, error) {
 	// Handle exit notification after shutdown
@@ -253,6 +426,11 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		return cmd(ctx, req.Params)
 	}
 
+	// File events of the client are redundant while the server side watcher is running
+	if clientFileEvents[req.Method] && s.fileScanner.IsWatching() {
+		return nil, nil
+	}
+
 	switch req.Method {
 	case "initialize":
 		var params protocol.InitializeParams
@@ -262,6 +440,9 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		return s.initialize(ctx, &params), nil
 
 	case "initialized":
+		go s.fetchSettings(ctx)
+		go s.startFileWatcher()
+
 		// Build the index when the client is initialized
 		go func() {
 			// Check if we need to force reindex due to version change
@@ -306,21 +487,45 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 				URI     string `json:"uri"`
 				Version int    `json:"version"`
 			} `json:"textDocument"`
-			ContentChanges []struct {
-				Text string `json:"text"`
-			} `json:"contentChanges"`
+			ContentChanges []protocol.TextDocumentContentChangeEvent `json:"contentChanges"`
 		}
 		if err := json.Unmarshal(*req.Params, &params); err != nil {
 			return nil, err
 		}
 		if len(params.ContentChanges) > 0 {
-			s.documentManager.UpdateDocument(params.TextDocument.URI, params.ContentChanges[0].Text, params.TextDocument.Version)
+			s.documentManager.ApplyChanges(params.TextDocument.URI, params.ContentChanges, params.TextDocument.Version)
 
-			// Run diagnostics on the updated document
-			go s.publishDiagnostics(ctx, params.TextDocument.URI, params.TextDocument.Version)
+			// Run diagnostics on the updated document, unless they are only wanted on save
+			if !s.GetSettings().Diagnostics.OnSave {
+				go s.publishDiagnostics(ctx, params.TextDocument.URI, params.TextDocument.Version)
+			}
 		}
 		return nil, nil
 
+	case "textDocument/didSave":
+		var params struct {
+			TextDocument struct {
+				URI string `json:"uri"`
+			} `json:"textDocument"`
+		}
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+
+		// The server side watcher reindexes the saved file already, indexing it here as well would run twice
+		if s.fileScanner.IsWatching() {
+			return nil, nil
+		}
+
+		// Reindex the saved file right away, clients without watched file events would serve stale results until
+		// the next full index. Indexing publishes the diagnostics of all open documents afterwards.
+		go func() {
+			if err := s.fileScanner.IndexFiles(ctx, []string{strings.TrimPrefix(params.TextDocument.URI, "file://")}); err != nil {
+				log.Printf("Error indexing saved file: %v", err)
+			}
+		}()
+		return nil, nil
+
 	case "textDocument/didClose":
 		var params struct {
 			TextDocument struct {
@@ -333,6 +538,36 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		s.documentManager.CloseDocument(params.TextDocument.URI)
 		return nil, nil
 
+	case "workspace/didChangeConfiguration":
+		var params struct {
+			Settings map[string]json.RawMessage `json:"settings"`
+		}
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+
+		go func() {
+			// Clients supporting workspace/configuration may only notify about the change
+			if s.supportsConfiguration {
+				s.fetchSettings(ctx)
+			} else if err := s.setSettings(params.Settings[settingsSection]); err != nil {
+				log.Printf("Error parsing workspace configuration: %v", err)
+			}
+
+			s.PublishDiagnostics(ctx, nil)
+		}()
+		return nil, nil
+
+	case "$/cancelRequest":
+		var params struct {
+			ID jsonrpc2.ID `json:"id"`
+		}
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		s.cancelRequest(params.ID)
+		return nil, nil
+
 	case "textDocument/completion":
 		var params protocol.CompletionParams
 		if err := json.Unmarshal(*req.Params, &params); err != nil {
@@ -348,6 +583,13 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		}
 		return s.definition(ctx, &params), nil
 
+	case "textDocument/typeDefinition":
+		var params protocol.DefinitionParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.typeDefinition(ctx, &params), nil
+
 	case "textDocument/references":
 		var params protocol.ReferenceParams
 		if err := json.Unmarshal(*req.Params, &params); err != nil {
@@ -369,6 +611,41 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		}
 		return s.hover(ctx, &params)
 
+	case "textDocument/documentHighlight":
+		var params protocol.DocumentHighlightParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.documentHighlight(ctx, &params), nil
+
+	case "textDocument/prepareRename":
+		var params protocol.PrepareRenameParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.prepareRename(ctx, &params), nil
+
+	case "textDocument/rename":
+		var params protocol.RenameParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.rename(ctx, &params)
+
+	case "textDocument/inlayHint":
+		var params protocol.InlayHintParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.inlayHint(ctx, &params), nil
+
+	case "textDocument/signatureHelp":
+		var params protocol.SignatureHelpParams
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.signatureHelp(ctx, &params), nil
+
 	case "textDocument/diagnostic":
 		var params protocol.DiagnosticParams
 		if err := json.Unmarshal(*req.Params, &params); err != nil {
@@ -390,16 +667,19 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.
 		}
 		return s.codeAction(ctx, &params), nil
 
-	case "shopware/forceReindex":
-		// Force reindex all indexers
-		go func() {
-			if err := s.indexAll(ctx, true); err != nil {
-				log.Printf("Error force reindexing: %v", err)
-			}
-		}()
-		return map[string]interface{}{
-			"message": "Force reindexing started",
-		}, nil
+	case "codeAction/resolve":
+		var codeAction protocol.CodeAction
+		if err := json.Unmarshal(*req.Params, &codeAction); err != nil {
+			return nil, err
+		}
+		return s.resolveCodeAction(ctx, &codeAction)
+
+	case "workspace/executeCommand":
+		var params protocol.CommandRequest
+		if err := json.Unmarshal(*req.Params, &params); err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+		}
+		return s.executeCommand(ctx, &params)
 
 	case "shutdown":
 		// Clean up resources
@@ -524,12 +804,10 @@ func (s *Server) initialize(ctx context.Context, params *protocol.InitializePara
 	// Extract root path from params
 	s.extractRootPath(params)
 
-	// Start the file watcher
-	if err := s.fileScanner.StartWatcher(); err != nil {
-		log.Printf("Error starting file watcher: %v", err)
-	} else {
-		log.Println("File watcher started successfully")
-	}
+	s.supportsConfiguration = params.Capabilities.Workspace.Configuration
+	s.supportsWorkDoneProgress = params.Capabilities.Window.WorkDoneProgress
+	s.supportsCodeActionEdit = slices.Contains(params.Capabilities.TextDocument.CodeAction.ResolveSupport.Properties, "edit")
+	s.applyInitializationOptions(params.InitializationOptions)
 
 	// Collect all trigger characters from providers
 	triggerChars := s.collectTriggerCharacters()
@@ -542,7 +820,8 @@ func (s *Server) initialize(ctx context.Context, params *protocol.InitializePara
 		"capabilities": map[string]interface{}{
 			"textDocumentSync": map[string]interface{}{
 				"openClose": true,
-				"change":    1, // Full sync
+				"change":    protocol.TextDocumentSyncIncremental,
+				"save":      true,
 			},
 			"diagnosticProvider": map[string]interface{}{
 				"interFileDependencies": true,
@@ -551,14 +830,28 @@ func (s *Server) initialize(ctx context.Context, params *protocol.InitializePara
 			"completionProvider": map[string]interface{}{
 				"triggerCharacters": triggerChars,
 			},
-			"definitionProvider": true,
-			"referencesProvider": true,
-			"hoverProvider":      true,
+			"definitionProvider":        true,
+			"typeDefinitionProvider":    true,
+			"referencesProvider":        true,
+			"hoverProvider":             true,
+			"documentHighlightProvider": true,
+			"inlayHintProvider":         true,
+			"executeCommandProvider": map[string]interface{}{
+				"commands": s.commandNames(),
+			},
+			"renameProvider": map[string]interface{}{
+				"prepareProvider": true,
+			},
+			"signatureHelpProvider": map[string]interface{}{
+				"triggerCharacters":   []string{"("},
+				"retriggerCharacters": []string{","},
+			},
 			"codeLensProvider": map[string]interface{}{
 				"resolveProvider": true,
 			},
 			"codeActionProvider": map[string]interface{}{
 				"codeActionKinds": codeActionKinds,
+				"resolveProvider": true,
 			},
 			"workspace": map[string]interface{}{
 				"fileOperations": map[string]interface{}{
@@ -586,6 +879,68 @@ func (s *Server) initialize(ctx context.Context, params *protocol.InitializePara
 	}
 }
 
+// indexStats collects the number of entries of all indexers implementing indexer.Counter
+// and the duration of the last full indexing run
+func (s *Server) indexStats() (map[string]interface{}, error) {
+	s.indexerMu.RLock()
+	counts := make(map[string]int, len(s.indexers))
+	for id, idx := range s.indexers {
+		count, ok, err := indexer.Count(idx)
+		if err != nil {
+			s.indexerMu.RUnlock()
+			return nil, fmt.Errorf("failed to count %s: %w", id, err)
+		}
+		if ok {
+			counts[id] = count
+		}
+	}
+	s.indexerMu.RUnlock()
+
+	s.indexStatsMu.RLock()
+	defer s.indexStatsMu.RUnlock()
+
+	stats := map[string]interface{}{
+		"indexers":                  counts,
+		"lastIndexingTimeInSeconds": s.lastIndexDuration.Seconds(),
+		"cache":                     indexer.TotalCacheStats(),
+	}
+	if !s.lastIndexedAt.IsZero() {
+		stats["lastIndexedAt"] = s.lastIndexedAt.Format(time.RFC3339)
+	}
+
+	return stats, nil
+}
+
+// resolveReindexPath validates the directory passed to shopware/reindexPath.
+// Relative paths and file URIs are resolved against the project root, the directory must exist inside the project.
+func (s *Server) resolveReindexPath(path string) (string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "file://")
+	if path == "" {
+		return "", fmt.Errorf("missing parameter: path")
+	}
+
+	projectRoot := s.fileScanner.ProjectRoot()
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(projectRoot, path)
+	}
+	path = filepath.Clean(path)
+
+	relPath, err := filepath.Rel(projectRoot, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(os.PathSeparator)) {
+		return "", fmt.Errorf("path %s is outside of the project root", path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("path %s does not exist", path)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("path %s is not a directory", path)
+	}
+
+	return path, nil
+}
+
 // extractRootPath extracts the root path from the initialize params
 func (s *Server) extractRootPath(params *protocol.InitializeParams) {
 	// Try to get from RootPath
@@ -674,11 +1029,12 @@ func (s *Server) PublishDiagnostics(ctx context.Context, files []string) {
 	var docs []docAnalyse
 
 	if files == nil {
-		for _, doc := range s.DocumentManager().documents {
+		for _, doc := range s.DocumentManager().GetDocuments() {
 			docs = append(docs, docAnalyse{
 				uri:     doc.URI,
 				version: doc.Version,
 			})
+			doc.Release()
 		}
 	} else {
 		for _, uri := range files {
@@ -686,6 +1042,7 @@ func (s *Server) PublishDiagnostics(ctx context.Context, files []string) {
 
 			if doc, ok := s.DocumentManager().GetDocument(uri); ok {
 				version = doc.Version
+				doc.Release()
 			}
 
 			docs = append(docs, docAnalyse{
@@ -706,22 +1063,31 @@ func (s *Server) publishDiagnostics(ctx context.Context, uri string, version int
 		return
 	}
 
-	// Get document content
-	content, ok := s.documentManager.GetDocumentText(uri)
+	doc, ok := s.documentManager.GetDocument(uri)
 	if !ok {
 		return
 	}
+	defer doc.Release()
+
+	if doc.Tree == nil {
+		return
+	}
+
+	content := doc.Text
+	node := doc.Tree.RootNode()
 
 	// Collect diagnostics from all providers
 	allDiagnostics := []protocol.Diagnostic{}
 
-	node := s.documentManager.GetRootNode(uri)
+	for _, provider := range s.diagnosticsProviders {
+		if ctx.Err() != nil {
+			break
+		}
 
-	if node == nil {
-		return
-	}
+		if !s.isProviderEnabled(provider) {
+			continue
+		}
 
-	for _, provider := range s.diagnosticsProviders {
 		diagnostics, err := provider.GetDiagnostics(ctx, uri, node, content)
 		if err != nil {
 			log.Printf("Error getting diagnostics from provider %s: %v", provider, err)
@@ -747,26 +1113,35 @@ func (s *Server) publishDiagnostics(ctx context.Context, uri string, version int
 func (s *Server) diagnostic(ctx context.Context, params *protocol.DiagnosticParams) interface{} {
 	uri := params.TextDocument.URI
 
-	// Get document content
-	content, ok := s.documentManager.GetDocumentText(uri)
+	doc, ok := s.documentManager.GetDocument(uri)
 	if !ok {
 		return protocol.DiagnosticResult{
 			Items: []protocol.Diagnostic{},
 		}
 	}
+	defer doc.Release()
 
-	// Collect diagnostics from all providers
-	allDiagnostics := []protocol.Diagnostic{}
-
-	node := s.documentManager.GetRootNode(uri)
-
-	if node == nil {
+	if doc.Tree == nil {
 		return protocol.DiagnosticResult{
 			Items: []protocol.Diagnostic{},
 		}
 	}
 
+	content := doc.Text
+	node := doc.Tree.RootNode()
+
+	// Collect diagnostics from all providers
+	allDiagnostics := []protocol.Diagnostic{}
+
 	for _, provider := range s.diagnosticsProviders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !s.isProviderEnabled(provider) {
+			continue
+		}
+
 		diagnostics, err := provider.GetDiagnostics(ctx, uri, node, content)
 		if err != nil {
 			log.Printf("Error getting diagnostics from provider %s: %v", provider, err)
@@ -784,6 +1159,7 @@ func (s *Server) diagnostic(ctx context.Context, params *protocol.DiagnosticPara
 // codeAction handles textDocument/codeAction requests
 func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionParams) []protocol.CodeAction {
 	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Range.Start.Line, params.Range.Start.Character)
+	defer docText.Release()
 	if ok {
 		params.Node = node
 		params.DocumentContent = docText.Text
@@ -798,3 +1174,30 @@ func (s *Server) codeAction(ctx context.Context, params *protocol.CodeActionPara
 
 	return allCodeActions
 }
+
+// resolveCodeAction handles codeAction/resolve requests
+func (s *Server) resolveCodeAction(ctx context.Context, codeAction *protocol.CodeAction) (*protocol.CodeAction, error) {
+	for _, provider := range s.codeActionProviders {
+		resolver, ok := provider.(CodeActionResolver)
+		if !ok {
+			continue
+		}
+
+		resolved, err := resolver.ResolveCodeAction(ctx, codeAction)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != nil {
+			return resolved, nil
+		}
+	}
+
+	// If no provider could resolve it, return the original
+	return codeAction, nil
+}
+
+// SupportsCodeActionResolve reports whether the client resolves the edit of code actions lazily,
+// only then providers may return code actions without an edit
+func (s *Server) SupportsCodeActionResolve() bool {
+	return s.supportsCodeActionEdit
+}
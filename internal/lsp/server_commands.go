@@ -0,0 +1,107 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// registerCommands collects the commands of the server and of the command providers. They can be
+// called as JSON-RPC methods or with workspace/executeCommand and are advertised on initialize.
+func (s *Server) registerCommands() {
+	for command, fn := range s.serverCommands() {
+		s.commandMap[command] = fn
+	}
+
+	for _, provider := range s.commandProviders {
+		for command, fn := range provider.GetCommands(context.Background()) {
+			s.commandMap[command] = fn
+		}
+	}
+}
+
+// serverCommands are the commands managing the index
+func (s *Server) serverCommands() map[string]CommandFunc {
+	return map[string]CommandFunc{
+		"shopware/forceReindex": s.forceReindex,
+		"shopware/indexStats":   s.indexStatsCommand,
+		"shopware/reindexPath":  s.reindexPath,
+		"shopware/reindexType":  s.reindexType,
+	}
+}
+
+func (s *Server) forceReindex(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	// Force reindex all indexers
+	go func() {
+		if err := s.indexAll(ctx, true); err != nil {
+			log.Printf("Error force reindexing: %v", err)
+		}
+	}()
+	return map[string]interface{}{
+		"message": "Force reindexing started",
+	}, nil
+}
+
+func (s *Server) indexStatsCommand(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	return s.indexStats()
+}
+
+func (s *Server) reindexPath(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	var params struct {
+		Path string `json:"path"`
+	}
+	if args == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "Missing parameter: path"}
+	}
+	if err := json.Unmarshal(*args, &params); err != nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	}
+
+	dir, err := s.resolveReindexPath(params.Path)
+	if err != nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	}
+
+	go func() {
+		count, err := s.fileScanner.ReindexDirectory(ctx, dir)
+		if err != nil {
+			log.Printf("Error reindexing %s: %v", dir, err)
+			return
+		}
+		log.Printf("Reindexed %d files in %s", count, dir)
+	}()
+	return map[string]interface{}{
+		"message": "Reindexing " + dir + " started",
+	}, nil
+}
+
+func (s *Server) reindexType(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	var params struct {
+		Indexer string `json:"indexer"`
+	}
+	if args == nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "Missing parameter: indexer"}
+	}
+	if err := json.Unmarshal(*args, &params); err != nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: err.Error()}
+	}
+
+	idx, ok := s.GetIndexer(params.Indexer)
+	if !ok {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInvalidParams, Message: "Unknown indexer: " + params.Indexer}
+	}
+
+	go func() {
+		count, err := s.fileScanner.ReindexIndexer(ctx, idx)
+		if err != nil {
+			log.Printf("Error reindexing %s: %v", idx.ID(), err)
+			return
+		}
+		log.Printf("Reindexed %d files with %s", count, idx.ID())
+	}()
+	return map[string]interface{}{
+		"message": "Reindexing " + idx.ID() + " started",
+	}, nil
+}
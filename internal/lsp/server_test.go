@@ -0,0 +1,421 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestServer_CancelRequest(t *testing.T) {
+	s := &Server{
+		requests:   make(map[jsonrpc2.ID]context.CancelFunc),
+		commandMap: make(map[string]CommandFunc),
+	}
+
+	id := jsonrpc2.ID{Num: 42}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.trackRequest(id, cancel)
+
+	// Unknown IDs are ignored
+	s.cancelRequest(jsonrpc2.ID{Num: 7})
+	assert.NoError(t, ctx.Err())
+
+	s.cancelRequest(id)
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	// A cancelled request replies with RequestCancelled
+	_, err := s.handleCancellable(ctx, nil, &jsonrpc2.Request{Method: "textDocument/unknown", ID: id})
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, codeRequestCancelled, rpcErr.Code)
+
+	s.finishRequest(id)
+	assert.Empty(t, s.requests)
+}
+
+func TestServer_ResolveReindexPath(t *testing.T) {
+	projectRoot := t.TempDir()
+	pluginDir := filepath.Join(projectRoot, "custom", "plugins", "MyPlugin")
+	require.NoError(t, os.MkdirAll(pluginDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "composer.json"), []byte("{}"), 0644))
+
+	scanner, err := indexer.NewFileScanner(projectRoot, filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	s := &Server{fileScanner: scanner}
+
+	path, err := s.resolveReindexPath("custom/plugins/MyPlugin")
+	require.NoError(t, err)
+	assert.Equal(t, pluginDir, path)
+
+	path, err = s.resolveReindexPath("file://" + pluginDir + "/")
+	require.NoError(t, err)
+	assert.Equal(t, pluginDir, path)
+
+	_, err = s.resolveReindexPath("")
+	assert.Error(t, err)
+
+	_, err = s.resolveReindexPath("../outside")
+	assert.ErrorContains(t, err, "outside of the project root")
+
+	_, err = s.resolveReindexPath("custom/plugins/Missing")
+	assert.ErrorContains(t, err, "does not exist")
+
+	_, err = s.resolveReindexPath("custom/plugins/MyPlugin/composer.json")
+	assert.ErrorContains(t, err, "not a directory")
+}
+
+func TestServer_FileWatcherOption(t *testing.T) {
+	scanner, err := indexer.NewFileScanner(t.TempDir(), filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	s := &Server{fileScanner: scanner, fileWatcherEnabled: true}
+
+	s.applyInitializationOptions([]byte(`{"fileWatcher": false}`))
+	s.startFileWatcher()
+	assert.False(t, scanner.IsWatching())
+
+	s.applyInitializationOptions([]byte(`{"fileWatcher": true}`))
+	s.startFileWatcher()
+	assert.True(t, scanner.IsWatching())
+
+	// Client file events are ignored while the server watches itself
+	params := json.RawMessage(`{"changes": [{"uri": "file:///does/not/exist.php", "type": 1}]}`)
+	result, err := s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "workspace/didChangeWatchedFiles", Params: &params, Notif: true})
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	require.NoError(t, s.CloseAll())
+	assert.False(t, scanner.IsWatching())
+}
+
+type testIndexer struct {
+	id    string
+	count int
+}
+
+func (i *testIndexer) ID() string { return i.id }
+
+func (i *testIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
+	return nil
+}
+
+func (i *testIndexer) RemovedFiles(paths []string) error { return nil }
+
+func (i *testIndexer) Close() error { return nil }
+
+func (i *testIndexer) Clear() error { return nil }
+
+type testCountingIndexer struct {
+	testIndexer
+}
+
+func (i *testCountingIndexer) Count() (int, error) { return i.count, nil }
+
+func TestServer_IndexStats(t *testing.T) {
+	s := &Server{indexers: map[string]indexer.Indexer{
+		"counting": &testCountingIndexer{testIndexer{id: "counting", count: 3}},
+		"plain":    &testIndexer{id: "plain"},
+	}}
+
+	stats, err := s.indexStats()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"counting": 3}, stats["indexers"])
+	assert.Equal(t, float64(0), stats["lastIndexingTimeInSeconds"])
+	assert.NotContains(t, stats, "lastIndexedAt")
+	assert.IsType(t, indexer.CacheStats{}, stats["cache"])
+
+	s.lastIndexDuration = 1500 * time.Millisecond
+	s.lastIndexedAt = time.Now()
+
+	stats, err = s.indexStats()
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, stats["lastIndexingTimeInSeconds"])
+	assert.Contains(t, stats, "lastIndexedAt")
+}
+
+type testCodeActionProvider struct{}
+
+func (p *testCodeActionProvider) GetCodeActions(ctx context.Context, params *protocol.CodeActionParams) []protocol.CodeAction {
+	return []protocol.CodeAction{{Title: "Lazy", Data: map[string]any{"id": "lazy"}}}
+}
+
+func (p *testCodeActionProvider) GetCodeActionKinds() []protocol.CodeActionKind {
+	return []protocol.CodeActionKind{protocol.CodeActionQuickFix}
+}
+
+func (p *testCodeActionProvider) ResolveCodeAction(ctx context.Context, codeAction *protocol.CodeAction) (*protocol.CodeAction, error) {
+	if data, _ := codeAction.Data.(map[string]any); data["id"] != "lazy" {
+		return nil, nil
+	}
+
+	resolved := *codeAction
+	resolved.Edit = &protocol.WorkspaceEdit{Changes: map[string][]protocol.TextEdit{"file:///foo.php": {{NewText: "resolved"}}}}
+
+	return &resolved, nil
+}
+
+func TestServer_ResolveCodeAction(t *testing.T) {
+	scanner, err := indexer.NewFileScanner(t.TempDir(), filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	s := NewServer(scanner, t.TempDir(), "test")
+	s.RegisterCodeActionProvider(&testCodeActionProvider{})
+
+	initParams := json.RawMessage(`{"capabilities": {"textDocument": {"codeAction": {"resolveSupport": {"properties": ["edit"]}}}}}`)
+	result, err := s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "initialize", Params: &initParams})
+	require.NoError(t, err)
+	assert.True(t, s.SupportsCodeActionResolve())
+
+	capabilities := result.(map[string]interface{})["capabilities"].(map[string]interface{})
+	assert.Equal(t, true, capabilities["codeActionProvider"].(map[string]interface{})["resolveProvider"])
+
+	params := json.RawMessage(`{"title": "Lazy", "data": {"id": "lazy"}}`)
+	result, err = s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "codeAction/resolve", Params: &params})
+	require.NoError(t, err)
+
+	resolved := result.(*protocol.CodeAction)
+	require.NotNil(t, resolved.Edit)
+	assert.Equal(t, "resolved", resolved.Edit.Changes["file:///foo.php"][0].NewText)
+
+	// Actions no provider knows are returned unchanged
+	params = json.RawMessage(`{"title": "Other"}`)
+	result, err = s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "codeAction/resolve", Params: &params})
+	require.NoError(t, err)
+	assert.Nil(t, result.(*protocol.CodeAction).Edit)
+}
+
+type testRecordingIndexer struct {
+	testIndexer
+	indexed chan string
+}
+
+func (i *testRecordingIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
+	i.indexed <- path
+	return nil
+}
+
+func TestServer_DidSaveIndexesFile(t *testing.T) {
+	projectRoot := t.TempDir()
+	scanner, err := indexer.NewFileScanner(projectRoot, filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	s := NewServer(scanner, t.TempDir(), "test")
+	recorder := &testRecordingIndexer{testIndexer: testIndexer{id: "recording"}, indexed: make(chan string, 1)}
+	s.RegisterIndexer(recorder, nil)
+
+	initParams := json.RawMessage(`{"capabilities": {}}`)
+	result, err := s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "initialize", Params: &initParams})
+	require.NoError(t, err)
+
+	capabilities := result.(map[string]interface{})["capabilities"].(map[string]interface{})
+	assert.Equal(t, true, capabilities["textDocumentSync"].(map[string]interface{})["save"])
+
+	path := filepath.Join(projectRoot, "Foo.php")
+	require.NoError(t, os.WriteFile(path, []byte("<?php class Foo {}"), 0644))
+
+	params := json.RawMessage(`{"textDocument": {"uri": "file://` + path + `"}}`)
+	result, err = s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "textDocument/didSave", Params: &params, Notif: true})
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	select {
+	case indexed := <-recorder.indexed:
+		assert.Equal(t, path, indexed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("saved file was not indexed")
+	}
+}
+
+func TestServer_DidSaveSkippedWhileWatching(t *testing.T) {
+	projectRoot := t.TempDir()
+	scanner, err := indexer.NewFileScanner(projectRoot, filepath.Join(t.TempDir(), "scanner.db"))
+	require.NoError(t, err)
+	defer func() { _ = scanner.Close() }()
+
+	s := NewServer(scanner, t.TempDir(), "test")
+	recorder := &testRecordingIndexer{testIndexer: testIndexer{id: "recording"}, indexed: make(chan string, 1)}
+	s.RegisterIndexer(recorder, nil)
+
+	path := filepath.Join(projectRoot, "Foo.php")
+	require.NoError(t, os.WriteFile(path, []byte("<?php class Foo {}"), 0644))
+
+	// The file was written before the watcher started, so only didSave could index it
+	require.NoError(t, scanner.StartWatcher())
+
+	params := json.RawMessage(`{"textDocument": {"uri": "file://` + path + `"}}`)
+	_, err = s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "textDocument/didSave", Params: &params, Notif: true})
+	require.NoError(t, err)
+
+	select {
+	case indexed := <-recorder.indexed:
+		t.Fatalf("saved file %s was indexed while the watcher is running", indexed)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+type testInlayHintProvider struct{}
+
+func (p *testInlayHintProvider) GetInlayHints(ctx context.Context, params *protocol.InlayHintParams) []protocol.InlayHint {
+	return []protocol.InlayHint{
+		{Position: protocol.Position{Line: 0, Character: 3}, Label: "before"},
+		{Position: protocol.Position{Line: 1, Character: 0}, Label: "start"},
+		{Position: protocol.Position{Line: 2, Character: 4}, Label: "end"},
+		{Position: protocol.Position{Line: 2, Character: 5}, Label: "after"},
+	}
+}
+
+func TestServer_InlayHintRange(t *testing.T) {
+	s := &Server{documentManager: NewDocumentManager()}
+	defer s.documentManager.Close()
+
+	s.RegisterInlayHintProvider(&testInlayHintProvider{})
+	s.documentManager.OpenDocument("file:///project/index.html.twig", "{{ a }}\n{{ b }}\n{{ c }}", 1)
+
+	params := &protocol.InlayHintParams{Range: protocol.Range{
+		Start: protocol.Position{Line: 1},
+		End:   protocol.Position{Line: 2, Character: 4},
+	}}
+	params.TextDocument.URI = "file:///project/index.html.twig"
+
+	var labels []string
+	for _, hint := range s.inlayHint(context.Background(), params) {
+		labels = append(labels, hint.Label)
+	}
+	assert.Equal(t, []string{"start", "end"}, labels)
+
+	params.TextDocument.URI = "file:///project/closed.html.twig"
+	assert.Empty(t, s.inlayHint(context.Background(), params))
+}
+
+func TestServer_ExecuteCommand(t *testing.T) {
+	var received []string
+	s := &Server{
+		commandMap: map[string]CommandFunc{
+			"shopware/test": func(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+				if args == nil {
+					received = append(received, "")
+				} else {
+					received = append(received, string(*args))
+				}
+				return "done", nil
+			},
+			"shopware/other": func(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	}
+
+	execute := func(params string) (interface{}, error) {
+		raw := json.RawMessage(params)
+		return s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "workspace/executeCommand", Params: &raw})
+	}
+
+	result, err := execute(`{"command": "shopware/test", "arguments": [{"textUri": "file:///a.twig"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, "done", result)
+
+	_, err = execute(`{"command": "shopware/test"}`)
+	require.NoError(t, err)
+
+	_, err = execute(`{"command": "shopware/test", "arguments": ["a", 1]}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"textUri": "file:///a.twig"}`, "", `["a",1]`}, received)
+
+	_, err = execute(`{"command": "shopware/unknown"}`)
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, int64(jsonrpc2.CodeInvalidParams), rpcErr.Code)
+
+	assert.Equal(t, []string{"shopware/other", "shopware/test"}, s.commandNames())
+}
+
+func TestServer_ExecuteEditorCommand(t *testing.T) {
+	var received string
+	s := &Server{
+		commandMap: map[string]CommandFunc{
+			"shopware/twig/getBlockDiff": func(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+				received = string(*args)
+				return nil, nil
+			},
+		},
+	}
+
+	execute := func(params string) (interface{}, error) {
+		raw := json.RawMessage(params)
+		return s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "workspace/executeCommand", Params: &raw})
+	}
+
+	// Positional arguments of code actions are passed as the parameter object of the registered command
+	_, err := execute(`{"command": "shopware.twig.showBlockDiff", "arguments": ["file:///a.twig", "page_content"]}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"textUri": "file:///a.twig", "blockName": "page_content"}`, received)
+
+	result, err := execute(`{"command": "shopware.openReferences", "arguments": [["file:///a.twig#3", "file:///b.xml#1"]]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []protocol.Location{
+		{URI: "file:///a.twig", Range: protocol.Range{Start: protocol.Position{Line: 2}, End: protocol.Position{Line: 2}}},
+		{URI: "file:///b.xml", Range: protocol.Range{Start: protocol.Position{Line: 0}, End: protocol.Position{Line: 0}}},
+	}, result)
+
+	// Commands of the registered providers which are not loaded are reported
+	_, err = execute(`{"command": "shopware.createSnippet", "arguments": ["key", "file:///a.twig"]}`)
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, "Unknown command: shopware/snippet/storefront/create", rpcErr.Message)
+
+	// Editor commands are implemented by the VSCode extension and not advertised
+	assert.Equal(t, []string{"shopware/twig/getBlockDiff"}, s.commandNames())
+}
+
+type testCommandProvider map[string]CommandFunc
+
+func (p testCommandProvider) GetCommands(ctx context.Context) map[string]CommandFunc {
+	return p
+}
+
+func TestServer_RegisterCommands(t *testing.T) {
+	s := &Server{
+		commandMap: make(map[string]CommandFunc),
+		commandProviders: []CommandProvider{testCommandProvider{
+			"shopware/test": func(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+				return nil, nil
+			},
+		}},
+	}
+	s.registerCommands()
+
+	assert.Equal(t, []string{
+		"shopware/forceReindex",
+		"shopware/indexStats",
+		"shopware/reindexPath",
+		"shopware/reindexType",
+		"shopware/test",
+	}, s.commandNames())
+
+	// The index commands can be run with workspace/executeCommand and as JSON-RPC methods
+	raw := json.RawMessage(`{"command": "shopware/reindexType", "arguments": [{"indexer": "unknown.indexer"}]}`)
+	_, err := s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "workspace/executeCommand", Params: &raw})
+	var rpcErr *jsonrpc2.Error
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, "Unknown indexer: unknown.indexer", rpcErr.Message)
+
+	_, err = s.handle(context.Background(), nil, &jsonrpc2.Request{Method: "shopware/reindexType"})
+	require.ErrorAs(t, err, &rpcErr)
+	assert.Equal(t, "Missing parameter: indexer", rpcErr.Message)
+}
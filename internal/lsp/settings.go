@@ -0,0 +1,161 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+)
+
+// settingsSection is the workspace configuration section read by the server
+const settingsSection = "shopwareLSP"
+
+// Settings represents the user configuration of the server
+type Settings struct {
+	// Providers toggles completion and diagnostics providers by their ID,
+	// providers which are not listed are enabled
+	Providers map[string]bool `json:"providers,omitempty"`
+	// PHP holds the options of the PHP features
+	PHP PHPSettings `json:"php,omitempty"`
+	// Diagnostics holds the options of publishing diagnostics
+	Diagnostics DiagnosticsSettings `json:"diagnostics,omitempty"`
+}
+
+// DiagnosticsSettings represents the user configuration of the published diagnostics
+type DiagnosticsSettings struct {
+	// OnSave publishes diagnostics only when a document is opened or saved and not while it is edited,
+	// textDocument/diagnostic requests of the client are still answered
+	OnSave bool `json:"onSave,omitempty"`
+}
+
+// PHPSettings represents the user configuration of the PHP features
+type PHPSettings struct {
+	// GroupImports separates class, function and const imports by a blank line when organizing imports
+	GroupImports bool `json:"groupImports,omitempty"`
+}
+
+// IsProviderEnabled reports whether the provider with the given ID is enabled
+func (settings Settings) IsProviderEnabled(id string) bool {
+	enabled, ok := settings.Providers[id]
+	return !ok || enabled
+}
+
+// InitializationOptions are passed by the client with the initialize request
+type InitializationOptions struct {
+	// SkipDirs are directory names excluded from indexing in addition to the defaults
+	SkipDirs []string `json:"skipDirs,omitempty"`
+	// IncludeDirs are directory names which are indexed even though they are skipped by default
+	IncludeDirs []string `json:"includeDirs,omitempty"`
+	// FileWatcher enables the server side file watcher, it is enabled when not set
+	FileWatcher *bool `json:"fileWatcher,omitempty"`
+	// IndexWorkers is the number of parallel indexing workers
+	IndexWorkers int `json:"indexWorkers,omitempty"`
+	// ContentHashing skips reindexing of files whose modification time changed but whose content did not
+	ContentHashing bool `json:"contentHashing,omitempty"`
+	// FileTypes maps additional file name suffixes to a supported file type, e.g. ".twig.html" to "twig"
+	FileTypes map[string]string `json:"fileTypes,omitempty"`
+	// CacheSize is the number of keys each index keeps in memory, 0 disables the cache
+	CacheSize *int `json:"cacheSize,omitempty"`
+}
+
+// applyInitializationOptions configures the file scanner with the options of the initialize request
+func (s *Server) applyInitializationOptions(raw json.RawMessage) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return
+	}
+
+	var options InitializationOptions
+	if err := json.Unmarshal(raw, &options); err != nil {
+		log.Printf("Error parsing initialization options: %v", err)
+		return
+	}
+
+	if len(options.SkipDirs) > 0 || len(options.IncludeDirs) > 0 {
+		s.fileScanner.SetSkipDirs(options.SkipDirs, options.IncludeDirs)
+	}
+
+	s.fileScanner.SetContentHashing(options.ContentHashing)
+
+	if options.IndexWorkers > 0 {
+		s.fileScanner.SetWorkerCount(options.IndexWorkers)
+	}
+
+	if options.CacheSize != nil {
+		indexer.SetCacheSize(*options.CacheSize)
+	}
+
+	if len(options.FileTypes) > 0 {
+		if err := indexer.SetCustomFileTypes(options.FileTypes); err != nil {
+			log.Printf("Error configuring file types: %v", err)
+		}
+	}
+
+	if options.FileWatcher != nil {
+		s.fileWatcherEnabled = *options.FileWatcher
+	}
+}
+
+// IdentifiableProvider is implemented by providers which can be toggled in the settings
+type IdentifiableProvider interface {
+	ID() string
+}
+
+// GetSettings returns the current settings
+func (s *Server) GetSettings() Settings {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.settings
+}
+
+// setSettings parses and stores the settings, a null payload resets them to the defaults
+func (s *Server) setSettings(raw json.RawMessage) error {
+	var settings Settings
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &settings); err != nil {
+			return err
+		}
+	}
+
+	s.settingsMu.Lock()
+	s.settings = settings
+	s.settingsMu.Unlock()
+
+	return nil
+}
+
+// isProviderEnabled checks the settings for providers implementing IdentifiableProvider
+func (s *Server) isProviderEnabled(provider any) bool {
+	identifiable, ok := provider.(IdentifiableProvider)
+	if !ok {
+		return true
+	}
+	return s.GetSettings().IsProviderEnabled(identifiable.ID())
+}
+
+// fetchSettings requests the settings from the client with workspace/configuration.
+// It must not be called from the message loop, as it waits for the client's response.
+func (s *Server) fetchSettings(ctx context.Context) {
+	if s.conn == nil || !s.supportsConfiguration {
+		return
+	}
+
+	var result []json.RawMessage
+	err := s.conn.Call(ctx, "workspace/configuration", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"section": settingsSection},
+		},
+	}, &result)
+	if err != nil {
+		log.Printf("Error fetching workspace configuration: %v", err)
+		return
+	}
+
+	if len(result) == 0 {
+		return
+	}
+
+	if err := s.setSettings(result[0]); err != nil {
+		log.Printf("Error parsing workspace configuration: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package lsp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCompletionProvider struct {
+	id    string
+	items []protocol.CompletionItem
+}
+
+func (p *testCompletionProvider) ID() string { return p.id }
+
+func (p *testCompletionProvider) GetCompletions(ctx context.Context, params *protocol.CompletionParams) []protocol.CompletionItem {
+	return p.items
+}
+
+func (p *testCompletionProvider) GetTriggerCharacters() []string { return nil }
+
+func TestSettings_IsProviderEnabled(t *testing.T) {
+	settings := Settings{Providers: map[string]bool{
+		"diagnostics.admin": false,
+		"completion.twig":   true,
+	}}
+
+	assert.False(t, settings.IsProviderEnabled("diagnostics.admin"))
+	assert.True(t, settings.IsProviderEnabled("completion.twig"))
+	assert.True(t, settings.IsProviderEnabled("completion.route"))
+	assert.True(t, Settings{}.IsProviderEnabled("diagnostics.admin"))
+}
+
+func TestServer_DisabledCompletionProvider(t *testing.T) {
+	s := &Server{documentManager: NewDocumentManager()}
+	defer s.documentManager.Close()
+
+	s.RegisterCompletionProvider(&testCompletionProvider{id: "completion.a", items: []protocol.CompletionItem{{Label: "a"}}})
+	s.RegisterCompletionProvider(&testCompletionProvider{id: "completion.b", items: []protocol.CompletionItem{{Label: "b"}}})
+
+	require.NoError(t, s.setSettings(json.RawMessage(`{"providers": {"completion.a": false}}`)))
+
+	result := s.completion(context.Background(), &protocol.CompletionParams{})
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, "b", result.Items[0].Label)
+
+	// A null configuration resets to the defaults
+	require.NoError(t, s.setSettings(json.RawMessage(`null`)))
+
+	result = s.completion(context.Background(), &protocol.CompletionParams{})
+	assert.Len(t, result.Items, 2)
+}
+
+func TestServer_PHPSettings(t *testing.T) {
+	s := &Server{}
+
+	require.NoError(t, s.setSettings(json.RawMessage(`{"php": {"groupImports": true}}`)))
+	assert.True(t, s.GetSettings().PHP.GroupImports)
+
+	require.NoError(t, s.setSettings(json.RawMessage(`{"providers": {"completion.php": false}}`)))
+	assert.False(t, s.GetSettings().PHP.GroupImports)
+}
+
+func TestServer_DiagnosticsSettings(t *testing.T) {
+	s := &Server{}
+
+	assert.False(t, s.GetSettings().Diagnostics.OnSave)
+
+	require.NoError(t, s.setSettings(json.RawMessage(`{"diagnostics": {"onSave": true}}`)))
+	assert.True(t, s.GetSettings().Diagnostics.OnSave)
+}
@@ -0,0 +1,28 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// signatureHelp handles textDocument/signatureHelp requests
+func (s *Server) signatureHelp(ctx context.Context, params *protocol.SignatureHelpParams) *protocol.SignatureHelp {
+	node, document, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer document.Release()
+	if !ok || node == nil {
+		return nil
+	}
+
+	params.Node = node
+	params.DocumentContent = document.Text
+
+	// The first provider knowing the call wins
+	for _, provider := range s.signatureHelpProviders {
+		if help := provider.GetSignatureHelp(ctx, params); help != nil {
+			return help
+		}
+	}
+
+	return nil
+}
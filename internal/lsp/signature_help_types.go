@@ -0,0 +1,13 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// SignatureHelpProvider is an interface for providing the signature of the function called at the cursor
+type SignatureHelpProvider interface {
+	// GetSignatureHelp returns nil when the cursor is not within the arguments of a known call
+	GetSignatureHelp(ctx context.Context, params *protocol.SignatureHelpParams) *protocol.SignatureHelp
+}
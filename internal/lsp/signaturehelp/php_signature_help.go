@@ -0,0 +1,62 @@
+package signaturehelp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/indexer"
+	"github.com/shopware/shopware-lsp/internal/lsp"
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+)
+
+// PHPSignatureHelpProvider shows the parameters of the method or constructor called at the cursor
+type PHPSignatureHelpProvider struct {
+	phpIndex *php.PHPIndex
+}
+
+func NewPHPSignatureHelpProvider(lspServer *lsp.Server) *PHPSignatureHelpProvider {
+	phpIndex, _ := lspServer.GetIndexer("php.index")
+
+	return &PHPSignatureHelpProvider{
+		phpIndex: phpIndex.(*php.PHPIndex),
+	}
+}
+
+func (p *PHPSignatureHelpProvider) GetSignatureHelp(ctx context.Context, params *protocol.SignatureHelpParams) *protocol.SignatureHelp {
+	if params.Node == nil || params.DocumentContent == nil || indexer.FileType(params.TextDocument.URI) != ".php" {
+		return nil
+	}
+
+	offset := lsp.ByteOffset(params.DocumentContent, params.Position)
+	method, class, argument := p.phpIndex.ResolveCallAt(params.Node, params.DocumentContent, uint(offset))
+	if method == nil {
+		return nil
+	}
+
+	signature := protocol.SignatureInformation{
+		Documentation: class.Name,
+		Parameters:    []protocol.ParameterInformation{},
+	}
+
+	labels := make([]string, 0, len(method.Parameters))
+	for _, parameter := range method.Parameters {
+		labels = append(labels, parameter.Label())
+		signature.Parameters = append(signature.Parameters, protocol.ParameterInformation{Label: parameter.Label()})
+	}
+
+	signature.Label = method.Name + "(" + strings.Join(labels, ", ") + ")"
+	if method.Name != "__construct" && method.ReturnType != nil {
+		signature.Label += ": " + method.ReturnType.Name()
+	}
+
+	// All further arguments are collected by a variadic parameter
+	if last := len(method.Parameters) - 1; last >= 0 && argument > last && method.Parameters[last].IsVariadic {
+		argument = last
+	}
+
+	return &protocol.SignatureHelp{
+		Signatures:      []protocol.SignatureInformation{signature},
+		ActiveParameter: argument,
+	}
+}
@@ -0,0 +1,180 @@
+package signaturehelp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+	"github.com/shopware/shopware-lsp/internal/php"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+const productRepository = `<?php
+
+namespace App\Repository;
+
+class ProductRepository
+{
+    public function __construct(string $entityName, bool $versionAware = false)
+    {
+    }
+
+    public static function create(string $entityName, string ...$fields): static
+    {
+    }
+}
+`
+
+const productService = `<?php
+
+namespace App\Service;
+
+use App\Repository\ProductRepository;
+
+class ProductService
+{
+    public function load(string $id, array $criteria = [], bool $withVariants = false): ?array
+    {
+        <cursor>
+    }
+}
+`
+
+func newTestProvider(t *testing.T) *PHPSignatureHelpProvider {
+	t.Helper()
+
+	phpIndex, err := php.NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = phpIndex.Close() })
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	for path, content := range map[string]string{
+		"/project/src/Repository/ProductRepository.php": productRepository,
+		"/project/src/Service/ProductService.php":       strings.Replace(productService, "<cursor>", "", 1),
+	} {
+		tree := parser.Parse([]byte(content), nil)
+		require.NoError(t, phpIndex.Index(path, tree.RootNode(), []byte(content)))
+		tree.Close()
+	}
+
+	return &PHPSignatureHelpProvider{phpIndex: phpIndex}
+}
+
+// signatureHelp requests the signature at the | within the statement
+func signatureHelp(t *testing.T, provider *PHPSignatureHelpProvider, statement string) *protocol.SignatureHelp {
+	t.Helper()
+
+	content := strings.Replace(productService, "<cursor>", statement, 1)
+	offset := strings.Index(content, "|")
+	content = content[:offset] + content[offset+1:]
+
+	lines := strings.Split(content[:offset], "\n")
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(content), nil)
+	t.Cleanup(tree.Close)
+
+	params := &protocol.SignatureHelpParams{
+		Position: protocol.Position{
+			Line:      len(lines) - 1,
+			Character: len(lines[len(lines)-1]),
+		},
+		DocumentContent: []byte(content),
+		Node:            tree.RootNode().DescendantForByteRange(uint(offset), uint(offset)),
+	}
+	params.TextDocument.URI = "file:///project/src/Service/ProductService.php"
+
+	return provider.GetSignatureHelp(context.Background(), params)
+}
+
+func TestPHPSignatureHelpProvider(t *testing.T) {
+	provider := newTestProvider(t)
+
+	tests := []struct {
+		name            string
+		statement       string
+		label           string
+		declaringClass  string
+		activeParameter int
+	}{
+		{
+			name:           "method call",
+			statement:      "$this->load(|)",
+			label:          "load(string $id, array $criteria = ..., bool $withVariants = ...): array|null",
+			declaringClass: "App\\Service\\ProductService",
+		},
+		{
+			name:            "unclosed method call",
+			statement:       "$this->load('id', ['a', 'b'], |",
+			label:           "load(string $id, array $criteria = ..., bool $withVariants = ...): array|null",
+			declaringClass:  "App\\Service\\ProductService",
+			activeParameter: 2,
+		},
+		{
+			name:            "constructor",
+			statement:       "$repository = new ProductRepository('product', |);",
+			label:           "__construct(string $entityName, bool $versionAware = ...)",
+			declaringClass:  "App\\Repository\\ProductRepository",
+			activeParameter: 1,
+		},
+		{
+			name:            "static call with variadic parameter",
+			statement:       "ProductRepository::create('product', 'id', 'name', |",
+			label:           "create(string $entityName, string ...$fields): static",
+			declaringClass:  "App\\Repository\\ProductRepository",
+			activeParameter: 1,
+		},
+		{
+			name:            "heredoc argument",
+			statement:       "$this->load(<<<EOT\n    (,\n    EOT, |",
+			label:           "load(string $id, array $criteria = ..., bool $withVariants = ...): array|null",
+			declaringClass:  "App\\Service\\ProductService",
+			activeParameter: 1,
+		},
+		{
+			name:            "attribute before call",
+			statement:       "$fn = #[Pure] fn () => 1;\n        $this->load('id', |)",
+			label:           "load(string $id, array $criteria = ..., bool $withVariants = ...): array|null",
+			declaringClass:  "App\\Service\\ProductService",
+			activeParameter: 1,
+		},
+		{
+			name:            "nested call",
+			statement:       "$this->load(sprintf('%s,%s', $a, $b), |",
+			label:           "load(string $id, array $criteria = ..., bool $withVariants = ...): array|null",
+			declaringClass:  "App\\Service\\ProductService",
+			activeParameter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			help := signatureHelp(t, provider, tt.statement)
+			require.NotNil(t, help)
+			require.Len(t, help.Signatures, 1)
+
+			assert.Equal(t, tt.label, help.Signatures[0].Label)
+			assert.Equal(t, tt.declaringClass, help.Signatures[0].Documentation)
+			assert.Equal(t, tt.activeParameter, help.ActiveParameter)
+		})
+	}
+}
+
+func TestPHPSignatureHelpProvider_NoCall(t *testing.T) {
+	provider := newTestProvider(t)
+
+	assert.Nil(t, signatureHelp(t, provider, "if ($a|) {}"))
+	assert.Nil(t, signatureHelp(t, provider, "$this->load(function () { |"))
+	assert.Nil(t, signatureHelp(t, provider, "$this->unknown(|)"))
+	assert.Nil(t, signatureHelp(t, provider, "// $this->load(|"))
+}
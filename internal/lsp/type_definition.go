@@ -0,0 +1,30 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// typeDefinition handles textDocument/typeDefinition requests, the parameters have the shape of a definition request
+func (s *Server) typeDefinition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location {
+	node, docText, ok := s.documentManager.GetNodeAtPosition(params.TextDocument.URI, params.Position.Line, params.Position.Character)
+	defer docText.Release()
+	if !ok || node == nil {
+		return nil
+	}
+
+	params.Node = node
+	params.DocumentContent = docText.Text
+
+	var locations []protocol.Location
+	for _, provider := range s.typeDefinitionProviders {
+		if ctx.Err() != nil {
+			break
+		}
+
+		locations = append(locations, provider.GetTypeDefinition(ctx, params)...)
+	}
+
+	return locations
+}
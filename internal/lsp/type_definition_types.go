@@ -0,0 +1,13 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/shopware/shopware-lsp/internal/lsp/protocol"
+)
+
+// TypeDefinitionProvider is an interface for providing the definition of the type of a symbol
+type TypeDefinitionProvider interface {
+	// GetTypeDefinition returns location(s) for the definition of the type of the symbol at the given position
+	GetTypeDefinition(ctx context.Context, params *protocol.DefinitionParams) []protocol.Location
+}
@@ -15,6 +15,13 @@ type CompletionProvider interface {
 	GetTriggerCharacters() []string
 }
 
+// IncompleteCompletionProvider is optionally implemented by completion providers which filter
+// their items server-side, so the client has to request them again while the user keeps typing
+type IncompleteCompletionProvider interface {
+	// IsIncomplete reports whether the items returned for the given parameters are a filtered subset
+	IsIncomplete(params *protocol.CompletionParams) bool
+}
+
 // HoverProvider is an interface for providing hover information
 type HoverProvider interface {
 	// GetHover returns hover information for the given parameters
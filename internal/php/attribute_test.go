@@ -0,0 +1,48 @@
+package php
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestAttributeClasses(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	path := filepath.Join("testdata", "attributes.php")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	require.NoError(t, idx.Index(path, tree.RootNode(), content))
+
+	assert.True(t, idx.GetClass("App\\Attribute\\Cached").IsAttribute)
+	assert.True(t, idx.GetClass("App\\Attribute\\Audited").IsAttribute)
+	assert.False(t, idx.GetClass("App\\Attribute\\NotAnAttribute").IsAttribute)
+
+	assert.Equal(t, []string{"App\\Attribute\\Audited", "App\\Attribute\\Cached"}, idx.GetAttributeClassNames())
+
+	// Reindexing the file without the attributes removes the classes from the attribute index
+	withoutAttributes := []byte("<?php\n\nnamespace App\\Attribute;\n\nfinal class Cached\n{\n}\n")
+	tree = parser.Parse(withoutAttributes, nil)
+	defer tree.Close()
+
+	require.NoError(t, idx.Index(path, tree.RootNode(), withoutAttributes))
+	assert.Empty(t, idx.GetAttributeClassNames())
+
+	require.NoError(t, idx.RemovedFiles([]string{path}))
+	assert.Nil(t, idx.GetClass("App\\Attribute\\Cached"))
+}
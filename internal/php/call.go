@@ -0,0 +1,212 @@
+package php
+
+import (
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ResolveCalledMethod returns the method invoked by a method call, a static call or a new expression
+// together with the class declaring it. Calls on objects are resolved by the type of the object expression.
+func (c *PHPIndex) ResolveCalledMethod(call *tree_sitter.Node, content []byte) (*PHPMethod, *PHPClass) {
+	if call == nil {
+		return nil, nil
+	}
+
+	switch call.Kind() {
+	case "object_creation_expression":
+		// new Foo(...)
+		for i := uint(0); i < call.NamedChildCount(); i++ {
+			child := call.NamedChild(i)
+			if child.Kind() == "name" || child.Kind() == "qualified_name" {
+				return c.GetMethodWithDeclaringClass(c.ResolveScopeClass(child, content), "__construct")
+			}
+		}
+	case "scoped_call_expression":
+		// Foo::create(...), self::create(...) or parent::__construct(...)
+		name := call.ChildByFieldName("name")
+		className := c.ResolveScopeClass(call.ChildByFieldName("scope"), content)
+		if name != nil && className != "" {
+			return c.GetMethodWithDeclaringClass(className, name.Utf8Text(content))
+		}
+	case "member_call_expression", "nullsafe_member_call_expression":
+		// $this->foo(...) or $this->service->foo(...)
+		name := call.ChildByFieldName("name")
+		className := c.classOfExpression(call.ChildByFieldName("object"), content)
+		if name != nil && className != "" {
+			return c.GetMethodWithDeclaringClass(className, name.Utf8Text(content))
+		}
+	}
+
+	return nil, nil
+}
+
+// classOfExpression returns the class of an object expression like $this, a variable or a property of them
+func (c *PHPIndex) classOfExpression(node *tree_sitter.Node, content []byte) string {
+	if node == nil {
+		return ""
+	}
+
+	return ClassNameOfType(c.TypeOfExpression(node, content))
+}
+
+// ClassNameOfType returns the class of an object type, nullable types are unions with null
+func ClassNameOfType(phpType PHPType) string {
+	for _, typeName := range strings.Split(strings.TrimPrefix(phpType.Name(), "?"), "|") {
+		typeName = strings.TrimPrefix(typeName, "\\")
+		if typeName != "" && !isPrimitiveType(typeName) && !isSpecialType(typeName) {
+			return typeName
+		}
+	}
+
+	return ""
+}
+
+// ResolveCallAt resolves the call whose argument list contains the offset by walking up from the node at the offset,
+// the closing parenthesis may be missing while typing. It returns the index of the argument at the offset,
+// which is the number of commas before it.
+func (c *PHPIndex) ResolveCallAt(node *tree_sitter.Node, content []byte, offset uint) (*PHPMethod, *PHPClass, int) {
+	for ; node != nil; node = node.Parent() {
+		if node.Kind() == "arguments" && node.StartByte() < offset && (offset < node.EndByte() || !isClosedArguments(node)) {
+			method, class := c.ResolveCalledMethod(node.Parent(), content)
+
+			return method, class, countCommasBefore(node, offset)
+		}
+
+		// An unclosed call is parsed as an error node holding the tokens of the call
+		if node.Kind() == "ERROR" {
+			if method, class, argument, ok := c.resolveUnclosedCall(node, content, offset); ok {
+				return method, class, argument
+			}
+		}
+
+		// The cursor may be behind the error node, e.g. after the whitespace following a comma
+		if previous := lastChildBefore(node, offset); previous != nil && previous.Kind() == "ERROR" && previous.EndByte() <= offset {
+			if method, class, argument, ok := c.resolveUnclosedCall(previous, content, offset); ok {
+				return method, class, argument
+			}
+		}
+
+		// Blocks like closure bodies end the search, their statements are not arguments
+		if node.Kind() == "compound_statement" {
+			break
+		}
+	}
+
+	return nil, nil, 0
+}
+
+// resolveUnclosedCall resolves the call of the innermost parenthesis of the error node left open before the offset
+func (c *PHPIndex) resolveUnclosedCall(errorNode *tree_sitter.Node, content []byte, offset uint) (*PHPMethod, *PHPClass, int, bool) {
+	type bracket struct {
+		index  uint
+		kind   string
+		commas int
+	}
+
+	var stack []bracket
+	for i := uint(0); i < errorNode.ChildCount(); i++ {
+		child := errorNode.Child(i)
+		if child.StartByte() >= offset {
+			break
+		}
+
+		switch child.Kind() {
+		case "(", "[", "{":
+			stack = append(stack, bracket{index: i, kind: child.Kind()})
+		case ")", "]", "}":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case ",":
+			if len(stack) > 0 {
+				stack[len(stack)-1].commas++
+			}
+		}
+	}
+
+	// Arrays within the arguments belong to the argument, blocks like closure bodies don't
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].kind {
+		case "(":
+			method, class := c.resolveCalleeBefore(errorNode, stack[i].index, content)
+
+			return method, class, stack[i].commas, method != nil
+		case "{":
+			return nil, nil, 0, false
+		}
+	}
+
+	return nil, nil, 0, false
+}
+
+// resolveCalleeBefore resolves the method called by the tokens of the error node before the opening parenthesis
+func (c *PHPIndex) resolveCalleeBefore(errorNode *tree_sitter.Node, index uint, content []byte) (*PHPMethod, *PHPClass) {
+	if index < 2 {
+		return nil, nil
+	}
+
+	name := errorNode.Child(index - 1)
+	if name.Kind() != "name" && name.Kind() != "qualified_name" {
+		return nil, nil
+	}
+
+	switch errorNode.Child(index - 2).Kind() {
+	case "new":
+		// new Foo(...
+		return c.GetMethodWithDeclaringClass(c.ResolveScopeClass(name, content), "__construct")
+	case "::":
+		// Foo::create(...
+		if index >= 3 {
+			if className := c.ResolveScopeClass(errorNode.Child(index-3), content); className != "" {
+				return c.GetMethodWithDeclaringClass(className, name.Utf8Text(content))
+			}
+		}
+	case "->", "?->":
+		// $this->foo(...
+		if index >= 3 {
+			if className := c.classOfExpression(errorNode.Child(index-3), content); className != "" {
+				return c.GetMethodWithDeclaringClass(className, name.Utf8Text(content))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// isClosedArguments reports whether the argument list ends with a closing parenthesis
+func isClosedArguments(arguments *tree_sitter.Node) bool {
+	last := arguments.Child(arguments.ChildCount() - 1)
+
+	return last != nil && last.Kind() == ")" && !last.IsMissing()
+}
+
+// countCommasBefore returns the number of commas separating the arguments before the offset
+func countCommasBefore(arguments *tree_sitter.Node, offset uint) int {
+	commas := 0
+	for i := uint(0); i < arguments.ChildCount(); i++ {
+		child := arguments.Child(i)
+		if child.StartByte() >= offset {
+			break
+		}
+		if child.Kind() == "," {
+			commas++
+		}
+	}
+
+	return commas
+}
+
+// lastChildBefore returns the last child of the node starting before the offset
+func lastChildBefore(node *tree_sitter.Node, offset uint) *tree_sitter.Node {
+	var last *tree_sitter.Node
+	for i := uint(0); i < node.ChildCount(); i++ {
+		child := node.Child(i)
+		if child.StartByte() >= offset {
+			break
+		}
+		last = child
+	}
+
+	return last
+}
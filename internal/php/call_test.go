@@ -0,0 +1,69 @@
+package php
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestResolveCalledMethod(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	var content []byte
+	var root *tree_sitter.Node
+	for _, file := range []string{"calls_repository.php", "calls.php"} {
+		path := filepath.Join("testdata", file)
+		content, err = os.ReadFile(path)
+		require.NoError(t, err)
+
+		tree := parser.Parse(content, nil)
+		defer tree.Close()
+		require.NoError(t, idx.Index(path, tree.RootNode(), content))
+		root = tree.RootNode()
+	}
+
+	var calls []string
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		switch node.Kind() {
+		case "member_call_expression", "nullsafe_member_call_expression", "scoped_call_expression", "object_creation_expression":
+			method, class := idx.ResolveCalledMethod(node, content)
+			if method == nil {
+				calls = append(calls, "unresolved")
+				break
+			}
+
+			var parameters []string
+			for _, parameter := range method.Parameters {
+				parameters = append(parameters, parameter.Name)
+			}
+			calls = append(calls, class.Name+"::"+method.Name+"("+strings.Join(parameters, ", ")+")")
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(root)
+
+	assert.Equal(t, []string{
+		"App\\Service\\BaseService::__construct(name, priority)",
+		"App\\Service\\ProductService::__construct(repository)",
+		"unresolved",
+		"App\\Service\\BaseService::log(message, context)",
+		"App\\Repository\\ProductRepository::search(criteria, withTotal)",
+		"App\\Service\\ProductService::create(name, ids)",
+	}, calls)
+}
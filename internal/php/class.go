@@ -1,5 +1,28 @@
 package php
 
+import (
+	"sort"
+
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// Cases returns the cases of an enum in the order they are declared
+func (c *PHPClass) Cases() []PHPConstant {
+	var cases []PHPConstant
+	for _, constant := range c.Constants {
+		if constant.IsEnumCase {
+			cases = append(cases, constant)
+		}
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		return cases[i].Line < cases[j].Line
+	})
+
+	return cases
+}
+
 func (c *PHPIndex) GetProperty(className string, name string) *PHPProperty {
 	class := c.GetClass(className)
 	if class == nil {
@@ -24,24 +47,136 @@ func (c *PHPIndex) GetProperty(className string, name string) *PHPProperty {
 }
 
 func (c *PHPIndex) GetMethod(className string, name string) *PHPMethod {
+	method, _ := c.GetMethodWithDeclaringClass(className, name)
+
+	return method
+}
+
+// GetMethodWithDeclaringClass looks up a method like GetMethod and also returns
+// the class in the hierarchy that declares it
+func (c *PHPIndex) GetMethodWithDeclaringClass(className string, name string) (*PHPMethod, *PHPClass) {
 	class := c.GetClass(className)
 	if class == nil {
-		return nil
+		return nil, nil
 	}
 
 	method, ok := class.Methods[name]
 	if !ok {
-		// Method not found in this class, check parent
 		if class.Parent != "" {
-			parentMethod := c.GetMethod(class.Parent, name)
-			if parentMethod != nil {
-				return parentMethod
+			return c.GetMethodWithDeclaringClass(class.Parent, name)
+		}
+
+		return nil, nil
+	}
+
+	return &method, class
+}
+
+// GetConstantWithDeclaringClass looks up a class constant in the class, its parents and
+// its interfaces and returns the class declaring it
+func (c *PHPIndex) GetConstantWithDeclaringClass(className string, name string) (*PHPConstant, *PHPClass) {
+	var found *PHPConstant
+	var declaringClass *PHPClass
+
+	c.WalkHierarchy(className, func(class *PHPClass) bool {
+		if constant, ok := class.Constants[name]; ok {
+			found, declaringClass = &constant, class
+			return false
+		}
+		return true
+	})
+
+	return found, declaringClass
+}
+
+// ResolveScopeClass returns the fully qualified class name of the scope of a static access
+// like Foo::BAR, self::BAR or parent::bar()
+func (c *PHPIndex) ResolveScopeClass(scopeNode *tree_sitter.Node, content []byte) string {
+	if scopeNode == nil {
+		return ""
+	}
+
+	if scopeNode.Kind() == "relative_scope" {
+		className := treesitterhelper.GetClassName(scopeNode, content)
+
+		if scopeNode.Utf8Text(content) == "parent" {
+			class := c.GetClass(className)
+			if class == nil {
+				return ""
 			}
+
+			return class.Parent
 		}
 
-		// Method not found
-		return nil
+		return className
 	}
 
-	return &method
+	if scopeNode.Kind() != "name" && scopeNode.Kind() != "qualified_name" {
+		return ""
+	}
+
+	rootNode := scopeNode
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	return ResolveClassName(rootNode, content, scopeNode.Utf8Text(content))
+}
+
+// WalkHierarchy calls fn for the class, its parents and its interfaces, starting with the class itself.
+// Walking stops when fn returns false.
+func (c *PHPIndex) WalkHierarchy(className string, fn func(class *PHPClass) bool) {
+	c.walkHierarchy(className, fn, make(map[string]bool))
+}
+
+func (c *PHPIndex) walkHierarchy(className string, fn func(class *PHPClass) bool, visited map[string]bool) bool {
+	if className == "" || visited[className] {
+		return true
+	}
+	visited[className] = true
+
+	class := c.GetClass(className)
+	if class == nil {
+		return true
+	}
+
+	if !fn(class) {
+		return false
+	}
+
+	if !c.walkHierarchy(class.Parent, fn, visited) {
+		return false
+	}
+
+	for _, interfaceName := range class.Interfaces {
+		if !c.walkHierarchy(interfaceName, fn, visited) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSubclassOf reports whether the class is the given class or extends or implements it
+func (c *PHPIndex) IsSubclassOf(className, parentName string) bool {
+	found := false
+	c.WalkHierarchy(className, func(class *PHPClass) bool {
+		found = class.Name == parentName
+		return !found
+	})
+
+	return found || className == parentName
+}
+
+// IsAccessible reports whether a member declared in declaringClass with the given visibility
+// can be accessed from code inside fromClass, fromClass is empty outside of classes
+func (c *PHPIndex) IsAccessible(visibility Visibility, declaringClass, fromClass string) bool {
+	switch visibility {
+	case Private:
+		return fromClass == declaringClass
+	case Protected:
+		return fromClass != "" && (c.IsSubclassOf(fromClass, declaringClass) || c.IsSubclassOf(declaringClass, fromClass))
+	default:
+		return true
+	}
 }
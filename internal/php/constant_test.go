@@ -0,0 +1,200 @@
+package php
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestClassConstants(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	classes := idx.GetClassesOfFile(filepath.Join("testdata", "constants.php"))
+
+	checkoutEvents := classes["App\\Event\\CheckoutEvents"]
+	require.Len(t, checkoutEvents.Constants, 4)
+
+	assert.Equal(t, PHPConstant{Name: "CART_LOADED", Line: 17, Value: "checkout.cart.loaded"}, checkoutEvents.Constants["CART_LOADED"])
+	assert.Equal(t, PHPConstant{Name: "CART_CLEARED", Line: 17, Value: "checkout.cart.cleared"}, checkoutEvents.Constants["CART_CLEARED"])
+	assert.Equal(t, "100", checkoutEvents.Constants["PRIORITY"].Value)
+	assert.Equal(t, "self::CART_LOADED", checkoutEvents.Constants["ALIAS"].Value)
+
+	assert.Contains(t, classes["App\\Event\\EventNames"].Constants, "PREFIX")
+}
+
+func TestGetConstantWithDeclaringClass(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	path := filepath.Join("testdata", "constants.php")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+	require.NoError(t, idx.Index(path, tree.RootNode(), content))
+
+	tests := []struct {
+		constant       string
+		declaringClass string
+		line           int
+	}{
+		{constant: "CART_LOADED", declaringClass: "App\\Event\\CheckoutEvents", line: 17},
+		{constant: "ORDER_PLACED", declaringClass: "App\\Event\\BaseEvents", line: 12},
+		{constant: "PREFIX", declaringClass: "App\\Event\\EventNames", line: 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constant, func(t *testing.T) {
+			constant, class := idx.GetConstantWithDeclaringClass("App\\Event\\CheckoutEvents", tt.constant)
+			require.NotNil(t, constant)
+			assert.Equal(t, tt.declaringClass, class.Name)
+			assert.Equal(t, tt.line, constant.Line)
+		})
+	}
+
+	constant, class := idx.GetConstantWithDeclaringClass("App\\Event\\CheckoutEvents", "UNKNOWN")
+	assert.Nil(t, constant)
+	assert.Nil(t, class)
+}
+
+func TestGetConstantWithDeclaringClass_CyclicHierarchy(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	content := []byte(`<?php
+
+namespace App;
+
+class Loop extends Loop implements Looping
+{
+}
+
+interface Looping extends Loop
+{
+}
+`)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+	require.NoError(t, idx.Index("/project/src/Loop.php", tree.RootNode(), content))
+
+	constant, class := idx.GetConstantWithDeclaringClass("App\\Loop", "UNKNOWN")
+	assert.Nil(t, constant)
+	assert.Nil(t, class)
+}
+
+func TestResolveClassName(t *testing.T) {
+	content := []byte(`<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Checkout\Cart\Event\CartEvents;
+use Shopware\Core\Checkout\Order as OrderNs;
+use Shopware\Core\Framework\{Context, Uuid\Uuid as Id};
+`)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	tests := map[string]string{
+		"CartEvents":             "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents",
+		"Id":                     "Shopware\\Core\\Framework\\Uuid\\Uuid",
+		"Context":                "Shopware\\Core\\Framework\\Context",
+		"OrderNs\\OrderEvents":   "Shopware\\Core\\Checkout\\Order\\OrderEvents",
+		"LocalEvents":            "App\\Subscriber\\LocalEvents",
+		"Sub\\LocalEvents":       "App\\Subscriber\\Sub\\LocalEvents",
+		"\\Global\\KernelEvents": "Global\\KernelEvents",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, ResolveClassName(tree.RootNode(), content, name), name)
+	}
+}
+
+func TestEnumCasesAndTypedConstants(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	classes := idx.GetClassesOfFile(filepath.Join("testdata", "enum.php"))
+
+	orderState, ok := classes["App\\Order\\OrderState"]
+	require.True(t, ok)
+	assert.Equal(t, []string{"App\\Order\\StateInterface"}, orderState.Interfaces)
+	assert.Contains(t, orderState.Methods, "label")
+
+	require.Len(t, orderState.Constants, 3)
+	assert.Equal(t, PHPConstant{Name: "Open", Line: 7, Visibility: Public, Value: "open", IsEnumCase: true}, orderState.Constants["Open"])
+	assert.Equal(t, PHPConstant{Name: "FALLBACK", Line: 10, Visibility: Public, Value: "open"}, orderState.Constants["FALLBACK"])
+
+	assert.True(t, orderState.IsEnum)
+	cases := orderState.Cases()
+	require.Len(t, cases, 2)
+	assert.Equal(t, "Open", cases[0].Name)
+	assert.Equal(t, "Done", cases[1].Name)
+
+	// Nullable types resolve the class name as well
+	assert.Equal(t, "App\\Order\\OrderState|null", classes["App\\Order\\Order"].Properties["state"].Type.Name())
+
+	checkoutEvents := idx.GetClassesOfFile(filepath.Join("testdata", "constants.php"))["App\\Event\\CheckoutEvents"]
+	assert.False(t, checkoutEvents.IsEnum)
+	assert.Equal(t, Private, checkoutEvents.Constants["PRIORITY"].Visibility)
+	assert.Equal(t, Public, checkoutEvents.Constants["CART_LOADED"].Visibility)
+}
+
+func TestIsAccessible(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	path := filepath.Join("testdata", "constants.php")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+	require.NoError(t, idx.Index(path, tree.RootNode(), content))
+
+	var visited []string
+	idx.WalkHierarchy("App\\Event\\CheckoutEvents", func(class *PHPClass) bool {
+		visited = append(visited, class.Name)
+		return true
+	})
+	assert.Equal(t, []string{"App\\Event\\CheckoutEvents", "App\\Event\\BaseEvents", "App\\Event\\EventNames"}, visited)
+
+	assert.True(t, idx.IsSubclassOf("App\\Event\\CheckoutEvents", "App\\Event\\EventNames"))
+	assert.False(t, idx.IsSubclassOf("App\\Event\\BaseEvents", "App\\Event\\CheckoutEvents"))
+
+	assert.True(t, idx.IsAccessible(Public, "App\\Event\\BaseEvents", ""))
+	assert.True(t, idx.IsAccessible(Protected, "App\\Event\\BaseEvents", "App\\Event\\CheckoutEvents"))
+	assert.True(t, idx.IsAccessible(Protected, "App\\Event\\CheckoutEvents", "App\\Event\\BaseEvents"))
+	assert.False(t, idx.IsAccessible(Protected, "App\\Event\\BaseEvents", "App\\Other"))
+	assert.False(t, idx.IsAccessible(Private, "App\\Event\\BaseEvents", "App\\Event\\CheckoutEvents"))
+	assert.True(t, idx.IsAccessible(Private, "App\\Event\\CheckoutEvents", "App\\Event\\CheckoutEvents"))
+}
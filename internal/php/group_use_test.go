@@ -35,3 +35,19 @@ func TestGroupUseStatements(t *testing.T) {
 		assert.Equal(t, expectedType, class.Properties[propName].Type.Name(), "Property %s should have type %s", propName, expectedType)
 	}
 }
+
+func TestGroupUseStatements_PlainClasses(t *testing.T) {
+	imports := parseFileImports(t, `<?php
+
+namespace App\Controller;
+
+use Shopware\Core\Framework\{Context, Uuid\Uuid};
+use Shopware\Core\{Defaults as CoreDefaults};
+`)
+
+	assert.Equal(t, map[string]string{
+		"Context": "Shopware\\Core\\Framework\\Context",
+		"Uuid":    "Shopware\\Core\\Framework\\Uuid\\Uuid",
+	}, imports.UseStatements)
+	assert.Equal(t, map[string]string{"CoreDefaults": "Shopware\\Core\\Defaults"}, imports.Aliases)
+}
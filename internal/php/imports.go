@@ -0,0 +1,289 @@
+package php
+
+import (
+	"regexp"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// docBlockClassName matches class names inside docblocks, e.g. in @param, @var, @throws or @see
+var docBlockClassName = regexp.MustCompile(`\\?[A-Za-z_][A-Za-z0-9_]*(?:\\[A-Za-z_][A-Za-z0-9_]*)*`)
+
+// FileImports holds the namespace and the class imports declared at the top of a PHP file
+type FileImports struct {
+	Namespace string
+	// UseStatements maps the short name of imported classes to their FQCN
+	UseStatements map[string]string
+	// Aliases maps the alias of classes imported with "as" to their FQCN
+	Aliases map[string]string
+}
+
+// ParseFileImports collects the namespace and use statements of a file, including group use statements
+func ParseFileImports(rootNode *tree_sitter.Node, fileContent []byte) FileImports {
+	imports := FileImports{
+		UseStatements: make(map[string]string),
+		Aliases:       make(map[string]string),
+	}
+
+	for i := uint(0); i < rootNode.NamedChildCount(); i++ {
+		child := rootNode.NamedChild(i)
+		if child == nil {
+			continue
+		}
+
+		switch child.Kind() {
+		case "namespace_definition":
+			if nameNode := findDirectChildOfKind(child, "namespace_name"); nameNode != nil {
+				imports.Namespace = string(nameNode.Utf8Text(fileContent))
+			}
+		case "namespace_use_declaration":
+			collectUseStatements(child, fileContent, imports.UseStatements, imports.Aliases)
+		}
+	}
+
+	return imports
+}
+
+// UseKind is the kind of symbol a use statement imports
+type UseKind string
+
+const (
+	UseKindClass    UseKind = "class"
+	UseKindFunction UseKind = "function"
+	UseKindConst    UseKind = "const"
+)
+
+// UseClause is a single import of a use statement
+type UseClause struct {
+	Kind UseKind
+	FQCN string
+	// Name is the alias or the short name the symbol is available as
+	Name string
+	// Alias is only set for imports using "as"
+	Alias string
+	// Declaration is the namespace_use_declaration containing the clause
+	Declaration *tree_sitter.Node
+	// Clause is the namespace_use_clause of the import
+	Clause *tree_sitter.Node
+}
+
+// ParseUseClauses returns the class imports of a file in the order they are declared,
+// group use statements yield one clause per class while function and const imports are skipped
+func ParseUseClauses(rootNode *tree_sitter.Node, fileContent []byte) []UseClause {
+	var classes []UseClause
+	for _, clause := range ParseAllUseClauses(rootNode, fileContent) {
+		if clause.Kind == UseKindClass {
+			classes = append(classes, clause)
+		}
+	}
+
+	return classes
+}
+
+// ParseAllUseClauses returns the class, function and const imports of a file in the order they are declared
+func ParseAllUseClauses(rootNode *tree_sitter.Node, fileContent []byte) []UseClause {
+	var clauses []UseClause
+
+	for i := uint(0); i < rootNode.NamedChildCount(); i++ {
+		declaration := rootNode.NamedChild(i)
+		if declaration == nil || declaration.Kind() != "namespace_use_declaration" {
+			continue
+		}
+
+		declarationKind := useKindOf(declaration)
+
+		prefix := ""
+		container := declaration
+		if group := declaration.ChildByFieldName("body"); group != nil {
+			if namespaceName := findDirectChildOfKind(declaration, "namespace_name"); namespaceName != nil {
+				prefix = strings.TrimPrefix(string(namespaceName.Utf8Text(fileContent)), "\\") + "\\"
+			}
+			container = group
+		}
+
+		for j := uint(0); j < container.NamedChildCount(); j++ {
+			clause := container.NamedChild(j)
+			if clause == nil || clause.Kind() != "namespace_use_clause" {
+				continue
+			}
+
+			// A trailing comma in a group use statement yields a clause with a missing name
+			nameNode := clause.NamedChild(0)
+			if nameNode == nil || nameNode.IsMissing() || (nameNode.Kind() != "name" && nameNode.Kind() != "qualified_name") {
+				continue
+			}
+
+			useClause := UseClause{
+				Kind:        declarationKind,
+				FQCN:        prefix + strings.TrimPrefix(string(nameNode.Utf8Text(fileContent)), "\\"),
+				Declaration: declaration,
+				Clause:      clause,
+			}
+
+			if useClause.Kind == UseKindClass {
+				useClause.Kind = useKindOf(clause)
+			}
+
+			useClause.Name = useClause.FQCN[strings.LastIndex(useClause.FQCN, "\\")+1:]
+			if alias := clause.ChildByFieldName("alias"); alias != nil {
+				useClause.Alias = string(alias.Utf8Text(fileContent))
+				useClause.Name = useClause.Alias
+			}
+
+			clauses = append(clauses, useClause)
+		}
+	}
+
+	return clauses
+}
+
+// UnusedUseClauses returns the class imports of a file which are never referenced outside the use statements
+func UnusedUseClauses(rootNode *tree_sitter.Node, fileContent []byte) []UseClause {
+	clauses := ParseUseClauses(rootNode, fileContent)
+	if len(clauses) == 0 {
+		return nil
+	}
+
+	used := make(map[string]bool)
+	collectReferencedNames(rootNode, fileContent, used)
+
+	var unused []UseClause
+	for _, clause := range clauses {
+		if !used[clause.Name] {
+			unused = append(unused, clause)
+		}
+	}
+
+	return unused
+}
+
+// collectReferencedNames records every name which may refer to an imported class outside the use statements.
+// Docblocks count as usage as well, as static analysis relies on the imports of types only mentioned there.
+func collectReferencedNames(node *tree_sitter.Node, content []byte, used map[string]bool) {
+	switch node.Kind() {
+	case "namespace_use_declaration", "variable_name":
+		return
+	case "comment":
+		text := node.Utf8Text(content)
+		if strings.HasPrefix(text, "/**") {
+			for _, name := range docBlockClassName.FindAllString(text, -1) {
+				addReferencedName(name, used)
+			}
+		}
+		return
+	case "name", "qualified_name":
+		addReferencedName(node.Utf8Text(content), used)
+		return
+	}
+
+	for i := uint(0); i < node.ChildCount(); i++ {
+		// Namespace, property and method names never refer to classes
+		if node.FieldNameForChild(uint32(i)) == "name" && isNonClassNameParent(node.Kind()) {
+			continue
+		}
+
+		if child := node.Child(i); child != nil {
+			collectReferencedNames(child, content, used)
+		}
+	}
+}
+
+// addReferencedName records the first segment of a class name, which is the part resolved through the imports,
+// fully qualified names don't use the imports at all
+func addReferencedName(name string, used map[string]bool) {
+	if strings.HasPrefix(name, "\\") {
+		return
+	}
+
+	first, _, _ := strings.Cut(name, "\\")
+	used[first] = true
+}
+
+func isNonClassNameParent(kind string) bool {
+	switch kind {
+	case "namespace_definition", "member_access_expression", "nullsafe_member_access_expression",
+		"member_call_expression", "nullsafe_member_call_expression", "method_declaration":
+		return true
+	}
+
+	return false
+}
+
+// Resolve resolves a class name as written inside the file to its fully qualified name
+func (f FileImports) Resolve(className string) string {
+	if strings.HasPrefix(className, "\\") {
+		return strings.TrimPrefix(className, "\\")
+	}
+
+	// Imports may refer to a namespace, e.g. "use Foo\Events;" with "Events\Checkout::PLACED"
+	if first, rest, found := strings.Cut(className, "\\"); found {
+		if fqcn, ok := f.Aliases[first]; ok {
+			return fqcn + "\\" + rest
+		}
+		if fqcn, ok := f.UseStatements[first]; ok {
+			return fqcn + "\\" + rest
+		}
+		if f.Namespace != "" {
+			return f.Namespace + "\\" + className
+		}
+		return className
+	}
+
+	return NewAliasResolver(f.Namespace, f.UseStatements, f.Aliases).ResolveType(className)
+}
+
+// ImportedClasses returns the FQCNs imported by the file mapped to the name they are available as,
+// covering plain, grouped and aliased imports. Classes imported plain and aliased map to the short name.
+func (f FileImports) ImportedClasses() map[string]string {
+	imported := make(map[string]string, len(f.UseStatements)+len(f.Aliases))
+
+	for alias, fqcn := range f.Aliases {
+		// Several aliases for one class are possible, pick one deterministically
+		if existing, ok := imported[fqcn]; !ok || alias < existing {
+			imported[fqcn] = alias
+		}
+	}
+
+	for shortName, fqcn := range f.UseStatements {
+		imported[fqcn] = shortName
+	}
+
+	return imported
+}
+
+// LocalName returns the name a class can be referenced with inside the file without adding an import,
+// which is the name it is imported as or its short name for classes of the same namespace
+func (f FileImports) LocalName(fqcn string) (string, bool) {
+	if localName, ok := f.ImportedClasses()[fqcn]; ok {
+		return localName, true
+	}
+
+	shortName := fqcn[strings.LastIndex(fqcn, "\\")+1:]
+
+	namespace := ""
+	if idx := strings.LastIndex(fqcn, "\\"); idx != -1 {
+		namespace = fqcn[:idx]
+	}
+
+	// Classes of the own namespace need no import, unless an import shadows the name
+	if namespace == f.Namespace && !f.IsNameTaken(shortName) {
+		return shortName, true
+	}
+
+	return "", false
+}
+
+// IsNameTaken reports whether a short name is already used by an import
+func (f FileImports) IsNameTaken(name string) bool {
+	_, isUse := f.UseStatements[name]
+	_, isAlias := f.Aliases[name]
+
+	return isUse || isAlias
+}
+
+// ResolveClassName resolves a class name as written inside the given file to its fully qualified name,
+// using the namespace and the use statements of that file
+func ResolveClassName(rootNode *tree_sitter.Node, fileContent []byte, className string) string {
+	return ParseFileImports(rootNode, fileContent).Resolve(className)
+}
@@ -0,0 +1,168 @@
+package php
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func parseFileImports(t *testing.T, code string) FileImports {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	return ParseFileImports(tree.RootNode(), []byte(code))
+}
+
+func TestFileImports_ImportedClasses(t *testing.T) {
+	imports := parseFileImports(t, `<?php
+
+namespace App\Controller;
+
+use Shopware\Core\Framework\Context;
+use \Symfony\Component\HttpFoundation\Request;
+use Doctrine\DBAL\Connection as DbConnection;
+use Shopware\Core\Framework\DataAbstractionLayer\{EntityRepository, Search\Criteria, Search\Filter\EqualsFilter as Equals};
+use Shopware\Core\Checkout\{Cart\Cart as CheckoutCart};
+use Shopware\Core\Framework\Uuid\Uuid, Shopware\Core\Framework\Uuid\Uuid as Id;
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape;
+use const Shopware\Core\Defaults\LIVE_VERSION;
+use Shopware\Core\Content\{Product\ProductEntity, function Product\helper};
+`)
+
+	assert.Equal(t, "App\\Controller", imports.Namespace)
+	assert.Equal(t, map[string]string{
+		"Shopware\\Core\\Framework\\Context":                                            "Context",
+		"Symfony\\Component\\HttpFoundation\\Request":                                   "Request",
+		"Doctrine\\DBAL\\Connection":                                                    "DbConnection",
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\EntityRepository":             "EntityRepository",
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria":             "Criteria",
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Filter\\EqualsFilter": "Equals",
+		"Shopware\\Core\\Checkout\\Cart\\Cart":                                          "CheckoutCart",
+		"Shopware\\Core\\Framework\\Uuid\\Uuid":                                         "Uuid",
+		"Shopware\\Core\\Content\\Product\\ProductEntity":                               "ProductEntity",
+	}, imports.ImportedClasses())
+}
+
+func TestFileImports_GlobalClasses(t *testing.T) {
+	imports := parseFileImports(t, `<?php
+
+namespace App\Entity;
+
+use Traversable;
+use \Countable;
+use Stringable as Printable;
+`)
+
+	assert.Equal(t, map[string]string{"Traversable": "Traversable", "Countable": "Countable"}, imports.UseStatements)
+	assert.Equal(t, map[string]string{"Printable": "Stringable"}, imports.Aliases)
+}
+
+func TestFileImports_LocalName(t *testing.T) {
+	imports := parseFileImports(t, `<?php
+
+namespace App\Search;
+
+use Shopware\Core\Framework\DataAbstractionLayer\{Search\Criteria as DalCriteria, EntityRepository};
+use App\Other\Builder;
+`)
+
+	tests := []struct {
+		fqcn      string
+		localName string
+		found     bool
+	}{
+		{fqcn: "Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria", localName: "DalCriteria", found: true},
+		{fqcn: "Shopware\\Core\\Framework\\DataAbstractionLayer\\EntityRepository", localName: "EntityRepository", found: true},
+		{fqcn: "App\\Search\\Criteria", localName: "Criteria", found: true},
+		{fqcn: "App\\Search\\Builder"},
+		{fqcn: "Shopware\\Core\\Framework\\Context"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fqcn, func(t *testing.T) {
+			localName, found := imports.LocalName(tt.fqcn)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.localName, localName)
+		})
+	}
+
+	assert.True(t, imports.IsNameTaken("Builder"))
+	assert.True(t, imports.IsNameTaken("DalCriteria"))
+	assert.False(t, imports.IsNameTaken("Criteria"))
+}
+
+func TestParseUseClauses(t *testing.T) {
+	code := `<?php
+
+namespace App;
+
+use Shopware\Core\Framework\Context, \Doctrine\DBAL\Connection as Db;
+use Shopware\Core\Framework\DataAbstractionLayer\{EntityRepository, Search\Criteria as DalCriteria, function helper};
+use const Shopware\Core\Defaults\LIVE_VERSION;
+use Stringable;
+`
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	clauses := ParseUseClauses(tree.RootNode(), []byte(code))
+	require.Len(t, clauses, 5)
+
+	var names, fqcns []string
+	for _, clause := range clauses {
+		names = append(names, clause.Name)
+		fqcns = append(fqcns, clause.FQCN)
+		assert.Equal(t, "namespace_use_clause", clause.Clause.Kind())
+		assert.Equal(t, "namespace_use_declaration", clause.Declaration.Kind())
+	}
+
+	assert.Equal(t, []string{"Context", "Db", "EntityRepository", "DalCriteria", "Stringable"}, names)
+	assert.Equal(t, []string{
+		"Shopware\\Core\\Framework\\Context",
+		"Doctrine\\DBAL\\Connection",
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\EntityRepository",
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\Search\\Criteria",
+		"Stringable",
+	}, fqcns)
+	assert.Equal(t, "use Stringable;", clauses[4].Declaration.Utf8Text([]byte(code)))
+}
+
+func TestParseAllUseClauses(t *testing.T) {
+	code := `<?php
+
+use Shopware\Core\Framework\Context;
+use function Shopware\Core\Framework\Adapter\Twig\sw_escape as escape;
+use const Shopware\Core\Defaults\{LIVE_VERSION, SYSTEM_LANGUAGE};
+use Shopware\Core\Content\{Product\ProductEntity, function Product\helper};
+`
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+
+	var imports []string
+	for _, clause := range ParseAllUseClauses(tree.RootNode(), []byte(code)) {
+		imports = append(imports, string(clause.Kind)+" "+clause.FQCN+" "+clause.Alias)
+	}
+
+	assert.Equal(t, []string{
+		"class Shopware\\Core\\Framework\\Context ",
+		"function Shopware\\Core\\Framework\\Adapter\\Twig\\sw_escape escape",
+		"const Shopware\\Core\\Defaults\\LIVE_VERSION ",
+		"const Shopware\\Core\\Defaults\\SYSTEM_LANGUAGE ",
+		"class Shopware\\Core\\Content\\Product\\ProductEntity ",
+		"function Shopware\\Core\\Content\\Product\\helper ",
+	}, imports)
+}
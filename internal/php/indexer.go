@@ -46,26 +46,47 @@ type PHPClass struct {
 	Line        int
 	Methods     map[string]PHPMethod
 	Properties  map[string]PHPProperty
+	Constants   map[string]PHPConstant
 	Parent      string   // The class this class extends from
 	Interfaces  []string // Interfaces this class implements
+	Traits      []string // Traits used in the class body, their members are not indexed
 	IsInterface bool     // Whether this is an interface or a class
+	IsAttribute bool     // Whether the class is declared with #[Attribute] and can be used as attribute
+	IsEnum      bool     // Whether this is an enum, its cases are constants with IsEnumCase set
 }
 
 type PHPMethod struct {
 	Name       string
 	Line       int
 	Visibility Visibility
+	IsStatic   bool
+	IsAbstract bool
+	IsFinal    bool
 	ReturnType PHPType
+	Parameters []PHPParameter
 	// Serialization helpers
 	ReturnTypeName string
 }
 
+// PHPParameter is a parameter of a method, the name is stored without the $ prefix
+type PHPParameter struct {
+	Name       string
+	Line       int
+	Type       PHPType
+	HasDefault bool
+	IsVariadic bool
+}
+
 // marshalMethod creates a serializable version of PHPMethod
 type marshalMethod struct {
-	Name           string     `msgpack:"name"`
-	Line           int        `msgpack:"line"`
-	Visibility     Visibility `msgpack:"visibility"`
-	ReturnTypeName string     `msgpack:"return_type_name,omitempty"`
+	Name           string         `msgpack:"name"`
+	Line           int            `msgpack:"line"`
+	Visibility     Visibility     `msgpack:"visibility"`
+	IsStatic       bool           `msgpack:"is_static,omitempty"`
+	IsAbstract     bool           `msgpack:"is_abstract,omitempty"`
+	IsFinal        bool           `msgpack:"is_final,omitempty"`
+	ReturnTypeName string         `msgpack:"return_type_name,omitempty"`
+	Parameters     []PHPParameter `msgpack:"parameters,omitempty"`
 }
 
 // MarshalMsgpack implements msgpack.Marshaler interface
@@ -74,6 +95,10 @@ func (m PHPMethod) MarshalMsgpack() ([]byte, error) {
 		Name:       m.Name,
 		Line:       m.Line,
 		Visibility: m.Visibility,
+		IsStatic:   m.IsStatic,
+		IsAbstract: m.IsAbstract,
+		IsFinal:    m.IsFinal,
+		Parameters: m.Parameters,
 	}
 
 	if m.ReturnType != nil {
@@ -93,6 +118,10 @@ func (m *PHPMethod) UnmarshalMsgpack(data []byte) error {
 	m.Name = mm.Name
 	m.Line = mm.Line
 	m.Visibility = mm.Visibility
+	m.IsStatic = mm.IsStatic
+	m.IsAbstract = mm.IsAbstract
+	m.IsFinal = mm.IsFinal
+	m.Parameters = mm.Parameters
 
 	// Reconstruct the return type from the type name
 	if mm.ReturnTypeName != "" {
@@ -102,6 +131,23 @@ func (m *PHPMethod) UnmarshalMsgpack(data []byte) error {
 	return nil
 }
 
+// Modifiers returns the modifiers of the method in declaration order, e.g. "final public static"
+func (m PHPMethod) Modifiers() string {
+	modifiers := m.Visibility.String()
+
+	if m.IsAbstract {
+		modifiers = "abstract " + modifiers
+	} else if m.IsFinal {
+		modifiers = "final " + modifiers
+	}
+
+	if m.IsStatic {
+		modifiers += " static"
+	}
+
+	return modifiers
+}
+
 // Visibility constants for PHP properties and methods
 const (
 	Public Visibility = iota
@@ -112,6 +158,17 @@ const (
 // Visibility represents the visibility level of a PHP element
 type Visibility int
 
+func (v Visibility) String() string {
+	switch v {
+	case Protected:
+		return "protected"
+	case Private:
+		return "private"
+	default:
+		return "public"
+	}
+}
+
 type PHPProperty struct {
 	Name       string
 	Line       int
@@ -163,8 +220,80 @@ func (p *PHPProperty) UnmarshalMsgpack(data []byte) error {
 	return nil
 }
 
+// marshalParameter creates a serializable version of PHPParameter
+type marshalParameter struct {
+	Name       string `msgpack:"name"`
+	Line       int    `msgpack:"line,omitempty"`
+	TypeName   string `msgpack:"type_name,omitempty"`
+	HasDefault bool   `msgpack:"has_default,omitempty"`
+	IsVariadic bool   `msgpack:"is_variadic,omitempty"`
+}
+
+// MarshalMsgpack implements msgpack.Marshaler interface
+func (p PHPParameter) MarshalMsgpack() ([]byte, error) {
+	mp := marshalParameter{
+		Name:       p.Name,
+		Line:       p.Line,
+		HasDefault: p.HasDefault,
+		IsVariadic: p.IsVariadic,
+	}
+
+	if p.Type != nil {
+		mp.TypeName = p.Type.Name()
+	}
+
+	return msgpack.Marshal(mp)
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler interface
+func (p *PHPParameter) UnmarshalMsgpack(data []byte) error {
+	var mp marshalParameter
+	if err := msgpack.Unmarshal(data, &mp); err != nil {
+		return err
+	}
+
+	p.Name = mp.Name
+	p.Line = mp.Line
+	p.HasDefault = mp.HasDefault
+	p.IsVariadic = mp.IsVariadic
+
+	if mp.TypeName != "" {
+		p.Type = NewPHPType(mp.TypeName)
+	}
+
+	return nil
+}
+
+// Label returns the parameter as declared, e.g. "?string $name = ..." or "int ...$ids"
+func (p PHPParameter) Label() string {
+	label := "$" + p.Name
+	if p.IsVariadic {
+		label = "..." + label
+	}
+
+	if p.Type != nil {
+		label = p.Type.Name() + " " + label
+	}
+
+	if p.HasDefault {
+		label += " = ..."
+	}
+
+	return label
+}
+
+type PHPConstant struct {
+	Name       string
+	Line       int
+	Visibility Visibility
+	Value      string // String literals without quotes, other expressions as written
+	IsEnumCase bool
+}
+
 type PHPIndex struct {
 	dataIndexer *indexer.DataIndexer[PHPClass]
+	// attributeIndex maps the attribute classes to the file declaring them
+	attributeIndex *indexer.DataIndexer[string]
 }
 
 func NewPHPIndex(configDir string) (*PHPIndex, error) {
@@ -173,8 +302,14 @@ func NewPHPIndex(configDir string) (*PHPIndex, error) {
 		return nil, fmt.Errorf("failed to create data indexer: %w", err)
 	}
 
+	attributeIndex, err := indexer.NewDataIndexer[string](filepath.Join(configDir, "php_attribute.db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attribute indexer: %w", err)
+	}
+
 	idx := &PHPIndex{
-		dataIndexer: dataIndexer,
+		dataIndexer:    dataIndexer,
+		attributeIndex: attributeIndex,
 	}
 
 	return idx, nil
@@ -184,19 +319,36 @@ func (idx *PHPIndex) ID() string {
 	return "php.index"
 }
 
+func (idx *PHPIndex) FileExtensions() []string {
+	return []string{".php"}
+}
+
 func (idx *PHPIndex) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	classes := GetClassesOfFileWithParser(path, node, fileContent)
 
 	batchSave := make(map[string]map[string]PHPClass)
+	// The file is always listed, so a removed #[Attribute] drops the class from the attribute index
+	attributeBatchSave := map[string]map[string]string{path: {}}
 
 	for _, class := range classes {
 		if _, ok := batchSave[class.Path]; !ok {
 			batchSave[class.Path] = make(map[string]PHPClass)
 		}
 		batchSave[class.Path][class.Name] = class
+
+		if class.IsAttribute {
+			if _, ok := attributeBatchSave[class.Path]; !ok {
+				attributeBatchSave[class.Path] = make(map[string]string)
+			}
+			attributeBatchSave[class.Path][class.Name] = class.Path
+		}
+	}
+
+	if err := idx.dataIndexer.BatchSaveItems(batchSave); err != nil {
+		return err
 	}
 
-	return idx.dataIndexer.BatchSaveItems(batchSave)
+	return idx.attributeIndex.BatchSaveItems(attributeBatchSave)
 }
 
 func (idx *PHPIndex) GetClassesOfFile(path string) map[string]PHPClass {
@@ -315,6 +467,7 @@ func (idx *PHPIndex) GetClasses() map[string]PHPClass {
 // Currently supports:
 // - $this->method() expressions
 // - $this->property expressions
+// - variables assigned in the enclosing function, see TypeOfExpression
 func (idx *PHPIndex) GetTypeOfNode(ctx context.Context, node *tree_sitter.Node, fileContent []byte) PHPType {
 	if node == nil {
 		return nil
@@ -334,8 +487,8 @@ func (idx *PHPIndex) GetTypeOfNode(ctx context.Context, node *tree_sitter.Node,
 		return idx.handleMemberCallExpression(node, fileContent, phpCtx.InsideClass.Name)
 	}
 
-	// Default to mixed type if we can't determine a specific type
-	return NewMixedType()
+	// Variables, properties and calls are typed by the assignments in the enclosing function
+	return idx.TypeOfExpression(node, fileContent)
 }
 
 // handleMemberCallExpression processes $this->method() calls and returns the return type of that method
@@ -370,15 +523,40 @@ func (idx *PHPIndex) handleMemberCallExpression(node *tree_sitter.Node, fileCont
 }
 
 func (idx *PHPIndex) RemovedFiles(paths []string) error {
-	return idx.dataIndexer.BatchDeleteByFilePaths(paths)
+	if err := idx.dataIndexer.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+
+	return idx.attributeIndex.BatchDeleteByFilePaths(paths)
 }
 
 func (idx *PHPIndex) Close() error {
-	return idx.dataIndexer.Close()
+	if err := idx.dataIndexer.Close(); err != nil {
+		return err
+	}
+
+	return idx.attributeIndex.Close()
 }
 
 func (idx *PHPIndex) Clear() error {
-	return idx.dataIndexer.Clear()
+	if err := idx.dataIndexer.Clear(); err != nil {
+		return err
+	}
+
+	return idx.attributeIndex.Clear()
+}
+
+func (idx *PHPIndex) BeginBatch() error {
+	return indexer.BeginBatches(idx.dataIndexer, idx.attributeIndex)
+}
+
+func (idx *PHPIndex) Commit() error {
+	return indexer.CommitBatches(idx.dataIndexer, idx.attributeIndex)
+}
+
+// Count returns the number of indexed classes
+func (idx *PHPIndex) Count() (int, error) {
+	return idx.dataIndexer.CountKeys()
 }
 
 func (idx *PHPIndex) GetClass(className string) *PHPClass {
@@ -404,3 +582,26 @@ func (idx *PHPIndex) GetClassNames() []string {
 
 	return keys
 }
+
+// SearchClassesByShortName returns the classes whose name without namespace starts with the prefix,
+// ignoring case. At most limit classes are returned, sorted by name.
+func (idx *PHPIndex) SearchClassesByShortName(prefix string, limit int) []string {
+	classNames, err := idx.dataIndexer.SearchKeysBySegmentPrefix("\\", prefix, limit)
+	if err != nil {
+		log.Printf("Error searching classes: %v", err)
+		return nil
+	}
+
+	return classNames
+}
+
+// GetAttributeClassNames returns the classes declared with #[Attribute] sorted by name
+func (idx *PHPIndex) GetAttributeClassNames() []string {
+	keys, err := idx.attributeIndex.GetAllKeysSorted()
+	if err != nil {
+		log.Printf("Error retrieving attribute classes: %v", err)
+		return nil
+	}
+
+	return keys
+}
@@ -5,6 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
 )
 
 func TestClassInheritance(t *testing.T) {
@@ -24,12 +27,9 @@ func TestClassInheritance(t *testing.T) {
 	// Check that parent is correctly identified
 	assert.Equal(t, "App\\BaseClass", product.Parent, "Class should extend App\\BaseClass")
 
-	// Check that interfaces are correctly identified
-	// NOTE: Currently the AliasResolver implementation treats global interfaces
-	// imported with 'use' statements as being in the current namespace.
-	// This can be improved in the future to properly recognize global PHP interfaces.
-	assert.Contains(t, product.Interfaces, "App\\Entity\\Traversable", "Class should implement Traversable")
-	assert.Contains(t, product.Interfaces, "App\\Entity\\Countable", "Class should implement Countable")
+	// Check that interfaces are correctly identified, global interfaces imported with 'use' keep their name
+	assert.Contains(t, product.Interfaces, "Traversable", "Class should implement Traversable")
+	assert.Contains(t, product.Interfaces, "Countable", "Class should implement Countable")
 	assert.Len(t, product.Interfaces, 2, "Class should implement exactly 2 interfaces")
 
 	// Verify other class aspects are still correctly indexed
@@ -43,3 +43,44 @@ func TestClassInheritance(t *testing.T) {
 	assert.Contains(t, product.Properties, "id", "Class should have id property")
 	assert.Contains(t, product.Properties, "name", "Class should have name property")
 }
+
+func TestClassTraitUses(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	content := []byte(`<?php
+
+namespace App\Entity;
+
+use Shopware\Core\Framework\DataAbstractionLayer\EntityCustomFieldsTrait;
+
+class Product
+{
+    use EntityCustomFieldsTrait, \App\Traits\IdTrait {
+        IdTrait::getId insteadof EntityCustomFieldsTrait;
+    }
+    use SortTrait;
+}
+
+class Category
+{
+}
+`)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	require.NoError(t, idx.Index("/project/src/Entity/Product.php", tree.RootNode(), content))
+
+	assert.Equal(t, []string{
+		"Shopware\\Core\\Framework\\DataAbstractionLayer\\EntityCustomFieldsTrait",
+		"App\\Traits\\IdTrait",
+		"App\\Entity\\SortTrait",
+	}, idx.GetClass("App\\Entity\\Product").Traits)
+	assert.Empty(t, idx.GetClass("App\\Entity\\Category").Traits)
+}
@@ -25,8 +25,7 @@ func TestInterfaceIndexing(t *testing.T) {
 	assert.True(t, customInterface.IsInterface, "CustomInterface should be identified as an interface")
 
 	// Check that extended interfaces are correctly identified
-	// Note: Current namespace resolution results in local namespace prefixing for Traversable
-	assert.Contains(t, customInterface.Interfaces, "App\\Interfaces\\Traversable", "Interface should extend Traversable")
+	assert.Contains(t, customInterface.Interfaces, "Traversable", "Interface should extend Traversable")
 	assert.Contains(t, customInterface.Interfaces, "LoggerInterface", "Interface should extend LoggerInterface")
 	assert.Len(t, customInterface.Interfaces, 2, "Interface should extend exactly 2 interfaces")
 
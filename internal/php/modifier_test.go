@@ -0,0 +1,60 @@
+package php
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodModifiers(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	classes := idx.GetClassesOfFile(filepath.Join("testdata", "modifiers.php"))
+	factory, ok := classes["App\\Service\\Factory"]
+	require.True(t, ok)
+
+	tests := []struct {
+		method     string
+		isStatic   bool
+		isAbstract bool
+		isFinal    bool
+		modifiers  string
+	}{
+		{method: "create", isStatic: true, modifiers: "public static"},
+		{method: "register", isStatic: true, isFinal: true, modifiers: "final protected static"},
+		{method: "build", isAbstract: true, modifiers: "abstract public"},
+		{method: "name", isFinal: true, modifiers: "final public"},
+		{method: "reset", modifiers: "private"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			method, ok := factory.Methods[tt.method]
+			require.True(t, ok)
+
+			assert.Equal(t, tt.isStatic, method.IsStatic)
+			assert.Equal(t, tt.isAbstract, method.IsAbstract)
+			assert.Equal(t, tt.isFinal, method.IsFinal)
+			assert.Equal(t, tt.modifiers, method.Modifiers())
+		})
+	}
+}
+
+func TestMethodModifiers_Msgpack(t *testing.T) {
+	method := PHPMethod{Name: "register", Line: 11, Visibility: Protected, IsStatic: true, IsFinal: true, ReturnType: NewVoidType()}
+
+	data, err := method.MarshalMsgpack()
+	require.NoError(t, err)
+
+	var decoded PHPMethod
+	require.NoError(t, decoded.UnmarshalMsgpack(data))
+
+	assert.True(t, decoded.IsStatic)
+	assert.True(t, decoded.IsFinal)
+	assert.False(t, decoded.IsAbstract)
+	assert.Equal(t, "final protected static", decoded.Modifiers())
+}
@@ -0,0 +1,76 @@
+package php
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodParameters(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	classes := idx.GetClassesOfFile(filepath.Join("testdata", "parameters.php"))
+	service, ok := classes["App\\Service\\ParameterService"]
+	require.True(t, ok)
+
+	tests := []struct {
+		method string
+		labels []string
+	}{
+		{method: "__construct", labels: []string{
+			"Shopware\\Core\\Framework\\DataAbstractionLayer\\EntityRepository $productRepository",
+			"Shopware\\Core\\Framework\\Context|null $context = ...",
+		}},
+		{method: "none"},
+		{method: "load", labels: []string{
+			"string $id",
+			"Shopware\\Core\\Framework\\Context $context",
+			"bool $withVariants = ...",
+			"int|null $limit = ...",
+		}},
+		{method: "collect", labels: []string{"int $limit", "string ...$ids"}},
+		{method: "legacy", labels: []string{"$value", "$reference", "$options = ..."}},
+		{method: "create", labels: []string{"self $other", "DateTimeInterface $date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			method, ok := service.Methods[tt.method]
+			require.True(t, ok)
+
+			var labels []string
+			for _, parameter := range method.Parameters {
+				labels = append(labels, parameter.Label())
+			}
+			assert.Equal(t, tt.labels, labels)
+		})
+	}
+
+	collect := service.Methods["collect"]
+	assert.False(t, collect.Parameters[0].IsVariadic)
+	assert.True(t, collect.Parameters[1].IsVariadic)
+	assert.Nil(t, service.Methods["legacy"].Parameters[0].Type)
+}
+
+func TestMethodParameters_Msgpack(t *testing.T) {
+	method := PHPMethod{Name: "load", Parameters: []PHPParameter{
+		{Name: "id", Type: NewPHPType("string")},
+		{Name: "limit", Type: NewPHPType("?int"), HasDefault: true},
+		{Name: "ids", IsVariadic: true},
+	}}
+
+	data, err := method.MarshalMsgpack()
+	require.NoError(t, err)
+
+	var decoded PHPMethod
+	require.NoError(t, decoded.UnmarshalMsgpack(data))
+
+	require.Len(t, decoded.Parameters, 3)
+	assert.Equal(t, "string $id", decoded.Parameters[0].Label())
+	assert.Equal(t, "int|null $limit = ...", decoded.Parameters[1].Label())
+	assert.Equal(t, "...$ids", decoded.Parameters[2].Label())
+}
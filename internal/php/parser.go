@@ -52,7 +52,7 @@ func clearTypeMap(m map[string]PHPType) {
 func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent []byte) map[string]PHPClass {
 	classes := make(map[string]PHPClass)
 
-	if !bytes.Contains(fileContent, []byte("class")) && !bytes.Contains(fileContent, []byte("interface")) {
+	if !bytes.Contains(fileContent, []byte("class")) && !bytes.Contains(fileContent, []byte("interface")) && !bytes.Contains(fileContent, []byte("enum")) {
 		return classes
 	}
 
@@ -97,107 +97,10 @@ func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent
 
 			// Process namespace use declarations
 			if node.Kind() == "namespace_use_declaration" {
-				// Check if this is a group use statement with a namespace prefix and a group
-				namespaceNameNode := findChildByKind(node, "namespace_name")
-				namespaceUseGroupNode := findChildByKind(node, "namespace_use_group")
-
-				if namespaceNameNode != nil && namespaceUseGroupNode != nil {
-					// This is a group use statement (e.g., use Symfony\Component\{HttpFoundation\Request, ...})
-					baseNamespace := string(namespaceNameNode.Utf8Text(fileContent))
-
-					// Process each use clause in the group
-					for i := uint(0); i < namespaceUseGroupNode.NamedChildCount(); i++ {
-						useClause := namespaceUseGroupNode.NamedChild(i)
-						if useClause == nil || useClause.Kind() != "namespace_use_clause" {
-							continue
-						}
-
-						// Get the qualified name
-						qualifiedName := findChildByKind(useClause, "qualified_name")
-						if qualifiedName != nil {
-							// Get the relative path
-							relativePath := string(qualifiedName.Utf8Text(fileContent))
-
-							// Construct the full path
-							fullPath := baseNamespace + "\\" + relativePath
-
-							// Get the class name (last part of the path)
-							classNameNode := qualifiedName.NamedChild(qualifiedName.NamedChildCount() - 1)
-							if classNameNode != nil && classNameNode.Kind() == "name" {
-								className := string(classNameNode.Utf8Text(fileContent))
-
-								// Check if there's an alias
-								aliasNode := findChildByKind(useClause, "name")
-								if aliasNode != nil && aliasNode != classNameNode {
-									// This is an alias (e.g., use Symfony\Component\{HttpFoundation\Request as Req})
-									aliasName := string(aliasNode.Utf8Text(fileContent))
-									aliases[aliasName] = fullPath
-								} else {
-									// No alias, use the class name
-									useStatements[className] = fullPath
-								}
-							}
-						} else {
-							// Handle direct alias format (e.g., use Doctrine\DBAL\{Connection as DbConnection})
-							// In this case, we have two name nodes directly under namespace_use_clause
-							if useClause.NamedChildCount() >= 2 {
-								classNameNode := useClause.NamedChild(0)
-								aliasNode := useClause.NamedChild(1)
-
-								if classNameNode != nil && classNameNode.Kind() == "name" &&
-									aliasNode != nil && aliasNode.Kind() == "name" {
-									className := string(classNameNode.Utf8Text(fileContent))
-									aliasName := string(aliasNode.Utf8Text(fileContent))
-
-									// Construct the full path
-									fullPath := baseNamespace + "\\" + className
-
-									// Add to aliases map
-									aliases[aliasName] = fullPath
-								}
-							}
-						}
-					}
-				} else {
-					// Process regular use statements (non-group)
-					for i := uint(0); i < node.NamedChildCount(); i++ {
-						useClause := node.NamedChild(i)
-						if useClause != nil && useClause.Kind() == "namespace_use_clause" {
-							// Handle regular use statements
-							qualifiedName := findChildByKind(useClause, "qualified_name")
-							if qualifiedName != nil {
-								// Get the full namespace path
-								fullPath := string(qualifiedName.Utf8Text(fileContent))
-
-								// Get the class name (last part of the path)
-								classNameNode := qualifiedName.NamedChild(qualifiedName.NamedChildCount() - 1)
-								if classNameNode != nil && classNameNode.Kind() == "name" {
-									className := string(classNameNode.Utf8Text(fileContent))
-
-									// Check if there's an alias
-									aliasNode := findChildByKind(useClause, "name")
-									if aliasNode != nil && aliasNode != classNameNode {
-										// This is an alias (e.g., use Doctrine\DBAL\Connection as DbConnection)
-										aliasName := string(aliasNode.Utf8Text(fileContent))
-										aliases[aliasName] = fullPath
-									} else {
-										// No alias, use the class name
-										// Special handling for global interfaces (no namespace separator)
-										if !strings.Contains(fullPath, "\\") {
-											// This is a global interface/class without namespace
-											useStatements[className] = className
-										} else {
-											useStatements[className] = fullPath
-										}
-									}
-								}
-							}
-						}
-					}
-				}
+				collectUseStatements(node, fileContent, useStatements, aliases)
 			}
 
-			if node.Kind() == "class_declaration" || node.Kind() == "interface_declaration" {
+			if node.Kind() == "class_declaration" || node.Kind() == "interface_declaration" || node.Kind() == "enum_declaration" {
 				classNameNode := treesitterhelper.GetFirstNodeOfKind(node, "name")
 
 				// Determine if this is an interface or a class
@@ -218,6 +121,7 @@ func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent
 						Line:        int(classNameNode.Range().StartPoint.Row) + 1,
 						Methods:     make(map[string]PHPMethod),
 						Properties:  make(map[string]PHPProperty),
+						Constants:   make(map[string]PHPConstant),
 						Interfaces:  []string{},  // Initialize empty interfaces slice
 						IsInterface: isInterface, // Set based on whether this is an interface or class
 					}
@@ -228,6 +132,9 @@ func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent
 						lastNamespace = currentNamespace
 					}
 
+					phpClass.IsAttribute = node.Kind() == "class_declaration" && hasAttribute(node, fileContent, aliasResolver, "Attribute")
+					phpClass.IsEnum = node.Kind() == "enum_declaration"
+
 					// Handle inheritance differently based on whether this is a class or interface
 					if isInterface {
 						// For interfaces, the 'base_clause' contains interfaces that this interface extends
@@ -314,8 +221,10 @@ func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent
 						}
 					}
 
-					// Extract methods and properties from the class (pass shared typeCache)
-					phpClass.Methods, phpClass.Properties = extractMembersFromClass(node, fileContent, aliasResolver, typeCache)
+					phpClass.Traits = extractTraitUses(node, fileContent, aliasResolver)
+
+					// Extract methods, properties and constants from the class (pass shared typeCache)
+					phpClass.Methods, phpClass.Properties, phpClass.Constants = extractMembersFromClass(node, fileContent, aliasResolver, typeCache)
 
 					classes[className] = phpClass
 				}
@@ -330,14 +239,216 @@ func GetClassesOfFileWithParser(path string, node *tree_sitter.Node, fileContent
 	return classes
 }
 
-func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver, typeCache map[string]PHPType) (map[string]PHPMethod, map[string]PHPProperty) {
+// collectUseStatements adds the imports of a namespace_use_declaration to the given maps,
+// plain imports are keyed by their class name and aliased imports by their alias
+func collectUseStatements(node *tree_sitter.Node, fileContent []byte, useStatements, aliases map[string]string) {
+	// use function Foo\bar; and use const Foo\BAR; don't import classes
+	if isFunctionOrConstImport(node) {
+		return
+	}
+
+	// Check if this is a group use statement with a namespace prefix and a group
+	namespaceNameNode := findChildByKind(node, "namespace_name")
+	namespaceUseGroupNode := findChildByKind(node, "namespace_use_group")
+
+	if namespaceNameNode != nil && namespaceUseGroupNode != nil {
+		// This is a group use statement (e.g., use Symfony\Component\{HttpFoundation\Request, ...})
+		baseNamespace := string(namespaceNameNode.Utf8Text(fileContent))
+
+		// Process each use clause in the group
+		for i := uint(0); i < namespaceUseGroupNode.NamedChildCount(); i++ {
+			useClause := namespaceUseGroupNode.NamedChild(i)
+			if useClause == nil || useClause.Kind() != "namespace_use_clause" || isFunctionOrConstImport(useClause) {
+				continue
+			}
+
+			// Get the qualified name
+			qualifiedName := findChildByKind(useClause, "qualified_name")
+			if qualifiedName != nil {
+				// Get the relative path
+				relativePath := string(qualifiedName.Utf8Text(fileContent))
+
+				// Construct the full path
+				fullPath := baseNamespace + "\\" + relativePath
+
+				// Get the class name (last part of the path)
+				classNameNode := qualifiedName.NamedChild(qualifiedName.NamedChildCount() - 1)
+				if classNameNode != nil && classNameNode.Kind() == "name" {
+					className := string(classNameNode.Utf8Text(fileContent))
+
+					// Check if there's an alias
+					aliasNode := findChildByKind(useClause, "name")
+					if aliasNode != nil && aliasNode != classNameNode {
+						// This is an alias (e.g., use Symfony\Component\{HttpFoundation\Request as Req})
+						aliasName := string(aliasNode.Utf8Text(fileContent))
+						aliases[aliasName] = fullPath
+					} else {
+						// No alias, use the class name
+						useStatements[className] = fullPath
+					}
+				}
+			} else {
+				// Handle direct alias format (e.g., use Doctrine\DBAL\{Connection as DbConnection})
+				// In this case, we have two name nodes directly under namespace_use_clause
+				if useClause.NamedChildCount() >= 2 {
+					classNameNode := useClause.NamedChild(0)
+					aliasNode := useClause.NamedChild(1)
+
+					if classNameNode != nil && classNameNode.Kind() == "name" &&
+						aliasNode != nil && aliasNode.Kind() == "name" {
+						className := string(classNameNode.Utf8Text(fileContent))
+						aliasName := string(aliasNode.Utf8Text(fileContent))
+
+						// Construct the full path
+						fullPath := baseNamespace + "\\" + className
+
+						// Add to aliases map
+						aliases[aliasName] = fullPath
+					}
+				} else if classNameNode := useClause.NamedChild(0); classNameNode != nil && classNameNode.Kind() == "name" {
+					// Plain class directly inside the group (e.g., use Shopware\Core\Framework\{Context})
+					className := string(classNameNode.Utf8Text(fileContent))
+					useStatements[className] = baseNamespace + "\\" + className
+				}
+			}
+		}
+	} else {
+		// Process regular use statements (non-group)
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			useClause := node.NamedChild(i)
+			if useClause != nil && useClause.Kind() == "namespace_use_clause" && !isFunctionOrConstImport(useClause) {
+				// Handle regular use statements
+				qualifiedName := findChildByKind(useClause, "qualified_name")
+				if qualifiedName != nil {
+					// Get the full namespace path
+					fullPath := strings.TrimPrefix(string(qualifiedName.Utf8Text(fileContent)), "\\")
+
+					// Get the class name (last part of the path)
+					classNameNode := qualifiedName.NamedChild(qualifiedName.NamedChildCount() - 1)
+					if classNameNode != nil && classNameNode.Kind() == "name" {
+						className := string(classNameNode.Utf8Text(fileContent))
+
+						// Check if there's an alias
+						aliasNode := findChildByKind(useClause, "name")
+						if aliasNode != nil && aliasNode != classNameNode {
+							// This is an alias (e.g., use Doctrine\DBAL\Connection as DbConnection)
+							aliasName := string(aliasNode.Utf8Text(fileContent))
+							aliases[aliasName] = fullPath
+						} else {
+							// No alias, use the class name
+							// Special handling for global interfaces (no namespace separator)
+							if !strings.Contains(fullPath, "\\") {
+								// This is a global interface/class without namespace
+								useStatements[className] = className
+							} else {
+								useStatements[className] = fullPath
+							}
+						}
+					}
+				} else if classNameNode := useClause.NamedChild(0); classNameNode != nil && classNameNode.Kind() == "name" {
+					// Global class without namespace (e.g., use Attribute;)
+					className := string(classNameNode.Utf8Text(fileContent))
+					if aliasNode := useClause.ChildByFieldName("alias"); aliasNode != nil {
+						aliases[string(aliasNode.Utf8Text(fileContent))] = className
+					} else {
+						useStatements[className] = className
+					}
+				}
+			}
+		}
+	}
+}
+
+// hasAttribute checks whether a declaration carries an attribute of the given class
+func hasAttribute(node *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver, className string) bool {
+	attributeList := node.ChildByFieldName("attributes")
+	if attributeList == nil {
+		return false
+	}
+
+	for i := uint(0); i < attributeList.NamedChildCount(); i++ {
+		group := attributeList.NamedChild(i)
+		for j := uint(0); j < group.NamedChildCount(); j++ {
+			attribute := group.NamedChild(j)
+			if attribute.Kind() != "attribute" || attribute.NamedChildCount() == 0 {
+				continue
+			}
+
+			name := string(attribute.NamedChild(0).Utf8Text(fileContent))
+			if strings.HasPrefix(name, "\\") {
+				name = strings.TrimPrefix(name, "\\")
+			} else {
+				name = aliasResolver.ResolveType(name)
+			}
+
+			if name == className {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isFunctionOrConstImport checks for the function or const keyword of a use declaration or clause
+func isFunctionOrConstImport(node *tree_sitter.Node) bool {
+	return useKindOf(node) != UseKindClass
+}
+
+// useKindOf returns the kind of a use declaration or clause by its function or const keyword
+func useKindOf(node *tree_sitter.Node) UseKind {
+	for i := uint(0); i < node.ChildCount(); i++ {
+		switch node.Child(i).Kind() {
+		case "function":
+			return UseKindFunction
+		case "const":
+			return UseKindConst
+		}
+	}
+
+	return UseKindClass
+}
+
+// extractTraitUses returns the traits used in the class body (use Foo, Bar;)
+func extractTraitUses(node *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver) []string {
+	classBodyNode := treesitterhelper.GetFirstNodeOfKind(node, "declaration_list")
+	if classBodyNode == nil {
+		classBodyNode = treesitterhelper.GetFirstNodeOfKind(node, "enum_declaration_list")
+	}
+	if classBodyNode == nil {
+		return nil
+	}
+
+	var traits []string
+	for i := uint(0); i < classBodyNode.NamedChildCount(); i++ {
+		useNode := classBodyNode.NamedChild(i)
+		if useNode == nil || useNode.Kind() != "use_declaration" {
+			continue
+		}
+
+		// Conflict resolutions in the use_list are not traits
+		for j := uint(0); j < useNode.NamedChildCount(); j++ {
+			if traitNode := useNode.NamedChild(j); traitNode.Kind() == "name" || traitNode.Kind() == "qualified_name" {
+				traits = append(traits, strings.TrimPrefix(aliasResolver.ResolveType(string(traitNode.Utf8Text(fileContent))), "\\"))
+			}
+		}
+	}
+
+	return traits
+}
+
+func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver, typeCache map[string]PHPType) (map[string]PHPMethod, map[string]PHPProperty, map[string]PHPConstant) {
 	methods := make(map[string]PHPMethod)
 	properties := make(map[string]PHPProperty)
+	constants := make(map[string]PHPConstant)
 
-	// Find the class body node
+	// Find the class body node, enums have their own body kind
 	classBodyNode := treesitterhelper.GetFirstNodeOfKind(node, "declaration_list")
 	if classBodyNode == nil {
-		return methods, properties
+		classBodyNode = treesitterhelper.GetFirstNodeOfKind(node, "enum_declaration_list")
+	}
+	if classBodyNode == nil {
+		return methods, properties, constants
 	}
 
 	// Iterate through all children of the class body
@@ -347,6 +458,60 @@ func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasRe
 			continue
 		}
 
+		// Constant declarations can define multiple constants at once (const A = 1, B = 2;)
+		if child.Kind() == "const_declaration" {
+			visibility := Public
+			if modifier := findDirectChildOfKind(child, "visibility_modifier"); modifier != nil {
+				switch string(modifier.Utf8Text(fileContent)) {
+				case "private":
+					visibility = Private
+				case "protected":
+					visibility = Protected
+				}
+			}
+
+			for j := uint(0); j < child.NamedChildCount(); j++ {
+				constElement := child.NamedChild(j)
+				if constElement == nil || constElement.Kind() != "const_element" {
+					continue
+				}
+
+				nameNode := findDirectChildOfKind(constElement, "name")
+				if nameNode == nil {
+					continue
+				}
+
+				constName := string(nameNode.Utf8Text(fileContent))
+				constants[constName] = PHPConstant{
+					Name:       constName,
+					Line:       int(nameNode.Range().StartPoint.Row) + 1,
+					Visibility: visibility,
+					Value:      constantValue(constElement.NamedChild(constElement.NamedChildCount()-1), fileContent),
+				}
+			}
+
+			continue
+		}
+
+		// Enum cases are accessed like constants (Status::Open)
+		if child.Kind() == "enum_case" {
+			nameNode := child.ChildByFieldName("name")
+			if nameNode == nil {
+				continue
+			}
+
+			caseName := string(nameNode.Utf8Text(fileContent))
+			constants[caseName] = PHPConstant{
+				Name:       caseName,
+				Line:       int(nameNode.Range().StartPoint.Row) + 1,
+				Visibility: Public,
+				Value:      constantValue(child.ChildByFieldName("value"), fileContent),
+				IsEnumCase: true,
+			}
+
+			continue
+		}
+
 		// Check if the child is a property declaration
 		if child.Kind() == "property_declaration" {
 			visibility := Public // Default visibility
@@ -405,6 +570,7 @@ func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasRe
 
 			methodName := string(methodNameNode.Utf8Text(fileContent))
 			visibility := Public
+			isStatic, isAbstract, isFinal := false, false, false
 
 			for k := uint(0); k < child.NamedChildCount(); k++ {
 				modifier := child.NamedChild(k)
@@ -420,6 +586,12 @@ func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasRe
 					visibility = Protected
 				case "public":
 					visibility = Public
+				case "static":
+					isStatic = true
+				case "abstract":
+					isAbstract = true
+				case "final":
+					isFinal = true
 				}
 			}
 
@@ -429,7 +601,11 @@ func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasRe
 				Name:       methodName,
 				Line:       int(methodNameNode.Range().StartPoint.Row) + 1,
 				Visibility: visibility,
+				IsStatic:   isStatic,
+				IsAbstract: isAbstract,
+				IsFinal:    isFinal,
 				ReturnType: returnType,
+				Parameters: extractParameters(child, fileContent, aliasResolver, typeCache),
 			}
 
 			if methodName == "__construct" {
@@ -483,7 +659,29 @@ func extractMembersFromClass(node *tree_sitter.Node, fileContent []byte, aliasRe
 		}
 	}
 
-	return methods, properties
+	return methods, properties, constants
+}
+
+// constantValue returns the value expression of a constant or enum case, string literals are
+// returned without quotes and any other expression as written in the source
+func constantValue(valueNode *tree_sitter.Node, fileContent []byte) string {
+	if valueNode == nil || valueNode.Kind() == "name" {
+		return ""
+	}
+
+	// Double quoted strings are only taken over when they don't interpolate variables
+	isPlainString := valueNode.Kind() == "string" ||
+		(valueNode.Kind() == "encapsed_string" && valueNode.NamedChildCount() <= 1)
+
+	if isPlainString {
+		if content := findDirectChildOfKind(valueNode, "string_content"); content != nil {
+			return string(content.Utf8Text(fileContent))
+		}
+
+		return ""
+	}
+
+	return string(valueNode.Utf8Text(fileContent))
 }
 
 func resolveTypeFromDeclaration(node *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver, typeCache map[string]PHPType, fallback PHPType) PHPType {
@@ -493,18 +691,32 @@ func resolveTypeFromDeclaration(node *tree_sitter.Node, fileContent []byte, alia
 	namedTypeNode := findDirectChildOfKind(node, "named_type")
 	if namedTypeNode != nil {
 		nameNode := findFirstNodeOfKind(namedTypeNode, "name")
+		// Qualified names like \DateTimeInterface are resolved as a whole, a leading backslash makes them fully qualified
+		if qualifiedNameNode := findDirectChildOfKind(namedTypeNode, "qualified_name"); qualifiedNameNode != nil {
+			nameNode = qualifiedNameNode
+		}
 		if nameNode != nil {
 			shortClassName := string(nameNode.Utf8Text(fileContent))
 			if cachedType, ok := typeCache[shortClassName]; ok {
 				return cachedType
 			}
 			typeString := aliasResolver.ResolveType(shortClassName)
+			if strings.HasPrefix(shortClassName, "\\") {
+				typeString = shortClassName[1:]
+			}
 			phpType := NewPHPType(typeString)
 			typeCache[shortClassName] = phpType
 			return phpType
 		}
 	}
 
+	// ?Foo wraps the type into an optional_type node
+	if optionalTypeNode := findDirectChildOfKind(node, "optional_type"); optionalTypeNode != nil {
+		if innerType := resolveTypeFromDeclaration(optionalTypeNode, fileContent, aliasResolver, typeCache, nil); innerType != nil {
+			return NewPHPType("?" + innerType.Name())
+		}
+	}
+
 	primitiveTypeNode := findDirectChildOfKind(node, "primitive_type")
 	if primitiveTypeNode != nil {
 		typeString := string(primitiveTypeNode.Utf8Text(fileContent))
@@ -519,6 +731,40 @@ func resolveTypeFromDeclaration(node *tree_sitter.Node, fileContent []byte, alia
 	return fallback
 }
 
+// extractParameters returns the parameters of a method declaration in declaration order.
+// Untyped parameters have no type.
+func extractParameters(methodNode *tree_sitter.Node, fileContent []byte, aliasResolver *AliasResolver, typeCache map[string]PHPType) []PHPParameter {
+	parametersNode := methodNode.ChildByFieldName("parameters")
+	if parametersNode == nil {
+		return nil
+	}
+
+	var parameters []PHPParameter
+	for i := uint(0); i < parametersNode.NamedChildCount(); i++ {
+		param := parametersNode.NamedChild(i)
+		switch param.Kind() {
+		case "simple_parameter", "variadic_parameter", "property_promotion_parameter":
+		default:
+			continue
+		}
+
+		nameNode := param.ChildByFieldName("name")
+		if nameNode == nil {
+			continue
+		}
+
+		parameters = append(parameters, PHPParameter{
+			Name:       strings.TrimPrefix(string(nameNode.Utf8Text(fileContent)), "$"),
+			Line:       int(param.StartPosition().Row) + 1,
+			Type:       resolveTypeFromDeclaration(param, fileContent, aliasResolver, typeCache, nil),
+			HasDefault: param.ChildByFieldName("default_value") != nil,
+			IsVariadic: param.Kind() == "variadic_parameter",
+		})
+	}
+
+	return parameters
+}
+
 // findDirectChildOfKind finds a direct named child of the given kind (non-recursive)
 func findDirectChildOfKind(node *tree_sitter.Node, kind string) *tree_sitter.Node {
 	if node == nil {
@@ -0,0 +1,145 @@
+package php
+
+import (
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// ResolveClassReference returns the fully qualified name of the class referenced at the node, like
+// type hints, new expressions, static access scopes, extends/implements clauses and use statements.
+// An empty string is returned when the node is not part of a class reference.
+func ResolveClassReference(node *tree_sitter.Node, content []byte) string {
+	ref := classReferenceNode(node)
+	if ref == nil {
+		return ""
+	}
+
+	if ref.Parent().Kind() == "namespace_use_clause" {
+		return useClauseClassName(ref.Parent(), content)
+	}
+
+	rootNode := ref
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	return ResolveClassName(rootNode, content, ref.Utf8Text(content))
+}
+
+// IsClassNamePosition reports whether the node is an unqualified name at a position where a class is expected,
+// like a type hint or a new expression. A name standing alone as a statement is accepted as well,
+// as that is how a class name parses while it is being typed.
+func IsClassNamePosition(node *tree_sitter.Node) bool {
+	if node == nil || node.Kind() != "name" || node.Parent() == nil {
+		return false
+	}
+
+	switch node.Parent().Kind() {
+	case "expression_statement":
+		return true
+	case "ERROR":
+		return node.Parent().NamedChildCount() == 1
+	}
+
+	ref := classReferenceNode(node)
+	return ref != nil && ref.Id() == node.Id() && node.Parent().Kind() != "namespace_use_clause"
+}
+
+// IsAttributeNamePosition reports whether the node is the name of an attribute, including the empty
+// positions of "#[]" and after a trailing comma where the name has not been typed yet
+func IsAttributeNamePosition(node *tree_sitter.Node) bool {
+	if node == nil || node.Parent() == nil {
+		return false
+	}
+
+	if node.Kind() == "name" {
+		attribute := node.Parent()
+		return attribute.Kind() == "attribute" && attribute.NamedChild(0).Id() == node.Id()
+	}
+
+	if node.Parent().Kind() != "attribute_group" {
+		return false
+	}
+
+	switch node.Kind() {
+	case "#[", ",":
+		next := node.NextSibling()
+		return next != nil && (next.Kind() == "]" || isEmptyAttribute(next))
+	case "]":
+		prev := node.PrevSibling()
+		return prev != nil && (prev.Kind() == "#[" || prev.Kind() == "," || isEmptyAttribute(prev))
+	}
+
+	return false
+}
+
+// isEmptyAttribute checks for the attribute tree-sitter inserts with a missing name for "#[]"
+func isEmptyAttribute(node *tree_sitter.Node) bool {
+	return node.Kind() == "attribute" && node.NamedChildCount() > 0 && node.NamedChild(0).IsMissing()
+}
+
+// classReferenceNode returns the name or qualified name of the class reference the node is part of
+func classReferenceNode(node *tree_sitter.Node) *tree_sitter.Node {
+	if node == nil {
+		return nil
+	}
+
+	// The cursor can be on any segment of Foo\Bar\Baz
+	ref := node
+	for ref.Parent() != nil && (ref.Parent().Kind() == "namespace_name" || ref.Parent().Kind() == "qualified_name") {
+		ref = ref.Parent()
+	}
+
+	if ref.Kind() != "name" && ref.Kind() != "qualified_name" {
+		return nil
+	}
+
+	parent := ref.Parent()
+	if parent == nil {
+		return nil
+	}
+
+	switch parent.Kind() {
+	case "namespace_use_clause":
+		// The alias of a use clause refers to the imported class as well
+		return ref
+	case "named_type", "base_clause", "class_interface_clause", "object_creation_expression", "attribute":
+		return ref
+	case "class_constant_access_expression":
+		if parent.NamedChild(0).Id() == ref.Id() {
+			return ref
+		}
+	case "scoped_call_expression", "scoped_property_access_expression":
+		if scope := parent.ChildByFieldName("scope"); scope != nil && scope.Id() == ref.Id() {
+			return ref
+		}
+	case "binary_expression":
+		operator := parent.ChildByFieldName("operator")
+		right := parent.ChildByFieldName("right")
+		if operator != nil && operator.Kind() == "instanceof" && right != nil && right.Id() == ref.Id() {
+			return ref
+		}
+	}
+
+	return nil
+}
+
+// useClauseClassName returns the imported class of a use clause, also when the cursor is on its alias
+func useClauseClassName(clause *tree_sitter.Node, content []byte) string {
+	nameNode := clause.NamedChild(0)
+	if nameNode == nil || (nameNode.Kind() != "name" && nameNode.Kind() != "qualified_name") {
+		return ""
+	}
+
+	className := strings.TrimPrefix(nameNode.Utf8Text(content), "\\")
+
+	// use Shopware\Core\{Framework\Context}
+	if group := clause.Parent(); group != nil && group.Kind() == "namespace_use_group" && group.Parent() != nil {
+		if prefix := findDirectChildOfKind(group.Parent(), "namespace_name"); prefix != nil {
+			className = strings.TrimPrefix(prefix.Utf8Text(content), "\\") + "\\" + className
+		}
+	}
+
+	return className
+}
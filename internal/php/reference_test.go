@@ -0,0 +1,84 @@
+package php
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
+)
+
+func TestResolveClassReference(t *testing.T) {
+	content := []byte(`<?php
+
+namespace App\Subscriber;
+
+use Shopware\Core\Checkout\Cart\Event\CartEvents as Events;
+use Shopware\Core\Framework\{Context, Uuid\Uuid};
+use Symfony\Component\Routing\Attribute\Route;
+
+#[Route('/foo')]
+class OrderSubscriber extends AbstractSubscriber implements \Countable
+{
+    public function handle(?Context $context, OrderEntity|Order\LineItem $item): Events
+    {
+        try {
+            $id = Uuid::randomHex();
+            $name = Events::NAME;
+            $static = Registry::$instance;
+            $new = new \DateTimeImmutable();
+        } catch (OrderException $e) {
+            return $item instanceof OrderEntity;
+        }
+
+        return strlen($name);
+    }
+}
+`)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	tests := []struct {
+		name     string
+		needle   string
+		expected string
+	}{
+		{name: "use statement", needle: "CartEvents as", expected: "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents"},
+		{name: "use statement namespace segment", needle: "Checkout\\Cart", expected: "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents"},
+		{name: "use statement alias", needle: "Events;", expected: "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents"},
+		{name: "group use", needle: "Context, Uuid", expected: "Shopware\\Core\\Framework\\Context"},
+		{name: "nested group use", needle: "Uuid};", expected: "Shopware\\Core\\Framework\\Uuid\\Uuid"},
+		{name: "attribute", needle: "Route('", expected: "Symfony\\Component\\Routing\\Attribute\\Route"},
+		{name: "extends", needle: "AbstractSubscriber", expected: "App\\Subscriber\\AbstractSubscriber"},
+		{name: "implements global class", needle: "Countable", expected: "Countable"},
+		{name: "nullable parameter type", needle: "Context $context", expected: "Shopware\\Core\\Framework\\Context"},
+		{name: "union type with relative name", needle: "LineItem", expected: "App\\Subscriber\\Order\\LineItem"},
+		{name: "return type", needle: "Events\n", expected: "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents"},
+		{name: "static call scope", needle: "Uuid::randomHex", expected: "Shopware\\Core\\Framework\\Uuid\\Uuid"},
+		{name: "constant access scope", needle: "Events::NAME", expected: "Shopware\\Core\\Checkout\\Cart\\Event\\CartEvents"},
+		{name: "static property scope", needle: "Registry::", expected: "App\\Subscriber\\Registry"},
+		{name: "new expression", needle: "DateTimeImmutable", expected: "DateTimeImmutable"},
+		{name: "catch type", needle: "OrderException", expected: "App\\Subscriber\\OrderException"},
+		{name: "instanceof", needle: "OrderEntity;", expected: "App\\Subscriber\\OrderEntity"},
+		{name: "static method name", needle: "randomHex"},
+		{name: "function call", needle: "strlen"},
+		{name: "method name", needle: "handle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offset := strings.Index(string(content), tt.needle)
+			require.NotEqual(t, -1, offset)
+
+			node := tree.RootNode().DescendantForByteRange(uint(offset), uint(offset))
+			assert.Equal(t, tt.expected, ResolveClassReference(node, content))
+		})
+	}
+}
@@ -0,0 +1,24 @@
+<?php declare(strict_types=1);
+
+namespace App\Attribute;
+
+use Attribute;
+use Shopware\Core\Framework\Log\Package;
+
+#[Attribute(Attribute::TARGET_CLASS | Attribute::TARGET_METHOD)]
+final class Cached
+{
+    public function __construct(public readonly int $ttl = 3600)
+    {
+    }
+}
+
+#[Package('core'), \Attribute]
+class Audited
+{
+}
+
+#[Package('core')]
+class NotAnAttribute
+{
+}
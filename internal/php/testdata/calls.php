@@ -0,0 +1,38 @@
+<?php
+
+namespace App\Service;
+
+use App\Repository\ProductRepository;
+
+class BaseService
+{
+    public function __construct(string $name, int $priority = 0)
+    {
+    }
+
+    public function log(string $message, array $context = []): void
+    {
+    }
+}
+
+class ProductService extends BaseService
+{
+    private ?ProductRepository $repository;
+
+    public function __construct(ProductRepository $repository)
+    {
+        parent::__construct('product', 10);
+    }
+
+    public static function create(string $name, int ...$ids): self
+    {
+        return new ProductService(new ProductRepository());
+    }
+
+    public function load(): void
+    {
+        $this->log('loading', ['id' => 1]);
+        $this->repository?->search('criteria', true);
+        self::create('product', 1, 2);
+    }
+}
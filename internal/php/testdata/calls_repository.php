@@ -0,0 +1,11 @@
+<?php
+
+namespace App\Repository;
+
+class ProductRepository
+{
+    public function search(string $criteria, bool $withTotal = false): array
+    {
+        return [];
+    }
+}
@@ -0,0 +1,22 @@
+<?php
+
+namespace App\Event;
+
+interface EventNames
+{
+    public const PREFIX = 'app.';
+}
+
+class BaseEvents implements EventNames
+{
+    public const ORDER_PLACED = 'checkout.order.placed';
+}
+
+final class CheckoutEvents extends BaseEvents
+{
+    public const CART_LOADED = 'checkout.cart.loaded', CART_CLEARED = "checkout.cart.cleared";
+
+    private const int PRIORITY = 100;
+
+    public const ALIAS = self::CART_LOADED;
+}
@@ -0,0 +1,21 @@
+<?php
+
+namespace App\Order;
+
+enum OrderState: string implements StateInterface
+{
+    case Open = 'open';
+    case Done = 'done';
+
+    public const string FALLBACK = 'open';
+
+    public function label(): string
+    {
+        return $this->value;
+    }
+}
+
+class Order
+{
+    private ?OrderState $state = null;
+}
@@ -0,0 +1,24 @@
+<?php
+
+namespace App\Service;
+
+abstract class Factory
+{
+    public static function create(): static
+    {
+    }
+
+    final protected static function register(): void
+    {
+    }
+
+    abstract public function build(): array;
+
+    final public function name(): string
+    {
+    }
+
+    private function reset(): void
+    {
+    }
+}
@@ -0,0 +1,35 @@
+<?php
+
+namespace App\Service;
+
+use Shopware\Core\Framework\Context;
+use Shopware\Core\Framework\DataAbstractionLayer\EntityRepository as Repository;
+
+class ParameterService
+{
+    public function __construct(
+        private readonly Repository $productRepository,
+        protected ?Context $context = null,
+    ) {
+    }
+
+    public function none(): void
+    {
+    }
+
+    public function load(string $id, Context $context, bool $withVariants = false, ?int $limit = null): array
+    {
+    }
+
+    public function collect(int $limit, string ...$ids): array
+    {
+    }
+
+    public function legacy($value, &$reference, $options = []): void
+    {
+    }
+
+    public static function create(self $other, \DateTimeInterface $date): static
+    {
+    }
+}
@@ -1,10 +1,205 @@
 // Package php provides PHP language support for the LSP
 package php
 
-// typeinference.go
-// This file is a placeholder for type inference functionality.
-// The actual implementation for PHP type inference is in indexer.go
-// where the handleMemberCallExpression method now supports:
-// 1. Type inference for $this->method() calls
-// 2. Full class hierarchy traversal (parent classes and interfaces)
-// 3. Method return type resolution through multiple inheritance levels
+import (
+	"strings"
+
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// TypeOfExpression returns the type of an expression like $this, $product, new Foo(), $this->foo
+// or $repository->search(), using the variables known at its position. Unknown types are mixed.
+func (c *PHPIndex) TypeOfExpression(node *tree_sitter.Node, content []byte) PHPType {
+	if node == nil {
+		return NewMixedType()
+	}
+
+	return c.typeOfExpression(node, content, c.VariableTypes(node, content))
+}
+
+// VariableTypes returns the types of the variables of the function enclosing the node as they are known
+// at the node. Parameters are typed by their declaration and other variables by the last assignment
+// before the node, this is a best-effort scan without control flow analysis.
+func (c *PHPIndex) VariableTypes(node *tree_sitter.Node, content []byte) map[string]PHPType {
+	variables := make(map[string]PHPType)
+	if className := treesitterhelper.GetClassName(node, content); className != "" {
+		variables["$this"] = NewPHPType(className)
+	}
+
+	c.collectVariableTypes(node, node.StartByte(), content, variables)
+
+	return variables
+}
+
+// collectVariableTypes adds the variables of the function enclosing the node as they are known at the position
+func (c *PHPIndex) collectVariableTypes(node *tree_sitter.Node, position uint, content []byte, variables map[string]PHPType) {
+	function := node
+	for function != nil && !isFunctionScope(function) {
+		function = function.Parent()
+	}
+	if function == nil {
+		return
+	}
+
+	// Arrow functions capture the variables of the enclosing scope, their parameters shadow them
+	if function.Kind() == "arrow_function" && function.Parent() != nil {
+		c.collectVariableTypes(function.Parent(), function.StartByte(), content, variables)
+	}
+
+	rootNode := function
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	if parameters := function.ChildByFieldName("parameters"); parameters != nil {
+		for i := uint(0); i < parameters.NamedChildCount(); i++ {
+			parameter := parameters.NamedChild(i)
+			name := parameter.ChildByFieldName("name")
+			if name == nil {
+				continue
+			}
+
+			switch parameter.Kind() {
+			case "simple_parameter", "property_promotion_parameter":
+				variables[name.Utf8Text(content)] = declaredType(rootNode, parameter.ChildByFieldName("type"), content)
+			case "variadic_parameter":
+				variables[name.Utf8Text(content)] = NewPHPType("array")
+			}
+		}
+	}
+
+	body := function.ChildByFieldName("body")
+	if body == nil {
+		return
+	}
+
+	// Assignments are visited in document order, the value is visited first as it is evaluated first
+	var walk func(current *tree_sitter.Node)
+	walk = func(current *tree_sitter.Node) {
+		if current.StartByte() >= position || isFunctionScope(current) {
+			return
+		}
+
+		for i := uint(0); i < current.NamedChildCount(); i++ {
+			walk(current.NamedChild(i))
+		}
+
+		if current.Kind() != "assignment_expression" || current.EndByte() > position {
+			return
+		}
+
+		left := current.ChildByFieldName("left")
+		right := current.ChildByFieldName("right")
+		if left != nil && left.Kind() == "variable_name" && right != nil {
+			variables[left.Utf8Text(content)] = c.typeOfExpression(right, content, variables)
+		}
+	}
+	walk(body)
+}
+
+func (c *PHPIndex) typeOfExpression(node *tree_sitter.Node, content []byte, variables map[string]PHPType) PHPType {
+	switch node.Kind() {
+	case "parenthesized_expression":
+		if node.NamedChildCount() > 0 {
+			return c.typeOfExpression(node.NamedChild(0), content, variables)
+		}
+	case "variable_name":
+		if variableType, ok := variables[node.Utf8Text(content)]; ok {
+			return variableType
+		}
+	case "object_creation_expression":
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			child := node.NamedChild(i)
+			if child.Kind() == "name" || child.Kind() == "qualified_name" {
+				if className := c.ResolveScopeClass(child, content); className != "" {
+					return NewPHPType(className)
+				}
+			}
+		}
+	case "member_access_expression", "nullsafe_member_access_expression":
+		className := ClassNameOfType(c.typeOfExpression(node.ChildByFieldName("object"), content, variables))
+		name := node.ChildByFieldName("name")
+		if className == "" || name == nil {
+			break
+		}
+
+		if property := c.GetProperty(className, name.Utf8Text(content)); property != nil && property.Type != nil {
+			return property.Type
+		}
+	case "member_call_expression", "nullsafe_member_call_expression", "scoped_call_expression":
+		var className string
+		if node.Kind() == "scoped_call_expression" {
+			className = c.ResolveScopeClass(node.ChildByFieldName("scope"), content)
+		} else {
+			className = ClassNameOfType(c.typeOfExpression(node.ChildByFieldName("object"), content, variables))
+		}
+
+		name := node.ChildByFieldName("name")
+		if className == "" || name == nil {
+			break
+		}
+
+		method := c.GetMethod(className, name.Utf8Text(content))
+		if method == nil || method.ReturnType == nil {
+			break
+		}
+
+		// Fluent methods return the class they are called on
+		switch strings.TrimPrefix(method.ReturnType.Name(), "?") {
+		case "self", "static", "$this":
+			return NewPHPType(className)
+		}
+
+		return method.ReturnType
+	}
+
+	return NewMixedType()
+}
+
+// isFunctionScope reports whether the node starts a new variable scope
+func isFunctionScope(node *tree_sitter.Node) bool {
+	switch node.Kind() {
+	case "method_declaration", "function_definition", "anonymous_function", "arrow_function":
+		return true
+	}
+
+	return false
+}
+
+// declaredType resolves the type of a parameter declaration to fully qualified class names
+func declaredType(rootNode *tree_sitter.Node, typeNode *tree_sitter.Node, content []byte) PHPType {
+	if typeNode == nil {
+		return NewMixedType()
+	}
+
+	var names []string
+	var collect func(node *tree_sitter.Node)
+	collect = func(node *tree_sitter.Node) {
+		switch node.Kind() {
+		case "named_type":
+			name := node.Utf8Text(content)
+			if strings.HasPrefix(name, "\\") {
+				names = append(names, name[1:])
+			} else {
+				names = append(names, ResolveClassName(rootNode, content, name))
+			}
+		case "primitive_type":
+			names = append(names, node.Utf8Text(content))
+		case "optional_type":
+			names = append(names, "null")
+			fallthrough
+		default:
+			for i := uint(0); i < node.NamedChildCount(); i++ {
+				collect(node.NamedChild(i))
+			}
+		}
+	}
+	collect(typeNode)
+
+	if len(names) == 0 {
+		return NewMixedType()
+	}
+
+	return NewPHPType(strings.Join(names, "|"))
+}
@@ -1,9 +1,13 @@
 package php
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	tree_sitter_php "github.com/tree-sitter/tree-sitter-php/bindings/go"
 )
 
 func TestTypeInferenceWithInheritance(t *testing.T) {
@@ -228,3 +232,106 @@ func TestTypeInferenceWithInheritance(t *testing.T) {
 		}
 	})
 }
+
+func TestVariableTypes(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	content := []byte(`<?php
+
+namespace App\Service;
+
+use App\Entity\Product;
+
+class ProductService
+{
+    public function load(?Product $product, int|string $id, $untyped, Product ...$variants)
+    {
+        $criteria = new Criteria();
+        $name = $product->getName();
+        $copy = $criteria;
+        $criteria = 'reassigned';
+        $fn = function () {
+            $inner = new Criteria();
+        };
+        /* cursor */
+        $later = new Product();
+    }
+}
+`)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	offset := strings.Index(string(content), "/* cursor */")
+	node := tree.RootNode().NamedDescendantForByteRange(uint(offset), uint(offset))
+
+	names := make(map[string]string)
+	for name, variableType := range idx.VariableTypes(node, content) {
+		names[name] = variableType.Name()
+	}
+
+	assert.Equal(t, map[string]string{
+		"$this":     "App\\Service\\ProductService",
+		"$product":  NewPHPType("null|App\\Entity\\Product").Name(),
+		"$id":       NewPHPType("int|string").Name(),
+		"$untyped":  "mixed",
+		"$variants": "array",
+		"$criteria": "mixed",
+		"$name":     "mixed",
+		"$copy":     "App\\Service\\Criteria",
+		"$fn":       "mixed",
+	}, names)
+}
+
+func TestVariableTypes_ArrowFunction(t *testing.T) {
+	idx, err := NewPHPIndex(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = idx.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+
+	code := `<?php
+
+namespace App\Service;
+
+class Loader
+{
+    public function run(Repository $repository, string $id): void
+    {
+        $criteria = new Criteria();
+        $find = fn (int $id) => $repository->find($criteria, $id);
+        $nested = fn () => fn () => $criteria;
+        $closure = function () { return $repository; };
+    }
+}
+`
+	content := []byte(code)
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	variablesAt := func(marker string) map[string]PHPType {
+		offset := uint(strings.Index(code, marker))
+		return idx.VariableTypes(tree.RootNode().DescendantForByteRange(offset, offset), content)
+	}
+
+	variables := variablesAt("$repository->find")
+	require.Contains(t, variables, "$repository")
+	assert.Equal(t, "App\\Service\\Repository", variables["$repository"].Name())
+	assert.Equal(t, "App\\Service\\Criteria", variables["$criteria"].Name())
+	// The parameter of the arrow function shadows the one of the method
+	assert.Equal(t, "int", variables["$id"].Name())
+
+	variables = variablesAt("$criteria;")
+	require.Contains(t, variables, "$criteria")
+	assert.Equal(t, "App\\Service\\Criteria", variables["$criteria"].Name())
+
+	// Closures don't capture variables without use
+	assert.NotContains(t, variablesAt("$repository; }"), "$repository")
+}
@@ -143,8 +143,22 @@ func (s *SnippetCommandProvider) getPossibleSnippets(ctx context.Context, args *
 		return nil, fmt.Errorf("invalid arguments for getPossibleSnippets: %w", err)
 	}
 
+	possibleSnippets, err := possibleSnippetFiles(params.FileURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return success message
+	return map[string]interface{}{
+		"paths": possibleSnippets,
+	}, nil
+}
+
+// possibleSnippetFiles returns the storefront snippet files of the bundle containing the file,
+// a new en_GB file is created when the bundle has none yet
+func possibleSnippetFiles(fileURI string) ([]SnippetFile, error) {
 	// Convert URI to file path
-	filePath := strings.TrimPrefix(params.FileURI, "file://")
+	filePath := strings.TrimPrefix(fileURI, "file://")
 
 	// // Find Resources directory
 	dirPath := filepath.Dir(filePath)
@@ -192,10 +206,7 @@ func (s *SnippetCommandProvider) getPossibleSnippets(ctx context.Context, args *
 		}
 	}
 
-	// Return success message
-	return map[string]interface{}{
-		"paths": possibleSnippets,
-	}, nil
+	return possibleSnippets, nil
 }
 
 func (s *SnippetCommandProvider) createSnippet(ctx context.Context, args *json.RawMessage) (interface{}, error) {
@@ -209,6 +220,15 @@ func (s *SnippetCommandProvider) createSnippet(ctx context.Context, args *json.R
 		return nil, fmt.Errorf("invalid arguments for createSnippet: %w", err)
 	}
 
+	// Clients without a picker send no snippet files, the key is added to the preferred file
+	if len(params.Snippets) == 0 {
+		snippets, err := possibleSnippetFiles(params.FileURI)
+		if err != nil {
+			return nil, err
+		}
+		params.Snippets = preferredSnippetFile(snippets, params.SnippetKey)
+	}
+
 	files := make([]string, len(params.Snippets))
 
 	for _, snippet := range params.Snippets {
@@ -244,6 +264,18 @@ type SnippetFile struct {
 	Value string `json:"value"`
 }
 
+// preferredSnippetFile returns the first of the possible snippet files with the key as placeholder value
+func preferredSnippetFile(possibleSnippets []SnippetFile, snippetKey string) []SnippetFile {
+	if len(possibleSnippets) == 0 {
+		return nil
+	}
+
+	snippet := possibleSnippets[0]
+	snippet.Value = snippetKey
+
+	return []SnippetFile{snippet}
+}
+
 func (s *SnippetCommandProvider) getPossibleAdminSnippets(ctx context.Context, args *json.RawMessage) (interface{}, error) {
 	var params struct {
 		FileURI string `json:"fileUri"`
@@ -253,8 +285,22 @@ func (s *SnippetCommandProvider) getPossibleAdminSnippets(ctx context.Context, a
 		return nil, fmt.Errorf("invalid arguments for getPossibleAdminSnippets: %w", err)
 	}
 
+	possibleSnippets, err := possibleAdminSnippetFiles(params.FileURI)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return success message
+	return map[string]interface{}{
+		"paths": possibleSnippets,
+	}, nil
+}
+
+// possibleAdminSnippetFiles returns the administration snippet files of the bundle containing the file,
+// a new en-GB file is created when the bundle has none yet
+func possibleAdminSnippetFiles(fileURI string) ([]SnippetFile, error) {
 	// Convert URI to file path
-	filePath := strings.TrimPrefix(params.FileURI, "file://")
+	filePath := strings.TrimPrefix(fileURI, "file://")
 
 	// Find Resources/app/administration directory
 	dirPath := filepath.Dir(filePath)
@@ -312,10 +358,7 @@ func (s *SnippetCommandProvider) getPossibleAdminSnippets(ctx context.Context, a
 		}
 	}
 
-	// Return success message
-	return map[string]interface{}{
-		"paths": possibleSnippets,
-	}, nil
+	return possibleSnippets, nil
 }
 
 func (s *SnippetCommandProvider) createAdminSnippet(ctx context.Context, args *json.RawMessage) (interface{}, error) {
@@ -329,6 +372,15 @@ func (s *SnippetCommandProvider) createAdminSnippet(ctx context.Context, args *j
 		return nil, fmt.Errorf("invalid arguments for createAdminSnippet: %w", err)
 	}
 
+	// Clients without a picker send no snippet files, the key is added to the preferred file
+	if len(params.Snippets) == 0 {
+		snippets, err := possibleAdminSnippetFiles(params.FileURI)
+		if err != nil {
+			return nil, err
+		}
+		params.Snippets = preferredSnippetFile(snippets, params.SnippetKey)
+	}
+
 	files := make([]string, 0, len(params.Snippets))
 
 	for _, snippet := range params.Snippets {
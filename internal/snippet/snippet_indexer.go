@@ -35,6 +35,10 @@ func (s *SnippetIndexer) ID() string {
 	return "snippet.indexer"
 }
 
+func (s *SnippetIndexer) FileExtensions() []string {
+	return []string{".json"}
+}
+
 func (s *SnippetIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	// Skip test fixtures
 	if strings.Contains(path, "/_fixtures/") {
@@ -150,8 +154,31 @@ func (s *SnippetIndexer) Clear() error {
 	return s.adminIndex.Clear()
 }
 
+func (s *SnippetIndexer) BeginBatch() error {
+	return indexer.BeginBatches(s.frontendIndex, s.adminIndex)
+}
+
+func (s *SnippetIndexer) Commit() error {
+	return indexer.CommitBatches(s.frontendIndex, s.adminIndex)
+}
+
+// Count returns the number of storefront and administration snippet keys
+func (s *SnippetIndexer) Count() (int, error) {
+	frontend, err := s.frontendIndex.CountKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	admin, err := s.adminIndex.CountKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	return frontend + admin, nil
+}
+
 func (s *SnippetIndexer) GetFrontendSnippets() ([]string, error) {
-	return s.frontendIndex.GetAllKeys()
+	return s.frontendIndex.GetAllKeysSorted()
 }
 
 func (s *SnippetIndexer) GetFrontendSnippet(key string) ([]Snippet, error) {
@@ -163,7 +190,7 @@ func (s *SnippetIndexer) GetAllFrontendSnippets() ([]Snippet, error) {
 }
 
 func (s *SnippetIndexer) GetAdminSnippetKeys() ([]string, error) {
-	return s.adminIndex.GetAllKeys()
+	return s.adminIndex.GetAllKeysSorted()
 }
 
 func (s *SnippetIndexer) GetAdminSnippet(key string) ([]Snippet, error) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -54,6 +55,10 @@ func (idx *ServiceIndex) ID() string {
 	return "symfony.service"
 }
 
+func (idx *ServiceIndex) FileExtensions() []string {
+	return []string{".xml", ".yaml", ".yml"}
+}
+
 // Index scans the project for XML and YAML files and builds the service index
 func (idx *ServiceIndex) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	var services []Service
@@ -80,14 +85,15 @@ func (idx *ServiceIndex) Index(path string, node *tree_sitter.Node, fileContent
 		return err
 	}
 
-	serviceWrite := make(map[string]map[string]Service)
+	// Services keep every definition, an id defined twice in one file is still a duplicate
+	serviceWrite := make(map[string]map[string][]Service)
 	parameterWrite := make(map[string]map[string]Parameter)
 
 	for _, service := range services {
 		if _, ok := serviceWrite[service.Path]; !ok {
-			serviceWrite[service.Path] = make(map[string]Service)
+			serviceWrite[service.Path] = make(map[string][]Service)
 		}
-		serviceWrite[service.Path][service.ID] = service
+		serviceWrite[service.Path][service.ID] = append(serviceWrite[service.Path][service.ID], service)
 	}
 
 	for _, param := range params {
@@ -101,7 +107,7 @@ func (idx *ServiceIndex) Index(path string, node *tree_sitter.Node, fileContent
 		return err
 	}
 
-	if err := idx.serviceIndex.BatchSaveItems(serviceWrite); err != nil {
+	if err := idx.serviceIndex.BatchSaveItemLists(serviceWrite); err != nil {
 		return err
 	}
 
@@ -148,15 +154,10 @@ func (idx *ServiceIndex) GetAllServices() []string {
 	return dbServiceIDs
 }
 
-// GetServiceByID returns a specific service by its ID
+// GetServiceByID returns the effective definition of a service, which is the last one of GetServiceDefinitions
 func (idx *ServiceIndex) GetServiceByID(id string) (Service, bool) {
-	services, err := idx.serviceIndex.GetValues(id)
-	if err != nil {
-		return Service{}, false
-	}
-
-	if len(services) > 0 {
-		return services[0], true
+	if services := idx.GetServiceDefinitions(id); len(services) > 0 {
+		return services[len(services)-1], true
 	}
 
 	// If not found in database, fallback to container watcher
@@ -167,6 +168,69 @@ func (idx *ServiceIndex) GetServiceByID(id string) (Service, bool) {
 	return Service{}, false
 }
 
+// ResolveServiceForType returns the service autowiring injects for a type hint. A service using the class name as ID
+// wins, an alias like <service id="App\FooInterface" alias="app.foo"/> is followed one hop to the aliased service.
+// Otherwise the first service of that class is used.
+func (idx *ServiceIndex) ResolveServiceForType(fqcn string) (Service, bool) {
+	fqcn = strings.TrimPrefix(fqcn, "\\")
+	if fqcn == "" {
+		return Service{}, false
+	}
+
+	if service, ok := idx.GetServiceByID(fqcn); ok {
+		if service.AliasTarget == "" {
+			return service, true
+		}
+
+		return idx.GetServiceByID(service.AliasTarget)
+	}
+
+	values, err := idx.serviceIndex.GetAllValues()
+	if err != nil {
+		return Service{}, false
+	}
+
+	for _, value := range values {
+		if value.AliasTarget == "" && value.Class == fqcn {
+			return value, true
+		}
+	}
+
+	return Service{}, false
+}
+
+// GetServiceDefinitions returns all definitions of a service ID, including repeated definitions within a file,
+// ordered by their location
+func (idx *ServiceIndex) GetServiceDefinitions(id string) []Service {
+	services, err := idx.serviceIndex.GetValues(id)
+	if err != nil {
+		return nil
+	}
+
+	// The files are indexed in parallel, so order the definitions by their location instead of the indexing order.
+	// Definitions of the project override the ones of vendor packages.
+	services = slices.Clone(services)
+	slices.SortStableFunc(services, func(a, b Service) int {
+		if aVendor, bVendor := isVendorPath(a.Path), isVendorPath(b.Path); aVendor != bVendor {
+			if aVendor {
+				return -1
+			}
+			return 1
+		}
+		if a.Path != b.Path {
+			return strings.Compare(a.Path, b.Path)
+		}
+		return a.Line - b.Line
+	})
+
+	return services
+}
+
+// isVendorPath reports whether the file belongs to a composer package
+func isVendorPath(path string) bool {
+	return strings.Contains(filepath.ToSlash(path), "/vendor/")
+}
+
 // Close shuts down the database and cleans up temporary files
 func (idx *ServiceIndex) Close() error {
 	var err error
@@ -203,6 +267,19 @@ func (idx *ServiceIndex) Clear() error {
 	return nil
 }
 
+func (idx *ServiceIndex) BeginBatch() error {
+	return indexer.BeginBatches(idx.serviceIndex, idx.parameterIndex)
+}
+
+func (idx *ServiceIndex) Commit() error {
+	return indexer.CommitBatches(idx.serviceIndex, idx.parameterIndex)
+}
+
+// Count returns the number of indexed service ids
+func (idx *ServiceIndex) Count() (int, error) {
+	return idx.serviceIndex.CountKeys()
+}
+
 // GetAllTags returns all tag names in the index
 func (idx *ServiceIndex) GetAllTags() []string {
 	values, err := idx.serviceIndex.GetAllValues()
@@ -229,17 +306,28 @@ func (idx *ServiceIndex) GetAllTags() []string {
 
 // GetServicesByTag returns all service IDs that have the specified tag
 func (idx *ServiceIndex) GetServicesByTag(tagName string) []string {
-	values, err := idx.serviceIndex.GetAllValues()
+	tagged := idx.GetTaggedServices(tagName)
+
+	services := make([]string, 0, len(tagged))
+	for _, service := range tagged {
+		services = append(services, service.ID)
+	}
+
+	return slices.Compact(services)
+}
+
+// GetTaggedServices returns every definition carrying the tag sorted by service ID,
+// these are the services injected by <argument type="tagged_iterator" tag="..."/>
+func (idx *ServiceIndex) GetTaggedServices(tagName string) []Service {
+	values, err := idx.serviceIndex.GetAllValuesSorted()
 	if err != nil {
-		panic(err)
+		return nil
 	}
 
-	services := make([]string, 0, len(values))
+	var services []Service
 	for _, value := range values {
-		for tag := range value.Tags {
-			if tag == tagName {
-				services = append(services, value.ID)
-			}
+		if _, ok := value.Tags[tagName]; ok {
+			services = append(services, value)
 		}
 	}
 
@@ -287,3 +375,28 @@ func (idx *ServiceIndex) GetServicesUsageByClassName(className string) []Locatio
 
 	return locations
 }
+
+// GetServiceReferences returns the definitions of the service, the aliases pointing to it
+// and all service arguments referencing it
+func (idx *ServiceIndex) GetServiceReferences(id string) []Location {
+	values, err := idx.serviceIndex.GetAllValues()
+	if err != nil {
+		panic(err)
+	}
+
+	locations := make([]Location, 0)
+
+	for _, value := range values {
+		if value.ID == id || value.AliasTarget == id {
+			locations = append(locations, Location{Path: value.Path, Line: value.Line})
+		}
+
+		for _, reference := range value.References {
+			if reference.ID == id {
+				locations = append(locations, Location{Path: value.Path, Line: reference.Line})
+			}
+		}
+	}
+
+	return locations
+}
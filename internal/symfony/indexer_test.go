@@ -0,0 +1,146 @@
+package symfony
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func TestServiceIndex_MultipleDefinitions(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	index := func(path, code string) {
+		tree := parser.Parse([]byte(code), nil)
+		defer tree.Close()
+		require.NoError(t, serviceIndex.Index(path, tree.RootNode(), []byte(code)))
+	}
+
+	index("/project/a/services.xml", `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="App\Mailer"/>
+        <service id="app.mailer" class="App\BetterMailer"/>
+    </services>
+</container>`)
+
+	index("/project/b/services.xml", `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="Plugin\Mailer"/>
+    </services>
+</container>`)
+
+	definitions := serviceIndex.GetServiceDefinitions("app.mailer")
+	require.Len(t, definitions, 3)
+	assert.Equal(t, "App\\Mailer", definitions[0].Class)
+	assert.Equal(t, "App\\BetterMailer", definitions[1].Class)
+	assert.Equal(t, "Plugin\\Mailer", definitions[2].Class)
+
+	service, ok := serviceIndex.GetServiceByID("app.mailer")
+	require.True(t, ok)
+	assert.Equal(t, "Plugin\\Mailer", service.Class)
+	assert.Equal(t, "/project/b/services.xml", service.Path)
+
+	require.NoError(t, serviceIndex.RemovedFiles([]string{"/project/b/services.xml"}))
+
+	service, ok = serviceIndex.GetServiceByID("app.mailer")
+	require.True(t, ok)
+	assert.Equal(t, "App\\BetterMailer", service.Class)
+	assert.Len(t, serviceIndex.GetServiceDefinitions("app.mailer"), 2)
+
+	assert.Empty(t, serviceIndex.GetServiceDefinitions("app.unknown"))
+
+	// The result doesn't depend on the indexing order, project definitions override vendor ones
+	index("/project/vendor/acme/mailer/services.xml", `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="Acme\Mailer"/>
+    </services>
+</container>`)
+	index("/project/b/services.xml", `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="Plugin\Mailer"/>
+    </services>
+</container>`)
+
+	definitions = serviceIndex.GetServiceDefinitions("app.mailer")
+	require.Len(t, definitions, 4)
+	assert.Equal(t, "Acme\\Mailer", definitions[0].Class)
+
+	service, ok = serviceIndex.GetServiceByID("app.mailer")
+	require.True(t, ok)
+	assert.Equal(t, "Plugin\\Mailer", service.Class)
+
+	require.NoError(t, serviceIndex.RemovedFiles([]string{"/project/b/services.xml"}))
+	index("/project/a/services.xml", `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="App\Mailer"/>
+    </services>
+</container>`)
+
+	service, ok = serviceIndex.GetServiceByID("app.mailer")
+	require.True(t, ok)
+	assert.Equal(t, "App\\Mailer", service.Class)
+}
+
+func TestServiceIndex_ResolveServiceForType(t *testing.T) {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	serviceIndex, err := NewServiceIndex(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = serviceIndex.Close() }()
+
+	code := `<?xml version="1.0" ?>
+<container>
+    <services>
+        <service id="app.mailer" class="App\Mailer"/>
+        <service id="App\MailerInterface" alias="app.mailer"/>
+        <alias id="App\TransportInterface" service="App\Transport"/>
+        <service id="App\Transport"/>
+        <service id="App\Unresolved" alias="app.missing"/>
+    </services>
+</container>`
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", tree.RootNode(), []byte(code)))
+
+	tests := []struct {
+		name      string
+		fqcn      string
+		serviceID string
+	}{
+		{name: "alias attribute", fqcn: "App\\MailerInterface", serviceID: "app.mailer"},
+		{name: "alias element", fqcn: "App\\TransportInterface", serviceID: "App\\Transport"},
+		{name: "class name as id", fqcn: "\\App\\Transport", serviceID: "App\\Transport"},
+		{name: "class of a service", fqcn: "App\\Mailer", serviceID: "app.mailer"},
+		{name: "alias to unknown service", fqcn: "App\\Unresolved"},
+		{name: "unknown type", fqcn: "App\\Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, ok := serviceIndex.ResolveServiceForType(tt.fqcn)
+			if tt.serviceID == "" {
+				assert.False(t, ok)
+				return
+			}
+
+			require.True(t, ok)
+			assert.Equal(t, tt.serviceID, service.ID)
+		})
+	}
+}
@@ -288,6 +288,10 @@ func extractRouteFromAttribute(node *tree_sitter.Node, content []byte) Route {
 				if nameNode != nil {
 					paramName := string(nameNode.Utf8Text(content))
 
+					if arrayNode := treesitterhelper.GetFirstNodeOfKind(child, "array_creation_expression"); arrayNode != nil && paramName == "methods" {
+						route.Methods = extractHTTPMethods(arrayNode, content)
+					}
+
 					// Look for string value
 					stringNode := treesitterhelper.GetFirstNodeOfKind(child, "string")
 					if stringNode != nil {
@@ -355,6 +359,8 @@ func extractRouteFromAttribute(node *tree_sitter.Node, content []byte) Route {
 						route.Path = value
 					case "controller":
 						route.Controller = value
+					case "methods":
+						route.Methods = []string{strings.ToUpper(value)}
 					}
 				} else {
 					// Positional arguments (first is path, second is name)
@@ -364,9 +370,22 @@ func extractRouteFromAttribute(node *tree_sitter.Node, content []byte) Route {
 						route.Name = value
 					}
 				}
+			} else if child.Kind() == "array_creation_expression" && namedArg && paramName == "methods" {
+				route.Methods = extractHTTPMethods(child, content)
 			}
 		}
 	}
 
 	return route
 }
+
+// extractHTTPMethods extracts the upper-cased strings of an array like ['GET', 'POST']
+func extractHTTPMethods(arrayNode *tree_sitter.Node, content []byte) []string {
+	var methods []string
+
+	for _, stringNode := range treesitterhelper.FindAll(arrayNode, treesitterhelper.AnyNodeKind("string", "encapsed_string"), content) {
+		methods = append(methods, strings.ToUpper(treesitterhelper.GetNodeText(stringNode, content)))
+	}
+
+	return methods
+}
@@ -80,6 +80,7 @@ func TestExtractRoutesStorefrontController(t *testing.T) {
 	expectedRouteMethod := Route{
 		Name:       "frontend.wishlist.page",
 		Path:       "/wishlist",
+		Methods:    []string{"GET"},
 		FilePath:   filePath,
 		Line:       55, // Line number of the Route attribute in the wishlist.php file
 		Controller: "Shopware\\Storefront\\Controller\\WishlistController::index",
@@ -104,3 +105,29 @@ func parsePHPFile(filePath string) (*tree_sitter.Node, []byte) {
 	tree := parser.Parse(content, nil)
 	return tree.RootNode(), content
 }
+
+func TestExtractRoutesWithMethods(t *testing.T) {
+	filePath := "testdata/controller_methods.php"
+	node, content := parsePHPFile(filePath)
+
+	routes := parsePHPRoutes(filePath, node, content)
+	assert.Len(t, routes, 2)
+
+	assert.Equal(t, Route{
+		Name:       "frontend.wishlist.product.add",
+		Path:       "/wishlist/add/{productId}",
+		Methods:    []string{"POST", "GET"},
+		FilePath:   filePath,
+		Line:       11,
+		Controller: "App\\Controller\\WishlistController::add",
+	}, routes[0])
+
+	assert.Equal(t, Route{
+		Name:       "frontend.wishlist.product.remove",
+		Path:       "/wishlist/remove",
+		Methods:    []string{"DELETE"},
+		FilePath:   filePath,
+		Line:       16,
+		Controller: "App\\Controller\\WishlistController::remove",
+	}, routes[1])
+}
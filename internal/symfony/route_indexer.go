@@ -12,6 +12,7 @@ import (
 type Route struct {
 	Name       string
 	Path       string
+	Methods    []string // Allowed HTTP methods, empty when all methods are allowed
 	Controller string
 	FilePath   string
 	Line       int
@@ -46,6 +47,10 @@ func (idx *RouteIndexer) ID() string {
 	return "symfony.route"
 }
 
+func (idx *RouteIndexer) FileExtensions() []string {
+	return []string{".yml", ".yaml", ".php"}
+}
+
 func (idx *RouteIndexer) GetRoutes() (RouteList, error) {
 	return idx.dataIndexer.GetAllValues()
 }
@@ -54,6 +59,23 @@ func (idx *RouteIndexer) GetRoute(name string) ([]Route, error) {
 	return idx.dataIndexer.GetValues(name)
 }
 
+// GetRoutesByController returns all routes mapped to the given controller, e.g. App\Controller\FooController::index
+func (idx *RouteIndexer) GetRoutesByController(controller string) ([]Route, error) {
+	routes, err := idx.dataIndexer.GetAllValuesSorted()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Route
+	for _, route := range routes {
+		if route.Controller == controller {
+			result = append(result, route)
+		}
+	}
+
+	return result, nil
+}
+
 func (idx *RouteIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	fileExt := strings.ToLower(filepath.Ext(path))
 
@@ -109,3 +131,16 @@ func (idx *RouteIndexer) Close() error {
 func (idx *RouteIndexer) Clear() error {
 	return idx.dataIndexer.Clear()
 }
+
+func (idx *RouteIndexer) BeginBatch() error {
+	return idx.dataIndexer.BeginBatch()
+}
+
+func (idx *RouteIndexer) Commit() error {
+	return idx.dataIndexer.Commit()
+}
+
+// Count returns the number of indexed route names
+func (idx *RouteIndexer) Count() (int, error) {
+	return idx.dataIndexer.CountKeys()
+}
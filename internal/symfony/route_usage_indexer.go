@@ -32,6 +32,10 @@ func (idx *RouteUsageIndexer) ID() string {
 	return "symfony.route_usage"
 }
 
+func (idx *RouteUsageIndexer) FileExtensions() []string {
+	return []string{".php", ".twig"}
+}
+
 func (idx *RouteUsageIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	matches := treesitterhelper.FindAll(node, treesitterhelper.IsPHPThisMethodCall("redirectToRoute"), fileContent)
 	matches = append(matches, treesitterhelper.FindAll(node, treesitterhelper.TwigStringInFunctionPattern("seoUrl", "url", "path"), fileContent)...)
@@ -61,6 +65,14 @@ func (idx *RouteUsageIndexer) Clear() error {
 	return idx.dataIndexer.Clear()
 }
 
+func (idx *RouteUsageIndexer) BeginBatch() error {
+	return idx.dataIndexer.BeginBatch()
+}
+
+func (idx *RouteUsageIndexer) Commit() error {
+	return idx.dataIndexer.Commit()
+}
+
 func (idx *RouteUsageIndexer) Close() error {
 	return idx.dataIndexer.Close()
 }
@@ -0,0 +1,188 @@
+package symfony
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shopware/shopware-lsp/internal/lsp"
+)
+
+// ServiceCommandProvider provides commands exposing the indexed service container to tooling outside the editor
+type ServiceCommandProvider struct {
+	serviceIndex *ServiceIndex
+	projectRoot  string
+	cacheDir     string
+}
+
+// ServiceExport is the result of the shopware/exportServices command
+type ServiceExport struct {
+	Services   []ExportedService   `json:"services"`
+	Parameters []ExportedParameter `json:"parameters"`
+}
+
+// ExportedService is a service definition of the export, aliases are services pointing to it
+type ExportedService struct {
+	ID          string   `json:"id"`
+	Class       string   `json:"class,omitempty"`
+	AliasTarget string   `json:"aliasTarget,omitempty"`
+	Tags        []string `json:"tags"`
+	Aliases     []string `json:"aliases"`
+	Path        string   `json:"path,omitempty"`
+	Line        int      `json:"line,omitempty"`
+}
+
+// ExportedParameter is a container parameter of the export
+type ExportedParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Path  string `json:"path,omitempty"`
+	Line  int    `json:"line,omitempty"`
+}
+
+func NewServiceCommandProvider(projectRoot, cacheDir string, server *lsp.Server) *ServiceCommandProvider {
+	serviceIndex, _ := server.GetIndexer("symfony.service")
+
+	return &ServiceCommandProvider{
+		serviceIndex: serviceIndex.(*ServiceIndex),
+		projectRoot:  projectRoot,
+		cacheDir:     cacheDir,
+	}
+}
+
+func (s *ServiceCommandProvider) GetCommands(ctx context.Context) map[string]lsp.CommandFunc {
+	return map[string]lsp.CommandFunc{
+		"shopware/exportServices": s.exportServices,
+	}
+}
+
+// exportServices returns all services and parameters, with a "path" argument they are written to that file instead
+func (s *ServiceCommandProvider) exportServices(ctx context.Context, args *json.RawMessage) (interface{}, error) {
+	var params struct {
+		Path string `json:"path"`
+	}
+
+	if args != nil {
+		if err := json.Unmarshal(*args, &params); err != nil {
+			return nil, fmt.Errorf("invalid arguments for exportServices: %w", err)
+		}
+	}
+
+	export := s.buildExport()
+
+	if params.Path == "" {
+		return export, nil
+	}
+
+	target, err := s.resolveExportPath(params.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode services: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", target, err)
+	}
+
+	if err := os.WriteFile(target, append(content, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", target, err)
+	}
+
+	return map[string]interface{}{
+		"path":       target,
+		"services":   len(export.Services),
+		"parameters": len(export.Parameters),
+	}, nil
+}
+
+// resolveExportPath validates the file passed to shopware/exportServices.
+// Relative paths and file URIs are resolved against the project root, the file must be inside the project or the cache directory.
+func (s *ServiceCommandProvider) resolveExportPath(path string) (string, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "file://")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.projectRoot, path)
+	}
+	path = filepath.Clean(path)
+
+	for _, root := range []string{s.projectRoot, s.cacheDir} {
+		if root == "" {
+			continue
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err == nil && relPath != "." && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(os.PathSeparator)) {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("path %s is outside of the project root and cache directory", path)
+}
+
+func (s *ServiceCommandProvider) buildExport() ServiceExport {
+	export := ServiceExport{
+		Services:   []ExportedService{},
+		Parameters: []ExportedParameter{},
+	}
+
+	aliases := make(map[string][]string)
+
+	for _, id := range s.serviceIndex.GetAllServices() {
+		service, ok := s.serviceIndex.GetServiceByID(id)
+		if !ok {
+			continue
+		}
+
+		tags := make([]string, 0, len(service.Tags))
+		for tag := range service.Tags {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+
+		if service.AliasTarget != "" {
+			aliases[service.AliasTarget] = append(aliases[service.AliasTarget], id)
+		}
+
+		export.Services = append(export.Services, ExportedService{
+			ID:          id,
+			Class:       service.Class,
+			AliasTarget: service.AliasTarget,
+			Tags:        tags,
+			Path:        service.Path,
+			Line:        service.Line,
+		})
+	}
+
+	sort.Slice(export.Services, func(i, j int) bool {
+		return export.Services[i].ID < export.Services[j].ID
+	})
+
+	for i := range export.Services {
+		serviceAliases := aliases[export.Services[i].ID]
+		sort.Strings(serviceAliases)
+
+		export.Services[i].Aliases = append([]string{}, serviceAliases...)
+	}
+
+	for _, parameter := range s.serviceIndex.GetAllParameters() {
+		export.Parameters = append(export.Parameters, ExportedParameter{
+			Name:  parameter.Name,
+			Value: parameter.Value,
+			Path:  parameter.Path,
+			Line:  parameter.Line,
+		})
+	}
+
+	sort.SliceStable(export.Parameters, func(i, j int) bool {
+		return export.Parameters[i].Name < export.Parameters[j].Name
+	})
+
+	return export
+}
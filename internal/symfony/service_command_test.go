@@ -0,0 +1,128 @@
+package symfony
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tree_sitter_xml "github.com/tree-sitter-grammars/tree-sitter-xml/bindings/go"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+func newExportTestProvider(t *testing.T) *ServiceCommandProvider {
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_xml.LanguageXML())))
+
+	projectRoot := t.TempDir()
+	serviceIndex, err := NewServiceIndex(projectRoot, t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serviceIndex.Close() })
+
+	code := `<?xml version="1.0" ?>
+<container>
+    <parameters>
+        <parameter key="mailer.sender">shop@example.com</parameter>
+    </parameters>
+    <services>
+        <service id="app.mailer" class="App\Mailer">
+            <tag name="kernel.reset"/>
+            <tag name="app.sender"/>
+        </service>
+        <alias id="App\MailerInterface" service="app.mailer"/>
+        <service id="app.logger" class="App\Logger"/>
+    </services>
+</container>`
+
+	tree := parser.Parse([]byte(code), nil)
+	defer tree.Close()
+	require.NoError(t, serviceIndex.Index("/project/services.xml", tree.RootNode(), []byte(code)))
+
+	return &ServiceCommandProvider{serviceIndex: serviceIndex, projectRoot: projectRoot, cacheDir: t.TempDir()}
+}
+
+func TestServiceCommandProvider_ExportServices(t *testing.T) {
+	provider := newExportTestProvider(t)
+
+	result, err := provider.exportServices(context.Background(), nil)
+	require.NoError(t, err)
+
+	export, ok := result.(ServiceExport)
+	require.True(t, ok, "unexpected result %#v", result)
+	require.Len(t, export.Services, 3)
+
+	assert.Equal(t, "App\\MailerInterface", export.Services[0].ID)
+	assert.Equal(t, "app.mailer", export.Services[0].AliasTarget)
+
+	assert.Equal(t, "app.logger", export.Services[1].ID)
+	assert.Empty(t, export.Services[1].Tags)
+	assert.Empty(t, export.Services[1].Aliases)
+
+	mailer := export.Services[2]
+	assert.Equal(t, "app.mailer", mailer.ID)
+	assert.Equal(t, "App\\Mailer", mailer.Class)
+	assert.Equal(t, []string{"app.sender", "kernel.reset"}, mailer.Tags)
+	assert.Equal(t, []string{"App\\MailerInterface"}, mailer.Aliases)
+	assert.Equal(t, "/project/services.xml", mailer.Path)
+
+	require.Len(t, export.Parameters, 1)
+	assert.Equal(t, "mailer.sender", export.Parameters[0].Name)
+	assert.Equal(t, "shop@example.com", export.Parameters[0].Value)
+
+	encoded, err := json.Marshal(mailer)
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"aliases":["App\\MailerInterface"]`)
+}
+
+func TestServiceCommandProvider_ExportServicesToFile(t *testing.T) {
+	provider := newExportTestProvider(t)
+
+	args := json.RawMessage(`{"path": "var/services.json"}`)
+	result, err := provider.exportServices(context.Background(), &args)
+	require.NoError(t, err)
+
+	target := filepath.Join(provider.projectRoot, "var", "services.json")
+	assert.Equal(t, map[string]interface{}{"path": target, "services": 3, "parameters": 1}, result)
+
+	content, err := os.ReadFile(target)
+	require.NoError(t, err)
+
+	var export ServiceExport
+	require.NoError(t, json.Unmarshal(content, &export))
+	assert.Len(t, export.Services, 3)
+	assert.Equal(t, "mailer.sender", export.Parameters[0].Name)
+}
+
+func TestServiceCommandProvider_ExportServicesToCacheDir(t *testing.T) {
+	provider := newExportTestProvider(t)
+
+	target := filepath.Join(provider.cacheDir, "services.json")
+	args, err := json.Marshal(map[string]string{"path": "file://" + target})
+	require.NoError(t, err)
+
+	raw := json.RawMessage(args)
+	_, err = provider.exportServices(context.Background(), &raw)
+	require.NoError(t, err)
+	assert.FileExists(t, target)
+}
+
+func TestServiceCommandProvider_ExportServicesOutsideProject(t *testing.T) {
+	provider := newExportTestProvider(t)
+	outside := t.TempDir()
+
+	for _, path := range []string{"../services.json", filepath.Join(outside, "services.json"), "."} {
+		args, err := json.Marshal(map[string]string{"path": path})
+		require.NoError(t, err)
+
+		raw := json.RawMessage(args)
+		_, err = provider.exportServices(context.Background(), &raw)
+		assert.Error(t, err, path)
+	}
+
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(provider.projectRoot), "services.json"))
+	assert.NoFileExists(t, filepath.Join(outside, "services.json"))
+}
@@ -0,0 +1,20 @@
+<?php
+
+namespace App\Controller;
+
+use Symfony\Component\Routing\Attribute\Route;
+use Symfony\Component\HttpFoundation\Response;
+
+#[Route('/wishlist')]
+class WishlistController
+{
+    #[Route('/add/{productId}', name: 'frontend.wishlist.product.add', methods: ['post', 'GET'])]
+    public function add(): Response
+    {
+    }
+
+    #[Route(path: '/remove', name: 'frontend.wishlist.product.remove', methods: 'DELETE')]
+    public function remove(): Response
+    {
+    }
+}
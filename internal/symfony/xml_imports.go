@@ -0,0 +1,92 @@
+package symfony
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveImportResource returns the path an <import resource="..."/> of a service file refers to.
+// Relative resources are resolved against the directory of the importing file, resources starting
+// with @BundleName against the directory bundleDir returns for the bundle. The result is empty when
+// the bundle is unknown and can contain wildcards, see IsGlobResource.
+func ResolveImportResource(filePath, resource string, bundleDir func(name string) string) string {
+	resource = strings.TrimSpace(resource)
+	if resource == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(resource, "@") {
+		name, rest, _ := strings.Cut(resource[1:], "/")
+		dir := ""
+		if bundleDir != nil {
+			dir = bundleDir(name)
+		}
+		if dir == "" {
+			return ""
+		}
+
+		return filepath.Join(dir, filepath.FromSlash(rest))
+	}
+
+	if filepath.IsAbs(resource) {
+		return filepath.Clean(resource)
+	}
+
+	return filepath.Join(filepath.Dir(filePath), filepath.FromSlash(resource))
+}
+
+// IsGlobResource reports whether an import resource uses wildcards, e.g. packages/*.xml
+func IsGlobResource(resource string) bool {
+	return strings.ContainsAny(resource, "*?[{")
+}
+
+// ImportResourceExists reports whether the resolved path of an import exists. For resources with
+// wildcards the directory in front of the first wildcard has to exist, as Symfony accepts globs
+// which match no files.
+func ImportResourceExists(path string) bool {
+	if IsGlobResource(path) {
+		prefix := path[:strings.IndexAny(path, "*?[{")]
+		if strings.HasSuffix(prefix, string(filepath.Separator)) {
+			path = prefix
+		} else {
+			// packages/shop*.xml needs packages/
+			path = filepath.Dir(prefix)
+		}
+	}
+
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// ImportResourceFiles returns the files an import loads: the file itself, the files matching a
+// resource with wildcards or the files of an imported directory
+func ImportResourceFiles(path string) []string {
+	if IsGlobResource(path) {
+		matches, _ := filepath.Glob(path)
+		return regularFiles(matches)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil
+	}
+
+	if info.IsDir() {
+		matches, _ := filepath.Glob(filepath.Join(path, "*"))
+		return regularFiles(matches)
+	}
+
+	return []string{path}
+}
+
+func regularFiles(paths []string) []string {
+	files := make([]string, 0, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
@@ -0,0 +1,57 @@
+package symfony
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveImportResource(t *testing.T) {
+	bundleDir := func(name string) string {
+		if name == "SwagExample" {
+			return "/project/custom/plugins/SwagExample/src"
+		}
+		return ""
+	}
+
+	tests := []struct {
+		resource string
+		expected string
+	}{
+		{"other.xml", "/project/config/other.xml"},
+		{"../routes/services.xml", "/project/routes/services.xml"},
+		{"packages/*.xml", "/project/config/packages/*.xml"},
+		{"/etc/services.xml", "/etc/services.xml"},
+		{"@SwagExample/Resources/config/services.xml", "/project/custom/plugins/SwagExample/src/Resources/config/services.xml"},
+		{"@Unknown/Resources/config/services.xml", ""},
+		{" ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resource, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ResolveImportResource("/project/config/services.xml", tt.resource, bundleDir))
+		})
+	}
+}
+
+func TestImportResourceExists(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "packages"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "services.xml"), []byte("<container/>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "packages", "shop.xml"), []byte("<container/>"), 0o644))
+
+	assert.True(t, ImportResourceExists(filepath.Join(dir, "services.xml")))
+	assert.True(t, ImportResourceExists(filepath.Join(dir, "packages")))
+	assert.True(t, ImportResourceExists(filepath.Join(dir, "packages", "*.yaml")))
+	assert.True(t, ImportResourceExists(filepath.Join(dir, "packages", "sh*.xml")))
+	assert.False(t, ImportResourceExists(filepath.Join(dir, "missing.xml")))
+	assert.False(t, ImportResourceExists(filepath.Join(dir, "missing", "*.xml")))
+
+	assert.Equal(t, []string{filepath.Join(dir, "packages", "shop.xml")}, ImportResourceFiles(filepath.Join(dir, "packages", "*.xml")))
+	assert.Equal(t, []string{filepath.Join(dir, "packages", "shop.xml")}, ImportResourceFiles(filepath.Join(dir, "packages")))
+	assert.Equal(t, []string{filepath.Join(dir, "services.xml")}, ImportResourceFiles(filepath.Join(dir, "services.xml")))
+	assert.Empty(t, ImportResourceFiles(filepath.Join(dir, "missing.xml")))
+}
@@ -10,12 +10,19 @@ import (
 
 // Service represents a Symfony service definition
 type Service struct {
-	ID          string            // Service ID
-	Class       string            // Service class
-	AliasTarget string            // Service alias target
-	Tags        map[string]string // Service tags
-	Path        string            // Source file path
-	Line        int               // Line number in source file
+	ID          string             // Service ID
+	Class       string             // Service class
+	AliasTarget string             // Service alias target
+	Tags        map[string]string  // Service tags
+	References  []ServiceReference // Services passed as arguments
+	Path        string             // Source file path
+	Line        int                // Line number in source file
+}
+
+// ServiceReference represents a service passed as argument, e.g. <argument type="service" id="..."/>
+type ServiceReference struct {
+	ID   string // Referenced service ID
+	Line int    // Line number in source file
 }
 
 // Parameter represents a Symfony container parameter
@@ -145,6 +152,12 @@ func ParseXMLServices(path string, rootNode *tree_sitter.Node, data []byte) ([]S
 	return services, parameters, nil
 }
 
+// IsServiceXML reports whether the XML content is a Symfony service container configuration.
+// It only looks at the text, so it also works for files which can't be parsed.
+func IsServiceXML(data []byte) bool {
+	return bytes.Contains(data, []byte("<container"))
+}
+
 // findContainerNode finds the container node in the XML tree
 func findContainerNode(rootNode *tree_sitter.Node, data []byte) *tree_sitter.Node {
 	// For Symfony XML files, the container is usually the document element
@@ -188,8 +201,11 @@ func processServiceNode(node *tree_sitter.Node, data []byte, path string) Servic
 
 	service.Class = attrs["class"]
 
+	// <service id="App\FooInterface" alias="app.foo"/> is the attribute form of an alias
+	service.AliasTarget = attrs["alias"]
+
 	// If service has no class, use ID as class (Symfony default behavior)
-	if service.Class == "" {
+	if service.Class == "" && service.AliasTarget == "" {
 		service.Class = service.ID
 	}
 
@@ -198,6 +214,8 @@ func processServiceNode(node *tree_sitter.Node, data []byte, path string) Servic
 	lineNum := 1 + bytes.Count(data[:startByte], []byte{'\n'})
 	service.Line = lineNum
 
+	service.References = collectServiceReferences(node, data)
+
 	// Only process tags if this isn't an empty element (has content)
 	if startTag.Kind() == "STag" && node.NamedChildCount() > 2 {
 		// Get content node (index 1 if we have STag, content, ETag)
@@ -239,6 +257,37 @@ func processServiceNode(node *tree_sitter.Node, data []byte, path string) Servic
 	return service
 }
 
+// collectServiceReferences collects all service arguments inside the given node, including
+// arguments of method calls and nested collections
+func collectServiceReferences(node *tree_sitter.Node, data []byte) []ServiceReference {
+	var references []ServiceReference
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		child := node.NamedChild(i)
+
+		if child.Kind() == "STag" || child.Kind() == "EmptyElemTag" {
+			nameNode := treesitterhelper.GetFirstNodeOfKind(child, "Name")
+			if nameNode == nil || nameNode.Utf8Text(data) != "argument" {
+				continue
+			}
+
+			attrs := treesitterhelper.GetXmlAttributeValues(child, data)
+			if attrs["type"] == "service" && attrs["id"] != "" {
+				references = append(references, ServiceReference{
+					ID:   attrs["id"],
+					Line: int(child.StartPosition().Row) + 1,
+				})
+			}
+
+			continue
+		}
+
+		references = append(references, collectServiceReferences(child, data)...)
+	}
+
+	return references
+}
+
 // processAliasNode extracts alias information from an alias element node
 func processAliasNode(node *tree_sitter.Node, data []byte, path string) Service {
 	alias := Service{
@@ -306,11 +355,17 @@ func processServicesNode(node *tree_sitter.Node, data []byte, path string) []Ser
 
 		// Fast string comparison
 		elementName := nameNode.Utf8Text(data)
-		if string(elementName) == "service" {
+		switch string(elementName) {
+		case "service":
 			service := processServiceNode(child, data, path)
 			if service.ID != "" {
 				services = append(services, service)
 			}
+		case "alias":
+			alias := processAliasNode(child, data, path)
+			if alias.ID != "" {
+				services = append(services, alias)
+			}
 		}
 	}
 
@@ -80,6 +80,19 @@ func TestParseXMLServices(t *testing.T) {
 <container>
     <service id="app.service1" class="App\Service\Service1" />
     <alias id="app.alias1" service="app.service1" />
+</container>`,
+			expectedServices:   1,
+			expectedAliases:    1,
+			expectedParameters: 0,
+			expectedTags:       map[string][]string{},
+			expectError:        false,
+		},
+		{
+			name: "Service with alias attribute",
+			xmlContent: `<?xml version="1.0" encoding="UTF-8" ?>
+<container>
+    <service id="app.service1" class="App\Service\Service1" />
+    <service id="App\Service\Service1Interface" alias="app.service1" />
 </container>`,
 			expectedServices:   1,
 			expectedAliases:    1,
@@ -30,6 +30,10 @@ func (s *SystemConfigIndexer) ID() string {
 	return "systemconfig.indexer"
 }
 
+func (s *SystemConfigIndexer) FileExtensions() []string {
+	return []string{".xml"}
+}
+
 // Index processes a file and indexes any system config entries found
 func (s *SystemConfigIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	// Skip non-system config files
@@ -76,9 +80,17 @@ func (s *SystemConfigIndexer) Clear() error {
 	return s.configIndex.Clear()
 }
 
-// GetSystemConfigEntries returns all system config entry keys
+func (s *SystemConfigIndexer) BeginBatch() error {
+	return s.configIndex.BeginBatch()
+}
+
+func (s *SystemConfigIndexer) Commit() error {
+	return s.configIndex.Commit()
+}
+
+// GetSystemConfigEntries returns all system config entry keys in alphabetical order
 func (s *SystemConfigIndexer) GetSystemConfigEntries() ([]string, error) {
-	return s.configIndex.GetAllKeys()
+	return s.configIndex.GetAllKeysSorted()
 }
 
 // GetSystemConfigEntry returns all entries for a specific key
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
@@ -49,6 +50,50 @@ func ParseThemeConfig(root *tree_sitter.Node, document []byte, filePath string)
 	return fields, nil
 }
 
+// ParseConfigInheritance returns the theme names listed in configInheritance without their @ prefix
+func ParseConfigInheritance(root *tree_sitter.Node, document []byte) []string {
+	if root.Kind() == "document" && root.NamedChildCount() > 0 {
+		root = root.NamedChild(0)
+	}
+
+	var themes []string
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		pair := root.NamedChild(uint(i))
+		if pair.Kind() != "pair" {
+			continue
+		}
+
+		key := pair.NamedChild(0)
+		value := pair.NamedChild(1)
+		if key == nil || value == nil || extractStringContent(key, document) != "configInheritance" || value.Kind() != "array" {
+			continue
+		}
+
+		for j := 0; j < int(value.NamedChildCount()); j++ {
+			if entry := value.NamedChild(uint(j)); entry.Kind() == "string" {
+				themes = append(themes, strings.TrimPrefix(extractStringContent(entry, document), "@"))
+			}
+		}
+	}
+
+	return themes
+}
+
+// ThemeNameByPath returns the technical name of the theme, the name of the bundle containing Resources/theme.json
+func ThemeNameByPath(themePath string) string {
+	index := strings.Index(themePath, "Resources/theme.json")
+	if index == -1 {
+		return ""
+	}
+
+	bundlePath := strings.Trim(themePath[:index], "/")
+	if filepath.Base(bundlePath) == "src" {
+		bundlePath = filepath.Dir(bundlePath)
+	}
+
+	return filepath.Base(bundlePath)
+}
+
 // findConfigNode finds the config section in the theme.json
 func findConfigNode(root *tree_sitter.Node, document []byte) *tree_sitter.Node {
 	for i := 0; i < int(root.NamedChildCount()); i++ {
@@ -2,6 +2,7 @@ package theme
 
 import (
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/indexer"
@@ -12,6 +13,7 @@ import (
 // ThemeConfigIndexer is responsible for indexing theme.json files
 type ThemeConfigIndexer struct {
 	configIndex *indexer.DataIndexer[ThemeConfigField]
+	themeIndex  *indexer.DataIndexer[Theme]
 }
 
 // NewThemeConfigIndexer creates a new theme config indexer
@@ -21,8 +23,14 @@ func NewThemeConfigIndexer(configDir string) (*ThemeConfigIndexer, error) {
 		return nil, err
 	}
 
+	themeIndexer, err := indexer.NewDataIndexer[Theme](filepath.Join(configDir, "theme.db"))
+	if err != nil {
+		return nil, err
+	}
+
 	return &ThemeConfigIndexer{
 		configIndex: configIndexer,
+		themeIndex:  themeIndexer,
 	}, nil
 }
 
@@ -31,6 +39,10 @@ func (t *ThemeConfigIndexer) ID() string {
 	return "theme.indexer"
 }
 
+func (t *ThemeConfigIndexer) FileExtensions() []string {
+	return []string{".json"}
+}
+
 // Index processes a file and indexes any theme config fields found
 func (t *ThemeConfigIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
 	// Skip non-theme.json files
@@ -56,8 +68,8 @@ func (t *ThemeConfigIndexer) Index(path string, node *tree_sitter.Node, fileCont
 		return err
 	}
 
-	// Prepare batch save
-	batchSave := make(map[string]map[string]ThemeConfigField)
+	// Prepare batch save, the path is always included so fields removed from the file are dropped
+	batchSave := map[string]map[string]ThemeConfigField{path: {}}
 
 	for _, field := range fields {
 		if _, ok := batchSave[field.Path]; !ok {
@@ -66,27 +78,60 @@ func (t *ThemeConfigIndexer) Index(path string, node *tree_sitter.Node, fileCont
 		batchSave[field.Path][field.Key] = field
 	}
 
-	return t.configIndex.BatchSaveItems(batchSave)
+	if err := t.configIndex.BatchSaveItems(batchSave); err != nil {
+		return err
+	}
+
+	themes := map[string]map[string]Theme{path: {}}
+	if name := ThemeNameByPath(path); name != "" {
+		themes[path][name] = Theme{
+			Name:              name,
+			Path:              path,
+			ConfigInheritance: ParseConfigInheritance(tree.RootNode(), fileContent),
+		}
+	}
+
+	return t.themeIndex.BatchSaveItems(themes)
 }
 
 // RemovedFiles handles cleanup when files are removed
 func (t *ThemeConfigIndexer) RemovedFiles(paths []string) error {
-	return t.configIndex.BatchDeleteByFilePaths(paths)
+	if err := t.configIndex.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+
+	return t.themeIndex.BatchDeleteByFilePaths(paths)
 }
 
 // Close closes the indexer
 func (t *ThemeConfigIndexer) Close() error {
-	return t.configIndex.Close()
+	if err := t.configIndex.Close(); err != nil {
+		return err
+	}
+
+	return t.themeIndex.Close()
 }
 
 // Clear clears all indexed data
 func (t *ThemeConfigIndexer) Clear() error {
-	return t.configIndex.Clear()
+	if err := t.configIndex.Clear(); err != nil {
+		return err
+	}
+
+	return t.themeIndex.Clear()
 }
 
-// GetThemeConfigFields returns all theme config field keys
+func (t *ThemeConfigIndexer) BeginBatch() error {
+	return indexer.BeginBatches(t.configIndex, t.themeIndex)
+}
+
+func (t *ThemeConfigIndexer) Commit() error {
+	return indexer.CommitBatches(t.configIndex, t.themeIndex)
+}
+
+// GetThemeConfigFields returns all theme config field keys in alphabetical order
 func (t *ThemeConfigIndexer) GetThemeConfigFields() ([]string, error) {
-	return t.configIndex.GetAllKeys()
+	return t.configIndex.GetAllKeysSorted()
 }
 
 // GetThemeConfigField returns all fields for a specific key
@@ -99,6 +144,143 @@ func (t *ThemeConfigIndexer) GetAllThemeConfigFields() ([]ThemeConfigField, erro
 	return t.configIndex.GetAllValues()
 }
 
+// GetThemeForFile returns the theme of the bundle containing the file, e.g. for a template or SCSS file of a theme
+func (t *ThemeConfigIndexer) GetThemeForFile(path string) (*Theme, error) {
+	themes, err := t.themeIndex.GetAllValues()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Theme
+	for i := range themes {
+		bundlePath := strings.TrimSuffix(themes[i].Path, "Resources/theme.json")
+		if !strings.HasPrefix(path, bundlePath) {
+			continue
+		}
+
+		// Prefer the innermost bundle
+		if found == nil || len(themes[i].Path) > len(found.Path) {
+			found = &themes[i]
+		}
+	}
+
+	return found, nil
+}
+
+// GetConfigWithInheritance returns the config fields of the theme.json at themePath merged with the fields of the
+// themes it inherits from. Like Shopware, the Storefront theme is the base of every chain, followed by the
+// configInheritance entries. Later themes override the properties they set, labels are merged.
+func (t *ThemeConfigIndexer) GetConfigWithInheritance(themePath string) ([]ThemeConfigField, error) {
+	themes, err := t.themeIndex.GetAllValues()
+	if err != nil {
+		return nil, err
+	}
+
+	themesByName := make(map[string]Theme, len(themes))
+	var current *Theme
+	for i := range themes {
+		themesByName[strings.ToLower(themes[i].Name)] = themes[i]
+		if themes[i].Path == themePath {
+			current = &themes[i]
+		}
+	}
+
+	chain := []string{themePath}
+	if current != nil {
+		chain = inheritanceChain(*current, themesByName, make(map[string]bool))
+		if base, ok := themesByName["storefront"]; ok && base.Path != themePath {
+			chain = append([]string{base.Path}, chain...)
+		}
+	}
+
+	fields, err := t.configIndex.GetAllValues()
+	if err != nil {
+		return nil, err
+	}
+
+	fieldsByPath := make(map[string][]ThemeConfigField)
+	for _, field := range fields {
+		fieldsByPath[field.Path] = append(fieldsByPath[field.Path], field)
+	}
+
+	merged := make(map[string]ThemeConfigField)
+	seen := make(map[string]bool)
+	for _, path := range chain {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		for _, field := range fieldsByPath[path] {
+			if inherited, ok := merged[field.Key]; ok {
+				field = mergeConfigField(inherited, field)
+			}
+			merged[field.Key] = field
+		}
+	}
+
+	result := make([]ThemeConfigField, 0, len(merged))
+	for _, field := range merged {
+		result = append(result, field)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result, nil
+}
+
+// inheritanceChain returns the theme.json paths a theme inherits from in merge order, ending with the theme itself
+func inheritanceChain(theme Theme, themesByName map[string]Theme, visiting map[string]bool) []string {
+	if visiting[theme.Path] {
+		return nil
+	}
+	visiting[theme.Path] = true
+
+	var chain []string
+	for _, name := range theme.ConfigInheritance {
+		if parent, ok := themesByName[strings.ToLower(name)]; ok {
+			chain = append(chain, inheritanceChain(parent, themesByName, visiting)...)
+		}
+	}
+
+	return append(chain, theme.Path)
+}
+
+// mergeConfigField applies the properties set by an overriding definition to the inherited one.
+// A definition without a type only changes the set properties, so the flags of the inherited field are kept.
+func mergeConfigField(inherited ThemeConfigField, override ThemeConfigField) ThemeConfigField {
+	merged := inherited
+	merged.Path = override.Path
+	merged.Line = override.Line
+
+	merged.Label = make(map[string]string, len(inherited.Label)+len(override.Label))
+	for locale, label := range inherited.Label {
+		merged.Label[locale] = label
+	}
+	for locale, label := range override.Label {
+		merged.Label[locale] = label
+	}
+
+	if override.Value != "" {
+		merged.Value = override.Value
+	}
+	if override.Block != "" {
+		merged.Block = override.Block
+	}
+	if override.Order != 0 {
+		merged.Order = override.Order
+	}
+	if override.Type != "" {
+		merged.Type = override.Type
+		merged.Editable = override.Editable
+		merged.Scss = override.Scss
+	}
+
+	return merged
+}
+
 // IsThemeFile checks if a file is a theme.json file
 func IsThemeFile(path string) bool {
 	return strings.HasSuffix(path, "theme.json")
@@ -62,3 +62,82 @@ func TestThemeConfigIndexer(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, emptyKeys)
 }
+
+func TestThemeConfigIndexer_GetConfigWithInheritance(t *testing.T) {
+	indexer, err := NewThemeConfigIndexer(t.TempDir())
+	require.NoError(t, err)
+	defer func() { _ = indexer.Close() }()
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_json.Language())))
+
+	storefrontPath := "/project/vendor/shopware/storefront/Resources/theme.json"
+	basePath := "/project/custom/plugins/BaseTheme/src/Resources/theme.json"
+	childPath := "/project/custom/plugins/ChildTheme/src/Resources/theme.json"
+
+	for path, content := range map[string]string{
+		storefrontPath: `{"name": "Shopware default theme", "config": {"fields": {
+			"sw-color-brand-primary": {"label": {"en-GB": "Primary colour"}, "type": "color", "value": "#0042a0", "editable": true},
+			"sw-font-family-base": {"type": "text", "value": "Inter"}
+		}}}`,
+		basePath: `{"name": "Base", "configInheritance": ["@Storefront"], "config": {"fields": {
+			"sw-color-brand-primary": {"label": {"de-DE": "Primärfarbe"}, "value": "#ff0000"},
+			"base-banner": {"type": "media"}
+		}}}`,
+		childPath: `{"name": "Child", "configInheritance": ["@Storefront", "@BaseTheme"], "config": {"fields": {
+			"sw-font-family-base": {"type": "fontFamily", "value": "Roboto", "scss": false},
+			"child-logo": {"type": "media"}
+		}}}`,
+		"/project/custom/plugins/OtherTheme/src/Resources/theme.json": `{"name": "Other", "config": {"fields": {
+			"other-field": {"type": "text"}
+		}}}`,
+	} {
+		tree := parser.Parse([]byte(content), nil)
+		require.NoError(t, indexer.Index(path, tree.RootNode(), []byte(content)))
+		tree.Close()
+	}
+
+	fields, err := indexer.GetConfigWithInheritance(childPath)
+	require.NoError(t, err)
+
+	byKey := make(map[string]ThemeConfigField)
+	var keys []string
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+		byKey[field.Key] = field
+	}
+	assert.Equal(t, []string{"base-banner", "child-logo", "sw-color-brand-primary", "sw-font-family-base"}, keys)
+
+	primary := byKey["sw-color-brand-primary"]
+	assert.Equal(t, "#ff0000", primary.Value)
+	assert.Equal(t, "color", primary.Type)
+	assert.True(t, primary.Editable)
+	assert.Equal(t, map[string]string{"en-GB": "Primary colour", "de-DE": "Primärfarbe"}, primary.Label)
+	assert.Equal(t, basePath, primary.Path)
+
+	font := byKey["sw-font-family-base"]
+	assert.Equal(t, "Roboto", font.Value)
+	assert.Equal(t, "fontFamily", font.Type)
+	assert.False(t, font.Scss)
+
+	// The Storefront theme is the base of every theme
+	fields, err = indexer.GetConfigWithInheritance("/project/custom/plugins/OtherTheme/src/Resources/theme.json")
+	require.NoError(t, err)
+	assert.Len(t, fields, 3)
+
+	theme, err := indexer.GetThemeForFile("/project/custom/plugins/ChildTheme/src/Resources/views/storefront/base.html.twig")
+	require.NoError(t, err)
+	require.NotNil(t, theme)
+	assert.Equal(t, "ChildTheme", theme.Name)
+	assert.Equal(t, []string{"Storefront", "BaseTheme"}, theme.ConfigInheritance)
+
+	theme, err = indexer.GetThemeForFile("/project/custom/plugins/MyPlugin/src/Resources/views/storefront/base.html.twig")
+	require.NoError(t, err)
+	assert.Nil(t, theme)
+
+	require.NoError(t, indexer.RemovedFiles([]string{basePath}))
+	fields, err = indexer.GetConfigWithInheritance(childPath)
+	require.NoError(t, err)
+	assert.Len(t, fields, 3)
+}
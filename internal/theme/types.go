@@ -13,3 +13,10 @@ type ThemeConfigField struct {
 	Line     int    // Line number where the field is defined
 	Scss     bool
 }
+
+// Theme represents a theme.json file and the themes it inherits its config from
+type Theme struct {
+	Name              string   // Technical name of the theme, which is the name of its bundle
+	Path              string   // Path to the theme.json file
+	ConfigInheritance []string // Themes whose config fields are inherited, e.g. @Storefront
+}
@@ -0,0 +1,94 @@
+package treesitterhelper
+
+import (
+	"slices"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// JSONStringNode returns the string node the node belongs to, which is the node itself or its parent
+// when the cursor is on a quote or the content
+func JSONStringNode(node *tree_sitter.Node) *tree_sitter.Node {
+	if node != nil && node.Kind() != "string" {
+		node = node.Parent()
+	}
+	if node == nil || node.Kind() != "string" {
+		return nil
+	}
+
+	return node
+}
+
+// JSONStringValue returns the content of a JSON string node without quotes
+func JSONStringValue(node *tree_sitter.Node, content []byte) string {
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		if child := node.NamedChild(i); child.Kind() == "string_content" {
+			return child.Utf8Text(content)
+		}
+	}
+
+	return ""
+}
+
+// isJSONRootProperty reports whether the node is the value of one of the given properties of the root object
+func isJSONRootProperty(value *tree_sitter.Node, content []byte, keys []string) bool {
+	pair := value.Parent()
+	if pair == nil || pair.Kind() != "pair" {
+		return false
+	}
+
+	root := pair.Parent()
+	if root == nil || root.Kind() != "object" || root.Parent() == nil || root.Parent().Kind() != "document" {
+		return false
+	}
+
+	key := pair.ChildByFieldName("key")
+	return key != nil && slices.Contains(keys, JSONStringValue(key, content))
+}
+
+// JSONArrayEntryPattern matches a string inside the array of one of the given properties of the root object
+// Matches the quotes as well, so completion works in an empty string
+//
+// Example: {"views": ["@Storefront", "<caret>"]}
+func JSONArrayEntryPattern(keys ...string) Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		node = JSONStringNode(node)
+		if node == nil {
+			return false
+		}
+
+		array := node.Parent()
+		return array != nil && array.Kind() == "array" && isJSONRootProperty(array, content, keys)
+	})
+}
+
+// JSONPropertyNamePattern matches the property name of an entry in the object of one of the given properties
+// of the root object, also while the entry has no value yet
+//
+// Example: {"require": {"<caret>"}}
+func JSONPropertyNamePattern(keys ...string) Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		node = JSONStringNode(node)
+		if node == nil {
+			return false
+		}
+
+		parent := node.Parent()
+		if parent == nil {
+			return false
+		}
+
+		switch parent.Kind() {
+		case "pair":
+			if key := parent.ChildByFieldName("key"); key == nil || !key.Equals(*node) {
+				return false
+			}
+		case "ERROR":
+		default:
+			return false
+		}
+
+		object := parent.Parent()
+		return object != nil && object.Kind() == "object" && isJSONRootProperty(object, content, keys)
+	})
+}
@@ -2,6 +2,8 @@ package treesitterhelper
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
@@ -41,6 +43,9 @@ func IsPHPThisMethodCall(methodName string) Pattern {
 			NodeKind("string_content"),
 			NodeKind("encapsed_string"),
 			NodeKind("string"),
+			// the quote of an empty string, e.g. right after typing renderStorefront('
+			NodeKind("'"),
+			NodeKind(`"`),
 		),
 		Ancestor(
 			And(
@@ -55,6 +60,200 @@ func IsPHPThisMethodCall(methodName string) Pattern {
 	)
 }
 
+// IsPHPTemplateRenderCall matches a string passed to a controller render call
+// $this->renderStorefront('<caret>'), $this->render('<caret>') or $this->renderView('<caret>')
+func IsPHPTemplateRenderCall() Pattern {
+	return Or(
+		IsPHPThisMethodCall("renderStorefront"),
+		IsPHPThisMethodCall("render"),
+		IsPHPThisMethodCall("renderView"),
+	)
+}
+
+// IsPHPNewExpressionArgument matches a string passed as the argument at argumentIndex
+// to the constructor of one of the given classes
+// new EqualsFilter('<caret>', $value)
+func IsPHPNewExpressionArgument(argumentIndex int, classNames ...string) Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		call, index := phpStringArgumentCall(node)
+		if call == nil || index != argumentIndex || call.Kind() != "object_creation_expression" {
+			return false
+		}
+
+		for i := uint(0); i < call.NamedChildCount(); i++ {
+			child := call.NamedChild(i)
+			if child.Kind() == "name" || child.Kind() == "qualified_name" {
+				className := child.Utf8Text(content)
+				return slices.Contains(classNames, className[strings.LastIndex(className, "\\")+1:])
+			}
+		}
+
+		return false
+	})
+}
+
+// IsPHPAttributeArgument matches a string passed as the argument at argumentIndex
+// to one of the given attributes
+// #[Package('<caret>')]
+func IsPHPAttributeArgument(argumentIndex int, attributeNames ...string) Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		attribute, index := phpStringArgumentCall(node)
+		if attribute == nil || index != argumentIndex || attribute.Kind() != "attribute" {
+			return false
+		}
+
+		nameNode := attribute.NamedChild(0)
+		if nameNode == nil || (nameNode.Kind() != "name" && nameNode.Kind() != "qualified_name") {
+			return false
+		}
+
+		attributeName := nameNode.Utf8Text(content)
+		return slices.Contains(attributeNames, attributeName[strings.LastIndex(attributeName, "\\")+1:])
+	})
+}
+
+// IsPHPContainerGetArgument matches the service id passed to the get method of a container
+// $container->get('<caret>') or $this->container->get('<caret>')
+func IsPHPContainerGetArgument() Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		call, index := phpStringArgumentCall(node)
+		if call == nil || index != 0 || call.Kind() != "member_call_expression" {
+			return false
+		}
+
+		nameNode := call.ChildByFieldName("name")
+		if nameNode == nil || nameNode.Utf8Text(content) != "get" {
+			return false
+		}
+
+		object := call.ChildByFieldName("object")
+		return object != nil && strings.Contains(strings.ToLower(object.Utf8Text(content)), "container")
+	})
+}
+
+// IsPHPSubscribedEventKey matches an array key string in the getSubscribedEvents method of an event subscriber
+// return ['<caret>' => 'onEvent'];
+func IsPHPSubscribedEventKey() Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		stringNode := node
+		for stringNode != nil && stringNode.Kind() != "string" && stringNode.Kind() != "encapsed_string" {
+			switch stringNode.Kind() {
+			case "string_content", "'", "\"":
+				stringNode = stringNode.Parent()
+			default:
+				return false
+			}
+		}
+
+		if stringNode == nil {
+			return false
+		}
+
+		// While typing the key there is no "=>" yet, so the key is the first element either way
+		element := stringNode.Parent()
+		if element == nil || element.Kind() != "array_element_initializer" || element.NamedChild(0).Id() != stringNode.Id() {
+			return false
+		}
+
+		// Only keys of the returned array, nested arrays hold the listener methods
+		array := element.Parent()
+		if array == nil || array.Parent() == nil || array.Parent().Kind() != "return_statement" {
+			return false
+		}
+
+		for parent := array.Parent(); parent != nil; parent = parent.Parent() {
+			if parent.Kind() == "method_declaration" {
+				nameNode := parent.ChildByFieldName("name")
+				return nameNode != nil && nameNode.Utf8Text(content) == "getSubscribedEvents"
+			}
+		}
+
+		return false
+	})
+}
+
+// IsPHPClassConstantName matches the constant name of a class constant access
+// CheckoutEvents::<caret>ORDER_PLACED
+func IsPHPClassConstantName() Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		if node.Kind() != "name" {
+			return false
+		}
+
+		parent := node.Parent()
+		if parent == nil || parent.Kind() != "class_constant_access_expression" || parent.NamedChildCount() < 2 {
+			return false
+		}
+
+		// The first child is the class, Foo::class is not a constant
+		return parent.NamedChild(parent.NamedChildCount()-1).Id() == node.Id() && node.Utf8Text(content) != "class"
+	})
+}
+
+// IsPHPStaticMemberAccess matches the "::" or the (possibly still missing) member name of a static access
+// CheckoutEvents::<caret> or Uuid::<caret>randomHex()
+func IsPHPStaticMemberAccess() Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		parent := node.Parent()
+		if parent == nil {
+			return false
+		}
+
+		switch parent.Kind() {
+		case "class_constant_access_expression":
+			return node.Kind() == "::" || (node.Kind() == "name" && parent.NamedChild(0).Id() != node.Id())
+		case "scoped_call_expression":
+			nameNode := parent.ChildByFieldName("name")
+			return node.Kind() == "::" || (nameNode != nil && nameNode.Id() == node.Id())
+		}
+
+		return false
+	})
+}
+
+// GetPHPStaticAccessScope returns the scope node (Foo, self, parent) of the static access a node belongs to
+func GetPHPStaticAccessScope(node *tree_sitter.Node) *tree_sitter.Node {
+	parent := node.Parent()
+	if parent == nil {
+		return nil
+	}
+
+	switch parent.Kind() {
+	case "class_constant_access_expression":
+		return parent.NamedChild(0)
+	case "scoped_call_expression":
+		return parent.ChildByFieldName("scope")
+	}
+
+	return nil
+}
+
+// phpStringArgumentCall returns the call a string node is passed to and the argument position
+func phpStringArgumentCall(node *tree_sitter.Node) (*tree_sitter.Node, int) {
+	argument := node
+	for argument != nil && argument.Kind() != "argument" {
+		switch argument.Kind() {
+		case "string_content", "string", "encapsed_string", "'", "\"":
+			argument = argument.Parent()
+		default:
+			return nil, -1
+		}
+	}
+
+	if argument == nil || argument.Parent() == nil || argument.Parent().Kind() != "arguments" {
+		return nil, -1
+	}
+
+	arguments := argument.Parent()
+	for i := uint(0); i < arguments.NamedChildCount(); i++ {
+		if arguments.NamedChild(i).Id() == argument.Id() {
+			return arguments.Parent(), int(i)
+		}
+	}
+
+	return nil, -1
+}
+
 // IsThisMethodCall checks if the node represents a $this->method() call
 func IsThisMethodCall(node *tree_sitter.Node, fileContent []byte) bool {
 	// Check that this is a member call expression
@@ -166,3 +365,33 @@ func GetClassName(node *tree_sitter.Node, content []byte) string {
 
 	return fmt.Sprintf("%s\\%s", ns, className)
 }
+
+// IsPHPMemberAccess matches the "->" of a property access or method call on an object and the member name after it
+func IsPHPMemberAccess() Pattern {
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		return GetPHPMemberAccessObject(node) != nil
+	})
+}
+
+// GetPHPMemberAccessObject returns the object expression left of "->" for a node matched by IsPHPMemberAccess.
+// Without a terminating semicolon "$product->" ends up in an error node.
+func GetPHPMemberAccessObject(node *tree_sitter.Node) *tree_sitter.Node {
+	parent := node.Parent()
+	if parent == nil {
+		return nil
+	}
+
+	switch parent.Kind() {
+	case "member_access_expression", "nullsafe_member_access_expression", "member_call_expression", "nullsafe_member_call_expression":
+		nameNode := parent.ChildByFieldName("name")
+		if node.Kind() == "->" || node.Kind() == "?->" || (nameNode != nil && nameNode.Id() == node.Id()) {
+			return parent.ChildByFieldName("object")
+		}
+	case "ERROR":
+		if node.Kind() == "->" || node.Kind() == "?->" {
+			return node.PrevNamedSibling()
+		}
+	}
+
+	return nil
+}
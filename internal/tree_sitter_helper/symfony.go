@@ -253,3 +253,103 @@ func SymfonyServiceIsTagElement(node *tree_sitter.Node, docText []byte) bool {
 
 	return true
 }
+
+// SymfonyServiceIsServiceClass returns true if the node is the class attribute of a service
+// <service id="foo" class="<caret>">
+func SymfonyServiceIsServiceClass(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "service", "class")
+}
+
+// SymfonyServiceIsFactoryClass returns true if the node is the class attribute of a factory
+// <factory class="<caret>" method="create"/>
+func SymfonyServiceIsFactoryClass(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "factory", "class")
+}
+
+// SymfonyServiceIsServiceReferenceAttribute returns true if the node is a service attribute referencing another service
+// <service id="foo" decorates="<caret>" parent="<caret>">
+func SymfonyServiceIsServiceReferenceAttribute(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "service", "decorates", "parent")
+}
+
+// SymfonyServiceIsAliasAttribute returns true if the node is the id or the target service of an alias
+// <alias id="<caret>" service="<caret>"/>
+func SymfonyServiceIsAliasAttribute(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "alias", "id", "service")
+}
+
+// SymfonyServiceIsDefinitionId returns true if the node is the id of a service or alias definition
+// <service id="<caret>"/> or <alias id="<caret>"/>
+func SymfonyServiceIsDefinitionId(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "service", "id") || isXmlAttributeValueOf(node, docText, "alias", "id")
+}
+
+// SymfonyServiceIsArgumentType returns true if the node is the type attribute of an argument
+// <argument type="<caret>"/>
+func SymfonyServiceIsArgumentType(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "argument", "type")
+}
+
+// SymfonyServiceIsCallMethod returns true if the node is the method of a method call of a service
+// <call method="<caret>"/>
+func SymfonyServiceIsCallMethod(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "call", "method")
+}
+
+// SymfonyGetEnclosingServiceClass returns the class of the service element containing the node,
+// which is the id when the service has no class attribute
+// <service id="foo" class="<get-this>"><call method="<caret>"/></service>
+func SymfonyGetEnclosingServiceClass(node *tree_sitter.Node, docText []byte) string {
+	for element := node.Parent(); element != nil; element = element.Parent() {
+		if element.Kind() != "element" || element.NamedChildCount() == 0 {
+			continue
+		}
+
+		startTag := element.NamedChild(0)
+		nameNode := GetFirstNodeOfKind(startTag, "Name")
+		if nameNode == nil || nameNode.Utf8Text(docText) != "service" {
+			continue
+		}
+
+		attributes := GetXmlAttributeValues(startTag, docText)
+		if attributes["class"] != "" {
+			return attributes["class"]
+		}
+		return attributes["id"]
+	}
+
+	return ""
+}
+
+// SymfonyServiceIsEventAttribute returns true if the node is the event of a listener tag
+// <tag name="kernel.event_listener" event="<caret>"/>
+func SymfonyServiceIsEventAttribute(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "tag", "event")
+}
+
+// SymfonyServiceIsImportResource returns true if the node is the resource of an import
+// <import resource="<caret>"/>
+func SymfonyServiceIsImportResource(node *tree_sitter.Node, docText []byte) bool {
+	return isXmlAttributeValueOf(node, docText, "import", "resource")
+}
+
+// isXmlAttributeValueOf checks if the node is the value of one of the given attributes on the given element
+func isXmlAttributeValueOf(node *tree_sitter.Node, docText []byte, element string, attributes ...string) bool {
+	if node.Kind() != "AttValue" || node.Parent() == nil || node.Parent().Kind() != "Attribute" {
+		return false
+	}
+
+	attrNode := node.Parent()
+
+	nameNode := GetFirstNodeOfKind(attrNode, "Name")
+	if nameNode == nil || !slices.Contains(attributes, nameNode.Utf8Text(docText)) {
+		return false
+	}
+
+	tagName := GetFirstNodeOfKind(attrNode.Parent(), "Name")
+	if tagName == nil {
+		return false
+	}
+
+	return tagName.Utf8Text(docText) == element
+}
@@ -1,27 +1,64 @@
 package treesitterhelper
 
 import (
+	"bytes"
+	"regexp"
 	"strings"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
+// TwigTransPattern matches the snippet key string of a trans filter
+// Other filters may be chained before or after trans
+//
+// Example: {{ 'snippet.key'|trans }} or {{ 'snippet.key'|trans|sw_sanitize }}
 func TwigTransPattern() Pattern {
-	return And(
-		NodeKind("string"),
-		Ancestor(
-			And(
-				NodeKind("filter_expression"),
-				HasChild(
-					And(
-						NodeKind("function"),
-						NodeText("trans"),
-					),
-				),
-			),
-			1,
-		),
-	)
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		if node.Kind() != "string" {
+			return false
+		}
+
+		// Walk up the filter chain as long as the node is the filtered object
+		current := node
+		for parent := node.Parent(); parent != nil && parent.Kind() == "filter_expression"; parent = parent.Parent() {
+			object := parent.ChildByFieldName("object")
+			if object == nil || !object.Equals(*current) {
+				return false
+			}
+
+			if name := parent.ChildByFieldName("name"); name != nil && name.Utf8Text(content) == "trans" {
+				return true
+			}
+
+			current = parent
+		}
+
+		return false
+	})
+}
+
+// TwigTransTagContentPattern matches the snippet key inside a trans tag
+// Matches the closing delimiter of the opening tag as well, so an empty tag body can be completed
+//
+// Example: {% trans %}snippet.key{% endtrans %}
+func TwigTransTagContentPattern() Pattern {
+	isTransTag := func(node *tree_sitter.Node, content []byte) bool {
+		if node == nil || node.Kind() != "tag" {
+			return false
+		}
+		keyword := GetFirstNodeOfKind(node, "keyword")
+		return keyword != nil && keyword.Utf8Text(content) == "trans"
+	}
+
+	return FuncPattern(func(node *tree_sitter.Node, content []byte) bool {
+		switch node.Kind() {
+		case "html_content":
+			return isTransTag(node.PrevSibling(), content)
+		case "embedded_end":
+			return isTransTag(node.Parent(), content)
+		}
+		return false
+	})
 }
 
 // TwigAdminSnippetPattern matches {{ $tc('snippet.key') }} or {{ $t('snippet.key') }}
@@ -163,3 +200,203 @@ func extractPairKeyValue(pairNode *tree_sitter.Node, content []byte) (string, st
 
 	return key, value
 }
+
+// TwigIncludeWithObject returns the hash passed with "with" to an include or sw_include tag and the
+// string naming the included template, when the node is at the position of a key inside of the hash
+//
+// Example: {% sw_include '@Storefront/storefront/component/foo.html.twig' with { <caret> } %}
+func TwigIncludeWithObject(node *tree_sitter.Node, content []byte) (*tree_sitter.Node, *tree_sitter.Node) {
+	var object *tree_sitter.Node
+
+	switch node.Kind() {
+	case "object":
+		object = node
+	case "{", ",":
+		object = node.Parent()
+	case "variable":
+		object = node.Parent()
+		if object != nil && object.Kind() == "pair" {
+			if key := object.ChildByFieldName("key"); key == nil || !key.Equals(*node) {
+				return nil, nil
+			}
+			object = object.Parent()
+		}
+	}
+
+	if object == nil || object.Kind() != "object" {
+		return nil, nil
+	}
+
+	tag := object.Parent()
+	if tag == nil {
+		return nil, nil
+	}
+
+	switch tag.Kind() {
+	case "include":
+		// {% include 'foo.html.twig' with { ... } %}
+		if variables := tag.ChildByFieldName("variables"); variables == nil || !variables.Equals(*object) {
+			return nil, nil
+		}
+		return object, tag.ChildByFieldName("expr")
+	case "tag":
+		// {% sw_include 'foo.html.twig' with { ... } %}
+		keyword := tag.ChildByFieldName("name")
+		with := object.PrevNamedSibling()
+		if keyword == nil || keyword.Utf8Text(content) != "sw_include" || with == nil || with.Utf8Text(content) != "with" {
+			return nil, nil
+		}
+		return object, GetFirstNodeOfKind(tag, "string")
+	}
+
+	return nil, nil
+}
+
+// TwigOutputVariablePath returns the members accessed before the node in a {{ }} expression, ok is false when the
+// node is not a variable or property of such an expression. The path is empty for a variable.
+//
+// Example: {{ page.product.<caret> }} returns ["page", "product"]
+func TwigOutputVariablePath(node *tree_sitter.Node, content []byte) ([]string, bool) {
+	var object *tree_sitter.Node
+
+	switch node.Kind() {
+	case "variable":
+	case "property":
+		// {{ page.pro<caret> }}
+		parent := node.Parent()
+		if parent == nil || parent.Kind() != "member_expression" {
+			return nil, false
+		}
+		object = parent.ChildByFieldName("object")
+	case ".":
+		// {{ page.<caret> is not complete and the dot follows the object inside an error node
+		object = node.PrevSibling()
+		if object == nil {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	if !isInTwigOutput(node, content) {
+		return nil, false
+	}
+
+	if object == nil {
+		return []string{}, true
+	}
+
+	path := twigMemberPath(object, content)
+	if path == nil {
+		return nil, false
+	}
+
+	return path, true
+}
+
+// twigMemberPath flattens variables and member expressions like page.product, anything else returns nil
+func twigMemberPath(node *tree_sitter.Node, content []byte) []string {
+	switch node.Kind() {
+	case "variable":
+		return []string{node.Utf8Text(content)}
+	case "member_expression":
+		object := node.ChildByFieldName("object")
+		property := node.ChildByFieldName("property")
+		if object == nil || property == nil {
+			return nil
+		}
+
+		path := twigMemberPath(object, content)
+		if path == nil {
+			return nil
+		}
+
+		return append(path, property.Utf8Text(content))
+	}
+
+	return nil
+}
+
+// isInTwigOutput checks for an enclosing {{ }}. While the expression is not closed, the parser puts it into
+// an error node together with the preceding tags, so the last opening delimiter before the node decides.
+func isInTwigOutput(node *tree_sitter.Node, content []byte) bool {
+	child := node
+	for current := node.Parent(); current != nil; child, current = current, current.Parent() {
+		switch current.Kind() {
+		case "output":
+			return true
+		case "ERROR":
+			for sibling := child.PrevSibling(); sibling != nil; sibling = sibling.PrevSibling() {
+				if sibling.Kind() == "embedded_begin" {
+					return sibling.Utf8Text(content) == "{{"
+				}
+			}
+			return false
+		}
+	}
+
+	return false
+}
+
+// twigTestPrefixPattern matches the is operator followed by the part of the test name typed so far
+var twigTestPrefixPattern = regexp.MustCompile(`\bis(\s+not)?\s+\w*$`)
+
+// TwigIsTestName checks whether the cursor at offset is at the name of a test after the is operator.
+// Incomplete tests like {{ foo is }} don't parse, so the text of the expression before the cursor decides.
+//
+// Example: {% if foo is <caret> %} or {{ foo is not def<caret> }}
+func TwigIsTestName(content []byte, offset int) bool {
+	if offset < 0 || offset > len(content) {
+		return false
+	}
+
+	before := content[:offset]
+	start := max(bytes.LastIndex(before, []byte("{{")), bytes.LastIndex(before, []byte("{%")))
+	if start == -1 || bytes.LastIndex(before, []byte("}}")) > start || bytes.LastIndex(before, []byte("%}")) > start {
+		return false
+	}
+
+	expression := before[start:]
+	// An odd number of quotes means the cursor is inside a string like {{ 'this is <caret>' }}
+	if bytes.Count(expression, []byte("'"))%2 == 1 || bytes.Count(expression, []byte(`"`))%2 == 1 {
+		return false
+	}
+
+	return twigTestPrefixPattern.Match(expression)
+}
+
+// TwigImportedTemplate returns the template imported with the alias by an import tag of the document,
+// _self is returned as it is. The result is empty when no import uses the alias.
+//
+// Example: {% import '@Storefront/storefront/macros.twig' as macros %}
+func TwigImportedTemplate(node *tree_sitter.Node, alias string, content []byte) string {
+	rootNode := node
+	for rootNode.Parent() != nil {
+		rootNode = rootNode.Parent()
+	}
+
+	var template string
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		if template != "" {
+			return
+		}
+
+		if node.Kind() == "import" {
+			variable := node.ChildByFieldName("variable")
+			expr := node.ChildByFieldName("expr")
+			if variable != nil && expr != nil && variable.Utf8Text(content) == alias {
+				template = strings.Trim(expr.Utf8Text(content), "'\"")
+			}
+			return
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(rootNode)
+
+	return template
+}
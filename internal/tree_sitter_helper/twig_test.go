@@ -1,6 +1,7 @@
 package treesitterhelper
 
 import (
+	"strings"
 	"testing"
 
 	tree_sitter_twig "github.com/shopware/shopware-lsp/internal/tree_sitter_grammars/twig/bindings/go"
@@ -268,3 +269,29 @@ func TestExtractSwIconObjectToMap(t *testing.T) {
 		assert.Len(t, result, 2, "Should have exactly 2 pairs")
 	}
 }
+
+func TestTwigIsTestName(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected bool
+	}{
+		{"{{ foo is <caret> }}", true},
+		{"{{ foo is<caret> }}", false},
+		{"{% if foo is not def<caret> %}{% endif %}", true},
+		{"{% if foo.bar is <caret> and bar %}{% endif %}", true},
+		{"{% if\n    foo is <caret>\n%}{% endif %}", true},
+		{"{{ 'this is <caret>' }}", false},
+		{"{{ foo }} is <caret>", false},
+		{"{{ foo|is<caret> }}", false},
+		{"{{ island <caret> }}", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			offset := strings.Index(tt.code, "<caret>")
+			content := []byte(strings.Replace(tt.code, "<caret>", "", 1))
+
+			assert.Equal(t, tt.expected, TwigIsTestName(content, offset))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 
+	treesitterhelper "github.com/shopware/shopware-lsp/internal/tree_sitter_helper"
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
@@ -39,6 +40,16 @@ type TwigFilter struct {
 	FilePath string
 }
 
+// TwigTest represents a test defined in a Twig extension, used with the is operator
+type TwigTest struct {
+	// Name of the test as used in Twig templates, e.g. "same as"
+	Name string
+	// Line number where the test is defined
+	Line int
+	// FilePath is the path to the file where the TwigTest is defined.
+	FilePath string
+}
+
 // TwigParameter represents a parameter for a function or filter
 type TwigParameter struct {
 	// Parameter name including $ prefix
@@ -134,6 +145,54 @@ func ParseTwigExtension(filePath string, rootNode *tree_sitter.Node, content []b
 	return functions, filters, nil
 }
 
+// ParseTwigTests parses the tests returned by the getTests method of a Twig extension class
+func ParseTwigTests(filePath string, rootNode *tree_sitter.Node, content []byte) []TwigTest {
+	if !bytes.Contains(content, []byte("AbstractExtension")) || !bytes.Contains(content, []byte("TwigTest")) {
+		return nil
+	}
+
+	ctx := newParseContext(rootNode)
+	if ctx.declList == nil || !classExtendsAbstractExtension(ctx.classNode, content) {
+		return nil
+	}
+
+	var tests []TwigTest
+	for i := uint(0); i < ctx.declList.NamedChildCount(); i++ {
+		method := ctx.declList.NamedChild(i)
+		if method.Kind() != "method_declaration" {
+			continue
+		}
+
+		name := method.ChildByFieldName("name")
+		if name == nil || name.Utf8Text(content) != "getTests" {
+			continue
+		}
+
+		for _, creation := range treesitterhelper.FindAll(method, treesitterhelper.NodeKind("object_creation_expression"), content) {
+			className := findNodeByKind(creation, "name")
+			argsNode := findNodeByKind(creation, "arguments")
+			if className == nil || className.Utf8Text(content) != "TwigTest" || argsNode == nil || argsNode.NamedChildCount() == 0 {
+				continue
+			}
+
+			stringNode := findNodeByKind(argsNode.NamedChild(0), "string")
+			if stringNode == nil {
+				continue
+			}
+
+			if contentNode := findNodeByKind(stringNode, "string_content"); contentNode != nil {
+				tests = append(tests, TwigTest{
+					Name:     contentNode.Utf8Text(content),
+					Line:     int(creation.StartPosition().Row) + 1,
+					FilePath: filePath,
+				})
+			}
+		}
+	}
+
+	return tests
+}
+
 type parseContext struct {
 	classNode      *tree_sitter.Node
 	declList       *tree_sitter.Node
@@ -739,7 +798,7 @@ func parseParameters(paramsNode *tree_sitter.Node, content []byte) []TwigParamet
 				subChild := child.NamedChild(uint(j))
 				if subChild.Kind() == "variable_name" {
 					param.Name = string(subChild.Utf8Text(content))
-				} else if subChild.Kind() == "primitive_type" || subChild.Kind() == "union_type" || subChild.Kind() == "intersection_type" {
+				} else if subChild.Kind() == "primitive_type" || subChild.Kind() == "named_type" || subChild.Kind() == "optional_type" || subChild.Kind() == "union_type" || subChild.Kind() == "intersection_type" {
 					param.Type = string(subChild.Utf8Text(content))
 				}
 			}
@@ -55,6 +55,35 @@ func TestParseTwigExtension(t *testing.T) {
 	assert.Equal(t, filePath, filters[2].FilePath)
 }
 
+func TestParseTwigTests(t *testing.T) {
+	filePath := filepath.Join("testdata", "extension.php")
+	content, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	require.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_php.LanguagePHP())))
+	tree := parser.Parse(content, nil)
+	defer tree.Close()
+
+	tests := ParseTwigTests(filePath, tree.RootNode(), content)
+	require.Len(t, tests, 2)
+	assert.Equal(t, TwigTest{Name: "instanceof", Line: 32, FilePath: filePath}, tests[0])
+	assert.Equal(t, "same product", tests[1].Name)
+
+	functionsOnly := []byte(`<?php
+class Ext extends AbstractExtension
+{
+    public function getFunctions(): array
+    {
+        return [new TwigFunction('test', [$this, 'test'])];
+    }
+}`)
+	tree2 := parser.Parse(functionsOnly, nil)
+	defer tree2.Close()
+	assert.Empty(t, ParseTwigTests("ext.php", tree2.RootNode(), functionsOnly))
+}
+
 func TestParseTwigExtension2(t *testing.T) {
 	// Read test file
 	filePath := filepath.Join("testdata", "extension2.php")
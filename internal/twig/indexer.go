@@ -2,7 +2,6 @@ package twig
 
 import (
 	"path"
-	"path/filepath"
 	"strings"
 
 	"github.com/shopware/shopware-lsp/internal/indexer"
@@ -15,6 +14,7 @@ type TwigIndexer struct {
 	twigBlockHashIndex *indexer.DataIndexer[TwigBlockHash]
 	twigFunctionIndex  *indexer.DataIndexer[TwigFunction]
 	twigFilterIndex    *indexer.DataIndexer[TwigFilter]
+	twigTestIndex      *indexer.DataIndexer[TwigTest]
 }
 
 func NewTwigIndexer(configDir string) (*TwigIndexer, error) {
@@ -43,12 +43,18 @@ func NewTwigIndexer(configDir string) (*TwigIndexer, error) {
 		return nil, err
 	}
 
+	twigTestIndex, err := indexer.NewDataIndexer[TwigTest](path.Join(configDir, "twig_test.index"))
+	if err != nil {
+		return nil, err
+	}
+
 	return &TwigIndexer{
 		twigFileIndex:      twigFileIndex,
 		twigBlockIndex:     twigBlockIndex,
 		twigBlockHashIndex: twigBlockHashIndex,
 		twigFunctionIndex:  twigFunctionIndex,
 		twigFilterIndex:    twigFilterIndex,
+		twigTestIndex:      twigTestIndex,
 	}, nil
 }
 
@@ -56,12 +62,19 @@ func (idx *TwigIndexer) ID() string {
 	return "twig.indexer"
 }
 
+func (idx *TwigIndexer) FileExtensions() []string {
+	return []string{".twig", ".php"}
+}
+
 func (idx *TwigIndexer) Index(path string, node *tree_sitter.Node, fileContent []byte) error {
-	switch filepath.Ext(path) {
+	switch indexer.FileType(path) {
 	case ".twig":
 		return idx.indexTwig(path, node, fileContent)
 	case ".php":
-		return idx.indexExtension(path, node, fileContent)
+		if err := idx.indexExtension(path, node, fileContent); err != nil {
+			return err
+		}
+		return idx.indexTests(path, node, fileContent)
 	default:
 		return nil
 	}
@@ -163,6 +176,20 @@ func (idx *TwigIndexer) indexExtension(path string, node *tree_sitter.Node, file
 	return nil
 }
 
+func (idx *TwigIndexer) indexTests(path string, node *tree_sitter.Node, fileContent []byte) error {
+	tests := ParseTwigTests(path, node, fileContent)
+	if len(tests) == 0 {
+		return nil
+	}
+
+	testsMap := map[string]map[string]TwigTest{path: {}}
+	for _, test := range tests {
+		testsMap[path][test.Name] = test
+	}
+
+	return idx.twigTestIndex.BatchSaveItems(testsMap)
+}
+
 func (idx *TwigIndexer) RemovedFiles(paths []string) error {
 	if err := idx.twigFileIndex.BatchDeleteByFilePaths(paths); err != nil {
 		return err
@@ -184,6 +211,10 @@ func (idx *TwigIndexer) RemovedFiles(paths []string) error {
 		return err
 	}
 
+	if err := idx.twigTestIndex.BatchDeleteByFilePaths(paths); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -208,6 +239,10 @@ func (idx *TwigIndexer) Close() error {
 		return err
 	}
 
+	if err := idx.twigTestIndex.Close(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -232,13 +267,35 @@ func (idx *TwigIndexer) Clear() error {
 		return err
 	}
 
+	if err := idx.twigTestIndex.Clear(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (idx *TwigIndexer) BeginBatch() error {
+	return indexer.BeginBatches(idx.twigFileIndex, idx.twigBlockIndex, idx.twigBlockHashIndex, idx.twigFunctionIndex, idx.twigFilterIndex, idx.twigTestIndex)
+}
+
+func (idx *TwigIndexer) Commit() error {
+	return indexer.CommitBatches(idx.twigFileIndex, idx.twigBlockIndex, idx.twigBlockHashIndex, idx.twigFunctionIndex, idx.twigFilterIndex, idx.twigTestIndex)
+}
+
+// Count returns the number of indexed templates
+func (idx *TwigIndexer) Count() (int, error) {
+	return idx.twigFileIndex.CountKeys()
+}
+
 func (idx *TwigIndexer) GetAllTemplateFiles() ([]string, error) {
 	return idx.twigFileIndex.GetAllKeys()
 }
 
+// GetAllTwigFiles returns all indexed template files sorted by their relative path
+func (idx *TwigIndexer) GetAllTwigFiles() ([]TwigFile, error) {
+	return idx.twigFileIndex.GetAllValuesSorted()
+}
+
 func (idx *TwigIndexer) GetAllTwigFunctions() ([]TwigFunction, error) {
 	return idx.twigFunctionIndex.GetAllValues()
 }
@@ -262,10 +319,104 @@ func (idx *TwigIndexer) GetAllTwigFilters() ([]TwigFilter, error) {
 	return values, nil
 }
 
+// GetAllTwigTests returns the tests registered by Twig extensions
+func (idx *TwigIndexer) GetAllTwigTests() ([]TwigTest, error) {
+	return idx.twigTestIndex.GetAllValues()
+}
+
 func (idx *TwigIndexer) GetTwigFilesByRelPath(relPath string) ([]TwigFile, error) {
 	return idx.twigFileIndex.GetValues(relPath)
 }
 
+// GetTwigFilesByTemplateName returns the files of a template name like @Storefront/storefront/base.html.twig.
+// Other namespaces than @Storefront only return the files of the bundle with that name.
+func (idx *TwigIndexer) GetTwigFilesByTemplateName(name string) ([]TwigFile, error) {
+	bundle, relPath := SplitTemplateName(name)
+	if relPath == "" {
+		return nil, nil
+	}
+
+	files, err := idx.twigFileIndex.GetValues(relPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if bundle == "" || bundle == "Storefront" {
+		return files, nil
+	}
+
+	var bundleFiles []TwigFile
+	for _, file := range files {
+		if strings.EqualFold(file.BundleName, bundle) {
+			bundleFiles = append(bundleFiles, file)
+		}
+	}
+
+	return bundleFiles, nil
+}
+
+// GetTwigMacros returns the macros defined in the files of a template name, a macro defined by several files
+// is only returned once
+func (idx *TwigIndexer) GetTwigMacros(name string) []TwigMacro {
+	files, err := idx.GetTwigFilesByTemplateName(name)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var macros []TwigMacro
+	for _, file := range files {
+		for _, macro := range file.Macros {
+			if seen[macro.Name] {
+				continue
+			}
+			seen[macro.Name] = true
+			macros = append(macros, macro)
+		}
+	}
+
+	return macros
+}
+
+// GetTemplateChain returns the files of a template name and of all templates they extend, the file at skipPath
+// is left out. The second result is false when a template of the chain could not be found in the index.
+func (idx *TwigIndexer) GetTemplateChain(name string, skipPath string) ([]TwigFile, bool) {
+	var chain []TwigFile
+	complete := true
+	visited := map[string]bool{skipPath: true}
+
+	for pending := []string{name}; len(pending) > 0; pending = pending[1:] {
+		files, err := idx.GetTwigFilesByTemplateName(pending[0])
+		if err != nil {
+			return chain, false
+		}
+
+		found := false
+		for _, file := range files {
+			if file.Path == skipPath {
+				continue
+			}
+			found = true
+
+			if visited[file.Path] {
+				continue
+			}
+			visited[file.Path] = true
+
+			chain = append(chain, file)
+			if file.ExtendsFile != "" {
+				pending = append(pending, file.ExtendsFile)
+			}
+		}
+
+		if !found {
+			complete = false
+		}
+	}
+
+	return chain, complete
+}
+
 func (idx *TwigIndexer) GetTwigBlockHashes(blockName string) ([]TwigBlockHash, error) {
 	return idx.twigBlockHashIndex.GetValues(blockName)
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	tree_sitter "github.com/tree-sitter/go-tree-sitter"
@@ -12,6 +13,17 @@ import (
 
 var shopwareBlockCommentRegex = regexp.MustCompile(`\{#\s*` + VersionCommentPrefix + `\s*([a-f0-9]+)@([\w\.\-]+)\s*#\}`)
 
+var variableCommentRegex = regexp.MustCompile(`\{#\s*@var\s+([A-Za-z_]\w*)`)
+
+// twigGlobalVariables are provided by Twig and never passed by the including template
+var twigGlobalVariables = map[string]bool{
+	"app":      true,
+	"loop":     true,
+	"_self":    true,
+	"_context": true,
+	"_charset": true,
+}
+
 func calculateBlockHash(content string) string {
 	hash := sha256.New()
 	hash.Write([]byte(content))
@@ -39,6 +51,16 @@ type TwigFile struct {
 	Blocks         map[string]TwigBlock
 	ExtendsFile    string
 	ExtendsTagLine int
+	// Variables the template expects, printed with {{ }} or documented with {# @var name #}
+	Variables []string
+	Macros    []TwigMacro
+}
+
+// TwigMacro is a {% macro name(arguments) %} definition, other templates call it after importing the file
+type TwigMacro struct {
+	Name      string
+	Arguments []string
+	Line      int
 }
 
 type TwigVersionComment struct {
@@ -97,6 +119,109 @@ func findBlocks(node *tree_sitter.Node, content []byte, file *TwigFile) {
 	}
 }
 
+// findMacros collects the macro definitions with the names of their arguments
+func findMacros(node *tree_sitter.Node, content []byte) []TwigMacro {
+	var macros []TwigMacro
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		child := node.NamedChild(i)
+		if child.Kind() != "macro" {
+			macros = append(macros, findMacros(child, content)...)
+			continue
+		}
+
+		name := child.ChildByFieldName("name")
+		if name == nil {
+			continue
+		}
+
+		macro := TwigMacro{
+			Name:      name.Utf8Text(content),
+			Arguments: []string{},
+			Line:      int(name.StartPosition().Row) + 1,
+		}
+
+		if arguments := child.ChildByFieldName("arguments"); arguments != nil {
+			for j := uint(0); j < arguments.NamedChildCount(); j++ {
+				argument := arguments.NamedChild(j)
+
+				// Arguments with a default value are named arguments like currency = 'EUR'
+				if argument.Kind() == "named_argument" {
+					argument = argument.ChildByFieldName("key")
+				}
+
+				if argument != nil {
+					macro.Arguments = append(macro.Arguments, argument.Utf8Text(content))
+				}
+			}
+		}
+
+		macros = append(macros, macro)
+	}
+
+	return macros
+}
+
+// findVariables collects the variables printed by output tags and documented by @var comments.
+// Variables assigned inside the template by set or for are not expected from the outside.
+func findVariables(node *tree_sitter.Node, content []byte) []string {
+	used := make(map[string]bool)
+	assigned := make(map[string]bool)
+
+	var walk func(node *tree_sitter.Node)
+	walk = func(node *tree_sitter.Node) {
+		switch node.Kind() {
+		case "output":
+			collectOutputVariables(node, content, used)
+			return
+		case "comment":
+			if match := variableCommentRegex.FindStringSubmatch(node.Utf8Text(content)); match != nil {
+				used[match[1]] = true
+			}
+			return
+		case "set", "for":
+			for i := uint(0); i < node.ChildCount(); i++ {
+				if node.FieldNameForChild(uint32(i)) == "variable" {
+					assigned[node.Child(i).Utf8Text(content)] = true
+				}
+			}
+		}
+
+		for i := uint(0); i < node.NamedChildCount(); i++ {
+			walk(node.NamedChild(i))
+		}
+	}
+	walk(node)
+
+	variables := make([]string, 0, len(used))
+	for name := range used {
+		if !assigned[name] && !twigGlobalVariables[name] {
+			variables = append(variables, name)
+		}
+	}
+	sort.Strings(variables)
+
+	return variables
+}
+
+// collectOutputVariables adds the variables of an expression, keys of hash literals are no variables
+func collectOutputVariables(node *tree_sitter.Node, content []byte, variables map[string]bool) {
+	if node.Kind() == "variable" {
+		if parent := node.Parent(); parent != nil && parent.Kind() == "pair" {
+			if key := parent.ChildByFieldName("key"); key != nil && key.Equals(*node) {
+				return
+			}
+		}
+
+		variables[node.Utf8Text(content)] = true
+		return
+	}
+
+	for i := uint(0); i < node.NamedChildCount(); i++ {
+		collectOutputVariables(node.NamedChild(i), content, variables)
+	}
+}
+
 func findPreviousComment(blockNode *tree_sitter.Node, content []byte) *tree_sitter.Node {
 	parent := blockNode.Parent()
 	if parent == nil {
@@ -134,6 +259,10 @@ func ParseTwig(filePath string, node *tree_sitter.Node, content []byte) (*TwigFi
 		Blocks:     make(map[string]TwigBlock),
 	}
 
+	if bytes.Contains(content, []byte("{{")) || bytes.Contains(content, []byte("@var")) {
+		file.Variables = findVariables(node, content)
+	}
+
 	if !bytes.Contains(content, []byte("{%")) {
 		return file, nil
 	}
@@ -143,6 +272,10 @@ func ParseTwig(filePath string, node *tree_sitter.Node, content []byte) (*TwigFi
 		findBlocks(node, content, file)
 	}
 
+	if bytes.Contains(content, []byte("macro")) {
+		file.Macros = findMacros(node, content)
+	}
+
 	// Find extends tag
 	if !bytes.Contains(content, []byte("extends")) && !bytes.Contains(content, []byte("sw_extends")) {
 		return file, nil
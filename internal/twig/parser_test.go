@@ -113,3 +113,46 @@ func TestBlockWithVersionComment(t *testing.T) {
 	assert.Equal(t, "6.4.15.0", block.VersionComment.Version)
 	assert.Equal(t, 3, block.VersionComment.Line)
 }
+
+func TestTwigParseVariables(t *testing.T) {
+	tpl := `{# @var customer \Shopware\Core\Checkout\Customer\CustomerEntity #}
+{% set title = product.translated.name %}
+<h1>{{ title }}</h1>
+{{ product.name|trans }} {{ 'label'|trans({ '%count%': count }) }}
+{{ sw_icon_name(icon) }} {{ { showLabel: true }|json_encode }}
+{% for item in items %}{{ item.label }} {{ loop.index }}{% endfor %}
+{{ app.request.locale }}
+`
+
+	parser := tree_sitter.NewParser()
+	assert.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(tpl), nil)
+	defer tree.Close()
+
+	file, err := ParseTwig("test", tree.RootNode(), []byte(tpl))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"count", "customer", "icon", "product"}, file.Variables)
+}
+
+func TestTwigParseMacros(t *testing.T) {
+	tpl := `{% macro price(amount, currency = 'EUR') %}{{ amount }} {{ currency }}{% endmacro %}
+
+{% macro badge() %}<span></span>{% endmacro badge %}
+`
+
+	parser := tree_sitter.NewParser()
+	assert.NoError(t, parser.SetLanguage(tree_sitter.NewLanguage(tree_sitter_twig.Language())))
+
+	tree := parser.Parse([]byte(tpl), nil)
+	defer tree.Close()
+
+	file, err := ParseTwig("test", tree.RootNode(), []byte(tpl))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []TwigMacro{
+		{Name: "price", Arguments: []string{"amount", "currency"}, Line: 1},
+		{Name: "badge", Arguments: []string{}, Line: 3},
+	}, file.Macros)
+}
@@ -41,3 +41,30 @@ func getBundleNameByPath(twigPath string) string {
 
 	return "unknown"
 }
+
+// SplitTemplateName splits a template name as used in render calls into the bundle
+// namespace and the relative path used as index key. Templates of all bundles share
+// the @Storefront namespace, so @MyPlugin/foo.html.twig becomes ("MyPlugin", "@Storefront/foo.html.twig")
+func SplitTemplateName(name string) (string, string) {
+	if !strings.HasPrefix(name, "@") {
+		return "", fmt.Sprintf("@Storefront/%s", strings.TrimPrefix(name, "/"))
+	}
+
+	bundle, path, found := strings.Cut(strings.TrimPrefix(name, "@"), "/")
+	if !found {
+		return "", ""
+	}
+
+	return bundle, fmt.Sprintf("@Storefront/%s", path)
+}
+
+// BundleTemplateName returns the template name of the file in its own bundle namespace,
+// e.g. @MyPlugin/storefront/page/index.html.twig for a template of the MyPlugin bundle
+func BundleTemplateName(file TwigFile) string {
+	namespace := file.BundleName
+	if strings.EqualFold(namespace, "storefront") {
+		namespace = "Storefront"
+	}
+
+	return fmt.Sprintf("@%s/%s", namespace, strings.TrimPrefix(file.RelPath, "@Storefront/"))
+}
@@ -19,3 +19,20 @@ func TestGetBundleNameByPath(t *testing.T) {
 	assert.Equal(t, "storefront", getBundleNameByPath("vendor/shopware/storefront/Resources/views/storefront/base.html.twig"))
 	assert.Equal(t, "MyFoo", getBundleNameByPath("vendor/store.shopware.com/MyFoo/src/Resources/views/storefront/base.html.twig"))
 }
+
+func TestSplitTemplateName(t *testing.T) {
+	bundle, relPath := SplitTemplateName("@Storefront/storefront/base.html.twig")
+	assert.Equal(t, "Storefront", bundle)
+	assert.Equal(t, "@Storefront/storefront/base.html.twig", relPath)
+
+	bundle, relPath = SplitTemplateName("@MyPlugin/storefront/page/custom.html.twig")
+	assert.Equal(t, "MyPlugin", bundle)
+	assert.Equal(t, "@Storefront/storefront/page/custom.html.twig", relPath)
+
+	bundle, relPath = SplitTemplateName("storefront/base.html.twig")
+	assert.Equal(t, "", bundle)
+	assert.Equal(t, "@Storefront/storefront/base.html.twig", relPath)
+
+	_, relPath = SplitTemplateName("@MyPlugin")
+	assert.Equal(t, "", relPath)
+}
@@ -0,0 +1,293 @@
+package twig
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TemplateVariable is a variable Shopware passes to Storefront templates, properties are the known members of its value
+type TemplateVariable struct {
+	Name       string
+	Type       string
+	Properties []TemplateVariable
+}
+
+// templateVariableScope provides variables to all templates below a path relative to Resources/views
+type templateVariableScope struct {
+	path      string
+	variables []TemplateVariable
+}
+
+var (
+	templateVariableScopes   []templateVariableScope
+	templateVariableScopesMu sync.RWMutex
+)
+
+// RegisterTemplateVariables adds variables for all templates below the given path, e.g. "storefront/page/checkout/".
+// Variables of the same name as already registered ones are merged, so only additional properties need to be listed.
+func RegisterTemplateVariables(path string, variables ...TemplateVariable) {
+	templateVariableScopesMu.Lock()
+	defer templateVariableScopesMu.Unlock()
+
+	templateVariableScopes = append(templateVariableScopes, templateVariableScope{path: path, variables: variables})
+}
+
+// GetTemplateVariables returns the variables available in the template, templatePath may be a file path
+// or a template name like @Storefront/storefront/page/product-detail/index.html.twig
+func GetTemplateVariables(templatePath string) []TemplateVariable {
+	relPath := templatePath
+	if !strings.HasPrefix(relPath, "@") {
+		relPath = ConvertToRelativePath(templatePath)
+	}
+	if _, path, found := strings.Cut(relPath, "/"); found {
+		relPath = path
+	}
+
+	templateVariableScopesMu.RLock()
+	defer templateVariableScopesMu.RUnlock()
+
+	var matching []templateVariableScope
+	for _, scope := range templateVariableScopes {
+		if strings.HasPrefix(relPath, scope.path) {
+			matching = append(matching, scope)
+		}
+	}
+
+	// More specific paths extend the variables of the general ones
+	sort.SliceStable(matching, func(i, j int) bool {
+		return len(matching[i].path) < len(matching[j].path)
+	})
+
+	var variables []TemplateVariable
+	for _, scope := range matching {
+		variables = mergeTemplateVariables(variables, scope.variables)
+	}
+
+	return variables
+}
+
+// FindTemplateVariable resolves a path like ["page", "product"] to the variable, ok is false for unknown members
+func FindTemplateVariable(variables []TemplateVariable, path []string) (TemplateVariable, bool) {
+	var current TemplateVariable
+	candidates := variables
+
+	for _, name := range path {
+		found := false
+		for _, candidate := range candidates {
+			if candidate.Name == name {
+				current = candidate
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return TemplateVariable{}, false
+		}
+
+		candidates = current.Properties
+	}
+
+	return current, true
+}
+
+func mergeTemplateVariables(base, overrides []TemplateVariable) []TemplateVariable {
+	merged := append([]TemplateVariable{}, base...)
+
+	for _, override := range overrides {
+		index := -1
+		for i, variable := range merged {
+			if variable.Name == override.Name {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			merged = append(merged, override)
+			continue
+		}
+
+		if override.Type != "" {
+			merged[index].Type = override.Type
+		}
+		merged[index].Properties = mergeTemplateVariables(merged[index].Properties, override.Properties)
+	}
+
+	return merged
+}
+
+func init() {
+	salesChannelContext := TemplateVariable{
+		Name: "context",
+		Type: "SalesChannelContext",
+		Properties: []TemplateVariable{
+			{Name: "token", Type: "string"},
+			{Name: "context", Type: "Context"},
+			{Name: "currency", Type: "CurrencyEntity", Properties: []TemplateVariable{
+				{Name: "id", Type: "string"},
+				{Name: "isoCode", Type: "string"},
+				{Name: "symbol", Type: "string"},
+				{Name: "shortName", Type: "string"},
+				{Name: "translated", Type: "array"},
+			}},
+			{Name: "customer", Type: "CustomerEntity|null", Properties: []TemplateVariable{
+				{Name: "id", Type: "string"},
+				{Name: "customerNumber", Type: "string"},
+				{Name: "firstName", Type: "string"},
+				{Name: "lastName", Type: "string"},
+				{Name: "email", Type: "string"},
+				{Name: "guest", Type: "bool"},
+				{Name: "salutation", Type: "SalutationEntity"},
+				{Name: "activeBillingAddress", Type: "CustomerAddressEntity"},
+				{Name: "activeShippingAddress", Type: "CustomerAddressEntity"},
+				{Name: "defaultBillingAddress", Type: "CustomerAddressEntity"},
+				{Name: "defaultShippingAddress", Type: "CustomerAddressEntity"},
+			}},
+			{Name: "currentCustomerGroup", Type: "CustomerGroupEntity"},
+			{Name: "salesChannel", Type: "SalesChannelEntity", Properties: []TemplateVariable{
+				{Name: "id", Type: "string"},
+				{Name: "name", Type: "string"},
+				{Name: "translated", Type: "array"},
+				{Name: "navigationCategoryId", Type: "string"},
+				{Name: "footerCategoryId", Type: "string|null"},
+				{Name: "serviceCategoryId", Type: "string|null"},
+			}},
+			{Name: "paymentMethod", Type: "PaymentMethodEntity"},
+			{Name: "shippingMethod", Type: "ShippingMethodEntity"},
+			{Name: "shippingLocation", Type: "ShippingLocation"},
+			{Name: "taxState", Type: "string"},
+			{Name: "languageId", Type: "string"},
+			{Name: "currencyId", Type: "string"},
+		},
+	}
+
+	cart := TemplateVariable{
+		Name: "cart",
+		Type: "Cart",
+		Properties: []TemplateVariable{
+			{Name: "token", Type: "string"},
+			{Name: "lineItems", Type: "LineItemCollection"},
+			{Name: "price", Type: "CartPrice", Properties: []TemplateVariable{
+				{Name: "netPrice", Type: "float"},
+				{Name: "totalPrice", Type: "float"},
+				{Name: "positionPrice", Type: "float"},
+				{Name: "calculatedTaxes", Type: "CalculatedTaxCollection"},
+				{Name: "taxStatus", Type: "string"},
+			}},
+			{Name: "deliveries", Type: "DeliveryCollection"},
+			{Name: "transactions", Type: "TransactionCollection"},
+			{Name: "errors", Type: "ErrorCollection"},
+			{Name: "customerComment", Type: "string|null"},
+			{Name: "affiliateCode", Type: "string|null"},
+			{Name: "campaignCode", Type: "string|null"},
+		},
+	}
+
+	// Passed by the Twig environment and the Storefront subscribers to every template
+	RegisterTemplateVariables("",
+		TemplateVariable{Name: "app", Type: "AppVariable", Properties: []TemplateVariable{
+			{Name: "request", Type: "Request"},
+			{Name: "session", Type: "Session"},
+			{Name: "user", Type: "UserInterface|null"},
+			{Name: "environment", Type: "string"},
+			{Name: "debug", Type: "bool"},
+			{Name: "flashes", Type: "array"},
+		}},
+	)
+
+	RegisterTemplateVariables("storefront/",
+		TemplateVariable{Name: "page", Type: "Page", Properties: []TemplateVariable{
+			{Name: "header", Type: "HeaderPagelet"},
+			{Name: "footer", Type: "FooterPagelet"},
+			{Name: "metaInformation", Type: "MetaInformation", Properties: []TemplateVariable{
+				{Name: "metaTitle", Type: "string"},
+				{Name: "metaDescription", Type: "string"},
+				{Name: "metaKeywords", Type: "string"},
+				{Name: "robots", Type: "string"},
+				{Name: "canonical", Type: "string|null"},
+			}},
+			{Name: "extensions", Type: "array"},
+		}},
+		salesChannelContext,
+		TemplateVariable{Name: "shopware", Type: "array", Properties: []TemplateVariable{
+			{Name: "config", Type: "array"},
+			{Name: "theme", Type: "array"},
+			{Name: "dateFormat", Type: "string"},
+		}},
+		TemplateVariable{Name: "themeIconConfig", Type: "array"},
+		TemplateVariable{Name: "controllerName", Type: "string"},
+		TemplateVariable{Name: "controllerAction", Type: "string"},
+		TemplateVariable{Name: "activeRoute", Type: "string"},
+	)
+
+	RegisterTemplateVariables("storefront/page/product-detail/",
+		TemplateVariable{Name: "page", Type: "ProductPage", Properties: []TemplateVariable{
+			{Name: "product", Type: "SalesChannelProductEntity", Properties: []TemplateVariable{
+				{Name: "id", Type: "string"},
+				{Name: "parentId", Type: "string|null"},
+				{Name: "productNumber", Type: "string"},
+				{Name: "translated", Type: "array"},
+				{Name: "calculatedPrice", Type: "CalculatedPrice"},
+				{Name: "calculatedPrices", Type: "PriceCollection"},
+				{Name: "calculatedCheapestPrice", Type: "CalculatedCheapestPrice"},
+				{Name: "cover", Type: "ProductMediaEntity"},
+				{Name: "media", Type: "ProductMediaCollection"},
+				{Name: "manufacturer", Type: "ProductManufacturerEntity"},
+				{Name: "seoCategory", Type: "CategoryEntity|null"},
+				{Name: "deliveryTime", Type: "DeliveryTimeEntity"},
+				{Name: "sortedProperties", Type: "PropertyGroupCollection"},
+				{Name: "options", Type: "PropertyGroupOptionCollection"},
+				{Name: "stock", Type: "int"},
+				{Name: "availableStock", Type: "int"},
+				{Name: "available", Type: "bool"},
+				{Name: "isCloseout", Type: "bool"},
+				{Name: "markAsTopseller", Type: "bool"},
+				{Name: "ratingAverage", Type: "float|null"},
+				{Name: "variation", Type: "array"},
+			}},
+			{Name: "reviews", Type: "ReviewLoaderResult"},
+			{Name: "cmsPage", Type: "CmsPageEntity|null"},
+			{Name: "configuratorSettings", Type: "PropertyGroupCollection"},
+			{Name: "crossSellings", Type: "CrossSellingElementCollection"},
+		}},
+	)
+
+	RegisterTemplateVariables("storefront/page/checkout/",
+		TemplateVariable{Name: "page", Type: "CheckoutPage", Properties: []TemplateVariable{
+			cart,
+			{Name: "paymentMethods", Type: "PaymentMethodCollection"},
+			{Name: "shippingMethods", Type: "ShippingMethodCollection"},
+		}},
+	)
+
+	RegisterTemplateVariables("storefront/page/checkout/finish/",
+		TemplateVariable{Name: "page", Type: "CheckoutFinishPage", Properties: []TemplateVariable{
+			{Name: "order", Type: "OrderEntity"},
+			{Name: "changedPayment", Type: "bool"},
+			{Name: "paymentFailed", Type: "bool"},
+			{Name: "logoutCustomer", Type: "bool"},
+		}},
+	)
+
+	RegisterTemplateVariables("storefront/page/account/",
+		TemplateVariable{Name: "page", Type: "AccountPage", Properties: []TemplateVariable{
+			{Name: "customer", Type: "CustomerEntity"},
+			{Name: "newestOrder", Type: "OrderEntity|null"},
+			{Name: "orders", Type: "StorefrontSearchResult"},
+			{Name: "addresses", Type: "CustomerAddressCollection"},
+			{Name: "salutations", Type: "SalutationCollection"},
+			{Name: "countries", Type: "CountryCollection"},
+			{Name: "paymentMethods", Type: "PaymentMethodCollection"},
+		}},
+	)
+
+	RegisterTemplateVariables("storefront/page/content/",
+		TemplateVariable{Name: "page", Type: "NavigationPage", Properties: []TemplateVariable{
+			{Name: "cmsPage", Type: "CmsPageEntity|null"},
+			{Name: "navigationId", Type: "string"},
+			{Name: "category", Type: "CategoryEntity|null"},
+		}},
+	)
+}
@@ -0,0 +1,70 @@
+package twig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTemplateVariables(t *testing.T) {
+	variables := GetTemplateVariables("/project/vendor/shopware/storefront/Resources/views/storefront/page/checkout/finish/index.html.twig")
+	assert.Contains(t, templateVariableNames(variables), "page")
+	assert.Contains(t, templateVariableNames(variables), "context")
+
+	page, ok := FindTemplateVariable(variables, []string{"page"})
+	require.True(t, ok)
+	assert.Equal(t, "CheckoutFinishPage", page.Type)
+	assert.Contains(t, templateVariableNames(page.Properties), "order")
+	assert.Contains(t, templateVariableNames(page.Properties), "cart")
+	assert.Contains(t, templateVariableNames(page.Properties), "header")
+
+	_, ok = FindTemplateVariable(variables, []string{"page", "product"})
+	assert.False(t, ok)
+
+	price, ok := FindTemplateVariable(variables, []string{"page", "cart", "price"})
+	require.True(t, ok)
+	assert.Contains(t, templateVariableNames(price.Properties), "totalPrice")
+
+	assert.Equal(t, []string{"app"}, templateVariableNames(GetTemplateVariables("/project/templates/documents/invoice.html.twig")))
+}
+
+func TestRegisterTemplateVariables(t *testing.T) {
+	templateVariableScopesMu.RLock()
+	original := templateVariableScopes
+	templateVariableScopesMu.RUnlock()
+	defer func() {
+		templateVariableScopesMu.Lock()
+		templateVariableScopes = original
+		templateVariableScopesMu.Unlock()
+	}()
+
+	RegisterTemplateVariables("storefront/page/product-detail/",
+		TemplateVariable{Name: "page", Properties: []TemplateVariable{{Name: "myPluginData", Type: "MyPluginStruct"}}},
+		TemplateVariable{Name: "myPluginConfig", Type: "array"},
+	)
+
+	variables := GetTemplateVariables("@Storefront/storefront/page/product-detail/buy-widget.html.twig")
+	assert.Contains(t, templateVariableNames(variables), "myPluginConfig")
+
+	page, ok := FindTemplateVariable(variables, []string{"page"})
+	require.True(t, ok)
+	assert.Equal(t, "ProductPage", page.Type)
+
+	data, ok := FindTemplateVariable(variables, []string{"page", "myPluginData"})
+	require.True(t, ok)
+	assert.Equal(t, "MyPluginStruct", data.Type)
+
+	product, ok := FindTemplateVariable(variables, []string{"page", "product"})
+	require.True(t, ok)
+	assert.NotEmpty(t, product.Properties)
+}
+
+func templateVariableNames(variables []TemplateVariable) []string {
+	var names []string
+	for _, variable := range variables {
+		names = append(names, variable.Name)
+	}
+
+	return names
+}
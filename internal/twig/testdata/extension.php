@@ -5,6 +5,7 @@ namespace App\Twig;
 use Twig\Extension\AbstractExtension;
 use Twig\TwigFilter;
 use Twig\TwigFunction;
+use Twig\TwigTest;
 
 class TwigExt extends AbstractExtension
 {
@@ -25,6 +26,14 @@ class TwigExt extends AbstractExtension
         ];
     }
 
+    public function getTests(): array
+    {
+        return [
+            new TwigTest('instanceof', [$this, 'isInstanceOf']),
+            new TwigTest('same product', $this->test(...)),
+        ];
+    }
+
     public function test(string $test)
     {
         return 'test';
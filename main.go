@@ -6,17 +6,24 @@ import (
 	"path/filepath"
 
 	"github.com/shopware/shopware-lsp/internal/admin"
+	"github.com/shopware/shopware-lsp/internal/dal"
+	"github.com/shopware/shopware-lsp/internal/event"
 	"github.com/shopware/shopware-lsp/internal/extension"
 	"github.com/shopware/shopware-lsp/internal/feature"
 	"github.com/shopware/shopware-lsp/internal/indexer"
 	"github.com/shopware/shopware-lsp/internal/lsp"
 	"github.com/shopware/shopware-lsp/internal/lsp/codeaction"
 	"github.com/shopware/shopware-lsp/internal/lsp/codelens"
+	"github.com/shopware/shopware-lsp/internal/lsp/command"
 	"github.com/shopware/shopware-lsp/internal/lsp/completion"
 	"github.com/shopware/shopware-lsp/internal/lsp/definition"
 	"github.com/shopware/shopware-lsp/internal/lsp/diagnostics"
+	"github.com/shopware/shopware-lsp/internal/lsp/highlight"
 	"github.com/shopware/shopware-lsp/internal/lsp/hover"
+	"github.com/shopware/shopware-lsp/internal/lsp/inlayhint"
 	"github.com/shopware/shopware-lsp/internal/lsp/reference"
+	"github.com/shopware/shopware-lsp/internal/lsp/rename"
+	"github.com/shopware/shopware-lsp/internal/lsp/signaturehelp"
 	"github.com/shopware/shopware-lsp/internal/php"
 	"github.com/shopware/shopware-lsp/internal/snippet"
 	"github.com/shopware/shopware-lsp/internal/symfony"
@@ -72,6 +79,10 @@ func main() {
 	server.RegisterIndexer(theme.NewThemeConfigIndexer(cacheDir))
 	server.RegisterIndexer(extension.NewExtensionIndexer(cacheDir))
 	server.RegisterIndexer(admin.NewAdminComponentIndexer(cacheDir))
+	server.RegisterIndexer(admin.NewAdminServiceIndexer(cacheDir))
+	server.RegisterIndexer(admin.NewCmsIndexer(cacheDir))
+	server.RegisterIndexer(dal.NewEntityIndexer(cacheDir))
+	server.RegisterIndexer(event.NewEventIndexer(cacheDir))
 
 	server.RegisterCompletionProvider(completion.NewServiceCompletionProvider(server))
 	server.RegisterCompletionProvider(completion.NewTwigCompletionProvider(projectRoot, server))
@@ -80,7 +91,13 @@ func main() {
 	server.RegisterCompletionProvider(completion.NewFeatureCompletionProvider(server))
 	server.RegisterCompletionProvider(completion.NewSystemConfigCompletion(server))
 	server.RegisterCompletionProvider(completion.NewThemeCompletionProvider(server))
-	server.RegisterCompletionProvider(completion.NewAdminCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewAdminCompletionProvider(projectRoot, server))
+	server.RegisterCompletionProvider(completion.NewAdminServiceCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewCmsCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewDALCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewEventCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewPHPCompletionProvider(server))
+	server.RegisterCompletionProvider(completion.NewExtensionCompletionProvider(server))
 
 	server.RegisterDefinitionProvider(definition.NewServiceXMLDefinitionProvider(server))
 	server.RegisterDefinitionProvider(definition.NewTwigDefinitionProvider(projectRoot, server))
@@ -90,31 +107,59 @@ func main() {
 	server.RegisterDefinitionProvider(definition.NewSystemConfigDefinitionProvider(server))
 	server.RegisterDefinitionProvider(definition.NewThemeDefinitionProvider(server))
 	server.RegisterDefinitionProvider(definition.NewAdminDefinitionProvider(server))
+	server.RegisterDefinitionProvider(definition.NewPHPDefinitionProvider(server))
+
+	server.RegisterTypeDefinitionProvider(definition.NewPHPTypeDefinitionProvider(server))
 
 	server.RegisterCodeLensProvider(codelens.NewPHPCodeLensProvider(server))
 	server.RegisterCodeLensProvider(codelens.NewTwigCodeLensProvider(server))
 
 	server.RegisterReferencesProvider(reference.NewRouteReferenceProvider(server))
+	server.RegisterReferencesProvider(reference.NewServiceReferenceProvider(server))
 
 	server.RegisterDiagnosticsProvider(diagnostics.NewSnippetDiagnosticsProvider(server))
 	server.RegisterDiagnosticsProvider(diagnostics.NewThemeDiagnosticsProvider(projectRoot, server))
 	server.RegisterDiagnosticsProvider(diagnostics.NewTwigVersioningDiagnosticsProvider(server))
+	server.RegisterDiagnosticsProvider(diagnostics.NewTwigParentBlockDiagnosticsProvider(server))
+	server.RegisterDiagnosticsProvider(diagnostics.NewTwigBlockDiagnosticsProvider(server))
 	server.RegisterDiagnosticsProvider(diagnostics.NewAdminDiagnosticsProvider(server))
+	server.RegisterDiagnosticsProvider(diagnostics.NewServiceDiagnosticsProvider(projectRoot, server))
+	server.RegisterDiagnosticsProvider(diagnostics.NewXMLSyntaxDiagnosticsProvider())
+	server.RegisterDiagnosticsProvider(diagnostics.NewServiceAttributeDiagnosticsProvider())
+	server.RegisterDiagnosticsProvider(diagnostics.NewPHPUnusedImportProvider())
 
 	// Register hover providers
 	server.RegisterHoverProvider(hover.NewTwigHoverProvider(projectRoot, server))
 	server.RegisterHoverProvider(hover.NewSnippetHoverProvider(projectRoot, server))
 	server.RegisterHoverProvider(hover.NewTwigVersioningHoverProvider(server))
 	server.RegisterHoverProvider(hover.NewAdminHoverProvider(projectRoot, server))
+	server.RegisterHoverProvider(hover.NewPHPHoverProvider(projectRoot, server))
+
+	// Register document highlight providers
+	server.RegisterDocumentHighlightProvider(highlight.NewTwigDocumentHighlightProvider())
+	server.RegisterDocumentHighlightProvider(highlight.NewPHPDocumentHighlightProvider())
+
+	// Register rename providers
+	server.RegisterRenameProvider(rename.NewTwigRenameProvider())
+
+	// Register inlay hint providers
+	server.RegisterInlayHintProvider(inlayhint.NewTwigInlayHintProvider(server))
+	server.RegisterInlayHintProvider(inlayhint.NewPHPInlayHintProvider(server))
+
+	// Register signature help providers
+	server.RegisterSignatureHelpProvider(signaturehelp.NewPHPSignatureHelpProvider(server))
 
 	// Register code action providers
 	server.RegisterCodeActionProvider(codeaction.NewSnippetCodeActionProvider(server))
 	server.RegisterCodeActionProvider(codeaction.NewTwigCodeActionProvider(projectRoot, server))
 	server.RegisterCodeActionProvider(codeaction.NewAdminCodeActionProvider(server))
+	server.RegisterCodeActionProvider(codeaction.NewPHPCodeActionProvider(server))
 
 	server.RegisterCommandProvider(snippet.NewSnippetCommandProvider(server))
 	server.RegisterCommandProvider(extension.NewExtensionCommandProvider(server))
 	server.RegisterCommandProvider(twig.NewTwigCommandProvider(projectRoot, server))
+	server.RegisterCommandProvider(command.NewAstCommandProvider(server))
+	server.RegisterCommandProvider(symfony.NewServiceCommandProvider(projectRoot, cacheDir, server))
 
 	if err := server.Start(os.Stdin, os.Stdout); err != nil {
 		log.Fatalf("LSP server error: %v", err)
@@ -197,6 +197,14 @@ export async function activate(context: vscode.ExtensionContext): Promise<void>
         { scheme: 'file', language: 'javascript' },
         { scheme: 'file', language: 'typescript' }
       ],
+      initializationOptions: {
+        skipDirs: vscode.workspace.getConfiguration('shopwareLSP').get<string[]>('skipDirs', []),
+        includeDirs: vscode.workspace.getConfiguration('shopwareLSP').get<string[]>('includeDirs', [])
+      },
+      // Notify the server about changes of the extension settings
+      synchronize: {
+        configurationSection: 'shopwareLSP'
+      },
       // Add output configuration
       outputChannel: outputChannel,
       traceOutputChannel: outputChannel,